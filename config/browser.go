@@ -0,0 +1,26 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+// BrowserConfiguration holds the Chrome session automation rules send connection requests from
+type BrowserConfiguration struct {
+	// ExecPath is the Chrome binary; empty looks for one on the PATH
+	ExecPath string
+	Headless bool
+	// CookieFile holds the cookies exported from a signed-in LinkedIn session
+	CookieFile string
+}
+
+// BrowserConfig returns the browser settings for the connection request executor
+func BrowserConfig() BrowserConfiguration {
+	viper.SetDefault("BROWSER_HEADLESS", true)
+	viper.SetDefault("LINKEDIN_COOKIE_FILE", "internal/services/cookie.json")
+
+	return BrowserConfiguration{
+		ExecPath:   viper.GetString("CHROME_PATH"),
+		Headless:   viper.GetBool("BROWSER_HEADLESS"),
+		CookieFile: viper.GetString("LINKEDIN_COOKIE_FILE"),
+	}
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+// InvitationQuotaConfiguration holds the invitation limits of users who have not set their own
+type InvitationQuotaConfiguration struct {
+	DailyLimit  int
+	WeeklyLimit int
+}
+
+// InvitationQuotaConfig returns the default invitation limits. LinkedIn
+// restricts accounts that send much over a hundred invitations a week.
+func InvitationQuotaConfig() InvitationQuotaConfiguration {
+	viper.SetDefault("INVITATION_DAILY_LIMIT", 20)
+	viper.SetDefault("INVITATION_WEEKLY_LIMIT", 100)
+
+	return InvitationQuotaConfiguration{
+		DailyLimit:  viper.GetInt("INVITATION_DAILY_LIMIT"),
+		WeeklyLimit: viper.GetInt("INVITATION_WEEKLY_LIMIT"),
+	}
+}
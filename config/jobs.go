@@ -0,0 +1,22 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// JobInterval returns how often the named background job runs, read from
+// JOB_<NAME>_INTERVAL (a Go duration such as "6h"). Invalid values fall back to the default.
+func JobInterval(name string, fallback time.Duration) time.Duration {
+	key := fmt.Sprintf("JOB_%s_INTERVAL", strings.ToUpper(name))
+	viper.SetDefault(key, fallback.String())
+
+	interval, err := time.ParseDuration(viper.GetString(key))
+	if err != nil || interval <= 0 {
+		return fallback
+	}
+	return interval
+}
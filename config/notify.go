@@ -0,0 +1,27 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+// SMTPConfiguration holds the mail server used by email notification channels
+type SMTPConfiguration struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPConfig returns the SMTP settings. Email channels are skipped when SMTP_HOST is empty.
+func SMTPConfig() SMTPConfiguration {
+	viper.SetDefault("SMTP_PORT", "587")
+
+	return SMTPConfiguration{
+		Host:     viper.GetString("SMTP_HOST"),
+		Port:     viper.GetString("SMTP_PORT"),
+		Username: viper.GetString("SMTP_USERNAME"),
+		Password: viper.GetString("SMTP_PASSWORD"),
+		From:     viper.GetString("SMTP_FROM"),
+	}
+}
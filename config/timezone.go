@@ -0,0 +1,22 @@
+package config
+
+import (
+	"linkedin-watcher/infra/logger"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultTimezone returns the timezone execution windows are evaluated in for
+// users and rules that have not set their own, SERVER_TIMEZONE, falling back
+// to UTC when it is not a known IANA name
+func DefaultTimezone() *time.Location {
+	viper.SetDefault("SERVER_TIMEZONE", "Europe/Madrid")
+
+	loc, err := time.LoadLocation(viper.GetString("SERVER_TIMEZONE"))
+	if err != nil {
+		logger.Warnf("Invalid SERVER_TIMEZONE, using UTC: %v", err)
+		return time.UTC
+	}
+	return loc
+}
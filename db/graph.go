@@ -0,0 +1,125 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// The network graph queries live outside queries.sql because sqlc always
+// materializes :many results into a slice. Exports of large networks iterate
+// the cursor directly and hand each row to a callback instead.
+
+const streamUserGraphNodes = `
+WITH network AS (
+    SELECT tc.profile_id FROM tracked_connections tc WHERE tc.user_id = $1
+    UNION
+    SELECT cr.profile_a_id FROM connection_relationships cr WHERE cr.discovered_by_user_id = $1
+    UNION
+    SELECT cr.profile_b_id FROM connection_relationships cr WHERE cr.discovered_by_user_id = $1
+)
+SELECT lp.id, lp.linkedin_url, lp.name, lp.headline, lp.location,
+       c.name as company_name,
+       EXISTS (
+         SELECT 1 FROM tracked_connections tc2
+         WHERE tc2.user_id = $1 AND tc2.profile_id = lp.id
+       ) as is_tracked,
+       ARRAY(
+         SELECT pt.tag FROM profile_tags pt
+         WHERE pt.user_id = $1 AND pt.profile_id = lp.id
+         ORDER BY pt.tag
+       )::text[] as tags,
+       ARRAY(
+         SELECT pl.name FROM profile_list_members plm
+         JOIN profile_lists pl ON plm.list_id = pl.id
+         WHERE pl.user_id = $1 AND plm.profile_id = lp.id
+         ORDER BY pl.name
+       )::text[] as lists
+FROM network n
+JOIN linkedin_profiles lp ON lp.id = n.profile_id
+LEFT JOIN companies c ON lp.current_company_id = c.id
+ORDER BY lp.name, lp.id
+`
+
+type UserGraphNodeRow struct {
+	ID          pgtype.UUID
+	LinkedinUrl string
+	Name        string
+	Headline    pgtype.Text
+	Location    pgtype.Text
+	CompanyName pgtype.Text
+	IsTracked   bool
+	Tags        []string
+	Lists       []string
+}
+
+// StreamUserGraphNodes calls fn for every profile in the user's network:
+// tracked profiles plus every profile on a relationship the user discovered,
+// along with the user's tags and list names for each profile.
+func (q *Queries) StreamUserGraphNodes(ctx context.Context, userID pgtype.UUID, fn func(UserGraphNodeRow) error) error {
+	rows, err := q.db.Query(ctx, streamUserGraphNodes, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i UserGraphNodeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.LinkedinUrl,
+			&i.Name,
+			&i.Headline,
+			&i.Location,
+			&i.CompanyName,
+			&i.IsTracked,
+			&i.Tags,
+			&i.Lists,
+		); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+const streamUserGraphEdges = `
+SELECT cr.id, cr.profile_a_id, cr.profile_b_id, cr.degree, cr.discovered_at
+FROM connection_relationships cr
+WHERE cr.discovered_by_user_id = $1
+ORDER BY cr.discovered_at, cr.id
+`
+
+type UserGraphEdgeRow struct {
+	ID           pgtype.UUID
+	ProfileAID   pgtype.UUID
+	ProfileBID   pgtype.UUID
+	Degree       int32
+	DiscoveredAt pgtype.Timestamp
+}
+
+// StreamUserGraphEdges calls fn for every connection relationship the user discovered.
+func (q *Queries) StreamUserGraphEdges(ctx context.Context, userID pgtype.UUID, fn func(UserGraphEdgeRow) error) error {
+	rows, err := q.db.Query(ctx, streamUserGraphEdges, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i UserGraphEdgeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProfileAID,
+			&i.ProfileBID,
+			&i.Degree,
+			&i.DiscoveredAt,
+		); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
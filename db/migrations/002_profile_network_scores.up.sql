@@ -0,0 +1,15 @@
+-- Per-user centrality scores computed over each user's stored network graph
+CREATE TABLE profile_network_scores (
+  user_id           UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  profile_id        UUID NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+  degree            INTEGER NOT NULL DEFAULT 0, -- Number of distinct neighbours in the user's graph
+  betweenness       DOUBLE PRECISION NOT NULL DEFAULT 0, -- Normalized to [0, 1]
+  pagerank          DOUBLE PRECISION NOT NULL DEFAULT 0, -- Scaled so the top profile scores 1
+  computed_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (user_id, profile_id)
+);
+
+CREATE INDEX idx_profile_network_scores_pagerank ON profile_network_scores(user_id, pagerank DESC);
+
+-- Rules can require a minimum network score before they match a profile
+ALTER TABLE automation_rules ADD COLUMN min_network_score DOUBLE PRECISION;
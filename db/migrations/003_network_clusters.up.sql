@@ -0,0 +1,21 @@
+-- Communities detected in each user's network graph
+CREATE TABLE network_clusters (
+  id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  user_id           UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  label             VARCHAR(255) NOT NULL, -- Generated from the dominant companies and locations
+  size              INTEGER NOT NULL,
+  top_companies     TEXT[] NOT NULL DEFAULT '{}',
+  top_locations     TEXT[] NOT NULL DEFAULT '{}',
+  computed_at       TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Cluster membership, one cluster per profile per user
+CREATE TABLE profile_clusters (
+  user_id           UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  profile_id        UUID NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+  cluster_id        UUID NOT NULL REFERENCES network_clusters(id) ON DELETE CASCADE,
+  PRIMARY KEY (user_id, profile_id)
+);
+
+CREATE INDEX idx_network_clusters_user ON network_clusters(user_id);
+CREATE INDEX idx_profile_clusters_cluster ON profile_clusters(cluster_id);
@@ -0,0 +1,20 @@
+-- Changes detected when a profile is re-scraped
+CREATE TABLE profile_events (
+  id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  profile_id        UUID NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+  event_type        VARCHAR(50) NOT NULL CHECK (event_type IN ('job_change')),
+  old_company_id    UUID REFERENCES companies(id) ON DELETE SET NULL,
+  new_company_id    UUID REFERENCES companies(id) ON DELETE SET NULL,
+  old_position      VARCHAR(255),
+  new_position      VARCHAR(255),
+  detected_at       TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_profile_events_profile ON profile_events(profile_id);
+CREATE INDEX idx_profile_events_type_detected ON profile_events(event_type, detected_at DESC);
+CREATE INDEX idx_profile_events_new_company ON profile_events(new_company_id);
+
+-- What makes an automation rule fire: a newly discovered connection or a job change in the network
+ALTER TABLE automation_rules
+  ADD COLUMN trigger_type VARCHAR(50) NOT NULL DEFAULT 'new_connection'
+  CHECK (trigger_type IN ('new_connection', 'job_change'));
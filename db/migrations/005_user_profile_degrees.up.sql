@@ -0,0 +1,18 @@
+-- Best connection degree from each user to every profile they can reach.
+-- Tracked connections are 1st degree; a profile with a degree-N relationship
+-- to a tracked connection is degree N+1, reached via that tracked connection.
+CREATE VIEW user_profile_degrees AS
+SELECT DISTINCT ON (paths.user_id, paths.profile_id)
+       paths.user_id, paths.profile_id, paths.degree, paths.via_profile_id
+FROM (
+    SELECT tc.user_id, tc.profile_id, 1 AS degree, NULL::uuid AS via_profile_id
+    FROM tracked_connections tc
+    UNION ALL
+    SELECT tc.user_id,
+           CASE WHEN cr.profile_a_id = tc.profile_id THEN cr.profile_b_id ELSE cr.profile_a_id END AS profile_id,
+           cr.degree + 1 AS degree,
+           tc.profile_id AS via_profile_id
+    FROM tracked_connections tc
+    JOIN connection_relationships cr ON (cr.profile_a_id = tc.profile_id OR cr.profile_b_id = tc.profile_id)
+) paths
+ORDER BY paths.user_id, paths.profile_id, paths.degree, paths.via_profile_id;
@@ -0,0 +1,25 @@
+-- Lowercased company name without punctuation or legal suffixes, so that
+-- "Google", "Google LLC" and "Google Inc." resolve to the same company.
+-- New rows are normalized by the application; existing rows are backfilled here.
+ALTER TABLE companies ADD COLUMN normalized_name VARCHAR(255);
+
+UPDATE companies
+SET normalized_name = btrim(regexp_replace(
+  btrim(regexp_replace(lower(regexp_replace(replace(name, '.', ''), '[,()]', ' ', 'g')), '\s+', ' ', 'g')),
+  '(\s(inc|incorporated|llc|ltd|limited|corp|corporation|co|company|gmbh|ag|sa|sl|slu|plc|bv|nv|srl|spa|pty|llp|lp))+\s*$',
+  ''
+));
+
+ALTER TABLE companies ALTER COLUMN normalized_name SET NOT NULL;
+CREATE INDEX idx_companies_normalized_name ON companies(normalized_name);
+
+-- Alternative names that resolve to a company, e.g. the names of companies merged into it
+CREATE TABLE company_aliases (
+  id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  company_id        UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+  alias             VARCHAR(255) NOT NULL,
+  normalized_alias  VARCHAR(255) UNIQUE NOT NULL,
+  created_at        TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_company_aliases_company ON company_aliases(company_id);
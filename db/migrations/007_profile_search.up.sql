@@ -0,0 +1,48 @@
+-- Full-text search document per profile: name, current company, headline and location.
+-- Kept in its own table and maintained by triggers so profile writes need no changes.
+CREATE TABLE profile_search (
+  profile_id        UUID PRIMARY KEY REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+  document          TSVECTOR NOT NULL
+);
+
+CREATE INDEX idx_profile_search_document ON profile_search USING GIN(document);
+
+CREATE FUNCTION refresh_profile_search(target_profile_id UUID) RETURNS VOID AS $$
+  INSERT INTO profile_search (profile_id, document)
+  SELECT lp.id,
+         setweight(to_tsvector('english', coalesce(lp.name, '')), 'A') ||
+         setweight(to_tsvector('english', coalesce(c.name, '')), 'B') ||
+         setweight(to_tsvector('english', coalesce(lp.headline, '')), 'B') ||
+         setweight(to_tsvector('english', coalesce(lp.location, '')), 'C')
+  FROM linkedin_profiles lp
+  LEFT JOIN companies c ON lp.current_company_id = c.id
+  WHERE lp.id = target_profile_id
+  ON CONFLICT (profile_id) DO UPDATE SET document = EXCLUDED.document;
+$$ LANGUAGE sql;
+
+CREATE FUNCTION linkedin_profiles_search_trigger() RETURNS TRIGGER AS $$
+BEGIN
+  PERFORM refresh_profile_search(NEW.id);
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_linkedin_profiles_search
+AFTER INSERT OR UPDATE OF name, headline, location, current_company_id ON linkedin_profiles
+FOR EACH ROW EXECUTE FUNCTION linkedin_profiles_search_trigger();
+
+-- Renaming a company changes the documents of everyone currently there
+CREATE FUNCTION companies_search_trigger() RETURNS TRIGGER AS $$
+BEGIN
+  PERFORM refresh_profile_search(lp.id)
+  FROM linkedin_profiles lp
+  WHERE lp.current_company_id = NEW.id;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_companies_search
+AFTER UPDATE OF name ON companies
+FOR EACH ROW EXECUTE FUNCTION companies_search_trigger();
+
+SELECT refresh_profile_search(id) FROM linkedin_profiles;
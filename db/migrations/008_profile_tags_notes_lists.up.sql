@@ -0,0 +1,46 @@
+-- Per-user tags on any profile
+CREATE TABLE profile_tags (
+  user_id           UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  profile_id        UUID NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+  tag               VARCHAR(50) NOT NULL,
+  created_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (user_id, profile_id, tag)
+);
+
+CREATE INDEX idx_profile_tags_user_tag ON profile_tags(user_id, tag);
+
+-- Private notes, only visible to the user who wrote them
+CREATE TABLE profile_notes (
+  id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  user_id           UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  profile_id        UUID NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+  body              TEXT NOT NULL,
+  created_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at        TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_profile_notes_user_profile ON profile_notes(user_id, profile_id);
+
+-- Named lists of profiles, e.g. "Q3 hiring targets"
+CREATE TABLE profile_lists (
+  id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  user_id           UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name              VARCHAR(255) NOT NULL,
+  description       TEXT,
+  created_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+  UNIQUE(user_id, name)
+);
+
+CREATE TABLE profile_list_members (
+  list_id           UUID NOT NULL REFERENCES profile_lists(id) ON DELETE CASCADE,
+  profile_id        UUID NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+  added_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (list_id, profile_id)
+);
+
+CREATE INDEX idx_profile_list_members_profile ON profile_list_members(profile_id);
+
+-- Rules can be restricted to the members of one list
+ALTER TABLE automation_rules
+  ADD COLUMN list_id UUID REFERENCES profile_lists(id) ON DELETE SET NULL;
@@ -0,0 +1,49 @@
+-- Where a user's alerts are delivered in addition to the in-app inbox
+CREATE TABLE notification_channels (
+  id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  user_id           UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  channel_type      VARCHAR(20) NOT NULL CHECK (channel_type IN ('webhook', 'email')),
+  target            VARCHAR(500) NOT NULL,
+  is_active         BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+  UNIQUE(user_id, channel_type, target)
+);
+
+-- In-app inbox; every alert is stored here whatever channels are configured
+CREATE TABLE notifications (
+  id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  user_id           UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  kind              VARCHAR(50) NOT NULL,
+  title             VARCHAR(255) NOT NULL,
+  body              TEXT NOT NULL,
+  payload           JSONB NOT NULL DEFAULT '{}',
+  created_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+  read_at           TIMESTAMP
+);
+
+CREATE INDEX idx_notifications_user_created ON notifications(user_id, created_at DESC);
+
+-- Standing profile searches re-evaluated after every connection check
+CREATE TABLE saved_searches (
+  id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  user_id           UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name              VARCHAR(255) NOT NULL,
+  query             VARCHAR(200),
+  company_id        UUID REFERENCES companies(id) ON DELETE CASCADE,
+  location          VARCHAR(255),
+  degree            INTEGER CHECK (degree BETWEEN 1 AND 4),
+  tag               VARCHAR(50),
+  list_id           UUID REFERENCES profile_lists(id) ON DELETE CASCADE,
+  is_active         BOOLEAN NOT NULL DEFAULT TRUE,
+  last_evaluated_at TIMESTAMP,
+  created_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+  UNIQUE(user_id, name)
+);
+
+-- Profiles already matched by a saved search, so only new matches are alerted
+CREATE TABLE saved_search_matches (
+  saved_search_id   UUID NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE,
+  profile_id        UUID NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+  matched_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (saved_search_id, profile_id)
+);
@@ -0,0 +1,16 @@
+-- Companies a user wants to hear about when new people there enter their network
+CREATE TABLE company_watches (
+  user_id           UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  company_id        UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+  created_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (user_id, company_id)
+);
+
+-- 2nd and 3rd degree profiles already seen at a watched company, so each is alerted once
+CREATE TABLE company_watch_matches (
+  user_id           UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  company_id        UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+  profile_id        UUID NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+  matched_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (user_id, company_id, profile_id)
+);
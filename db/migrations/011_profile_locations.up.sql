@@ -0,0 +1,40 @@
+-- Free-text profile locations parsed against the offline gazetteer. Unparsed
+-- locations are stored too, with NULL fields, so they are only retried once
+-- the profile's location text changes.
+CREATE TABLE profile_locations (
+  profile_id        UUID PRIMARY KEY REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+  raw_location      VARCHAR(255) NOT NULL,
+  city              VARCHAR(255),
+  region            VARCHAR(255),
+  country_code      CHAR(2),
+  latitude          DOUBLE PRECISION,
+  longitude         DOUBLE PRECISION,
+  parsed_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_profile_locations_country ON profile_locations(country_code);
+
+-- Great-circle distance in kilometres between two points
+CREATE FUNCTION distance_km(lat1 DOUBLE PRECISION, lon1 DOUBLE PRECISION, lat2 DOUBLE PRECISION, lon2 DOUBLE PRECISION)
+RETURNS DOUBLE PRECISION
+LANGUAGE sql IMMUTABLE STRICT AS $$
+  SELECT 2 * 6371 * asin(sqrt(
+    power(sin(radians(lat2 - lat1) / 2), 2) +
+    cos(radians(lat1)) * cos(radians(lat2)) * power(sin(radians(lon2 - lon1) / 2), 2)
+  ))
+$$;
+
+-- Rules and saved searches can filter by country or by distance from a city
+ALTER TABLE automation_rules
+  ADD COLUMN country_code   CHAR(2),
+  ADD COLUMN near           VARCHAR(255),
+  ADD COLUMN near_latitude  DOUBLE PRECISION,
+  ADD COLUMN near_longitude DOUBLE PRECISION,
+  ADD COLUMN radius_km      DOUBLE PRECISION;
+
+ALTER TABLE saved_searches
+  ADD COLUMN country_code   CHAR(2),
+  ADD COLUMN near           VARCHAR(255),
+  ADD COLUMN near_latitude  DOUBLE PRECISION,
+  ADD COLUMN near_longitude DOUBLE PRECISION,
+  ADD COLUMN radius_km      DOUBLE PRECISION;
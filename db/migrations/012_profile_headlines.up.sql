@@ -0,0 +1,32 @@
+-- Profile headlines split into title, employer, seniority and job function.
+-- Like profile_locations, raw_headline records the text that was parsed so a
+-- profile is only parsed again once its headline changes.
+CREATE TABLE profile_headlines (
+  profile_id        UUID PRIMARY KEY REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+  raw_headline      VARCHAR(500) NOT NULL,
+  title             VARCHAR(500),
+  employer          VARCHAR(500),
+  seniority         VARCHAR(20),
+  job_function      VARCHAR(50),
+  parsed_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_profile_headlines_seniority ON profile_headlines(seniority);
+CREATE INDEX idx_profile_headlines_function ON profile_headlines(job_function);
+
+-- Position of a seniority level from intern (1) to C-level (8), NULL when unknown.
+-- Keep in sync with seniorityLevels in internal/services/headline_parser.go.
+CREATE FUNCTION seniority_rank(level TEXT)
+RETURNS INTEGER
+LANGUAGE sql IMMUTABLE STRICT AS $$
+  SELECT array_position(ARRAY['intern', 'entry', 'senior', 'lead', 'manager', 'director', 'vp', 'c_level'], level)
+$$;
+
+-- Rules and saved searches can target a minimum seniority and a job function
+ALTER TABLE automation_rules
+  ADD COLUMN min_seniority  VARCHAR(20),
+  ADD COLUMN job_function   VARCHAR(50);
+
+ALTER TABLE saved_searches
+  ADD COLUMN min_seniority  VARCHAR(20),
+  ADD COLUMN job_function   VARCHAR(50);
@@ -0,0 +1,21 @@
+-- Pairs of profiles that likely describe the same person, awaiting review.
+-- The pair is stored once, lower profile ID first. Rejected pairs are kept so
+-- detection does not raise them again; confirmed pairs are merged, which
+-- deletes the duplicate profile and, with it, the candidate row.
+CREATE TABLE duplicate_candidates (
+  id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  profile_id        UUID NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+  duplicate_id      UUID NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+  score             DOUBLE PRECISION NOT NULL,
+  reasons           TEXT[] NOT NULL DEFAULT '{}',
+  status            VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'rejected')),
+  reviewed_by       UUID REFERENCES users(id) ON DELETE SET NULL,
+  reviewed_at       TIMESTAMP,
+  detected_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+  created_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+  UNIQUE(profile_id, duplicate_id),
+  CHECK (profile_id < duplicate_id)
+);
+
+CREATE INDEX idx_duplicate_candidates_status ON duplicate_candidates(status, score DESC);
+CREATE INDEX idx_duplicate_candidates_duplicate ON duplicate_candidates(duplicate_id);
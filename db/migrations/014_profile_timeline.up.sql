@@ -0,0 +1,24 @@
+-- Headline changes and connections gained or lost are recorded alongside job changes
+ALTER TABLE profile_events DROP CONSTRAINT profile_events_event_type_check;
+ALTER TABLE profile_events
+  ADD CONSTRAINT profile_events_event_type_check
+  CHECK (event_type IN ('job_change', 'headline_change', 'connection_added', 'connection_removed'));
+
+ALTER TABLE profile_events
+  ADD COLUMN related_profile_id UUID REFERENCES linkedin_profiles(id) ON DELETE SET NULL, -- The tracked connection a connection event is with
+  ADD COLUMN old_headline VARCHAR(500),
+  ADD COLUMN new_headline VARCHAR(500);
+
+DROP INDEX idx_profile_events_profile;
+CREATE INDEX idx_profile_events_profile_detected ON profile_events(profile_id, detected_at DESC);
+CREATE INDEX idx_profile_events_related_profile ON profile_events(related_profile_id);
+
+-- Consecutive connection checks of the tracked profile that did not list this
+-- connection; it is considered removed after a few, so one bad scrape is not enough
+ALTER TABLE connection_relationships ADD COLUMN missed_checks INTEGER NOT NULL DEFAULT 0;
+
+-- Connections discovered so far become connection_added events
+INSERT INTO profile_events (profile_id, event_type, related_profile_id, detected_at)
+SELECT profile_b_id, 'connection_added', profile_a_id, discovered_at
+FROM connection_relationships
+WHERE degree = 1;
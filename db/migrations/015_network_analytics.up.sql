@@ -0,0 +1,60 @@
+-- Every profile each user has discovered through each of their tracked
+-- connections, with the profile's current company and location. 2nd degree
+-- discoveries come from connection_added events, so connections removed since
+-- still count; 3rd degree ones from degree 2 relationships. Refreshed by the
+-- analytics_refresh job.
+CREATE MATERIALIZED VIEW network_discoveries AS
+WITH discoveries AS (
+    SELECT tc.user_id, tc.profile_id AS tracked_profile_id,
+           CASE WHEN pe.related_profile_id = tc.profile_id THEN pe.profile_id ELSE pe.related_profile_id END AS profile_id,
+           2 AS degree, pe.detected_at AS discovered_at
+    FROM profile_events pe
+    JOIN tracked_connections tc ON tc.profile_id IN (pe.profile_id, pe.related_profile_id)
+    WHERE pe.event_type = 'connection_added' AND pe.related_profile_id IS NOT NULL
+    UNION ALL
+    SELECT tc.user_id, tc.profile_id,
+           CASE WHEN cr.profile_a_id = tc.profile_id THEN cr.profile_b_id ELSE cr.profile_a_id END,
+           3, cr.discovered_at
+    FROM connection_relationships cr
+    JOIN tracked_connections tc ON tc.profile_id IN (cr.profile_a_id, cr.profile_b_id)
+    WHERE cr.degree = 2
+),
+-- Each tracked connection's first discovery of each profile, leaving out the user's own tracked connections
+per_tracked AS (
+    SELECT DISTINCT ON (d.user_id, d.tracked_profile_id, d.profile_id)
+           d.user_id, d.tracked_profile_id, d.profile_id, d.degree, d.discovered_at
+    FROM discoveries d
+    WHERE NOT EXISTS (
+        SELECT 1 FROM tracked_connections own
+        WHERE own.user_id = d.user_id AND own.profile_id = d.profile_id
+    )
+    ORDER BY d.user_id, d.tracked_profile_id, d.profile_id, d.discovered_at, d.degree
+)
+SELECT pt.user_id, pt.tracked_profile_id, pt.profile_id, pt.degree, pt.discovered_at,
+       -- Whether this is the user's first discovery of the profile through any tracked connection
+       ROW_NUMBER() OVER (
+           PARTITION BY pt.user_id, pt.profile_id
+           ORDER BY pt.discovered_at, pt.degree, pt.tracked_profile_id
+       ) = 1 AS first_for_user,
+       c.name AS company_name,
+       COALESCE(loc.city, lp.location) AS location,
+       loc.country_code
+FROM per_tracked pt
+JOIN linkedin_profiles lp ON lp.id = pt.profile_id
+LEFT JOIN companies c ON c.id = lp.current_company_id
+LEFT JOIN profile_locations loc ON loc.profile_id = lp.id;
+
+CREATE UNIQUE INDEX idx_network_discoveries_key ON network_discoveries(user_id, tracked_profile_id, profile_id);
+CREATE INDEX idx_network_discoveries_user_discovered ON network_discoveries(user_id, discovered_at);
+
+-- Every connection of each user's tracked connections found to be removed
+CREATE MATERIALIZED VIEW network_removals AS
+SELECT pe.id AS event_id, tc.user_id, tc.profile_id AS tracked_profile_id,
+       CASE WHEN pe.related_profile_id = tc.profile_id THEN pe.profile_id ELSE pe.related_profile_id END AS profile_id,
+       pe.detected_at AS removed_at
+FROM profile_events pe
+JOIN tracked_connections tc ON tc.profile_id IN (pe.profile_id, pe.related_profile_id)
+WHERE pe.event_type = 'connection_removed' AND pe.related_profile_id IS NOT NULL;
+
+CREATE UNIQUE INDEX idx_network_removals_key ON network_removals(event_id, user_id, tracked_profile_id);
+CREATE INDEX idx_network_removals_user_removed ON network_removals(user_id, removed_at);
@@ -0,0 +1,15 @@
+-- Every action an automation rule has taken on a profile. The unique key makes
+-- sure a rule actions a profile at most once; a failed attempt is retried by
+-- later runs on the same row, counting attempts.
+CREATE TABLE rule_executions (
+  id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  rule_id           UUID NOT NULL REFERENCES automation_rules(id) ON DELETE CASCADE,
+  profile_id        UUID NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+  status            VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+  error             TEXT,
+  attempts          INTEGER NOT NULL DEFAULT 1,
+  executed_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+  UNIQUE (rule_id, profile_id)
+);
+
+CREATE INDEX idx_rule_executions_profile ON rule_executions(profile_id);
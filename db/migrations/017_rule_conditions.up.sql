@@ -0,0 +1,65 @@
+-- Rules pick profiles with a condition expression evaluated by the rule
+-- engine, e.g. company in ["Acme", "Globex"] and degree <= 2. The substring
+-- filters become contains comparisons, which also ignore case. Filters using
+-- ILIKE wildcards become matches comparisons with the equivalent regular
+-- expression instead, so they keep matching what they did.
+ALTER TABLE automation_rules ADD COLUMN condition TEXT;
+
+-- like_regex translates an ILIKE pattern into a regular expression: % and _
+-- become .* and ., a backslash makes the next character literal and
+-- regular expression metacharacters are escaped
+CREATE FUNCTION pg_temp.like_regex(pattern TEXT) RETURNS TEXT AS $$
+DECLARE
+  result  TEXT := '';
+  escaped BOOLEAN := false;
+  c       TEXT;
+BEGIN
+  FOREACH c IN ARRAY regexp_split_to_array(pattern, '') LOOP
+    IF NOT escaped THEN
+      IF c = '\' THEN
+        escaped := true;
+        CONTINUE;
+      ELSIF c = '%' THEN
+        result := result || '.*';
+        CONTINUE;
+      ELSIF c = '_' THEN
+        result := result || '.';
+        CONTINUE;
+      END IF;
+    END IF;
+    escaped := false;
+    IF strpos('\.+*?()|[]{}^$', c) > 0 THEN
+      result := result || '\';
+    END IF;
+    result := result || c;
+  END LOOP;
+  RETURN result;
+END;
+$$ LANGUAGE plpgsql;
+
+-- condition_string quotes a value as a condition string literal, escaping
+-- what Go string literals cannot hold as is
+CREATE FUNCTION pg_temp.condition_string(value TEXT) RETURNS TEXT AS $$
+  SELECT '"' || replace(replace(replace(replace(value,
+    '\', '\\'), '"', '\"'), E'\n', '\n'), E'\r', '\r') || '"'
+$$ LANGUAGE sql;
+
+-- condition_filter returns the comparison equivalent to field ILIKE '%' || filter || '%'
+CREATE FUNCTION pg_temp.condition_filter(field TEXT, filter TEXT) RETURNS TEXT AS $$
+  SELECT CASE
+    WHEN filter IS NULL THEN NULL
+    WHEN filter ~ '[%_\\]' THEN field || ' matches ' || pg_temp.condition_string(pg_temp.like_regex(filter))
+    ELSE field || ' contains ' || pg_temp.condition_string(filter)
+  END
+$$ LANGUAGE sql;
+
+UPDATE automation_rules
+SET condition = CONCAT_WS(' and ',
+    pg_temp.condition_filter('company', company_filter),
+    pg_temp.condition_filter('location', location_filter)
+)
+WHERE company_filter IS NOT NULL OR location_filter IS NOT NULL;
+
+ALTER TABLE automation_rules
+  DROP COLUMN company_filter,
+  DROP COLUMN location_filter;
@@ -0,0 +1,8 @@
+-- What an action found or did on the profile, e.g. a connection request that
+-- was already pending. Skipped actions could not be performed, and unlike
+-- failed ones are not retried.
+ALTER TABLE rule_executions ADD COLUMN outcome VARCHAR(30);
+
+ALTER TABLE rule_executions DROP CONSTRAINT rule_executions_status_check;
+ALTER TABLE rule_executions ADD CONSTRAINT rule_executions_status_check
+  CHECK (status IN ('pending', 'succeeded', 'failed', 'skipped'));
@@ -0,0 +1,27 @@
+-- Invitation limits of the LinkedIn account each user's rules send connection
+-- requests from; users without a row use the configured defaults. Sending is
+-- paused for a day when LinkedIn reports its own limit was reached.
+CREATE TABLE invitation_quotas (
+  user_id           UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  daily_limit       INTEGER NOT NULL CHECK (daily_limit >= 0),
+  weekly_limit      INTEGER NOT NULL CHECK (weekly_limit >= 0),
+  paused_until      TIMESTAMP,
+  updated_at        TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Connection requests sent, kept apart from rule executions so that deleting
+-- a rule does not free up quota
+CREATE TABLE sent_invitations (
+  id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  user_id           UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  profile_id        UUID REFERENCES linkedin_profiles(id) ON DELETE SET NULL,
+  sent_at           TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_sent_invitations_user ON sent_invitations(user_id, sent_at);
+
+INSERT INTO sent_invitations (user_id, profile_id, sent_at)
+SELECT ar.user_id, re.profile_id, re.executed_at
+FROM rule_executions re
+JOIN automation_rules ar ON re.rule_id = ar.id
+WHERE re.outcome = 'sent';
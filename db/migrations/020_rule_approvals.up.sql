@@ -0,0 +1,16 @@
+-- Rules requiring approval queue their actions for the user instead of
+-- performing them. A queued action holds its rendered message, which the user
+-- may edit, until it is rejected or approved and performed by the next run.
+-- Approved actions that fail are retried with the approved message.
+ALTER TABLE automation_rules ADD COLUMN requires_approval BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE rule_executions
+  ADD COLUMN message TEXT,
+  ADD COLUMN approved_at TIMESTAMP;
+
+ALTER TABLE rule_executions DROP CONSTRAINT rule_executions_status_check;
+ALTER TABLE rule_executions ADD CONSTRAINT rule_executions_status_check
+  CHECK (status IN ('pending', 'succeeded', 'failed', 'skipped', 'awaiting_approval', 'approved', 'rejected'));
+
+CREATE INDEX idx_rule_executions_queue ON rule_executions(rule_id, executed_at)
+  WHERE status IN ('awaiting_approval', 'approved');
@@ -0,0 +1,40 @@
+-- A rule's sequence is a list of follow-up steps performed after its own
+-- action succeeds, stored as the JSON the API takes. Each profile the rule
+-- actioned is enrolled in the sequence, which keeps the step it waits on and
+-- the events observed so far; the scheduler checks for events and performs
+-- steps whose wait is over.
+ALTER TABLE automation_rules ADD COLUMN sequence JSONB;
+
+CREATE TABLE sequence_enrollments (
+  id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  rule_id           UUID NOT NULL REFERENCES automation_rules(id) ON DELETE CASCADE,
+  profile_id        UUID NOT NULL REFERENCES linkedin_profiles(id) ON DELETE CASCADE,
+  -- step is the 1-based step waited on, its wait starting at step_started_at
+  step              INTEGER NOT NULL DEFAULT 1,
+  step_started_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+  status            VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed', 'exited', 'failed')),
+  exit_reason       VARCHAR(30),
+  attempts          INTEGER NOT NULL DEFAULT 0,
+  error             TEXT,
+  accepted_at       TIMESTAMP,
+  replied_at        TIMESTAMP,
+  created_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+  UNIQUE (rule_id, profile_id)
+);
+
+CREATE INDEX idx_sequence_enrollments_active ON sequence_enrollments(rule_id) WHERE status = 'active';
+
+CREATE TABLE sequence_step_runs (
+  id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  enrollment_id     UUID NOT NULL REFERENCES sequence_enrollments(id) ON DELETE CASCADE,
+  step              INTEGER NOT NULL,
+  action_type       VARCHAR(50) NOT NULL,
+  status            VARCHAR(20) NOT NULL CHECK (status IN ('succeeded', 'failed', 'skipped')),
+  outcome           VARCHAR(30),
+  message           TEXT,
+  error             TEXT,
+  executed_at       TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_sequence_step_runs_enrollment ON sequence_step_runs(enrollment_id, executed_at);
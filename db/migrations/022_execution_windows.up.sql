@@ -0,0 +1,17 @@
+-- Rule actions and sequence steps run only within the user's execution
+-- window, evaluated in the rule's timezone if it has one, else the user's.
+-- Users without a window, and users or rules without a timezone, fall back to
+-- acting at any time and to the server's default timezone respectively.
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64);
+ALTER TABLE automation_rules ADD COLUMN timezone VARCHAR(64);
+
+CREATE TABLE execution_windows (
+  user_id           UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  -- weekdays are ISO day numbers, 1 for Monday to 7 for Sunday
+  weekdays          INTEGER[] NOT NULL,
+  start_time        TIME NOT NULL,
+  end_time          TIME NOT NULL,
+  holidays          DATE[] NOT NULL DEFAULT '{}',
+  updated_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+  CHECK (start_time < end_time)
+);
@@ -9,24 +9,58 @@ import (
 )
 
 type AutomationRule struct {
+	ID               pgtype.UUID
+	UserID           pgtype.UUID
+	Name             string
+	ActionType       string
+	MessageTemplate  pgtype.Text
+	IsActive         bool
+	CreatedAt        pgtype.Timestamp
+	MinNetworkScore  pgtype.Float8
+	TriggerType      string
+	ListID           pgtype.UUID
+	CountryCode      pgtype.Text
+	Near             pgtype.Text
+	NearLatitude     pgtype.Float8
+	NearLongitude    pgtype.Float8
+	RadiusKm         pgtype.Float8
+	MinSeniority     pgtype.Text
+	JobFunction      pgtype.Text
+	Condition        pgtype.Text
+	RequiresApproval bool
+	Sequence         []byte
+	Timezone         pgtype.Text
+}
+
+type Company struct {
+	ID             pgtype.UUID
+	Name           string
+	LinkedinUrl    pgtype.Text
+	Industry       pgtype.Text
+	CreatedAt      pgtype.Timestamp
+	UpdatedAt      pgtype.Timestamp
+	NormalizedName string
+}
+
+type CompanyAlias struct {
 	ID              pgtype.UUID
-	UserID          pgtype.UUID
-	Name            string
-	CompanyFilter   pgtype.Text
-	LocationFilter  pgtype.Text
-	ActionType      string
-	MessageTemplate pgtype.Text
-	IsActive        bool
+	CompanyID       pgtype.UUID
+	Alias           string
+	NormalizedAlias string
 	CreatedAt       pgtype.Timestamp
 }
 
-type Company struct {
-	ID          pgtype.UUID
-	Name        string
-	LinkedinUrl pgtype.Text
-	Industry    pgtype.Text
-	CreatedAt   pgtype.Timestamp
-	UpdatedAt   pgtype.Timestamp
+type CompanyWatch struct {
+	UserID    pgtype.UUID
+	CompanyID pgtype.UUID
+	CreatedAt pgtype.Timestamp
+}
+
+type CompanyWatchMatch struct {
+	UserID    pgtype.UUID
+	CompanyID pgtype.UUID
+	ProfileID pgtype.UUID
+	MatchedAt pgtype.Timestamp
 }
 
 type ConnectionRelationship struct {
@@ -36,6 +70,37 @@ type ConnectionRelationship struct {
 	Degree             int32
 	DiscoveredAt       pgtype.Timestamp
 	DiscoveredByUserID pgtype.UUID
+	MissedChecks       int32
+}
+
+type DuplicateCandidate struct {
+	ID          pgtype.UUID
+	ProfileID   pgtype.UUID
+	DuplicateID pgtype.UUID
+	Score       float64
+	Reasons     []string
+	Status      string
+	ReviewedBy  pgtype.UUID
+	ReviewedAt  pgtype.Timestamp
+	DetectedAt  pgtype.Timestamp
+	CreatedAt   pgtype.Timestamp
+}
+
+type ExecutionWindow struct {
+	UserID    pgtype.UUID
+	Weekdays  []int32
+	StartTime pgtype.Time
+	EndTime   pgtype.Time
+	Holidays  []pgtype.Date
+	UpdatedAt pgtype.Timestamp
+}
+
+type InvitationQuota struct {
+	UserID      pgtype.UUID
+	DailyLimit  int32
+	WeeklyLimit int32
+	PausedUntil pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
 }
 
 type LinkedinProfile struct {
@@ -50,6 +115,62 @@ type LinkedinProfile struct {
 	UpdatedAt        pgtype.Timestamp `json:"updated_at" db:"updated_at"`
 }
 
+type NetworkCluster struct {
+	ID           pgtype.UUID
+	UserID       pgtype.UUID
+	Label        string
+	Size         int32
+	TopCompanies []string
+	TopLocations []string
+	ComputedAt   pgtype.Timestamp
+}
+
+type NetworkDiscovery struct {
+	UserID           pgtype.UUID
+	TrackedProfileID pgtype.UUID
+	ProfileID        pgtype.UUID
+	Degree           int32
+	DiscoveredAt     pgtype.Timestamp
+	FirstForUser     bool
+	CompanyName      pgtype.Text
+	Location         pgtype.Text
+	CountryCode      pgtype.Text
+}
+
+type NetworkRemoval struct {
+	EventID          pgtype.UUID
+	UserID           pgtype.UUID
+	TrackedProfileID pgtype.UUID
+	ProfileID        pgtype.UUID
+	RemovedAt        pgtype.Timestamp
+}
+
+type Notification struct {
+	ID        pgtype.UUID
+	UserID    pgtype.UUID
+	Kind      string
+	Title     string
+	Body      string
+	Payload   []byte
+	CreatedAt pgtype.Timestamp
+	ReadAt    pgtype.Timestamp
+}
+
+type NotificationChannel struct {
+	ID          pgtype.UUID
+	UserID      pgtype.UUID
+	ChannelType string
+	Target      string
+	IsActive    bool
+	CreatedAt   pgtype.Timestamp
+}
+
+type ProfileCluster struct {
+	UserID    pgtype.UUID
+	ProfileID pgtype.UUID
+	ClusterID pgtype.UUID
+}
+
 type ProfileCompany struct {
 	ID        pgtype.UUID
 	ProfileID pgtype.UUID
@@ -61,6 +182,162 @@ type ProfileCompany struct {
 	CreatedAt pgtype.Timestamp
 }
 
+type ProfileEvent struct {
+	ID               pgtype.UUID
+	ProfileID        pgtype.UUID
+	EventType        string
+	OldCompanyID     pgtype.UUID
+	NewCompanyID     pgtype.UUID
+	OldPosition      pgtype.Text
+	NewPosition      pgtype.Text
+	DetectedAt       pgtype.Timestamp
+	RelatedProfileID pgtype.UUID
+	OldHeadline      pgtype.Text
+	NewHeadline      pgtype.Text
+}
+
+type ProfileHeadline struct {
+	ProfileID   pgtype.UUID
+	RawHeadline string
+	Title       pgtype.Text
+	Employer    pgtype.Text
+	Seniority   pgtype.Text
+	JobFunction pgtype.Text
+	ParsedAt    pgtype.Timestamp
+}
+
+type ProfileList struct {
+	ID          pgtype.UUID
+	UserID      pgtype.UUID
+	Name        string
+	Description pgtype.Text
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
+type ProfileListMember struct {
+	ListID    pgtype.UUID
+	ProfileID pgtype.UUID
+	AddedAt   pgtype.Timestamp
+}
+
+type ProfileLocation struct {
+	ProfileID   pgtype.UUID
+	RawLocation string
+	City        pgtype.Text
+	Region      pgtype.Text
+	CountryCode pgtype.Text
+	Latitude    pgtype.Float8
+	Longitude   pgtype.Float8
+	ParsedAt    pgtype.Timestamp
+}
+
+type ProfileNetworkScore struct {
+	UserID      pgtype.UUID
+	ProfileID   pgtype.UUID
+	Degree      int32
+	Betweenness float64
+	Pagerank    float64
+	ComputedAt  pgtype.Timestamp
+}
+
+type ProfileNote struct {
+	ID        pgtype.UUID
+	UserID    pgtype.UUID
+	ProfileID pgtype.UUID
+	Body      string
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
+type ProfileSearch struct {
+	ProfileID pgtype.UUID
+	Document  interface{}
+}
+
+type ProfileTag struct {
+	UserID    pgtype.UUID
+	ProfileID pgtype.UUID
+	Tag       string
+	CreatedAt pgtype.Timestamp
+}
+
+type RuleExecution struct {
+	ID         pgtype.UUID
+	RuleID     pgtype.UUID
+	ProfileID  pgtype.UUID
+	Status     string
+	Error      pgtype.Text
+	Attempts   int32
+	ExecutedAt pgtype.Timestamp
+	Outcome    pgtype.Text
+	Message    pgtype.Text
+	ApprovedAt pgtype.Timestamp
+}
+
+type SavedSearch struct {
+	ID              pgtype.UUID
+	UserID          pgtype.UUID
+	Name            string
+	Query           pgtype.Text
+	CompanyID       pgtype.UUID
+	Location        pgtype.Text
+	Degree          pgtype.Int4
+	Tag             pgtype.Text
+	ListID          pgtype.UUID
+	IsActive        bool
+	LastEvaluatedAt pgtype.Timestamp
+	CreatedAt       pgtype.Timestamp
+	CountryCode     pgtype.Text
+	Near            pgtype.Text
+	NearLatitude    pgtype.Float8
+	NearLongitude   pgtype.Float8
+	RadiusKm        pgtype.Float8
+	MinSeniority    pgtype.Text
+	JobFunction     pgtype.Text
+}
+
+type SavedSearchMatch struct {
+	SavedSearchID pgtype.UUID
+	ProfileID     pgtype.UUID
+	MatchedAt     pgtype.Timestamp
+}
+
+type SentInvitation struct {
+	ID        pgtype.UUID
+	UserID    pgtype.UUID
+	ProfileID pgtype.UUID
+	SentAt    pgtype.Timestamp
+}
+
+type SequenceEnrollment struct {
+	ID            pgtype.UUID
+	RuleID        pgtype.UUID
+	ProfileID     pgtype.UUID
+	Step          int32
+	StepStartedAt pgtype.Timestamp
+	Status        string
+	ExitReason    pgtype.Text
+	Attempts      int32
+	Error         pgtype.Text
+	AcceptedAt    pgtype.Timestamp
+	RepliedAt     pgtype.Timestamp
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+}
+
+type SequenceStepRun struct {
+	ID           pgtype.UUID
+	EnrollmentID pgtype.UUID
+	Step         int32
+	ActionType   string
+	Status       string
+	Outcome      pgtype.Text
+	Message      pgtype.Text
+	Error        pgtype.Text
+	ExecutedAt   pgtype.Timestamp
+}
+
 type TrackedConnection struct {
 	ID            pgtype.UUID
 	UserID        pgtype.UUID
@@ -81,4 +358,12 @@ type User struct {
 	IsActive     bool
 	CreatedAt    pgtype.Timestamp
 	UpdatedAt    pgtype.Timestamp
+	Timezone     pgtype.Text
+}
+
+type UserProfileDegree struct {
+	UserID       pgtype.UUID
+	ProfileID    pgtype.UUID
+	Degree       int32
+	ViaProfileID pgtype.UUID
 }
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// sessionTimezone is the clock NOW() is read on for TIMESTAMP columns. Times
+// sent from Go are converted to it, so both sides agree whatever the
+// timezone of the database server or of this process.
+const sessionTimezone = "UTC"
+
+// Connect opens a connection pool whose sessions run in UTC
+func Connect(ctx context.Context, connString string) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, err
+	}
+	config.ConnConfig.RuntimeParams["timezone"] = sessionTimezone
+	config.AfterConnect = func(_ context.Context, conn *pgx.Conn) error {
+		registerUTCTimestamp(conn.TypeMap())
+		return nil
+	}
+	return pgxpool.NewWithConfig(ctx, config)
+}
+
+// registerUTCTimestamp makes TIMESTAMP parameters carry the UTC wall clock of
+// the time they are given, instead of the wall clock of its location
+func registerUTCTimestamp(m *pgtype.Map) {
+	m.RegisterType(&pgtype.Type{Name: "timestamp", OID: pgtype.TimestampOID, Codec: &utcTimestampCodec{}})
+}
+
+type utcTimestampCodec struct {
+	pgtype.TimestampCodec
+}
+
+func (c *utcTimestampCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	if _, ok := value.(pgtype.TimestampValuer); !ok {
+		return nil
+	}
+	next := c.TimestampCodec.PlanEncode(m, oid, format, pgtype.Timestamp{})
+	if next == nil {
+		return nil
+	}
+	return utcTimestampPlan{next: next}
+}
+
+type utcTimestampPlan struct {
+	next pgtype.EncodePlan
+}
+
+func (p utcTimestampPlan) Encode(value any, buf []byte) ([]byte, error) {
+	ts, err := value.(pgtype.TimestampValuer).TimestampValue()
+	if err != nil {
+		return nil, err
+	}
+	ts.Time = ts.Time.UTC()
+	return p.next.Encode(ts, buf)
+}
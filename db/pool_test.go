@@ -0,0 +1,34 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUTCTimestamp(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+	m := pgtype.NewMap()
+	registerUTCTimestamp(m)
+
+	at := time.Date(2026, 10, 19, 9, 30, 0, 0, madrid)
+	for _, value := range []any{at, pgtype.Timestamp{Time: at, Valid: true}} {
+		text, err := m.Encode(pgtype.TimestampOID, pgtype.TextFormatCode, value, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "2026-10-19 07:30:00", string(text))
+
+		binary, err := m.Encode(pgtype.TimestampOID, pgtype.BinaryFormatCode, value, nil)
+		require.NoError(t, err)
+		var scanned pgtype.Timestamp
+		require.NoError(t, m.Scan(pgtype.TimestampOID, pgtype.BinaryFormatCode, binary, &scanned))
+		assert.True(t, at.Equal(scanned.Time), scanned.Time)
+	}
+
+	null, err := m.Encode(pgtype.TimestampOID, pgtype.BinaryFormatCode, pgtype.Timestamp{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, null)
+}
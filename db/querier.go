@@ -37,6 +37,10 @@ type Querier interface {
 	UpdateCompany(ctx context.Context, arg UpdateCompanyParams) error
 	ListCompanies(ctx context.Context) ([]Company, error)
 
+	// Network graph methods
+	StreamUserGraphNodes(ctx context.Context, userID pgtype.UUID, fn func(UserGraphNodeRow) error) error
+	StreamUserGraphEdges(ctx context.Context, userID pgtype.UUID, fn func(UserGraphEdgeRow) error) error
+
 	// Utility methods
 	PingDb(ctx context.Context) (int32, error)
 }
@@ -1,16 +1,16 @@
 -- Users queries
 -- name: GetUserByID :one
-SELECT id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at
+SELECT id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at, timezone
 FROM users
 WHERE id = $1;
 
 -- name: GetUserByGoogleID :one
-SELECT id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at
+SELECT id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at, timezone
 FROM users
 WHERE google_id = $1;
 
 -- name: GetUserByEmail :one
-SELECT id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at
+SELECT id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at, timezone
 FROM users
 WHERE email = $1;
 
@@ -70,35 +70,148 @@ ORDER BY created_at DESC;
 
 -- Companies queries
 -- name: GetCompanyByID :one
-SELECT id, name, linkedin_url, industry, created_at, updated_at
+SELECT id, name, linkedin_url, industry, created_at, updated_at, normalized_name
 FROM companies
 WHERE id = $1;
 
 -- name: GetCompanyByName :one
-SELECT id, name, linkedin_url, industry, created_at, updated_at
+SELECT id, name, linkedin_url, industry, created_at, updated_at, normalized_name
 FROM companies
 WHERE name = $1;
 
 -- name: GetCompanyByLinkedInURL :one
-SELECT id, name, linkedin_url, industry, created_at, updated_at
+SELECT id, name, linkedin_url, industry, created_at, updated_at, normalized_name
 FROM companies
 WHERE linkedin_url = $1;
 
 -- name: CreateCompany :one
-INSERT INTO companies (name, linkedin_url, industry)
-VALUES ($1, $2, $3)
+INSERT INTO companies (name, linkedin_url, industry, normalized_name)
+VALUES ($1, $2, $3, $4)
 RETURNING *;
 
 -- name: UpdateCompany :exec
 UPDATE companies 
-SET name = $2, linkedin_url = $3, industry = $4, updated_at = NOW()
+SET name = $2, linkedin_url = $3, industry = $4, normalized_name = $5, updated_at = NOW()
 WHERE id = $1;
 
 -- name: ListCompanies :many
-SELECT id, name, linkedin_url, industry, created_at, updated_at
+SELECT id, name, linkedin_url, industry, created_at, updated_at, normalized_name
 FROM companies
 ORDER BY name;
 
+-- name: FindCompanyByNormalizedName :one
+SELECT id, name, linkedin_url, industry, created_at, updated_at, normalized_name
+FROM companies
+WHERE normalized_name = $1
+   OR id IN (SELECT ca.company_id FROM company_aliases ca WHERE ca.normalized_alias = $1)
+ORDER BY created_at
+LIMIT 1;
+
+-- name: CountCompanyCurrentEmployees :one
+SELECT COUNT(*) FROM linkedin_profiles
+WHERE current_company_id = $1;
+
+-- name: DeleteCompany :exec
+DELETE FROM companies
+WHERE id = $1;
+
+-- Company Aliases queries
+-- name: CreateCompanyAlias :exec
+INSERT INTO company_aliases (company_id, alias, normalized_alias)
+VALUES ($1, $2, $3)
+ON CONFLICT (normalized_alias) DO UPDATE SET company_id = EXCLUDED.company_id;
+
+-- name: ListCompanyAliases :many
+SELECT id, company_id, alias, normalized_alias, created_at
+FROM company_aliases
+WHERE company_id = $1
+ORDER BY alias;
+
+-- Company Merge queries
+-- name: MoveCompanyAliases :exec
+UPDATE company_aliases
+SET company_id = sqlc.arg(target_id)
+WHERE company_id = sqlc.arg(source_id);
+
+-- name: MergeDuplicateProfileCompanies :exec
+UPDATE profile_companies t
+SET is_current = true
+FROM profile_companies s
+WHERE t.company_id = sqlc.arg(target_id) AND s.company_id = sqlc.arg(source_id)
+  AND t.profile_id = s.profile_id AND t.position = s.position AND t.start_date = s.start_date
+  AND s.is_current AND NOT t.is_current;
+
+-- name: DeleteDuplicateProfileCompanies :exec
+DELETE FROM profile_companies s
+USING profile_companies t
+WHERE s.company_id = sqlc.arg(source_id) AND t.company_id = sqlc.arg(target_id)
+  AND t.profile_id = s.profile_id AND t.position = s.position AND t.start_date = s.start_date;
+
+-- name: RepointProfileCompanies :exec
+UPDATE profile_companies
+SET company_id = sqlc.arg(target_id)
+WHERE company_id = sqlc.arg(source_id);
+
+-- name: RepointProfilesCurrentCompany :exec
+UPDATE linkedin_profiles
+SET current_company_id = sqlc.arg(target_id), updated_at = NOW()
+WHERE current_company_id = sqlc.arg(source_id);
+
+-- name: RepointProfileEventCompanies :exec
+UPDATE profile_events
+SET old_company_id = CASE WHEN old_company_id = sqlc.arg(source_id) THEN sqlc.arg(target_id) ELSE old_company_id END,
+    new_company_id = CASE WHEN new_company_id = sqlc.arg(source_id) THEN sqlc.arg(target_id) ELSE new_company_id END
+WHERE old_company_id = sqlc.arg(source_id) OR new_company_id = sqlc.arg(source_id);
+
+-- name: RepointSavedSearchCompanies :exec
+UPDATE saved_searches
+SET company_id = sqlc.arg(target_id)
+WHERE company_id = sqlc.arg(source_id);
+
+-- Watches and matches already held for the target are left to cascade with the source
+-- name: RepointCompanyWatches :exec
+UPDATE company_watches s
+SET company_id = sqlc.arg(target_id)
+WHERE s.company_id = sqlc.arg(source_id)
+  AND NOT EXISTS (
+    SELECT 1 FROM company_watches t
+    WHERE t.user_id = s.user_id AND t.company_id = sqlc.arg(target_id)
+  );
+
+-- name: RepointCompanyWatchMatches :exec
+UPDATE company_watch_matches s
+SET company_id = sqlc.arg(target_id)
+WHERE s.company_id = sqlc.arg(source_id)
+  AND NOT EXISTS (
+    SELECT 1 FROM company_watch_matches t
+    WHERE t.user_id = s.user_id AND t.company_id = sqlc.arg(target_id) AND t.profile_id = s.profile_id
+  );
+
+-- name: GetCompanyPeople :many
+WITH people AS (
+    SELECT lp.id FROM linkedin_profiles lp WHERE lp.current_company_id = sqlc.arg(company_id)
+    UNION
+    SELECT pc.profile_id FROM profile_companies pc
+    WHERE pc.company_id = sqlc.arg(company_id) AND (pc.is_current OR sqlc.arg(include_former)::boolean)
+)
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       role.position, role.start_date, role.end_date,
+       (COALESCE(lp.current_company_id = sqlc.arg(company_id), false) OR COALESCE(role.is_current, false))::boolean as is_current,
+       upd.degree as best_degree,
+       via.id as via_profile_id, via.name as via_profile_name, via.linkedin_url as via_profile_url
+FROM people p
+JOIN linkedin_profiles lp ON p.id = lp.id
+LEFT JOIN LATERAL (
+    SELECT pc.position, pc.start_date, pc.end_date, pc.is_current
+    FROM profile_companies pc
+    WHERE pc.profile_id = lp.id AND pc.company_id = sqlc.arg(company_id)
+    ORDER BY pc.is_current DESC, pc.start_date DESC
+    LIMIT 1
+) role ON true
+LEFT JOIN user_profile_degrees upd ON upd.profile_id = lp.id AND upd.user_id = sqlc.arg(user_id)
+LEFT JOIN linkedin_profiles via ON upd.via_profile_id = via.id
+ORDER BY is_current DESC, upd.degree NULLS LAST, lp.name;
+
 -- LinkedIn Profiles queries
 -- name: GetLinkedInProfileByID :one
 SELECT id, linkedin_url, linkedin_id, name, location, current_company_id, headline, created_at, updated_at
@@ -125,6 +238,573 @@ SELECT id, linkedin_url, linkedin_id, name, location, current_company_id, headli
 FROM linkedin_profiles
 ORDER BY name;
 
+-- Duplicate Candidates queries
+-- name: ListProfilesForDuplicateDetection :many
+SELECT id, linkedin_url, linkedin_id, name, location, current_company_id, created_at
+FROM linkedin_profiles
+ORDER BY id;
+
+-- Rejected pairs keep their status; pending pairs get the new score
+-- name: UpsertDuplicateCandidate :exec
+INSERT INTO duplicate_candidates (profile_id, duplicate_id, score, reasons, detected_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (profile_id, duplicate_id) DO UPDATE
+SET score = EXCLUDED.score, reasons = EXCLUDED.reasons, detected_at = EXCLUDED.detected_at
+WHERE duplicate_candidates.status = 'pending';
+
+-- Pending pairs not detected by the latest run no longer look alike
+-- name: DeleteUndetectedDuplicateCandidates :exec
+DELETE FROM duplicate_candidates
+WHERE status = 'pending' AND detected_at < $1;
+
+-- name: ListDuplicateCandidates :many
+SELECT dc.id, dc.score, dc.reasons, dc.status, dc.reviewed_at, dc.created_at,
+       p.id as profile_id, p.linkedin_url as profile_linkedin_url, p.name as profile_name,
+       p.location as profile_location, p.headline as profile_headline, pc.name as profile_company_name,
+       d.id as duplicate_id, d.linkedin_url as duplicate_linkedin_url, d.name as duplicate_name,
+       d.location as duplicate_location, d.headline as duplicate_headline, dco.name as duplicate_company_name
+FROM duplicate_candidates dc
+JOIN linkedin_profiles p ON dc.profile_id = p.id
+JOIN linkedin_profiles d ON dc.duplicate_id = d.id
+LEFT JOIN companies pc ON p.current_company_id = pc.id
+LEFT JOIN companies dco ON d.current_company_id = dco.id
+WHERE dc.status = $1
+ORDER BY dc.score DESC, dc.created_at, dc.id
+LIMIT $2;
+
+-- name: GetDuplicateCandidate :one
+SELECT id, profile_id, duplicate_id, score, reasons, status, reviewed_by, reviewed_at, detected_at, created_at
+FROM duplicate_candidates
+WHERE id = $1;
+
+-- name: RejectDuplicateCandidate :execrows
+UPDATE duplicate_candidates
+SET status = 'rejected', reviewed_by = $2, reviewed_at = NOW()
+WHERE id = $1 AND status = 'pending';
+
+-- Profile Merge queries
+-- name: DeleteRelationshipsBetween :exec
+DELETE FROM connection_relationships
+WHERE (profile_a_id = sqlc.arg(source_id) AND profile_b_id = sqlc.arg(target_id))
+   OR (profile_a_id = sqlc.arg(target_id) AND profile_b_id = sqlc.arg(source_id));
+
+-- Relationships the target already has are left to cascade with the source
+-- name: RepointRelationshipsFrom :exec
+UPDATE connection_relationships s
+SET profile_a_id = sqlc.arg(target_id)
+WHERE s.profile_a_id = sqlc.arg(source_id)
+  AND NOT EXISTS (
+    SELECT 1 FROM connection_relationships t
+    WHERE t.profile_a_id = sqlc.arg(target_id) AND t.profile_b_id = s.profile_b_id AND t.degree = s.degree
+  );
+
+-- name: RepointRelationshipsTo :exec
+UPDATE connection_relationships s
+SET profile_b_id = sqlc.arg(target_id)
+WHERE s.profile_b_id = sqlc.arg(source_id)
+  AND NOT EXISTS (
+    SELECT 1 FROM connection_relationships t
+    WHERE t.profile_b_id = sqlc.arg(target_id) AND t.profile_a_id = s.profile_a_id AND t.degree = s.degree
+  );
+
+-- name: RepointTrackedConnections :exec
+UPDATE tracked_connections s
+SET profile_id = sqlc.arg(target_id)
+WHERE s.profile_id = sqlc.arg(source_id)
+  AND NOT EXISTS (
+    SELECT 1 FROM tracked_connections t
+    WHERE t.profile_id = sqlc.arg(target_id) AND t.user_id = s.user_id
+  );
+
+-- name: RepointProfileEmployment :exec
+UPDATE profile_companies s
+SET profile_id = sqlc.arg(target_id)
+WHERE s.profile_id = sqlc.arg(source_id)
+  AND NOT EXISTS (
+    SELECT 1 FROM profile_companies t
+    WHERE t.profile_id = sqlc.arg(target_id) AND t.company_id = s.company_id
+      AND t.position = s.position AND t.start_date = s.start_date
+  );
+
+-- name: RepointProfileEvents :exec
+UPDATE profile_events
+SET profile_id = sqlc.arg(target_id)
+WHERE profile_id = sqlc.arg(source_id);
+
+-- name: RepointProfileEventRelations :exec
+UPDATE profile_events
+SET related_profile_id = sqlc.arg(target_id)
+WHERE related_profile_id = sqlc.arg(source_id);
+
+-- name: RepointProfileTags :exec
+UPDATE profile_tags s
+SET profile_id = sqlc.arg(target_id)
+WHERE s.profile_id = sqlc.arg(source_id)
+  AND NOT EXISTS (
+    SELECT 1 FROM profile_tags t
+    WHERE t.profile_id = sqlc.arg(target_id) AND t.user_id = s.user_id AND t.tag = s.tag
+  );
+
+-- name: RepointRuleExecutions :exec
+UPDATE rule_executions s
+SET profile_id = sqlc.arg(target_id)
+WHERE s.profile_id = sqlc.arg(source_id)
+  AND NOT EXISTS (
+    SELECT 1 FROM rule_executions t
+    WHERE t.profile_id = sqlc.arg(target_id) AND t.rule_id = s.rule_id
+  );
+
+-- Drops the target's sequence enrollments that the source's enrollment in the
+-- same rule is further along than, so that RepointSequenceEnrollments keeps
+-- the more advanced of the two
+-- name: DeleteSupersededSequenceEnrollments :exec
+DELETE FROM sequence_enrollments t
+USING sequence_enrollments s
+WHERE t.profile_id = sqlc.arg(target_id) AND s.profile_id = sqlc.arg(source_id)
+  AND t.rule_id = s.rule_id AND s.step > t.step;
+
+-- name: RepointSequenceEnrollments :exec
+UPDATE sequence_enrollments s
+SET profile_id = sqlc.arg(target_id), updated_at = NOW()
+WHERE s.profile_id = sqlc.arg(source_id)
+  AND NOT EXISTS (
+    SELECT 1 FROM sequence_enrollments t
+    WHERE t.profile_id = sqlc.arg(target_id) AND t.rule_id = s.rule_id
+  );
+
+-- name: RepointProfileNotes :exec
+UPDATE profile_notes
+SET profile_id = sqlc.arg(target_id)
+WHERE profile_id = sqlc.arg(source_id);
+
+-- name: RepointProfileListMembers :exec
+UPDATE profile_list_members s
+SET profile_id = sqlc.arg(target_id)
+WHERE s.profile_id = sqlc.arg(source_id)
+  AND NOT EXISTS (
+    SELECT 1 FROM profile_list_members t
+    WHERE t.profile_id = sqlc.arg(target_id) AND t.list_id = s.list_id
+  );
+
+-- Fields the target lacks are taken from the source
+-- name: FillMergedProfile :exec
+UPDATE linkedin_profiles t
+SET linkedin_id = COALESCE(t.linkedin_id, s.linkedin_id),
+    location = COALESCE(t.location, s.location),
+    headline = COALESCE(t.headline, s.headline),
+    current_company_id = COALESCE(t.current_company_id, s.current_company_id),
+    updated_at = NOW()
+FROM linkedin_profiles s
+WHERE t.id = sqlc.arg(target_id) AND s.id = sqlc.arg(source_id);
+
+-- name: DeleteLinkedInProfile :exec
+DELETE FROM linkedin_profiles
+WHERE id = $1;
+
+-- Profile Locations queries
+-- name: ListProfilesWithStaleLocation :many
+SELECT lp.id, lp.location
+FROM linkedin_profiles lp
+LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+WHERE lp.location IS NOT NULL
+  AND (loc.profile_id IS NULL OR loc.raw_location <> lp.location)
+ORDER BY lp.id
+LIMIT $1;
+
+-- name: UpsertProfileLocation :exec
+INSERT INTO profile_locations (profile_id, raw_location, city, region, country_code, latitude, longitude, parsed_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+ON CONFLICT (profile_id) DO UPDATE
+SET raw_location = EXCLUDED.raw_location, city = EXCLUDED.city, region = EXCLUDED.region,
+    country_code = EXCLUDED.country_code, latitude = EXCLUDED.latitude, longitude = EXCLUDED.longitude,
+    parsed_at = EXCLUDED.parsed_at;
+
+-- name: DeleteStaleProfileLocations :exec
+DELETE FROM profile_locations loc
+USING linkedin_profiles lp
+WHERE loc.profile_id = lp.id AND lp.location IS NULL;
+
+-- Profile Headlines queries
+-- name: ListProfilesWithStaleHeadline :many
+SELECT lp.id, lp.headline
+FROM linkedin_profiles lp
+LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+WHERE lp.headline IS NOT NULL
+  AND (ph.profile_id IS NULL OR ph.raw_headline <> lp.headline)
+ORDER BY lp.id
+LIMIT $1;
+
+-- name: UpsertProfileHeadline :exec
+INSERT INTO profile_headlines (profile_id, raw_headline, title, employer, seniority, job_function, parsed_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW())
+ON CONFLICT (profile_id) DO UPDATE
+SET raw_headline = EXCLUDED.raw_headline, title = EXCLUDED.title, employer = EXCLUDED.employer,
+    seniority = EXCLUDED.seniority, job_function = EXCLUDED.job_function, parsed_at = EXCLUDED.parsed_at;
+
+-- name: DeleteStaleProfileHeadlines :exec
+DELETE FROM profile_headlines ph
+USING linkedin_profiles lp
+WHERE ph.profile_id = lp.id AND lp.headline IS NULL;
+
+-- Profile Search queries
+-- name: SearchProfiles :many
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name, upd.degree, ph.seniority, ph.job_function,
+       ts_rank(ps.document, websearch_to_tsquery('english', sqlc.arg(query)))::float8 as rank
+FROM profile_search ps
+JOIN linkedin_profiles lp ON ps.profile_id = lp.id
+LEFT JOIN companies c ON lp.current_company_id = c.id
+LEFT JOIN user_profile_degrees upd ON upd.profile_id = lp.id AND upd.user_id = sqlc.arg(user_id)
+LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+WHERE ps.document @@ websearch_to_tsquery('english', sqlc.arg(query))
+  AND (sqlc.narg(company_id)::uuid IS NULL OR lp.current_company_id = sqlc.narg(company_id)::uuid)
+  AND (sqlc.narg(location)::text IS NULL OR lp.location = sqlc.narg(location)::text)
+  AND (sqlc.narg(degree)::int IS NULL OR upd.degree = sqlc.narg(degree)::int)
+  AND (sqlc.narg(list_id)::uuid IS NULL OR EXISTS (
+      SELECT 1 FROM profile_list_members plm
+      JOIN profile_lists pl ON plm.list_id = pl.id
+      WHERE plm.list_id = sqlc.narg(list_id)::uuid AND pl.user_id = sqlc.arg(user_id) AND plm.profile_id = lp.id
+    ))
+  AND (sqlc.narg(tag)::text IS NULL OR EXISTS (
+      SELECT 1 FROM profile_tags pt
+      WHERE pt.user_id = sqlc.arg(user_id) AND pt.profile_id = lp.id AND pt.tag = sqlc.narg(tag)::text
+    ))
+  AND (sqlc.narg(country_code)::text IS NULL OR loc.country_code = sqlc.narg(country_code)::text)
+  AND (sqlc.narg(near_latitude)::float8 IS NULL OR distance_km(loc.latitude, loc.longitude,
+      sqlc.narg(near_latitude)::float8, sqlc.narg(near_longitude)::float8) <= sqlc.narg(radius_km)::float8)
+  AND (sqlc.narg(min_seniority)::text IS NULL OR seniority_rank(ph.seniority) >= seniority_rank(sqlc.narg(min_seniority)::text))
+  AND (sqlc.narg(job_function)::text IS NULL OR ph.job_function = sqlc.narg(job_function)::text)
+ORDER BY rank DESC, lp.name, lp.id
+LIMIT sqlc.arg(max_results) OFFSET sqlc.arg(skip);
+
+-- name: SearchProfileFacets :many
+WITH matches AS (
+    SELECT lp.current_company_id, c.name as company_name, lp.location, upd.degree, loc.country_code,
+           ph.seniority, ph.job_function
+    FROM profile_search ps
+    JOIN linkedin_profiles lp ON ps.profile_id = lp.id
+    LEFT JOIN companies c ON lp.current_company_id = c.id
+    LEFT JOIN user_profile_degrees upd ON upd.profile_id = lp.id AND upd.user_id = sqlc.arg(user_id)
+    LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+    LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+    WHERE ps.document @@ websearch_to_tsquery('english', sqlc.arg(query))
+      AND (sqlc.narg(company_id)::uuid IS NULL OR lp.current_company_id = sqlc.narg(company_id)::uuid)
+      AND (sqlc.narg(location)::text IS NULL OR lp.location = sqlc.narg(location)::text)
+      AND (sqlc.narg(degree)::int IS NULL OR upd.degree = sqlc.narg(degree)::int)
+      AND (sqlc.narg(list_id)::uuid IS NULL OR EXISTS (
+          SELECT 1 FROM profile_list_members plm
+          JOIN profile_lists pl ON plm.list_id = pl.id
+          WHERE plm.list_id = sqlc.narg(list_id)::uuid AND pl.user_id = sqlc.arg(user_id) AND plm.profile_id = lp.id
+        ))
+      AND (sqlc.narg(tag)::text IS NULL OR EXISTS (
+          SELECT 1 FROM profile_tags pt
+          WHERE pt.user_id = sqlc.arg(user_id) AND pt.profile_id = lp.id AND pt.tag = sqlc.narg(tag)::text
+        ))
+      AND (sqlc.narg(country_code)::text IS NULL OR loc.country_code = sqlc.narg(country_code)::text)
+      AND (sqlc.narg(near_latitude)::float8 IS NULL OR distance_km(loc.latitude, loc.longitude,
+          sqlc.narg(near_latitude)::float8, sqlc.narg(near_longitude)::float8) <= sqlc.narg(radius_km)::float8)
+      AND (sqlc.narg(min_seniority)::text IS NULL OR seniority_rank(ph.seniority) >= seniority_rank(sqlc.narg(min_seniority)::text))
+      AND (sqlc.narg(job_function)::text IS NULL OR ph.job_function = sqlc.narg(job_function)::text)
+)
+SELECT 'company'::text as facet, current_company_id::text as value, COALESCE(company_name, '')::text as label, COUNT(*) as count
+FROM matches WHERE current_company_id IS NOT NULL
+GROUP BY current_company_id, company_name
+UNION ALL
+SELECT 'location'::text, location::text, location::text, COUNT(*)
+FROM matches WHERE location IS NOT NULL
+GROUP BY location
+UNION ALL
+SELECT 'degree'::text, degree::text, degree::text, COUNT(*)
+FROM matches WHERE degree IS NOT NULL
+GROUP BY degree
+UNION ALL
+SELECT 'country'::text, country_code::text, country_code::text, COUNT(*)
+FROM matches WHERE country_code IS NOT NULL
+GROUP BY country_code
+UNION ALL
+SELECT 'seniority'::text, seniority::text, seniority::text, COUNT(*)
+FROM matches WHERE seniority IS NOT NULL
+GROUP BY seniority
+UNION ALL
+SELECT 'function'::text, job_function::text, job_function::text, COUNT(*)
+FROM matches WHERE job_function IS NOT NULL
+GROUP BY job_function
+UNION ALL
+SELECT 'total'::text, ''::text, ''::text, COUNT(*)
+FROM matches
+ORDER BY facet, count DESC, label;
+
+-- Profile Tags queries
+-- name: ListProfileTags :many
+SELECT tag FROM profile_tags
+WHERE user_id = $1 AND profile_id = $2
+ORDER BY tag;
+
+-- name: CreateProfileTag :exec
+INSERT INTO profile_tags (user_id, profile_id, tag)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteProfileTags :exec
+DELETE FROM profile_tags
+WHERE user_id = $1 AND profile_id = $2;
+
+-- name: ListUserTags :many
+SELECT tag, COUNT(*) as profile_count
+FROM profile_tags
+WHERE user_id = $1
+GROUP BY tag
+ORDER BY tag;
+
+-- Profile Notes queries
+-- name: ListProfileNotes :many
+SELECT id, user_id, profile_id, body, created_at, updated_at
+FROM profile_notes
+WHERE user_id = $1 AND profile_id = $2
+ORDER BY created_at DESC;
+
+-- name: CreateProfileNote :one
+INSERT INTO profile_notes (user_id, profile_id, body)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UpdateProfileNote :one
+UPDATE profile_notes
+SET body = $4, updated_at = NOW()
+WHERE id = $1 AND user_id = $2 AND profile_id = $3
+RETURNING *;
+
+-- name: DeleteProfileNote :execrows
+DELETE FROM profile_notes
+WHERE id = $1 AND user_id = $2 AND profile_id = $3;
+
+-- Profile Lists queries
+-- name: ListProfileLists :many
+SELECT pl.id, pl.user_id, pl.name, pl.description, pl.created_at, pl.updated_at,
+       COUNT(plm.profile_id) as member_count
+FROM profile_lists pl
+LEFT JOIN profile_list_members plm ON plm.list_id = pl.id
+WHERE pl.user_id = $1
+GROUP BY pl.id
+ORDER BY pl.name;
+
+-- name: GetProfileList :one
+SELECT id, user_id, name, description, created_at, updated_at
+FROM profile_lists
+WHERE id = $1 AND user_id = $2;
+
+-- name: CreateProfileList :one
+INSERT INTO profile_lists (user_id, name, description)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UpdateProfileList :one
+UPDATE profile_lists
+SET name = $3, description = $4, updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteProfileList :execrows
+DELETE FROM profile_lists
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetProfileListMembers :many
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name, plm.added_at
+FROM profile_list_members plm
+JOIN linkedin_profiles lp ON plm.profile_id = lp.id
+LEFT JOIN companies c ON lp.current_company_id = c.id
+WHERE plm.list_id = $1
+ORDER BY plm.added_at DESC;
+
+-- name: AddProfileListMember :exec
+INSERT INTO profile_list_members (list_id, profile_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveProfileListMember :execrows
+DELETE FROM profile_list_members
+WHERE list_id = $1 AND profile_id = $2;
+
+-- Saved Searches queries
+-- name: ListSavedSearches :many
+SELECT id, user_id, name, query, company_id, location, degree, tag, list_id, is_active, last_evaluated_at, created_at,
+       country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function
+FROM saved_searches
+WHERE user_id = $1
+ORDER BY name;
+
+-- name: ListActiveSavedSearches :many
+SELECT id, user_id, name, query, company_id, location, degree, tag, list_id, is_active, last_evaluated_at, created_at,
+       country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function
+FROM saved_searches
+WHERE user_id = $1 AND is_active = true
+ORDER BY created_at;
+
+-- name: GetSavedSearch :one
+SELECT id, user_id, name, query, company_id, location, degree, tag, list_id, is_active, last_evaluated_at, created_at,
+       country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function
+FROM saved_searches
+WHERE id = $1 AND user_id = $2;
+
+-- name: CreateSavedSearch :one
+INSERT INTO saved_searches (user_id, name, query, company_id, location, degree, tag, list_id,
+                            country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+RETURNING *;
+
+-- name: DeleteSavedSearch :execrows
+DELETE FROM saved_searches
+WHERE id = $1 AND user_id = $2;
+
+-- name: MarkSavedSearchEvaluated :exec
+UPDATE saved_searches
+SET last_evaluated_at = $2
+WHERE id = $1;
+
+-- name: RecordSavedSearchMatches :many
+WITH matches AS (
+    SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+           c.name as company_name, upd.degree
+    FROM linkedin_profiles lp
+    JOIN user_profile_degrees upd ON upd.profile_id = lp.id AND upd.user_id = sqlc.arg(user_id)
+    LEFT JOIN companies c ON lp.current_company_id = c.id
+    LEFT JOIN profile_search ps ON ps.profile_id = lp.id
+    LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+    LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+    WHERE (sqlc.narg(query)::text IS NULL OR ps.document @@ websearch_to_tsquery('english', sqlc.narg(query)::text))
+      AND (sqlc.narg(company_id)::uuid IS NULL OR lp.current_company_id = sqlc.narg(company_id)::uuid)
+      AND (sqlc.narg(location)::text IS NULL OR lp.location = sqlc.narg(location)::text)
+      AND (sqlc.narg(degree)::int IS NULL OR upd.degree = sqlc.narg(degree)::int)
+      AND (sqlc.narg(list_id)::uuid IS NULL OR EXISTS (
+        SELECT 1 FROM profile_list_members plm
+        WHERE plm.list_id = sqlc.narg(list_id)::uuid AND plm.profile_id = lp.id
+      ))
+      AND (sqlc.narg(tag)::text IS NULL OR EXISTS (
+        SELECT 1 FROM profile_tags pt
+        WHERE pt.user_id = sqlc.arg(user_id) AND pt.profile_id = lp.id AND pt.tag = sqlc.narg(tag)::text
+      ))
+      AND (sqlc.narg(country_code)::text IS NULL OR loc.country_code = sqlc.narg(country_code)::text)
+      AND (sqlc.narg(near_latitude)::float8 IS NULL OR distance_km(loc.latitude, loc.longitude,
+          sqlc.narg(near_latitude)::float8, sqlc.narg(near_longitude)::float8) <= sqlc.narg(radius_km)::float8)
+      AND (sqlc.narg(min_seniority)::text IS NULL OR seniority_rank(ph.seniority) >= seniority_rank(sqlc.narg(min_seniority)::text))
+      AND (sqlc.narg(job_function)::text IS NULL OR ph.job_function = sqlc.narg(job_function)::text)
+),
+inserted AS (
+    INSERT INTO saved_search_matches (saved_search_id, profile_id)
+    SELECT sqlc.arg(saved_search_id), m.id FROM matches m
+    ON CONFLICT DO NOTHING
+    RETURNING profile_id
+)
+SELECT m.id, m.linkedin_url, m.name, m.location, m.headline, m.company_name, m.degree
+FROM matches m
+JOIN inserted i ON i.profile_id = m.id
+ORDER BY m.name, m.id;
+
+-- name: ListSavedSearchMatches :many
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name, ssm.matched_at
+FROM saved_search_matches ssm
+JOIN linkedin_profiles lp ON ssm.profile_id = lp.id
+LEFT JOIN companies c ON lp.current_company_id = c.id
+WHERE ssm.saved_search_id = $1
+ORDER BY ssm.matched_at DESC, lp.name
+LIMIT $2;
+
+-- Company Watches queries
+-- name: ListCompanyWatches :many
+SELECT c.id, c.name, c.linkedin_url, c.industry, cw.created_at as watched_at,
+       (SELECT COUNT(*) FROM company_watch_matches cwm
+        WHERE cwm.user_id = cw.user_id AND cwm.company_id = cw.company_id) as match_count
+FROM company_watches cw
+JOIN companies c ON c.id = cw.company_id
+WHERE cw.user_id = $1
+ORDER BY c.name;
+
+-- name: CreateCompanyWatch :one
+INSERT INTO company_watches (user_id, company_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: DeleteCompanyWatch :execrows
+DELETE FROM company_watches
+WHERE user_id = $1 AND company_id = $2;
+
+-- name: DeleteCompanyWatchMatches :exec
+DELETE FROM company_watch_matches
+WHERE user_id = $1 AND company_id = $2;
+
+-- Records the 2nd and 3rd degree profiles currently at the user's watched companies
+-- (or just one of them) and returns only those not recorded before, with the
+-- tracked connection that bridges to each.
+-- name: RecordCompanyWatchMatches :many
+WITH matches AS (
+    SELECT lp.id, lp.linkedin_url, lp.name, lp.headline,
+           c.id as company_id, c.name as company_name, upd.degree,
+           via.id as via_profile_id, via.name as via_name, via.linkedin_url as via_linkedin_url
+    FROM company_watches cw
+    JOIN companies c ON c.id = cw.company_id
+    JOIN linkedin_profiles lp ON lp.current_company_id = cw.company_id
+    JOIN user_profile_degrees upd ON upd.user_id = cw.user_id AND upd.profile_id = lp.id
+    JOIN linkedin_profiles via ON via.id = upd.via_profile_id
+    WHERE cw.user_id = sqlc.arg(user_id)
+      AND upd.degree IN (2, 3)
+      AND (sqlc.narg(company_id)::uuid IS NULL OR cw.company_id = sqlc.narg(company_id)::uuid)
+),
+inserted AS (
+    INSERT INTO company_watch_matches (user_id, company_id, profile_id)
+    SELECT sqlc.arg(user_id), m.company_id, m.id FROM matches m
+    ON CONFLICT DO NOTHING
+    RETURNING company_id, profile_id
+)
+SELECT m.id, m.linkedin_url, m.name, m.headline, m.company_id, m.company_name, m.degree,
+       m.via_profile_id, m.via_name, m.via_linkedin_url
+FROM matches m
+JOIN inserted i ON i.company_id = m.company_id AND i.profile_id = m.id
+ORDER BY m.company_name, m.degree, m.name;
+
+-- Notification Channels queries
+-- name: ListNotificationChannels :many
+SELECT id, user_id, channel_type, target, is_active, created_at
+FROM notification_channels
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: ListActiveNotificationChannels :many
+SELECT id, user_id, channel_type, target, is_active, created_at
+FROM notification_channels
+WHERE user_id = $1 AND is_active = true
+ORDER BY created_at;
+
+-- name: CreateNotificationChannel :one
+INSERT INTO notification_channels (user_id, channel_type, target)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: DeleteNotificationChannel :execrows
+DELETE FROM notification_channels
+WHERE id = $1 AND user_id = $2;
+
+-- Notifications queries
+-- name: CreateNotification :one
+INSERT INTO notifications (user_id, kind, title, body, payload)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListNotifications :many
+SELECT id, user_id, kind, title, body, payload, created_at, read_at
+FROM notifications
+WHERE user_id = sqlc.arg(user_id)
+  AND (NOT sqlc.arg(unread_only)::boolean OR read_at IS NULL)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(max_results);
+
+-- name: MarkNotificationRead :execrows
+UPDATE notifications
+SET read_at = COALESCE(read_at, NOW())
+WHERE id = $1 AND user_id = $2;
+
 -- Profile Companies (Employment History) queries
 -- name: GetProfileCompanies :many
 SELECT pc.id, pc.profile_id, pc.company_id, pc.position, pc.start_date, pc.end_date, pc.is_current, pc.created_at,
@@ -148,10 +828,21 @@ WHERE profile_id = $1 AND company_id = $2;
 DELETE FROM profile_companies 
 WHERE profile_id = $1 AND company_id = $2;
 
+-- name: UpsertProfileCompany :exec
+INSERT INTO profile_companies (profile_id, company_id, position, start_date, end_date, is_current)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (profile_id, company_id, position, start_date)
+DO UPDATE SET end_date = EXCLUDED.end_date, is_current = EXCLUDED.is_current;
+
+-- name: ClearCurrentProfileCompanies :exec
+UPDATE profile_companies
+SET is_current = false
+WHERE profile_id = $1 AND is_current = true;
+
 -- Tracked Connections queries
 -- name: GetTrackedConnections :many
 SELECT tc.id, tc.user_id, tc.profile_id, tc.created_at, tc.last_checked_at,
-       lp.linkedin_url, lp.name, lp.location, lp.headline,
+       lp.linkedin_url, lp.linkedin_id, lp.name, lp.location, lp.headline,
        c.name as company_name
 FROM tracked_connections tc
 JOIN linkedin_profiles lp ON tc.profile_id = lp.id
@@ -204,6 +895,17 @@ INSERT INTO connection_relationships (profile_a_id, profile_b_id, degree, discov
 VALUES ($1, $2, $3, $4)
 RETURNING *;
 
+-- Resets the missed checks of the tracked profile's connections seen in a check and counts one more for the rest
+-- name: UpdateConnectionMissedChecks :exec
+UPDATE connection_relationships
+SET missed_checks = CASE WHEN profile_b_id = ANY(sqlc.arg(seen_ids)::uuid[]) THEN 0 ELSE missed_checks + 1 END
+WHERE profile_a_id = sqlc.arg(profile_id) AND degree = 1;
+
+-- name: DeleteMissingConnections :many
+DELETE FROM connection_relationships
+WHERE profile_a_id = sqlc.arg(profile_id) AND degree = 1 AND missed_checks >= sqlc.arg(max_missed_checks)
+RETURNING profile_b_id;
+
 -- name: CheckConnectionExists :one
 SELECT id FROM connection_relationships 
 WHERE ((profile_a_id = $1 AND profile_b_id = $2) OR (profile_a_id = $2 AND profile_b_id = $1)) 
@@ -211,32 +913,43 @@ AND degree = $3;
 
 -- Automation Rules queries
 -- name: GetAutomationRules :many
-SELECT id, user_id, name, company_filter, location_filter, action_type, message_template, is_active, created_at
+SELECT id, user_id, name, action_type, message_template, is_active, created_at, min_network_score, trigger_type, list_id,
+       country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function, condition, requires_approval,
+       sequence, timezone
 FROM automation_rules
 WHERE user_id = $1
 ORDER BY created_at DESC;
 
 -- name: GetAutomationRuleByID :one
-SELECT id, user_id, name, company_filter, location_filter, action_type, message_template, is_active, created_at
+SELECT id, user_id, name, action_type, message_template, is_active, created_at, min_network_score, trigger_type, list_id,
+       country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function, condition, requires_approval,
+       sequence, timezone
 FROM automation_rules
 WHERE id = $1 AND user_id = $2;
 
 -- name: CreateAutomationRule :one
-INSERT INTO automation_rules (user_id, name, company_filter, location_filter, action_type, message_template)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO automation_rules (user_id, name, condition, action_type, message_template, min_network_score, trigger_type, list_id,
+                              country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function, is_active,
+                              requires_approval, sequence, timezone)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 RETURNING *;
 
--- name: UpdateAutomationRule :exec
+-- name: UpdateAutomationRule :one
 UPDATE automation_rules 
-SET name = $3, company_filter = $4, location_filter = $5, action_type = $6, message_template = $7, is_active = $8
-WHERE id = $1 AND user_id = $2;
+SET name = $3, condition = $4, action_type = $5, message_template = $6, is_active = $7, min_network_score = $8, trigger_type = $9, list_id = $10,
+    country_code = $11, near = $12, near_latitude = $13, near_longitude = $14, radius_km = $15,
+    min_seniority = $16, job_function = $17, requires_approval = $18, sequence = $19, timezone = $20
+WHERE id = $1 AND user_id = $2
+RETURNING *;
 
--- name: DeleteAutomationRule :exec
+-- name: DeleteAutomationRule :execrows
 DELETE FROM automation_rules 
 WHERE id = $1 AND user_id = $2;
 
 -- name: GetActiveAutomationRules :many
-SELECT id, user_id, name, company_filter, location_filter, action_type, message_template, is_active, created_at
+SELECT id, user_id, name, action_type, message_template, is_active, created_at, min_network_score, trigger_type, list_id,
+       country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function, condition, requires_approval,
+       sequence, timezone
 FROM automation_rules
 WHERE user_id = $1 AND is_active = true
 ORDER BY created_at DESC;
@@ -245,40 +958,689 @@ ORDER BY created_at DESC;
 -- name: GetNewConnectionsForUser :many
 SELECT DISTINCT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline, lp.created_at,
        c.name as company_name,
-       MIN(cr.degree) as connection_degree
+       MIN(cr.degree) as connection_degree,
+       COALESCE(pns.degree, 0)::int as network_degree,
+       COALESCE(pns.betweenness, 0)::float8 as betweenness,
+       COALESCE(pns.pagerank, 0)::float8 as network_score,
+       pcl.cluster_id
 FROM linkedin_profiles lp
 LEFT JOIN companies c ON lp.current_company_id = c.id
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = sqlc.arg(user_id)
+LEFT JOIN profile_clusters pcl ON pcl.profile_id = lp.id AND pcl.user_id = sqlc.arg(user_id)
 JOIN connection_relationships cr ON (cr.profile_a_id = lp.id OR cr.profile_b_id = lp.id)
 JOIN tracked_connections tc ON (
     (cr.profile_a_id = tc.profile_id AND cr.profile_b_id = lp.id) OR
     (cr.profile_b_id = tc.profile_id AND cr.profile_a_id = lp.id)
 )
-WHERE tc.user_id = $1 
+WHERE tc.user_id = sqlc.arg(user_id)
   AND lp.id NOT IN (
     SELECT DISTINCT tc2.profile_id 
     FROM tracked_connections tc2 
-    WHERE tc2.user_id = $1
+    WHERE tc2.user_id = sqlc.arg(user_id)
   )
-  AND cr.discovered_at > $2
-GROUP BY lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline, lp.created_at, c.name
-ORDER BY lp.created_at DESC;
+  AND cr.discovered_at > sqlc.arg(discovered_at)
+  AND (sqlc.narg(min_network_score)::float8 IS NULL OR COALESCE(pns.pagerank, 0) >= sqlc.narg(min_network_score)::float8)
+  AND (sqlc.narg(cluster_id)::uuid IS NULL OR pcl.cluster_id = sqlc.narg(cluster_id)::uuid)
+GROUP BY lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline, lp.created_at, c.name,
+         pns.degree, pns.betweenness, pns.pagerank, pcl.cluster_id
+ORDER BY
+  CASE WHEN sqlc.arg(sort_by)::text = 'network_score' THEN COALESCE(pns.pagerank, 0) END DESC,
+  CASE WHEN sqlc.arg(sort_by)::text = 'degree' THEN COALESCE(pns.degree, 0) END DESC,
+  CASE WHEN sqlc.arg(sort_by)::text = 'betweenness' THEN COALESCE(pns.betweenness, 0) END DESC,
+  lp.created_at DESC;
 
+-- Candidates for the rules' structured filters; each rule's condition is evaluated on them in Go
 -- name: GetProfilesMatchingRules :many
 SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
        c.name as company_name,
-       ar.id as rule_id, ar.name as rule_name, ar.action_type, ar.message_template
+       ar.id as rule_id, ar.name as rule_name, ar.action_type, ar.message_template,
+       COALESCE(pns.pagerank, 0)::float8 as network_score,
+       ar.condition, COALESCE(upd.degree, 0)::int as degree, via.name as via_name,
+       (SELECT MIN(cr.discovered_at) FROM connection_relationships cr
+        JOIN tracked_connections tc ON tc.user_id = sqlc.arg(user_id) AND tc.profile_id IN (cr.profile_a_id, cr.profile_b_id)
+        WHERE lp.id IN (cr.profile_a_id, cr.profile_b_id))::timestamp as discovered_at,
+       ARRAY(SELECT pt.tag FROM profile_tags pt WHERE pt.user_id = sqlc.arg(user_id) AND pt.profile_id = lp.id ORDER BY pt.tag)::text[] as tags,
+       ar.requires_approval, ar.timezone as rule_timezone
 FROM linkedin_profiles lp
 LEFT JOIN companies c ON lp.current_company_id = c.id
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = sqlc.arg(user_id)
+LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+LEFT JOIN user_profile_degrees upd ON upd.user_id = sqlc.arg(user_id) AND upd.profile_id = lp.id
+LEFT JOIN linkedin_profiles via ON via.id = upd.via_profile_id
 CROSS JOIN automation_rules ar
-WHERE ar.user_id = $1 
+WHERE ar.user_id = sqlc.arg(user_id) 
   AND ar.is_active = true
-  AND (ar.company_filter IS NULL OR c.name ILIKE '%' || ar.company_filter || '%')
-  AND (ar.location_filter IS NULL OR lp.location ILIKE '%' || ar.location_filter || '%')
+  AND ar.trigger_type = 'new_connection'
+  AND (ar.country_code IS NULL OR loc.country_code = ar.country_code)
+  AND (ar.radius_km IS NULL OR distance_km(loc.latitude, loc.longitude, ar.near_latitude, ar.near_longitude) <= ar.radius_km)
+  AND (ar.min_seniority IS NULL OR seniority_rank(ph.seniority) >= seniority_rank(ar.min_seniority))
+  AND (ar.job_function IS NULL OR ph.job_function = ar.job_function)
+  AND (ar.min_network_score IS NULL OR COALESCE(pns.pagerank, 0) >= ar.min_network_score)
+  AND (ar.list_id IS NULL OR EXISTS (
+    SELECT 1 FROM profile_list_members plm WHERE plm.list_id = ar.list_id AND plm.profile_id = lp.id
+  ))
   AND lp.id NOT IN (
     SELECT DISTINCT tc.profile_id 
     FROM tracked_connections tc 
-    WHERE tc.user_id = $1
-  );
+    WHERE tc.user_id = sqlc.arg(user_id)
+  )
+  -- Only connections discovered since the rule was created are new to it
+  AND EXISTS (
+    SELECT 1 FROM connection_relationships cr
+    JOIN tracked_connections tc ON tc.user_id = sqlc.arg(user_id)
+     AND tc.profile_id IN (cr.profile_a_id, cr.profile_b_id)
+    WHERE lp.id IN (cr.profile_a_id, cr.profile_b_id)
+      AND cr.discovered_at >= ar.created_at
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM rule_executions re
+    WHERE re.rule_id = ar.id AND re.profile_id = lp.id AND (re.status <> 'failed' OR re.approved_at IS NOT NULL OR re.attempts >= sqlc.arg(max_attempts))
+  )
+ORDER BY network_score DESC, ar.created_at;
+
+-- Candidates for the rules' structured filters; each rule's condition is evaluated on them in Go
+-- name: GetJobChangesMatchingRules :many
+SELECT pe.id as event_id, pe.detected_at,
+       lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       nc.name as company_name, pe.new_position,
+       ar.id as rule_id, ar.name as rule_name, ar.action_type, ar.message_template,
+       COALESCE(pns.pagerank, 0)::float8 as network_score,
+       ar.condition, COALESCE(upd.degree, 0)::int as degree, via.name as via_name,
+       (SELECT MIN(cr.discovered_at) FROM connection_relationships cr
+        JOIN tracked_connections tc ON tc.user_id = sqlc.arg(user_id) AND tc.profile_id IN (cr.profile_a_id, cr.profile_b_id)
+        WHERE lp.id IN (cr.profile_a_id, cr.profile_b_id))::timestamp as discovered_at,
+       ARRAY(SELECT pt.tag FROM profile_tags pt WHERE pt.user_id = sqlc.arg(user_id) AND pt.profile_id = lp.id ORDER BY pt.tag)::text[] as tags,
+       ar.requires_approval, ar.timezone as rule_timezone
+FROM profile_events pe
+JOIN linkedin_profiles lp ON pe.profile_id = lp.id
+JOIN companies nc ON pe.new_company_id = nc.id
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = sqlc.arg(user_id)
+LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+LEFT JOIN user_profile_degrees upd ON upd.user_id = sqlc.arg(user_id) AND upd.profile_id = lp.id
+LEFT JOIN linkedin_profiles via ON via.id = upd.via_profile_id
+CROSS JOIN automation_rules ar
+WHERE ar.user_id = sqlc.arg(user_id)
+  AND ar.is_active = true
+  AND ar.trigger_type = 'job_change'
+  AND pe.event_type = 'job_change'
+  AND pe.detected_at >= ar.created_at
+  AND (ar.country_code IS NULL OR loc.country_code = ar.country_code)
+  AND (ar.radius_km IS NULL OR distance_km(loc.latitude, loc.longitude, ar.near_latitude, ar.near_longitude) <= ar.radius_km)
+  AND (ar.min_seniority IS NULL OR seniority_rank(ph.seniority) >= seniority_rank(ar.min_seniority))
+  AND (ar.job_function IS NULL OR ph.job_function = ar.job_function)
+  AND (ar.min_network_score IS NULL OR COALESCE(pns.pagerank, 0) >= ar.min_network_score)
+  AND (ar.list_id IS NULL OR EXISTS (
+    SELECT 1 FROM profile_list_members plm WHERE plm.list_id = ar.list_id AND plm.profile_id = lp.id
+  ))
+  AND pe.profile_id IN (
+    SELECT tc.profile_id FROM tracked_connections tc WHERE tc.user_id = sqlc.arg(user_id)
+    UNION
+    SELECT cr.profile_a_id FROM connection_relationships cr WHERE cr.discovered_by_user_id = sqlc.arg(user_id)
+    UNION
+    SELECT cr.profile_b_id FROM connection_relationships cr WHERE cr.discovered_by_user_id = sqlc.arg(user_id)
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM rule_executions re
+    WHERE re.rule_id = ar.id AND re.profile_id = lp.id AND (re.status <> 'failed' OR re.approved_at IS NOT NULL OR re.attempts >= sqlc.arg(max_attempts))
+  )
+ORDER BY pe.detected_at DESC, ar.created_at;
+
+-- Rule Executions queries
+-- Claims a rule's action on a profile, or retries a failed one. Returns no row
+-- when the rule has already actioned or queued the profile, is actioning it,
+-- or has failed max_attempts times. Failed approved actions are retried by
+-- StartApprovedRuleExecution instead.
+-- name: ClaimRuleExecution :one
+INSERT INTO rule_executions (rule_id, profile_id)
+VALUES (sqlc.arg(rule_id), sqlc.arg(profile_id))
+ON CONFLICT (rule_id, profile_id) DO UPDATE
+SET status = 'pending', outcome = NULL, error = NULL, attempts = rule_executions.attempts + 1, executed_at = NOW()
+WHERE rule_executions.status = 'failed' AND rule_executions.approved_at IS NULL
+  AND rule_executions.attempts < sqlc.arg(max_attempts)::int
+RETURNING *;
+
+-- Claims an approved action, or retries one that failed. Returns no row when
+-- it was claimed already or has failed max_attempts times.
+-- name: StartApprovedRuleExecution :one
+UPDATE rule_executions
+SET status = 'pending', outcome = NULL, error = NULL, executed_at = NOW(),
+    attempts = CASE WHEN status = 'failed' THEN attempts + 1 ELSE attempts END
+WHERE id = sqlc.arg(id) AND approved_at IS NOT NULL
+  AND (status = 'approved' OR (status = 'failed' AND attempts < sqlc.arg(max_attempts)::int))
+RETURNING *;
+
+-- name: QueueRuleExecution :exec
+UPDATE rule_executions
+SET status = 'awaiting_approval', message = $2, executed_at = NOW()
+WHERE id = $1;
+
+-- Approved actions of the user's active rules still to perform, oldest
+-- approval first, with their profiles as the rules' matching queries return them
+-- name: ListApprovedRuleActions :many
+SELECT re.id as execution_id, re.message,
+       ar.id as rule_id, ar.name as rule_name, ar.trigger_type, ar.action_type, ar.timezone as rule_timezone,
+       lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name,
+       COALESCE(pns.pagerank, 0)::float8 as network_score,
+       via.name as via_name
+FROM rule_executions re
+JOIN automation_rules ar ON re.rule_id = ar.id
+JOIN linkedin_profiles lp ON re.profile_id = lp.id
+LEFT JOIN companies c ON lp.current_company_id = c.id
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = ar.user_id
+LEFT JOIN user_profile_degrees upd ON upd.user_id = ar.user_id AND upd.profile_id = lp.id
+LEFT JOIN linkedin_profiles via ON via.id = upd.via_profile_id
+WHERE ar.user_id = sqlc.arg(user_id) AND ar.is_active = true
+  AND re.approved_at IS NOT NULL
+  AND (re.status = 'approved' OR (re.status = 'failed' AND re.attempts < sqlc.arg(max_attempts)::int))
+ORDER BY re.approved_at, re.id;
+
+-- name: FinishRuleExecution :exec
+UPDATE rule_executions
+SET status = $2, outcome = $3, error = $4, executed_at = NOW()
+WHERE id = $1;
+
+-- Profiles in the user's network passing a rule's structured filters, whether
+-- or not the rule has actioned them; the condition is evaluated in Go
+-- name: GetRulePreviewCandidates :many
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name,
+       COALESCE(upd.degree, 0)::int as degree, via.name as via_name,
+       (SELECT MIN(cr.discovered_at) FROM connection_relationships cr
+        JOIN tracked_connections tc ON tc.user_id = $1 AND tc.profile_id IN (cr.profile_a_id, cr.profile_b_id)
+        WHERE lp.id IN (cr.profile_a_id, cr.profile_b_id))::timestamp as discovered_at,
+       ARRAY(SELECT pt.tag FROM profile_tags pt WHERE pt.user_id = $1 AND pt.profile_id = lp.id ORDER BY pt.tag)::text[] as tags
+FROM automation_rules ar
+JOIN user_profile_degrees upd ON upd.user_id = ar.user_id
+JOIN linkedin_profiles lp ON lp.id = upd.profile_id
+LEFT JOIN linkedin_profiles via ON via.id = upd.via_profile_id
+LEFT JOIN companies c ON lp.current_company_id = c.id
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = $1
+LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+WHERE ar.user_id = $1
+  AND ar.id = $2
+  AND upd.degree > 1
+  AND (ar.country_code IS NULL OR loc.country_code = ar.country_code)
+  AND (ar.radius_km IS NULL OR distance_km(loc.latitude, loc.longitude, ar.near_latitude, ar.near_longitude) <= ar.radius_km)
+  AND (ar.min_seniority IS NULL OR seniority_rank(ph.seniority) >= seniority_rank(ar.min_seniority))
+  AND (ar.job_function IS NULL OR ph.job_function = ar.job_function)
+  AND (ar.min_network_score IS NULL OR COALESCE(pns.pagerank, 0) >= ar.min_network_score)
+  AND (ar.list_id IS NULL OR EXISTS (
+    SELECT 1 FROM profile_list_members plm WHERE plm.list_id = ar.list_id AND plm.profile_id = lp.id
+  ))
+ORDER BY COALESCE(pns.pagerank, 0) DESC, lp.id
+LIMIT $3;
+
+-- Connections a rule would have matched had it been created days ago, as
+-- GetProfilesMatchingRules matches them but whether or not the rule is active.
+-- Profiles the rule has actioned, queued or given up on are flagged rather
+-- than left out, as are those an executor skipped for another of the user's
+-- rules with the same action, e.g. as already connected.
+-- name: GetRuleSimulationConnections :many
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name,
+       COALESCE(pns.pagerank, 0)::float8 as network_score,
+       COALESCE(upd.degree, 0)::int as degree, via.name as via_name,
+       (SELECT MIN(cr.discovered_at) FROM connection_relationships cr
+        JOIN tracked_connections tc ON tc.user_id = sqlc.arg(user_id) AND tc.profile_id IN (cr.profile_a_id, cr.profile_b_id)
+        WHERE lp.id IN (cr.profile_a_id, cr.profile_b_id))::timestamp as discovered_at,
+       ARRAY(SELECT pt.tag FROM profile_tags pt WHERE pt.user_id = sqlc.arg(user_id) AND pt.profile_id = lp.id ORDER BY pt.tag)::text[] as tags,
+       EXISTS (
+         SELECT 1 FROM rule_executions re
+         WHERE re.rule_id = ar.id AND re.profile_id = lp.id AND (re.status <> 'failed' OR re.approved_at IS NOT NULL OR re.attempts >= sqlc.arg(max_attempts))
+       ) as actioned,
+       EXISTS (
+         SELECT 1 FROM rule_executions re
+         JOIN automation_rules other ON re.rule_id = other.id
+         WHERE other.user_id = ar.user_id AND other.action_type = ar.action_type
+           AND re.profile_id = lp.id AND re.status = 'skipped'
+       ) as blocked
+FROM automation_rules ar
+CROSS JOIN linkedin_profiles lp
+LEFT JOIN companies c ON lp.current_company_id = c.id
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = ar.user_id
+LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+LEFT JOIN user_profile_degrees upd ON upd.user_id = ar.user_id AND upd.profile_id = lp.id
+LEFT JOIN linkedin_profiles via ON via.id = upd.via_profile_id
+WHERE ar.user_id = sqlc.arg(user_id)
+  AND ar.id = sqlc.arg(rule_id)
+  AND ar.trigger_type = 'new_connection'
+  AND (ar.country_code IS NULL OR loc.country_code = ar.country_code)
+  AND (ar.radius_km IS NULL OR distance_km(loc.latitude, loc.longitude, ar.near_latitude, ar.near_longitude) <= ar.radius_km)
+  AND (ar.min_seniority IS NULL OR seniority_rank(ph.seniority) >= seniority_rank(ar.min_seniority))
+  AND (ar.job_function IS NULL OR ph.job_function = ar.job_function)
+  AND (ar.min_network_score IS NULL OR COALESCE(pns.pagerank, 0) >= ar.min_network_score)
+  AND (ar.list_id IS NULL OR EXISTS (
+    SELECT 1 FROM profile_list_members plm WHERE plm.list_id = ar.list_id AND plm.profile_id = lp.id
+  ))
+  AND lp.id NOT IN (
+    SELECT tc.profile_id FROM tracked_connections tc WHERE tc.user_id = sqlc.arg(user_id)
+  )
+  AND EXISTS (
+    SELECT 1 FROM connection_relationships cr
+    JOIN tracked_connections tc ON tc.user_id = sqlc.arg(user_id)
+     AND tc.profile_id IN (cr.profile_a_id, cr.profile_b_id)
+    WHERE lp.id IN (cr.profile_a_id, cr.profile_b_id)
+      AND cr.discovered_at >= NOW() - make_interval(days => sqlc.arg(days)::int)
+  )
+ORDER BY network_score DESC, lp.id;
+
+-- Job changes a rule would have matched had it been created days ago, as
+-- GetJobChangesMatchingRules matches them but whether or not the rule is
+-- active, flagged as GetRuleSimulationConnections flags them
+-- name: GetRuleSimulationJobChanges :many
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       nc.name as company_name, pe.new_position,
+       COALESCE(pns.pagerank, 0)::float8 as network_score,
+       COALESCE(upd.degree, 0)::int as degree, via.name as via_name,
+       (SELECT MIN(cr.discovered_at) FROM connection_relationships cr
+        JOIN tracked_connections tc ON tc.user_id = sqlc.arg(user_id) AND tc.profile_id IN (cr.profile_a_id, cr.profile_b_id)
+        WHERE lp.id IN (cr.profile_a_id, cr.profile_b_id))::timestamp as discovered_at,
+       ARRAY(SELECT pt.tag FROM profile_tags pt WHERE pt.user_id = sqlc.arg(user_id) AND pt.profile_id = lp.id ORDER BY pt.tag)::text[] as tags,
+       EXISTS (
+         SELECT 1 FROM rule_executions re
+         WHERE re.rule_id = ar.id AND re.profile_id = lp.id AND (re.status <> 'failed' OR re.approved_at IS NOT NULL OR re.attempts >= sqlc.arg(max_attempts))
+       ) as actioned,
+       EXISTS (
+         SELECT 1 FROM rule_executions re
+         JOIN automation_rules other ON re.rule_id = other.id
+         WHERE other.user_id = ar.user_id AND other.action_type = ar.action_type
+           AND re.profile_id = lp.id AND re.status = 'skipped'
+       ) as blocked
+FROM profile_events pe
+JOIN linkedin_profiles lp ON pe.profile_id = lp.id
+JOIN companies nc ON pe.new_company_id = nc.id
+JOIN automation_rules ar ON ar.user_id = sqlc.arg(user_id) AND ar.id = sqlc.arg(rule_id)
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = ar.user_id
+LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+LEFT JOIN user_profile_degrees upd ON upd.user_id = ar.user_id AND upd.profile_id = lp.id
+LEFT JOIN linkedin_profiles via ON via.id = upd.via_profile_id
+WHERE ar.trigger_type = 'job_change'
+  AND pe.event_type = 'job_change'
+  AND pe.detected_at >= NOW() - make_interval(days => sqlc.arg(days)::int)
+  AND (ar.country_code IS NULL OR loc.country_code = ar.country_code)
+  AND (ar.radius_km IS NULL OR distance_km(loc.latitude, loc.longitude, ar.near_latitude, ar.near_longitude) <= ar.radius_km)
+  AND (ar.min_seniority IS NULL OR seniority_rank(ph.seniority) >= seniority_rank(ar.min_seniority))
+  AND (ar.job_function IS NULL OR ph.job_function = ar.job_function)
+  AND (ar.min_network_score IS NULL OR COALESCE(pns.pagerank, 0) >= ar.min_network_score)
+  AND (ar.list_id IS NULL OR EXISTS (
+    SELECT 1 FROM profile_list_members plm WHERE plm.list_id = ar.list_id AND plm.profile_id = lp.id
+  ))
+  AND pe.profile_id IN (
+    SELECT tc.profile_id FROM tracked_connections tc WHERE tc.user_id = sqlc.arg(user_id)
+    UNION
+    SELECT cr.profile_a_id FROM connection_relationships cr WHERE cr.discovered_by_user_id = sqlc.arg(user_id)
+    UNION
+    SELECT cr.profile_b_id FROM connection_relationships cr WHERE cr.discovered_by_user_id = sqlc.arg(user_id)
+  )
+ORDER BY pe.detected_at DESC;
+
+-- name: ListRuleExecutions :many
+SELECT re.id, re.profile_id, lp.name as profile_name, lp.linkedin_url,
+       re.status, re.outcome, re.error, re.attempts, re.executed_at, re.message
+FROM rule_executions re
+JOIN automation_rules ar ON re.rule_id = ar.id
+JOIN linkedin_profiles lp ON re.profile_id = lp.id
+WHERE re.rule_id = $1 AND ar.user_id = $2
+ORDER BY re.executed_at DESC, re.id
+LIMIT $3;
+
+-- name: ListProfileRuleExecutions :many
+SELECT re.id, re.rule_id, ar.name as rule_name, ar.action_type, re.status, re.outcome, re.error, re.executed_at
+FROM rule_executions re
+JOIN automation_rules ar ON re.rule_id = ar.id
+WHERE re.profile_id = $1 AND ar.user_id = $2
+ORDER BY re.executed_at DESC, re.id;
+
+-- Approval Queue queries
+-- name: ListPendingApprovals :many
+SELECT re.id, re.message, re.executed_at as queued_at,
+       ar.id as rule_id, ar.name as rule_name, ar.action_type,
+       lp.id as profile_id, lp.name as profile_name, lp.linkedin_url
+FROM rule_executions re
+JOIN automation_rules ar ON re.rule_id = ar.id
+JOIN linkedin_profiles lp ON re.profile_id = lp.id
+WHERE ar.user_id = sqlc.arg(user_id) AND re.status = 'awaiting_approval'
+  AND (sqlc.narg(rule_id)::uuid IS NULL OR ar.id = sqlc.narg(rule_id)::uuid)
+ORDER BY re.executed_at, re.id
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetPendingApproval :one
+SELECT re.id, re.message, re.executed_at as queued_at,
+       ar.id as rule_id, ar.name as rule_name, ar.action_type,
+       lp.id as profile_id, lp.name as profile_name, lp.linkedin_url
+FROM rule_executions re
+JOIN automation_rules ar ON re.rule_id = ar.id
+JOIN linkedin_profiles lp ON re.profile_id = lp.id
+WHERE re.id = $1 AND ar.user_id = $2 AND re.status = 'awaiting_approval';
+
+-- name: UpdatePendingApprovalMessage :execrows
+UPDATE rule_executions re
+SET message = $3
+FROM automation_rules ar
+WHERE re.rule_id = ar.id AND re.id = $1 AND ar.user_id = $2 AND re.status = 'awaiting_approval';
+
+-- Approves or rejects the user's queued actions among ids, returning those decided
+-- name: DecidePendingApprovals :many
+UPDATE rule_executions re
+SET status = sqlc.arg(status),
+    approved_at = CASE WHEN sqlc.arg(status) = 'approved' THEN NOW() END,
+    executed_at = NOW()
+FROM automation_rules ar
+WHERE re.rule_id = ar.id AND ar.user_id = sqlc.arg(user_id)
+  AND re.id = ANY(sqlc.arg(ids)::uuid[]) AND re.status = 'awaiting_approval'
+RETURNING re.id;
+
+-- Rule Sequence queries
+-- Enrolls a profile the rule actioned in the rule's sequence, if it has one
+-- name: EnrollRuleSequence :exec
+INSERT INTO sequence_enrollments (rule_id, profile_id)
+SELECT ar.id, sqlc.arg(profile_id) FROM automation_rules ar
+WHERE ar.id = sqlc.arg(rule_id) AND ar.sequence IS NOT NULL
+ON CONFLICT (rule_id, profile_id) DO NOTHING;
+
+-- Active enrollments of active rules, with whether the wait of the step they
+-- are on is over, measured on the database clock like step_started_at
+-- name: ListActiveEnrollments :many
+SELECT se.id, se.step, se.attempts,
+       (se.accepted_at IS NOT NULL)::bool as accepted,
+       (se.replied_at IS NOT NULL)::bool as replied,
+       (se.step_started_at + make_interval(days => COALESCE((ar.sequence -> (se.step - 1) ->> 'wait_days')::int, 0)) <= NOW())::bool as waited,
+       ar.id as rule_id, ar.user_id, ar.name as rule_name, ar.trigger_type, ar.sequence, ar.timezone as rule_timezone,
+       lp.id as profile_id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name,
+       COALESCE(pns.pagerank, 0)::float8 as network_score,
+       via.name as via_name
+FROM sequence_enrollments se
+JOIN automation_rules ar ON se.rule_id = ar.id
+JOIN linkedin_profiles lp ON se.profile_id = lp.id
+LEFT JOIN companies c ON lp.current_company_id = c.id
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = ar.user_id
+LEFT JOIN user_profile_degrees upd ON upd.user_id = ar.user_id AND upd.profile_id = lp.id
+LEFT JOIN linkedin_profiles via ON via.id = upd.via_profile_id
+WHERE se.status = 'active' AND ar.is_active = true
+ORDER BY se.step_started_at, se.id;
+
+-- Records the first time an event of the enrollment's profile was observed
+-- name: RecordEnrollmentEvent :exec
+UPDATE sequence_enrollments
+SET accepted_at = CASE WHEN sqlc.arg(event)::text = 'accepted' THEN COALESCE(accepted_at, NOW()) ELSE accepted_at END,
+    replied_at = CASE WHEN sqlc.arg(event)::text = 'replied' THEN COALESCE(replied_at, NOW()) ELSE replied_at END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id);
+
+-- name: RecordSequenceStepRun :exec
+INSERT INTO sequence_step_runs (enrollment_id, step, action_type, status, outcome, message, error)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- Moves the enrollment on to its next step, whose wait starts now
+-- name: AdvanceEnrollment :exec
+UPDATE sequence_enrollments
+SET step = step + 1, step_started_at = NOW(), attempts = 0, error = NULL, updated_at = NOW()
+WHERE id = $1;
+
+-- name: EndEnrollment :exec
+UPDATE sequence_enrollments
+SET status = $2, exit_reason = $3, updated_at = NOW()
+WHERE id = $1;
+
+-- Counts a failed attempt at the enrollment's step, failing the enrollment
+-- once the step used up its attempts
+-- name: FailEnrollmentStep :exec
+UPDATE sequence_enrollments
+SET attempts = attempts + 1, error = sqlc.arg(error),
+    status = CASE WHEN attempts + 1 >= sqlc.arg(max_attempts)::int THEN 'failed' ELSE status END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id);
+
+-- name: ListRuleEnrollments :many
+SELECT se.id, se.profile_id, lp.name as profile_name, lp.linkedin_url,
+       se.step, se.step_started_at, se.status, se.exit_reason, se.attempts, se.error,
+       se.accepted_at, se.replied_at, se.created_at, se.updated_at
+FROM sequence_enrollments se
+JOIN automation_rules ar ON se.rule_id = ar.id
+JOIN linkedin_profiles lp ON se.profile_id = lp.id
+WHERE se.rule_id = sqlc.arg(rule_id) AND ar.user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(status)::text IS NULL OR se.status = sqlc.narg(status)::text)
+ORDER BY se.updated_at DESC, se.id
+LIMIT sqlc.arg(limit_count);
+
+-- name: ListEnrollmentStepRuns :many
+SELECT enrollment_id, step, action_type, status, outcome, message, error, executed_at
+FROM sequence_step_runs
+WHERE enrollment_id = ANY(sqlc.arg(enrollment_ids)::uuid[])
+ORDER BY executed_at, id;
+
+-- Execution Windows queries
+-- name: GetUserTimezone :one
+SELECT timezone FROM users WHERE id = $1;
+
+-- name: SetUserTimezone :exec
+UPDATE users SET timezone = $2, updated_at = NOW() WHERE id = $1;
+
+-- name: GetExecutionWindow :one
+SELECT * FROM execution_windows WHERE user_id = $1;
+
+-- name: UpsertExecutionWindow :one
+INSERT INTO execution_windows (user_id, weekdays, start_time, end_time, holidays)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id) DO UPDATE
+SET weekdays = EXCLUDED.weekdays, start_time = EXCLUDED.start_time, end_time = EXCLUDED.end_time,
+    holidays = EXCLUDED.holidays, updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteExecutionWindow :execrows
+DELETE FROM execution_windows WHERE user_id = $1;
+
+-- Invitation Quotas queries
+-- name: GetInvitationQuota :one
+SELECT * FROM invitation_quotas WHERE user_id = $1;
+
+-- name: UpsertInvitationQuota :one
+INSERT INTO invitation_quotas (user_id, daily_limit, weekly_limit)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET daily_limit = EXCLUDED.daily_limit, weekly_limit = EXCLUDED.weekly_limit, paused_until = NULL, updated_at = NOW()
+RETURNING *;
+
+-- name: PauseInvitations :exec
+INSERT INTO invitation_quotas (user_id, daily_limit, weekly_limit, paused_until)
+VALUES ($1, $2, $3, NOW() + INTERVAL '1 day')
+ON CONFLICT (user_id) DO UPDATE
+SET paused_until = EXCLUDED.paused_until, updated_at = NOW();
+
+-- name: RecordSentInvitation :exec
+INSERT INTO sent_invitations (user_id, profile_id)
+VALUES ($1, $2);
+
+-- Invitations sent since the start of the user's day and over the last seven
+-- days, measured on the database clock like sent_at
+-- name: GetInvitationUsage :one
+SELECT NOW()::timestamp as checked_at,
+       COUNT(*) FILTER (WHERE si.sent_at >= sqlc.arg(day_start)::timestamp)::int as sent_today,
+       COUNT(*)::int as sent_this_week,
+       MIN(si.sent_at)::timestamp as first_sent_this_week,
+       MAX(si.sent_at)::timestamp as last_sent_at
+FROM sent_invitations si
+WHERE si.user_id = sqlc.arg(user_id) AND si.sent_at >= NOW() - INTERVAL '7 days';
+
+-- name: ListUserIDsWithActiveRules :many
+SELECT DISTINCT ar.user_id
+FROM automation_rules ar
+JOIN users u ON ar.user_id = u.id
+WHERE ar.is_active = true AND u.is_active = true;
+
+-- Profile Events queries
+-- name: CreateProfileEvent :one
+INSERT INTO profile_events (profile_id, event_type, old_company_id, new_company_id, old_position, new_position,
+                            related_profile_id, old_headline, new_headline)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- Every recorded change to a profile, newest first, with the companies and tracked connection it mentions
+-- name: ListProfileEvents :many
+SELECT pe.id, pe.event_type, pe.old_position, pe.new_position, pe.old_headline, pe.new_headline, pe.detected_at,
+       pe.old_company_id, oc.name as old_company_name, pe.new_company_id, nc.name as new_company_name,
+       pe.related_profile_id, rp.name as related_profile_name, rp.linkedin_url as related_profile_url
+FROM profile_events pe
+LEFT JOIN companies oc ON pe.old_company_id = oc.id
+LEFT JOIN companies nc ON pe.new_company_id = nc.id
+LEFT JOIN linkedin_profiles rp ON pe.related_profile_id = rp.id
+WHERE pe.profile_id = $1
+ORDER BY pe.detected_at DESC, pe.id;
+
+-- name: ListJobChangesForUser :many
+WITH network AS (
+    SELECT tc.profile_id FROM tracked_connections tc WHERE tc.user_id = sqlc.arg(user_id)
+    UNION
+    SELECT cr.profile_a_id FROM connection_relationships cr WHERE cr.discovered_by_user_id = sqlc.arg(user_id)
+    UNION
+    SELECT cr.profile_b_id FROM connection_relationships cr WHERE cr.discovered_by_user_id = sqlc.arg(user_id)
+)
+SELECT pe.id, pe.profile_id, pe.old_company_id, pe.new_company_id, pe.old_position, pe.new_position, pe.detected_at,
+       lp.name as profile_name, lp.linkedin_url,
+       oc.name as old_company_name, nc.name as new_company_name
+FROM profile_events pe
+JOIN network n ON pe.profile_id = n.profile_id
+JOIN linkedin_profiles lp ON pe.profile_id = lp.id
+LEFT JOIN companies oc ON pe.old_company_id = oc.id
+LEFT JOIN companies nc ON pe.new_company_id = nc.id
+WHERE pe.event_type = 'job_change'
+  AND pe.detected_at > sqlc.arg(since)
+  AND (sqlc.narg(company_id)::uuid IS NULL OR pe.new_company_id = sqlc.narg(company_id)::uuid)
+ORDER BY pe.detected_at DESC, pe.id
+LIMIT sqlc.arg(max_results);
+
+-- Profile Network Scores queries
+-- name: UpsertProfileNetworkScore :exec
+INSERT INTO profile_network_scores (user_id, profile_id, degree, betweenness, pagerank, computed_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (user_id, profile_id) DO UPDATE
+SET degree = EXCLUDED.degree, betweenness = EXCLUDED.betweenness,
+    pagerank = EXCLUDED.pagerank, computed_at = EXCLUDED.computed_at;
+
+-- name: DeleteStaleProfileNetworkScores :exec
+DELETE FROM profile_network_scores
+WHERE user_id = $1 AND computed_at < $2;
+
+-- name: ListUserIDsWithTrackedConnections :many
+SELECT DISTINCT tc.user_id
+FROM tracked_connections tc
+JOIN users u ON tc.user_id = u.id
+WHERE u.is_active = true;
+
+-- Network Clusters queries
+-- name: DeleteNetworkClustersForUser :exec
+DELETE FROM network_clusters
+WHERE user_id = $1;
+
+-- name: CreateNetworkCluster :one
+INSERT INTO network_clusters (user_id, label, size, top_companies, top_locations, computed_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: CreateProfileCluster :exec
+INSERT INTO profile_clusters (user_id, profile_id, cluster_id)
+VALUES ($1, $2, $3);
+
+-- name: ListNetworkClusters :many
+SELECT id, user_id, label, size, top_companies, top_locations, computed_at
+FROM network_clusters
+WHERE user_id = $1
+ORDER BY size DESC, label;
+
+-- name: GetNetworkCluster :one
+SELECT id, user_id, label, size, top_companies, top_locations, computed_at
+FROM network_clusters
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetClusterProfiles :many
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name
+FROM profile_clusters pcl
+JOIN linkedin_profiles lp ON pcl.profile_id = lp.id
+LEFT JOIN companies c ON lp.current_company_id = c.id
+WHERE pcl.cluster_id = $1 AND pcl.user_id = $2
+ORDER BY lp.name;
+
+-- Network Analytics queries
+-- name: RefreshNetworkDiscoveries :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY network_discoveries;
+
+-- name: RefreshNetworkRemovals :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY network_removals;
+
+-- Profiles discovered and connections removed per day or week, with empty periods as zeros.
+-- Without a tracked connection each profile counts once, when the user first discovered it.
+-- name: GetNetworkGrowth :many
+WITH periods AS (
+    SELECT generate_series(
+        date_trunc(sqlc.arg(bucket)::text, sqlc.arg(since)::timestamp),
+        date_trunc(sqlc.arg(bucket)::text, sqlc.arg(until)::timestamp),
+        ('1 ' || sqlc.arg(bucket)::text)::interval
+    ) AS period_start
+),
+discovered AS (
+    SELECT date_trunc(sqlc.arg(bucket)::text, nd.discovered_at) AS period_start,
+           COUNT(*) FILTER (WHERE nd.degree = 2) AS second_degree,
+           COUNT(*) FILTER (WHERE nd.degree = 3) AS third_degree
+    FROM network_discoveries nd
+    WHERE nd.user_id = sqlc.arg(user_id)
+      AND nd.discovered_at >= date_trunc(sqlc.arg(bucket)::text, sqlc.arg(since)::timestamp)
+      AND CASE WHEN sqlc.narg(tracked_profile_id)::uuid IS NULL THEN nd.first_for_user
+               ELSE nd.tracked_profile_id = sqlc.narg(tracked_profile_id)::uuid END
+    GROUP BY 1
+),
+removed AS (
+    SELECT date_trunc(sqlc.arg(bucket)::text, nr.removed_at) AS period_start,
+           COUNT(DISTINCT nr.event_id) AS removed
+    FROM network_removals nr
+    WHERE nr.user_id = sqlc.arg(user_id)
+      AND nr.removed_at >= date_trunc(sqlc.arg(bucket)::text, sqlc.arg(since)::timestamp)
+      AND (sqlc.narg(tracked_profile_id)::uuid IS NULL OR nr.tracked_profile_id = sqlc.narg(tracked_profile_id)::uuid)
+    GROUP BY 1
+)
+SELECT p.period_start::timestamp AS period_start,
+       COALESCE(d.second_degree, 0)::int AS second_degree,
+       COALESCE(d.third_degree, 0)::int AS third_degree,
+       COALESCE(r.removed, 0)::int AS removed
+FROM periods p
+LEFT JOIN discovered d ON d.period_start = p.period_start
+LEFT JOIN removed r ON r.period_start = p.period_start
+ORDER BY p.period_start;
+
+-- The most common companies, locations or countries among the profiles discovered per day or week
+-- name: GetNetworkComposition :many
+WITH counts AS (
+    SELECT date_trunc(sqlc.arg(bucket)::text, nd.discovered_at) AS period_start,
+           CASE sqlc.arg(dimension)::text
+               WHEN 'company' THEN nd.company_name
+               WHEN 'country' THEN nd.country_code::text
+               ELSE nd.location
+           END AS value,
+           COUNT(*) AS profiles
+    FROM network_discoveries nd
+    WHERE nd.user_id = sqlc.arg(user_id)
+      AND nd.discovered_at >= date_trunc(sqlc.arg(bucket)::text, sqlc.arg(since)::timestamp)
+      AND CASE WHEN sqlc.narg(tracked_profile_id)::uuid IS NULL THEN nd.first_for_user
+               ELSE nd.tracked_profile_id = sqlc.narg(tracked_profile_id)::uuid END
+    GROUP BY 1, 2
+),
+ranked AS (
+    SELECT c.period_start, c.value, c.profiles,
+           ROW_NUMBER() OVER (PARTITION BY c.period_start ORDER BY c.profiles DESC, c.value) AS position
+    FROM counts c
+    WHERE c.value IS NOT NULL
+)
+SELECT r.period_start::timestamp AS period_start, r.value::text AS value, r.profiles::int AS profiles
+FROM ranked r
+WHERE r.position <= sqlc.arg(top)::int
+ORDER BY r.period_start, r.profiles DESC, r.value;
 
 -- Utility queries
 -- name: PingDb :one
@@ -11,6 +11,34 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const addProfileListMember = `-- name: AddProfileListMember :exec
+INSERT INTO profile_list_members (list_id, profile_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type AddProfileListMemberParams struct {
+	ListID    pgtype.UUID
+	ProfileID pgtype.UUID
+}
+
+func (q *Queries) AddProfileListMember(ctx context.Context, arg AddProfileListMemberParams) error {
+	_, err := q.db.Exec(ctx, addProfileListMember, arg.ListID, arg.ProfileID)
+	return err
+}
+
+const advanceEnrollment = `-- name: AdvanceEnrollment :exec
+UPDATE sequence_enrollments
+SET step = step + 1, step_started_at = NOW(), attempts = 0, error = NULL, updated_at = NOW()
+WHERE id = $1
+`
+
+// Moves the enrollment on to its next step, whose wait starts now
+func (q *Queries) AdvanceEnrollment(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, advanceEnrollment, id)
+	return err
+}
+
 const checkConnectionExists = `-- name: CheckConnectionExists :one
 SELECT id FROM connection_relationships 
 WHERE ((profile_a_id = $1 AND profile_b_id = $2) OR (profile_a_id = $2 AND profile_b_id = $1)) 
@@ -30,59 +58,167 @@ func (q *Queries) CheckConnectionExists(ctx context.Context, arg CheckConnection
 	return id, err
 }
 
+const claimRuleExecution = `-- name: ClaimRuleExecution :one
+INSERT INTO rule_executions (rule_id, profile_id)
+VALUES ($1, $2)
+ON CONFLICT (rule_id, profile_id) DO UPDATE
+SET status = 'pending', outcome = NULL, error = NULL, attempts = rule_executions.attempts + 1, executed_at = NOW()
+WHERE rule_executions.status = 'failed' AND rule_executions.approved_at IS NULL
+  AND rule_executions.attempts < $3::int
+RETURNING id, rule_id, profile_id, status, error, attempts, executed_at, outcome, message, approved_at
+`
+
+type ClaimRuleExecutionParams struct {
+	RuleID      pgtype.UUID
+	ProfileID   pgtype.UUID
+	MaxAttempts int32
+}
+
+// Rule Executions queries
+// Claims a rule's action on a profile, or retries a failed one. Returns no row
+// when the rule has already actioned or queued the profile, is actioning it,
+// or has failed max_attempts times. Failed approved actions are retried by
+// StartApprovedRuleExecution instead.
+func (q *Queries) ClaimRuleExecution(ctx context.Context, arg ClaimRuleExecutionParams) (RuleExecution, error) {
+	row := q.db.QueryRow(ctx, claimRuleExecution, arg.RuleID, arg.ProfileID, arg.MaxAttempts)
+	var i RuleExecution
+	err := row.Scan(
+		&i.ID,
+		&i.RuleID,
+		&i.ProfileID,
+		&i.Status,
+		&i.Error,
+		&i.Attempts,
+		&i.ExecutedAt,
+		&i.Outcome,
+		&i.Message,
+		&i.ApprovedAt,
+	)
+	return i, err
+}
+
+const clearCurrentProfileCompanies = `-- name: ClearCurrentProfileCompanies :exec
+UPDATE profile_companies
+SET is_current = false
+WHERE profile_id = $1 AND is_current = true
+`
+
+func (q *Queries) ClearCurrentProfileCompanies(ctx context.Context, profileID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, clearCurrentProfileCompanies, profileID)
+	return err
+}
+
+const countCompanyCurrentEmployees = `-- name: CountCompanyCurrentEmployees :one
+SELECT COUNT(*) FROM linkedin_profiles
+WHERE current_company_id = $1
+`
+
+func (q *Queries) CountCompanyCurrentEmployees(ctx context.Context, currentCompanyID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countCompanyCurrentEmployees, currentCompanyID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAutomationRule = `-- name: CreateAutomationRule :one
-INSERT INTO automation_rules (user_id, name, company_filter, location_filter, action_type, message_template)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, user_id, name, company_filter, location_filter, action_type, message_template, is_active, created_at
+INSERT INTO automation_rules (user_id, name, condition, action_type, message_template, min_network_score, trigger_type, list_id,
+                              country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function, is_active,
+                              requires_approval, sequence, timezone)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+RETURNING id, user_id, name, action_type, message_template, is_active, created_at, min_network_score, trigger_type, list_id, country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function, condition, requires_approval, sequence, timezone
 `
 
 type CreateAutomationRuleParams struct {
-	UserID          pgtype.UUID
-	Name            string
-	CompanyFilter   pgtype.Text
-	LocationFilter  pgtype.Text
-	ActionType      string
-	MessageTemplate pgtype.Text
+	UserID           pgtype.UUID
+	Name             string
+	Condition        pgtype.Text
+	ActionType       string
+	MessageTemplate  pgtype.Text
+	MinNetworkScore  pgtype.Float8
+	TriggerType      string
+	ListID           pgtype.UUID
+	CountryCode      pgtype.Text
+	Near             pgtype.Text
+	NearLatitude     pgtype.Float8
+	NearLongitude    pgtype.Float8
+	RadiusKm         pgtype.Float8
+	MinSeniority     pgtype.Text
+	JobFunction      pgtype.Text
+	IsActive         bool
+	RequiresApproval bool
+	Sequence         []byte
+	Timezone         pgtype.Text
 }
 
 func (q *Queries) CreateAutomationRule(ctx context.Context, arg CreateAutomationRuleParams) (AutomationRule, error) {
 	row := q.db.QueryRow(ctx, createAutomationRule,
 		arg.UserID,
 		arg.Name,
-		arg.CompanyFilter,
-		arg.LocationFilter,
+		arg.Condition,
 		arg.ActionType,
 		arg.MessageTemplate,
+		arg.MinNetworkScore,
+		arg.TriggerType,
+		arg.ListID,
+		arg.CountryCode,
+		arg.Near,
+		arg.NearLatitude,
+		arg.NearLongitude,
+		arg.RadiusKm,
+		arg.MinSeniority,
+		arg.JobFunction,
+		arg.IsActive,
+		arg.RequiresApproval,
+		arg.Sequence,
+		arg.Timezone,
 	)
 	var i AutomationRule
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
 		&i.Name,
-		&i.CompanyFilter,
-		&i.LocationFilter,
 		&i.ActionType,
 		&i.MessageTemplate,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.MinNetworkScore,
+		&i.TriggerType,
+		&i.ListID,
+		&i.CountryCode,
+		&i.Near,
+		&i.NearLatitude,
+		&i.NearLongitude,
+		&i.RadiusKm,
+		&i.MinSeniority,
+		&i.JobFunction,
+		&i.Condition,
+		&i.RequiresApproval,
+		&i.Sequence,
+		&i.Timezone,
 	)
 	return i, err
 }
 
 const createCompany = `-- name: CreateCompany :one
-INSERT INTO companies (name, linkedin_url, industry)
-VALUES ($1, $2, $3)
-RETURNING id, name, linkedin_url, industry, created_at, updated_at
+INSERT INTO companies (name, linkedin_url, industry, normalized_name)
+VALUES ($1, $2, $3, $4)
+RETURNING id, name, linkedin_url, industry, created_at, updated_at, normalized_name
 `
 
 type CreateCompanyParams struct {
-	Name        string
-	LinkedinUrl pgtype.Text
-	Industry    pgtype.Text
+	Name           string
+	LinkedinUrl    pgtype.Text
+	Industry       pgtype.Text
+	NormalizedName string
 }
 
 func (q *Queries) CreateCompany(ctx context.Context, arg CreateCompanyParams) (Company, error) {
-	row := q.db.QueryRow(ctx, createCompany, arg.Name, arg.LinkedinUrl, arg.Industry)
+	row := q.db.QueryRow(ctx, createCompany,
+		arg.Name,
+		arg.LinkedinUrl,
+		arg.Industry,
+		arg.NormalizedName,
+	)
 	var i Company
 	err := row.Scan(
 		&i.ID,
@@ -91,6 +227,47 @@ func (q *Queries) CreateCompany(ctx context.Context, arg CreateCompanyParams) (C
 		&i.Industry,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.NormalizedName,
+	)
+	return i, err
+}
+
+const createCompanyAlias = `-- name: CreateCompanyAlias :exec
+INSERT INTO company_aliases (company_id, alias, normalized_alias)
+VALUES ($1, $2, $3)
+ON CONFLICT (normalized_alias) DO UPDATE SET company_id = EXCLUDED.company_id
+`
+
+type CreateCompanyAliasParams struct {
+	CompanyID       pgtype.UUID
+	Alias           string
+	NormalizedAlias string
+}
+
+// Company Aliases queries
+func (q *Queries) CreateCompanyAlias(ctx context.Context, arg CreateCompanyAliasParams) error {
+	_, err := q.db.Exec(ctx, createCompanyAlias, arg.CompanyID, arg.Alias, arg.NormalizedAlias)
+	return err
+}
+
+const createCompanyWatch = `-- name: CreateCompanyWatch :one
+INSERT INTO company_watches (user_id, company_id)
+VALUES ($1, $2)
+RETURNING user_id, company_id, created_at
+`
+
+type CreateCompanyWatchParams struct {
+	UserID    pgtype.UUID
+	CompanyID pgtype.UUID
+}
+
+func (q *Queries) CreateCompanyWatch(ctx context.Context, arg CreateCompanyWatchParams) (CompanyWatch, error) {
+	row := q.db.QueryRow(ctx, createCompanyWatch, arg.UserID, arg.CompanyID)
+	var i CompanyWatch
+	err := row.Scan(
+		&i.UserID,
+		&i.CompanyID,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -98,7 +275,7 @@ func (q *Queries) CreateCompany(ctx context.Context, arg CreateCompanyParams) (C
 const createConnectionRelationship = `-- name: CreateConnectionRelationship :one
 INSERT INTO connection_relationships (profile_a_id, profile_b_id, degree, discovered_by_user_id)
 VALUES ($1, $2, $3, $4)
-RETURNING id, profile_a_id, profile_b_id, degree, discovered_at, discovered_by_user_id
+RETURNING id, profile_a_id, profile_b_id, degree, discovered_at, discovered_by_user_id, missed_checks
 `
 
 type CreateConnectionRelationshipParams struct {
@@ -123,6 +300,7 @@ func (q *Queries) CreateConnectionRelationship(ctx context.Context, arg CreateCo
 		&i.Degree,
 		&i.DiscoveredAt,
 		&i.DiscoveredByUserID,
+		&i.MissedChecks,
 	)
 	return i, err
 }
@@ -163,6 +341,122 @@ func (q *Queries) CreateLinkedInProfile(ctx context.Context, arg CreateLinkedInP
 	return i, err
 }
 
+const createNetworkCluster = `-- name: CreateNetworkCluster :one
+INSERT INTO network_clusters (user_id, label, size, top_companies, top_locations, computed_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, user_id, label, size, top_companies, top_locations, computed_at
+`
+
+type CreateNetworkClusterParams struct {
+	UserID       pgtype.UUID
+	Label        string
+	Size         int32
+	TopCompanies []string
+	TopLocations []string
+	ComputedAt   pgtype.Timestamp
+}
+
+func (q *Queries) CreateNetworkCluster(ctx context.Context, arg CreateNetworkClusterParams) (NetworkCluster, error) {
+	row := q.db.QueryRow(ctx, createNetworkCluster,
+		arg.UserID,
+		arg.Label,
+		arg.Size,
+		arg.TopCompanies,
+		arg.TopLocations,
+		arg.ComputedAt,
+	)
+	var i NetworkCluster
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Label,
+		&i.Size,
+		&i.TopCompanies,
+		&i.TopLocations,
+		&i.ComputedAt,
+	)
+	return i, err
+}
+
+const createNotification = `-- name: CreateNotification :one
+INSERT INTO notifications (user_id, kind, title, body, payload)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, kind, title, body, payload, created_at, read_at
+`
+
+type CreateNotificationParams struct {
+	UserID  pgtype.UUID
+	Kind    string
+	Title   string
+	Body    string
+	Payload []byte
+}
+
+// Notifications queries
+func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error) {
+	row := q.db.QueryRow(ctx, createNotification,
+		arg.UserID,
+		arg.Kind,
+		arg.Title,
+		arg.Body,
+		arg.Payload,
+	)
+	var i Notification
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Kind,
+		&i.Title,
+		&i.Body,
+		&i.Payload,
+		&i.CreatedAt,
+		&i.ReadAt,
+	)
+	return i, err
+}
+
+const createNotificationChannel = `-- name: CreateNotificationChannel :one
+INSERT INTO notification_channels (user_id, channel_type, target)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, channel_type, target, is_active, created_at
+`
+
+type CreateNotificationChannelParams struct {
+	UserID      pgtype.UUID
+	ChannelType string
+	Target      string
+}
+
+func (q *Queries) CreateNotificationChannel(ctx context.Context, arg CreateNotificationChannelParams) (NotificationChannel, error) {
+	row := q.db.QueryRow(ctx, createNotificationChannel, arg.UserID, arg.ChannelType, arg.Target)
+	var i NotificationChannel
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ChannelType,
+		&i.Target,
+		&i.IsActive,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createProfileCluster = `-- name: CreateProfileCluster :exec
+INSERT INTO profile_clusters (user_id, profile_id, cluster_id)
+VALUES ($1, $2, $3)
+`
+
+type CreateProfileClusterParams struct {
+	UserID    pgtype.UUID
+	ProfileID pgtype.UUID
+	ClusterID pgtype.UUID
+}
+
+func (q *Queries) CreateProfileCluster(ctx context.Context, arg CreateProfileClusterParams) error {
+	_, err := q.db.Exec(ctx, createProfileCluster, arg.UserID, arg.ProfileID, arg.ClusterID)
+	return err
+}
+
 const createProfileCompany = `-- name: CreateProfileCompany :one
 INSERT INTO profile_companies (profile_id, company_id, position, start_date, end_date, is_current)
 VALUES ($1, $2, $3, $4, $5, $6)
@@ -201,6 +495,192 @@ func (q *Queries) CreateProfileCompany(ctx context.Context, arg CreateProfileCom
 	return i, err
 }
 
+const createProfileEvent = `-- name: CreateProfileEvent :one
+INSERT INTO profile_events (profile_id, event_type, old_company_id, new_company_id, old_position, new_position,
+                            related_profile_id, old_headline, new_headline)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, profile_id, event_type, old_company_id, new_company_id, old_position, new_position, detected_at, related_profile_id, old_headline, new_headline
+`
+
+type CreateProfileEventParams struct {
+	ProfileID        pgtype.UUID
+	EventType        string
+	OldCompanyID     pgtype.UUID
+	NewCompanyID     pgtype.UUID
+	OldPosition      pgtype.Text
+	NewPosition      pgtype.Text
+	RelatedProfileID pgtype.UUID
+	OldHeadline      pgtype.Text
+	NewHeadline      pgtype.Text
+}
+
+// Profile Events queries
+func (q *Queries) CreateProfileEvent(ctx context.Context, arg CreateProfileEventParams) (ProfileEvent, error) {
+	row := q.db.QueryRow(ctx, createProfileEvent,
+		arg.ProfileID,
+		arg.EventType,
+		arg.OldCompanyID,
+		arg.NewCompanyID,
+		arg.OldPosition,
+		arg.NewPosition,
+		arg.RelatedProfileID,
+		arg.OldHeadline,
+		arg.NewHeadline,
+	)
+	var i ProfileEvent
+	err := row.Scan(
+		&i.ID,
+		&i.ProfileID,
+		&i.EventType,
+		&i.OldCompanyID,
+		&i.NewCompanyID,
+		&i.OldPosition,
+		&i.NewPosition,
+		&i.DetectedAt,
+		&i.RelatedProfileID,
+		&i.OldHeadline,
+		&i.NewHeadline,
+	)
+	return i, err
+}
+
+const createProfileList = `-- name: CreateProfileList :one
+INSERT INTO profile_lists (user_id, name, description)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, name, description, created_at, updated_at
+`
+
+type CreateProfileListParams struct {
+	UserID      pgtype.UUID
+	Name        string
+	Description pgtype.Text
+}
+
+func (q *Queries) CreateProfileList(ctx context.Context, arg CreateProfileListParams) (ProfileList, error) {
+	row := q.db.QueryRow(ctx, createProfileList, arg.UserID, arg.Name, arg.Description)
+	var i ProfileList
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createProfileNote = `-- name: CreateProfileNote :one
+INSERT INTO profile_notes (user_id, profile_id, body)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, profile_id, body, created_at, updated_at
+`
+
+type CreateProfileNoteParams struct {
+	UserID    pgtype.UUID
+	ProfileID pgtype.UUID
+	Body      string
+}
+
+func (q *Queries) CreateProfileNote(ctx context.Context, arg CreateProfileNoteParams) (ProfileNote, error) {
+	row := q.db.QueryRow(ctx, createProfileNote, arg.UserID, arg.ProfileID, arg.Body)
+	var i ProfileNote
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProfileID,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createProfileTag = `-- name: CreateProfileTag :exec
+INSERT INTO profile_tags (user_id, profile_id, tag)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING
+`
+
+type CreateProfileTagParams struct {
+	UserID    pgtype.UUID
+	ProfileID pgtype.UUID
+	Tag       string
+}
+
+func (q *Queries) CreateProfileTag(ctx context.Context, arg CreateProfileTagParams) error {
+	_, err := q.db.Exec(ctx, createProfileTag, arg.UserID, arg.ProfileID, arg.Tag)
+	return err
+}
+
+const createSavedSearch = `-- name: CreateSavedSearch :one
+INSERT INTO saved_searches (user_id, name, query, company_id, location, degree, tag, list_id,
+                            country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+RETURNING id, user_id, name, query, company_id, location, degree, tag, list_id, is_active, last_evaluated_at, created_at, country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function
+`
+
+type CreateSavedSearchParams struct {
+	UserID        pgtype.UUID
+	Name          string
+	Query         pgtype.Text
+	CompanyID     pgtype.UUID
+	Location      pgtype.Text
+	Degree        pgtype.Int4
+	Tag           pgtype.Text
+	ListID        pgtype.UUID
+	CountryCode   pgtype.Text
+	Near          pgtype.Text
+	NearLatitude  pgtype.Float8
+	NearLongitude pgtype.Float8
+	RadiusKm      pgtype.Float8
+	MinSeniority  pgtype.Text
+	JobFunction   pgtype.Text
+}
+
+func (q *Queries) CreateSavedSearch(ctx context.Context, arg CreateSavedSearchParams) (SavedSearch, error) {
+	row := q.db.QueryRow(ctx, createSavedSearch,
+		arg.UserID,
+		arg.Name,
+		arg.Query,
+		arg.CompanyID,
+		arg.Location,
+		arg.Degree,
+		arg.Tag,
+		arg.ListID,
+		arg.CountryCode,
+		arg.Near,
+		arg.NearLatitude,
+		arg.NearLongitude,
+		arg.RadiusKm,
+		arg.MinSeniority,
+		arg.JobFunction,
+	)
+	var i SavedSearch
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Query,
+		&i.CompanyID,
+		&i.Location,
+		&i.Degree,
+		&i.Tag,
+		&i.ListID,
+		&i.IsActive,
+		&i.LastEvaluatedAt,
+		&i.CreatedAt,
+		&i.CountryCode,
+		&i.Near,
+		&i.NearLatitude,
+		&i.NearLongitude,
+		&i.RadiusKm,
+		&i.MinSeniority,
+		&i.JobFunction,
+	)
+	return i, err
+}
+
 const createTrackedConnection = `-- name: CreateTrackedConnection :one
 INSERT INTO tracked_connections (user_id, profile_id)
 VALUES ($1, $2)
@@ -228,7 +708,7 @@ func (q *Queries) CreateTrackedConnection(ctx context.Context, arg CreateTracked
 const createUserWithBoth = `-- name: CreateUserWithBoth :one
 INSERT INTO users (email, name, password_hash, google_id, access_token, refresh_token, auth_type)
 VALUES ($1, $2, $3, $4, $5, $6, 'both')
-RETURNING id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at
+RETURNING id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at, timezone
 `
 
 type CreateUserWithBothParams struct {
@@ -262,6 +742,7 @@ func (q *Queries) CreateUserWithBoth(ctx context.Context, arg CreateUserWithBoth
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
@@ -269,7 +750,7 @@ func (q *Queries) CreateUserWithBoth(ctx context.Context, arg CreateUserWithBoth
 const createUserWithGoogle = `-- name: CreateUserWithGoogle :one
 INSERT INTO users (email, name, google_id, access_token, refresh_token, auth_type)
 VALUES ($1, $2, $3, $4, $5, 'google')
-RETURNING id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at
+RETURNING id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at, timezone
 `
 
 type CreateUserWithGoogleParams struct {
@@ -301,6 +782,7 @@ func (q *Queries) CreateUserWithGoogle(ctx context.Context, arg CreateUserWithGo
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
@@ -308,7 +790,7 @@ func (q *Queries) CreateUserWithGoogle(ctx context.Context, arg CreateUserWithGo
 const createUserWithPassword = `-- name: CreateUserWithPassword :one
 INSERT INTO users (email, name, password_hash, auth_type)
 VALUES ($1, $2, $3, 'password')
-RETURNING id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at
+RETURNING id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at, timezone
 `
 
 type CreateUserWithPasswordParams struct {
@@ -332,11 +814,50 @@ func (q *Queries) CreateUserWithPassword(ctx context.Context, arg CreateUserWith
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
 
-const deleteAutomationRule = `-- name: DeleteAutomationRule :exec
+const decidePendingApprovals = `-- name: DecidePendingApprovals :many
+UPDATE rule_executions re
+SET status = $1,
+    approved_at = CASE WHEN $1 = 'approved' THEN NOW() END,
+    executed_at = NOW()
+FROM automation_rules ar
+WHERE re.rule_id = ar.id AND ar.user_id = $2
+  AND re.id = ANY($3::uuid[]) AND re.status = 'awaiting_approval'
+RETURNING re.id
+`
+
+type DecidePendingApprovalsParams struct {
+	Status string
+	UserID pgtype.UUID
+	Ids    []pgtype.UUID
+}
+
+// Approves or rejects the user's queued actions among ids, returning those decided
+func (q *Queries) DecidePendingApprovals(ctx context.Context, arg DecidePendingApprovalsParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, decidePendingApprovals, arg.Status, arg.UserID, arg.Ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteAutomationRule = `-- name: DeleteAutomationRule :execrows
 DELETE FROM automation_rules 
 WHERE id = $1 AND user_id = $2
 `
@@ -346,52 +867,480 @@ type DeleteAutomationRuleParams struct {
 	UserID pgtype.UUID
 }
 
-func (q *Queries) DeleteAutomationRule(ctx context.Context, arg DeleteAutomationRuleParams) error {
-	_, err := q.db.Exec(ctx, deleteAutomationRule, arg.ID, arg.UserID)
+func (q *Queries) DeleteAutomationRule(ctx context.Context, arg DeleteAutomationRuleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAutomationRule, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteCompany = `-- name: DeleteCompany :exec
+DELETE FROM companies
+WHERE id = $1
+`
+
+func (q *Queries) DeleteCompany(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCompany, id)
 	return err
 }
 
-const deleteProfileCompany = `-- name: DeleteProfileCompany :exec
-DELETE FROM profile_companies 
-WHERE profile_id = $1 AND company_id = $2
+const deleteCompanyWatch = `-- name: DeleteCompanyWatch :execrows
+DELETE FROM company_watches
+WHERE user_id = $1 AND company_id = $2
 `
 
-type DeleteProfileCompanyParams struct {
-	ProfileID pgtype.UUID
+type DeleteCompanyWatchParams struct {
+	UserID    pgtype.UUID
 	CompanyID pgtype.UUID
 }
 
-func (q *Queries) DeleteProfileCompany(ctx context.Context, arg DeleteProfileCompanyParams) error {
-	_, err := q.db.Exec(ctx, deleteProfileCompany, arg.ProfileID, arg.CompanyID)
-	return err
+func (q *Queries) DeleteCompanyWatch(ctx context.Context, arg DeleteCompanyWatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCompanyWatch, arg.UserID, arg.CompanyID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const deleteTrackedConnection = `-- name: DeleteTrackedConnection :exec
-DELETE FROM tracked_connections 
-WHERE user_id = $1 AND profile_id = $2
+const deleteCompanyWatchMatches = `-- name: DeleteCompanyWatchMatches :exec
+DELETE FROM company_watch_matches
+WHERE user_id = $1 AND company_id = $2
 `
 
-type DeleteTrackedConnectionParams struct {
+type DeleteCompanyWatchMatchesParams struct {
 	UserID    pgtype.UUID
-	ProfileID pgtype.UUID
+	CompanyID pgtype.UUID
 }
 
-func (q *Queries) DeleteTrackedConnection(ctx context.Context, arg DeleteTrackedConnectionParams) error {
-	_, err := q.db.Exec(ctx, deleteTrackedConnection, arg.UserID, arg.ProfileID)
+func (q *Queries) DeleteCompanyWatchMatches(ctx context.Context, arg DeleteCompanyWatchMatchesParams) error {
+	_, err := q.db.Exec(ctx, deleteCompanyWatchMatches, arg.UserID, arg.CompanyID)
 	return err
 }
 
-const deleteUser = `-- name: DeleteUser :exec
-DELETE FROM users WHERE id = $1
+const deleteDuplicateProfileCompanies = `-- name: DeleteDuplicateProfileCompanies :exec
+DELETE FROM profile_companies s
+USING profile_companies t
+WHERE s.company_id = $1 AND t.company_id = $2
+  AND t.profile_id = s.profile_id AND t.position = s.position AND t.start_date = s.start_date
 `
 
-func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) error {
+type DeleteDuplicateProfileCompaniesParams struct {
+	SourceID pgtype.UUID
+	TargetID pgtype.UUID
+}
+
+func (q *Queries) DeleteDuplicateProfileCompanies(ctx context.Context, arg DeleteDuplicateProfileCompaniesParams) error {
+	_, err := q.db.Exec(ctx, deleteDuplicateProfileCompanies, arg.SourceID, arg.TargetID)
+	return err
+}
+
+const deleteExecutionWindow = `-- name: DeleteExecutionWindow :execrows
+DELETE FROM execution_windows WHERE user_id = $1
+`
+
+func (q *Queries) DeleteExecutionWindow(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExecutionWindow, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteLinkedInProfile = `-- name: DeleteLinkedInProfile :exec
+DELETE FROM linkedin_profiles
+WHERE id = $1
+`
+
+func (q *Queries) DeleteLinkedInProfile(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteLinkedInProfile, id)
+	return err
+}
+
+const deleteMissingConnections = `-- name: DeleteMissingConnections :many
+DELETE FROM connection_relationships
+WHERE profile_a_id = $1 AND degree = 1 AND missed_checks >= $2
+RETURNING profile_b_id
+`
+
+type DeleteMissingConnectionsParams struct {
+	ProfileID       pgtype.UUID
+	MaxMissedChecks int32
+}
+
+func (q *Queries) DeleteMissingConnections(ctx context.Context, arg DeleteMissingConnectionsParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, deleteMissingConnections, arg.ProfileID, arg.MaxMissedChecks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var profile_b_id pgtype.UUID
+		if err := rows.Scan(&profile_b_id); err != nil {
+			return nil, err
+		}
+		items = append(items, profile_b_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteNetworkClustersForUser = `-- name: DeleteNetworkClustersForUser :exec
+DELETE FROM network_clusters
+WHERE user_id = $1
+`
+
+// Network Clusters queries
+func (q *Queries) DeleteNetworkClustersForUser(ctx context.Context, userID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteNetworkClustersForUser, userID)
+	return err
+}
+
+const deleteNotificationChannel = `-- name: DeleteNotificationChannel :execrows
+DELETE FROM notification_channels
+WHERE id = $1 AND user_id = $2
+`
+
+type DeleteNotificationChannelParams struct {
+	ID     pgtype.UUID
+	UserID pgtype.UUID
+}
+
+func (q *Queries) DeleteNotificationChannel(ctx context.Context, arg DeleteNotificationChannelParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteNotificationChannel, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteProfileCompany = `-- name: DeleteProfileCompany :exec
+DELETE FROM profile_companies 
+WHERE profile_id = $1 AND company_id = $2
+`
+
+type DeleteProfileCompanyParams struct {
+	ProfileID pgtype.UUID
+	CompanyID pgtype.UUID
+}
+
+func (q *Queries) DeleteProfileCompany(ctx context.Context, arg DeleteProfileCompanyParams) error {
+	_, err := q.db.Exec(ctx, deleteProfileCompany, arg.ProfileID, arg.CompanyID)
+	return err
+}
+
+const deleteProfileList = `-- name: DeleteProfileList :execrows
+DELETE FROM profile_lists
+WHERE id = $1 AND user_id = $2
+`
+
+type DeleteProfileListParams struct {
+	ID     pgtype.UUID
+	UserID pgtype.UUID
+}
+
+func (q *Queries) DeleteProfileList(ctx context.Context, arg DeleteProfileListParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProfileList, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteProfileNote = `-- name: DeleteProfileNote :execrows
+DELETE FROM profile_notes
+WHERE id = $1 AND user_id = $2 AND profile_id = $3
+`
+
+type DeleteProfileNoteParams struct {
+	ID        pgtype.UUID
+	UserID    pgtype.UUID
+	ProfileID pgtype.UUID
+}
+
+func (q *Queries) DeleteProfileNote(ctx context.Context, arg DeleteProfileNoteParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProfileNote, arg.ID, arg.UserID, arg.ProfileID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteProfileTags = `-- name: DeleteProfileTags :exec
+DELETE FROM profile_tags
+WHERE user_id = $1 AND profile_id = $2
+`
+
+type DeleteProfileTagsParams struct {
+	UserID    pgtype.UUID
+	ProfileID pgtype.UUID
+}
+
+func (q *Queries) DeleteProfileTags(ctx context.Context, arg DeleteProfileTagsParams) error {
+	_, err := q.db.Exec(ctx, deleteProfileTags, arg.UserID, arg.ProfileID)
+	return err
+}
+
+const deleteRelationshipsBetween = `-- name: DeleteRelationshipsBetween :exec
+DELETE FROM connection_relationships
+WHERE (profile_a_id = $1 AND profile_b_id = $2)
+   OR (profile_a_id = $2 AND profile_b_id = $1)
+`
+
+type DeleteRelationshipsBetweenParams struct {
+	SourceID pgtype.UUID
+	TargetID pgtype.UUID
+}
+
+// Profile Merge queries
+func (q *Queries) DeleteRelationshipsBetween(ctx context.Context, arg DeleteRelationshipsBetweenParams) error {
+	_, err := q.db.Exec(ctx, deleteRelationshipsBetween, arg.SourceID, arg.TargetID)
+	return err
+}
+
+const deleteSavedSearch = `-- name: DeleteSavedSearch :execrows
+DELETE FROM saved_searches
+WHERE id = $1 AND user_id = $2
+`
+
+type DeleteSavedSearchParams struct {
+	ID     pgtype.UUID
+	UserID pgtype.UUID
+}
+
+func (q *Queries) DeleteSavedSearch(ctx context.Context, arg DeleteSavedSearchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteSavedSearch, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteStaleProfileHeadlines = `-- name: DeleteStaleProfileHeadlines :exec
+DELETE FROM profile_headlines ph
+USING linkedin_profiles lp
+WHERE ph.profile_id = lp.id AND lp.headline IS NULL
+`
+
+func (q *Queries) DeleteStaleProfileHeadlines(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteStaleProfileHeadlines)
+	return err
+}
+
+const deleteStaleProfileLocations = `-- name: DeleteStaleProfileLocations :exec
+DELETE FROM profile_locations loc
+USING linkedin_profiles lp
+WHERE loc.profile_id = lp.id AND lp.location IS NULL
+`
+
+func (q *Queries) DeleteStaleProfileLocations(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteStaleProfileLocations)
+	return err
+}
+
+const deleteStaleProfileNetworkScores = `-- name: DeleteStaleProfileNetworkScores :exec
+DELETE FROM profile_network_scores
+WHERE user_id = $1 AND computed_at < $2
+`
+
+type DeleteStaleProfileNetworkScoresParams struct {
+	UserID     pgtype.UUID
+	ComputedAt pgtype.Timestamp
+}
+
+func (q *Queries) DeleteStaleProfileNetworkScores(ctx context.Context, arg DeleteStaleProfileNetworkScoresParams) error {
+	_, err := q.db.Exec(ctx, deleteStaleProfileNetworkScores, arg.UserID, arg.ComputedAt)
+	return err
+}
+
+const deleteSupersededSequenceEnrollments = `-- name: DeleteSupersededSequenceEnrollments :exec
+DELETE FROM sequence_enrollments t
+USING sequence_enrollments s
+WHERE t.profile_id = $1 AND s.profile_id = $2
+  AND t.rule_id = s.rule_id AND s.step > t.step
+`
+
+type DeleteSupersededSequenceEnrollmentsParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+// Drops the target's sequence enrollments that the source's enrollment in the
+// same rule is further along than, so that RepointSequenceEnrollments keeps
+// the more advanced of the two
+func (q *Queries) DeleteSupersededSequenceEnrollments(ctx context.Context, arg DeleteSupersededSequenceEnrollmentsParams) error {
+	_, err := q.db.Exec(ctx, deleteSupersededSequenceEnrollments, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const deleteTrackedConnection = `-- name: DeleteTrackedConnection :exec
+DELETE FROM tracked_connections 
+WHERE user_id = $1 AND profile_id = $2
+`
+
+type DeleteTrackedConnectionParams struct {
+	UserID    pgtype.UUID
+	ProfileID pgtype.UUID
+}
+
+func (q *Queries) DeleteTrackedConnection(ctx context.Context, arg DeleteTrackedConnectionParams) error {
+	_, err := q.db.Exec(ctx, deleteTrackedConnection, arg.UserID, arg.ProfileID)
+	return err
+}
+
+const deleteUndetectedDuplicateCandidates = `-- name: DeleteUndetectedDuplicateCandidates :exec
+DELETE FROM duplicate_candidates
+WHERE status = 'pending' AND detected_at < $1
+`
+
+// Pending pairs not detected by the latest run no longer look alike
+func (q *Queries) DeleteUndetectedDuplicateCandidates(ctx context.Context, detectedAt pgtype.Timestamp) error {
+	_, err := q.db.Exec(ctx, deleteUndetectedDuplicateCandidates, detectedAt)
+	return err
+}
+
+const deleteUser = `-- name: DeleteUser :exec
+DELETE FROM users WHERE id = $1
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) error {
 	_, err := q.db.Exec(ctx, deleteUser, id)
 	return err
 }
 
+const endEnrollment = `-- name: EndEnrollment :exec
+UPDATE sequence_enrollments
+SET status = $2, exit_reason = $3, updated_at = NOW()
+WHERE id = $1
+`
+
+type EndEnrollmentParams struct {
+	ID         pgtype.UUID
+	Status     string
+	ExitReason pgtype.Text
+}
+
+func (q *Queries) EndEnrollment(ctx context.Context, arg EndEnrollmentParams) error {
+	_, err := q.db.Exec(ctx, endEnrollment, arg.ID, arg.Status, arg.ExitReason)
+	return err
+}
+
+const enrollRuleSequence = `-- name: EnrollRuleSequence :exec
+INSERT INTO sequence_enrollments (rule_id, profile_id)
+SELECT ar.id, $1 FROM automation_rules ar
+WHERE ar.id = $2 AND ar.sequence IS NOT NULL
+ON CONFLICT (rule_id, profile_id) DO NOTHING
+`
+
+type EnrollRuleSequenceParams struct {
+	ProfileID pgtype.UUID
+	RuleID    pgtype.UUID
+}
+
+// Rule Sequence queries
+// Enrolls a profile the rule actioned in the rule's sequence, if it has one
+func (q *Queries) EnrollRuleSequence(ctx context.Context, arg EnrollRuleSequenceParams) error {
+	_, err := q.db.Exec(ctx, enrollRuleSequence, arg.ProfileID, arg.RuleID)
+	return err
+}
+
+const failEnrollmentStep = `-- name: FailEnrollmentStep :exec
+UPDATE sequence_enrollments
+SET attempts = attempts + 1, error = $1,
+    status = CASE WHEN attempts + 1 >= $2::int THEN 'failed' ELSE status END,
+    updated_at = NOW()
+WHERE id = $3
+`
+
+type FailEnrollmentStepParams struct {
+	Error       pgtype.Text
+	MaxAttempts int32
+	ID          pgtype.UUID
+}
+
+// Counts a failed attempt at the enrollment's step, failing the enrollment
+// once the step used up its attempts
+func (q *Queries) FailEnrollmentStep(ctx context.Context, arg FailEnrollmentStepParams) error {
+	_, err := q.db.Exec(ctx, failEnrollmentStep, arg.Error, arg.MaxAttempts, arg.ID)
+	return err
+}
+
+const fillMergedProfile = `-- name: FillMergedProfile :exec
+UPDATE linkedin_profiles t
+SET linkedin_id = COALESCE(t.linkedin_id, s.linkedin_id),
+    location = COALESCE(t.location, s.location),
+    headline = COALESCE(t.headline, s.headline),
+    current_company_id = COALESCE(t.current_company_id, s.current_company_id),
+    updated_at = NOW()
+FROM linkedin_profiles s
+WHERE t.id = $1 AND s.id = $2
+`
+
+type FillMergedProfileParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+// Fields the target lacks are taken from the source
+func (q *Queries) FillMergedProfile(ctx context.Context, arg FillMergedProfileParams) error {
+	_, err := q.db.Exec(ctx, fillMergedProfile, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const findCompanyByNormalizedName = `-- name: FindCompanyByNormalizedName :one
+SELECT id, name, linkedin_url, industry, created_at, updated_at, normalized_name
+FROM companies
+WHERE normalized_name = $1
+   OR id IN (SELECT ca.company_id FROM company_aliases ca WHERE ca.normalized_alias = $1)
+ORDER BY created_at
+LIMIT 1
+`
+
+func (q *Queries) FindCompanyByNormalizedName(ctx context.Context, normalizedName string) (Company, error) {
+	row := q.db.QueryRow(ctx, findCompanyByNormalizedName, normalizedName)
+	var i Company
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.LinkedinUrl,
+		&i.Industry,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.NormalizedName,
+	)
+	return i, err
+}
+
+const finishRuleExecution = `-- name: FinishRuleExecution :exec
+UPDATE rule_executions
+SET status = $2, outcome = $3, error = $4, executed_at = NOW()
+WHERE id = $1
+`
+
+type FinishRuleExecutionParams struct {
+	ID      pgtype.UUID
+	Status  string
+	Outcome pgtype.Text
+	Error   pgtype.Text
+}
+
+func (q *Queries) FinishRuleExecution(ctx context.Context, arg FinishRuleExecutionParams) error {
+	_, err := q.db.Exec(ctx, finishRuleExecution,
+		arg.ID,
+		arg.Status,
+		arg.Outcome,
+		arg.Error,
+	)
+	return err
+}
+
 const getActiveAutomationRules = `-- name: GetActiveAutomationRules :many
-SELECT id, user_id, name, company_filter, location_filter, action_type, message_template, is_active, created_at
+SELECT id, user_id, name, action_type, message_template, is_active, created_at, min_network_score, trigger_type, list_id,
+       country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function, condition, requires_approval,
+       sequence, timezone
 FROM automation_rules
 WHERE user_id = $1 AND is_active = true
 ORDER BY created_at DESC
@@ -410,12 +1359,24 @@ func (q *Queries) GetActiveAutomationRules(ctx context.Context, userID pgtype.UU
 			&i.ID,
 			&i.UserID,
 			&i.Name,
-			&i.CompanyFilter,
-			&i.LocationFilter,
 			&i.ActionType,
 			&i.MessageTemplate,
 			&i.IsActive,
 			&i.CreatedAt,
+			&i.MinNetworkScore,
+			&i.TriggerType,
+			&i.ListID,
+			&i.CountryCode,
+			&i.Near,
+			&i.NearLatitude,
+			&i.NearLongitude,
+			&i.RadiusKm,
+			&i.MinSeniority,
+			&i.JobFunction,
+			&i.Condition,
+			&i.RequiresApproval,
+			&i.Sequence,
+			&i.Timezone,
 		); err != nil {
 			return nil, err
 		}
@@ -428,7 +1389,9 @@ func (q *Queries) GetActiveAutomationRules(ctx context.Context, userID pgtype.UU
 }
 
 const getAutomationRuleByID = `-- name: GetAutomationRuleByID :one
-SELECT id, user_id, name, company_filter, location_filter, action_type, message_template, is_active, created_at
+SELECT id, user_id, name, action_type, message_template, is_active, created_at, min_network_score, trigger_type, list_id,
+       country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function, condition, requires_approval,
+       sequence, timezone
 FROM automation_rules
 WHERE id = $1 AND user_id = $2
 `
@@ -445,18 +1408,32 @@ func (q *Queries) GetAutomationRuleByID(ctx context.Context, arg GetAutomationRu
 		&i.ID,
 		&i.UserID,
 		&i.Name,
-		&i.CompanyFilter,
-		&i.LocationFilter,
 		&i.ActionType,
 		&i.MessageTemplate,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.MinNetworkScore,
+		&i.TriggerType,
+		&i.ListID,
+		&i.CountryCode,
+		&i.Near,
+		&i.NearLatitude,
+		&i.NearLongitude,
+		&i.RadiusKm,
+		&i.MinSeniority,
+		&i.JobFunction,
+		&i.Condition,
+		&i.RequiresApproval,
+		&i.Sequence,
+		&i.Timezone,
 	)
 	return i, err
 }
 
 const getAutomationRules = `-- name: GetAutomationRules :many
-SELECT id, user_id, name, company_filter, location_filter, action_type, message_template, is_active, created_at
+SELECT id, user_id, name, action_type, message_template, is_active, created_at, min_network_score, trigger_type, list_id,
+       country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function, condition, requires_approval,
+       sequence, timezone
 FROM automation_rules
 WHERE user_id = $1
 ORDER BY created_at DESC
@@ -476,12 +1453,24 @@ func (q *Queries) GetAutomationRules(ctx context.Context, userID pgtype.UUID) ([
 			&i.ID,
 			&i.UserID,
 			&i.Name,
-			&i.CompanyFilter,
-			&i.LocationFilter,
 			&i.ActionType,
 			&i.MessageTemplate,
 			&i.IsActive,
 			&i.CreatedAt,
+			&i.MinNetworkScore,
+			&i.TriggerType,
+			&i.ListID,
+			&i.CountryCode,
+			&i.Near,
+			&i.NearLatitude,
+			&i.NearLongitude,
+			&i.RadiusKm,
+			&i.MinSeniority,
+			&i.JobFunction,
+			&i.Condition,
+			&i.RequiresApproval,
+			&i.Sequence,
+			&i.Timezone,
 		); err != nil {
 			return nil, err
 		}
@@ -493,49 +1482,102 @@ func (q *Queries) GetAutomationRules(ctx context.Context, userID pgtype.UUID) ([
 	return items, nil
 }
 
-const getCompanyByID = `-- name: GetCompanyByID :one
-SELECT id, name, linkedin_url, industry, created_at, updated_at
-FROM companies
-WHERE id = $1
+const getClusterProfiles = `-- name: GetClusterProfiles :many
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name
+FROM profile_clusters pcl
+JOIN linkedin_profiles lp ON pcl.profile_id = lp.id
+LEFT JOIN companies c ON lp.current_company_id = c.id
+WHERE pcl.cluster_id = $1 AND pcl.user_id = $2
+ORDER BY lp.name
 `
 
-// Companies queries
-func (q *Queries) GetCompanyByID(ctx context.Context, id pgtype.UUID) (Company, error) {
-	row := q.db.QueryRow(ctx, getCompanyByID, id)
-	var i Company
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.LinkedinUrl,
-		&i.Industry,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+type GetClusterProfilesParams struct {
+	ClusterID pgtype.UUID
+	UserID    pgtype.UUID
 }
 
-const getCompanyByLinkedInURL = `-- name: GetCompanyByLinkedInURL :one
-SELECT id, name, linkedin_url, industry, created_at, updated_at
-FROM companies
-WHERE linkedin_url = $1
-`
+type GetClusterProfilesRow struct {
+	ID          pgtype.UUID
+	LinkedinUrl string
+	Name        string
+	Location    pgtype.Text
+	Headline    pgtype.Text
+	CompanyName pgtype.Text
+}
 
-func (q *Queries) GetCompanyByLinkedInURL(ctx context.Context, linkedinUrl pgtype.Text) (Company, error) {
-	row := q.db.QueryRow(ctx, getCompanyByLinkedInURL, linkedinUrl)
-	var i Company
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.LinkedinUrl,
+func (q *Queries) GetClusterProfiles(ctx context.Context, arg GetClusterProfilesParams) ([]GetClusterProfilesRow, error) {
+	rows, err := q.db.Query(ctx, getClusterProfiles, arg.ClusterID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetClusterProfilesRow
+	for rows.Next() {
+		var i GetClusterProfilesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.LinkedinUrl,
+			&i.Name,
+			&i.Location,
+			&i.Headline,
+			&i.CompanyName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCompanyByID = `-- name: GetCompanyByID :one
+SELECT id, name, linkedin_url, industry, created_at, updated_at, normalized_name
+FROM companies
+WHERE id = $1
+`
+
+// Companies queries
+func (q *Queries) GetCompanyByID(ctx context.Context, id pgtype.UUID) (Company, error) {
+	row := q.db.QueryRow(ctx, getCompanyByID, id)
+	var i Company
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.LinkedinUrl,
+		&i.Industry,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.NormalizedName,
+	)
+	return i, err
+}
+
+const getCompanyByLinkedInURL = `-- name: GetCompanyByLinkedInURL :one
+SELECT id, name, linkedin_url, industry, created_at, updated_at, normalized_name
+FROM companies
+WHERE linkedin_url = $1
+`
+
+func (q *Queries) GetCompanyByLinkedInURL(ctx context.Context, linkedinUrl pgtype.Text) (Company, error) {
+	row := q.db.QueryRow(ctx, getCompanyByLinkedInURL, linkedinUrl)
+	var i Company
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.LinkedinUrl,
 		&i.Industry,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.NormalizedName,
 	)
 	return i, err
 }
 
 const getCompanyByName = `-- name: GetCompanyByName :one
-SELECT id, name, linkedin_url, industry, created_at, updated_at
+SELECT id, name, linkedin_url, industry, created_at, updated_at, normalized_name
 FROM companies
 WHERE name = $1
 `
@@ -550,10 +1592,93 @@ func (q *Queries) GetCompanyByName(ctx context.Context, name string) (Company, e
 		&i.Industry,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.NormalizedName,
 	)
 	return i, err
 }
 
+const getCompanyPeople = `-- name: GetCompanyPeople :many
+WITH people AS (
+    SELECT lp.id FROM linkedin_profiles lp WHERE lp.current_company_id = $1
+    UNION
+    SELECT pc.profile_id FROM profile_companies pc
+    WHERE pc.company_id = $1 AND (pc.is_current OR $2::boolean)
+)
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       role.position, role.start_date, role.end_date,
+       (COALESCE(lp.current_company_id = $1, false) OR COALESCE(role.is_current, false))::boolean as is_current,
+       upd.degree as best_degree,
+       via.id as via_profile_id, via.name as via_profile_name, via.linkedin_url as via_profile_url
+FROM people p
+JOIN linkedin_profiles lp ON p.id = lp.id
+LEFT JOIN LATERAL (
+    SELECT pc.position, pc.start_date, pc.end_date, pc.is_current
+    FROM profile_companies pc
+    WHERE pc.profile_id = lp.id AND pc.company_id = $1
+    ORDER BY pc.is_current DESC, pc.start_date DESC
+    LIMIT 1
+) role ON true
+LEFT JOIN user_profile_degrees upd ON upd.profile_id = lp.id AND upd.user_id = $3
+LEFT JOIN linkedin_profiles via ON upd.via_profile_id = via.id
+ORDER BY is_current DESC, upd.degree NULLS LAST, lp.name
+`
+
+type GetCompanyPeopleParams struct {
+	CompanyID     pgtype.UUID
+	IncludeFormer bool
+	UserID        pgtype.UUID
+}
+
+type GetCompanyPeopleRow struct {
+	ID             pgtype.UUID
+	LinkedinUrl    string
+	Name           string
+	Location       pgtype.Text
+	Headline       pgtype.Text
+	Position       pgtype.Text
+	StartDate      pgtype.Date
+	EndDate        pgtype.Date
+	IsCurrent      bool
+	BestDegree     pgtype.Int4
+	ViaProfileID   pgtype.UUID
+	ViaProfileName pgtype.Text
+	ViaProfileUrl  pgtype.Text
+}
+
+func (q *Queries) GetCompanyPeople(ctx context.Context, arg GetCompanyPeopleParams) ([]GetCompanyPeopleRow, error) {
+	rows, err := q.db.Query(ctx, getCompanyPeople, arg.CompanyID, arg.IncludeFormer, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCompanyPeopleRow
+	for rows.Next() {
+		var i GetCompanyPeopleRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.LinkedinUrl,
+			&i.Name,
+			&i.Location,
+			&i.Headline,
+			&i.Position,
+			&i.StartDate,
+			&i.EndDate,
+			&i.IsCurrent,
+			&i.BestDegree,
+			&i.ViaProfileID,
+			&i.ViaProfileName,
+			&i.ViaProfileUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getConnectionRelationships = `-- name: GetConnectionRelationships :many
 SELECT cr.id, cr.profile_a_id, cr.profile_b_id, cr.degree, cr.discovered_at, cr.discovered_by_user_id,
        lp1.name as profile_a_name, lp1.linkedin_url as profile_a_url,
@@ -670,6 +1795,224 @@ func (q *Queries) GetConnectionsByDegree(ctx context.Context, arg GetConnections
 	return items, nil
 }
 
+const getDuplicateCandidate = `-- name: GetDuplicateCandidate :one
+SELECT id, profile_id, duplicate_id, score, reasons, status, reviewed_by, reviewed_at, detected_at, created_at
+FROM duplicate_candidates
+WHERE id = $1
+`
+
+func (q *Queries) GetDuplicateCandidate(ctx context.Context, id pgtype.UUID) (DuplicateCandidate, error) {
+	row := q.db.QueryRow(ctx, getDuplicateCandidate, id)
+	var i DuplicateCandidate
+	err := row.Scan(
+		&i.ID,
+		&i.ProfileID,
+		&i.DuplicateID,
+		&i.Score,
+		&i.Reasons,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.DetectedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getExecutionWindow = `-- name: GetExecutionWindow :one
+SELECT user_id, weekdays, start_time, end_time, holidays, updated_at FROM execution_windows WHERE user_id = $1
+`
+
+func (q *Queries) GetExecutionWindow(ctx context.Context, userID pgtype.UUID) (ExecutionWindow, error) {
+	row := q.db.QueryRow(ctx, getExecutionWindow, userID)
+	var i ExecutionWindow
+	err := row.Scan(
+		&i.UserID,
+		&i.Weekdays,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Holidays,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getInvitationQuota = `-- name: GetInvitationQuota :one
+SELECT user_id, daily_limit, weekly_limit, paused_until, updated_at FROM invitation_quotas WHERE user_id = $1
+`
+
+// Invitation Quotas queries
+func (q *Queries) GetInvitationQuota(ctx context.Context, userID pgtype.UUID) (InvitationQuota, error) {
+	row := q.db.QueryRow(ctx, getInvitationQuota, userID)
+	var i InvitationQuota
+	err := row.Scan(
+		&i.UserID,
+		&i.DailyLimit,
+		&i.WeeklyLimit,
+		&i.PausedUntil,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getInvitationUsage = `-- name: GetInvitationUsage :one
+SELECT NOW()::timestamp as checked_at,
+       COUNT(*) FILTER (WHERE si.sent_at >= $1::timestamp)::int as sent_today,
+       COUNT(*)::int as sent_this_week,
+       MIN(si.sent_at)::timestamp as first_sent_this_week,
+       MAX(si.sent_at)::timestamp as last_sent_at
+FROM sent_invitations si
+WHERE si.user_id = $2 AND si.sent_at >= NOW() - INTERVAL '7 days'
+`
+
+type GetInvitationUsageParams struct {
+	DayStart pgtype.Timestamp
+	UserID   pgtype.UUID
+}
+
+type GetInvitationUsageRow struct {
+	CheckedAt         pgtype.Timestamp
+	SentToday         int32
+	SentThisWeek      int32
+	FirstSentThisWeek pgtype.Timestamp
+	LastSentAt        pgtype.Timestamp
+}
+
+// Invitations sent since the start of the user's day and over the last seven
+// days, measured on the database clock like sent_at
+func (q *Queries) GetInvitationUsage(ctx context.Context, arg GetInvitationUsageParams) (GetInvitationUsageRow, error) {
+	row := q.db.QueryRow(ctx, getInvitationUsage, arg.DayStart, arg.UserID)
+	var i GetInvitationUsageRow
+	err := row.Scan(
+		&i.CheckedAt,
+		&i.SentToday,
+		&i.SentThisWeek,
+		&i.FirstSentThisWeek,
+		&i.LastSentAt,
+	)
+	return i, err
+}
+
+const getJobChangesMatchingRules = `-- name: GetJobChangesMatchingRules :many
+SELECT pe.id as event_id, pe.detected_at,
+       lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       nc.name as company_name, pe.new_position,
+       ar.id as rule_id, ar.name as rule_name, ar.action_type, ar.message_template,
+       COALESCE(pns.pagerank, 0)::float8 as network_score,
+       ar.condition, COALESCE(upd.degree, 0)::int as degree, via.name as via_name,
+       (SELECT MIN(cr.discovered_at) FROM connection_relationships cr
+        JOIN tracked_connections tc ON tc.user_id = $1 AND tc.profile_id IN (cr.profile_a_id, cr.profile_b_id)
+        WHERE lp.id IN (cr.profile_a_id, cr.profile_b_id))::timestamp as discovered_at,
+       ARRAY(SELECT pt.tag FROM profile_tags pt WHERE pt.user_id = $1 AND pt.profile_id = lp.id ORDER BY pt.tag)::text[] as tags,
+       ar.requires_approval, ar.timezone as rule_timezone
+FROM profile_events pe
+JOIN linkedin_profiles lp ON pe.profile_id = lp.id
+JOIN companies nc ON pe.new_company_id = nc.id
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = $1
+LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+LEFT JOIN user_profile_degrees upd ON upd.user_id = $1 AND upd.profile_id = lp.id
+LEFT JOIN linkedin_profiles via ON via.id = upd.via_profile_id
+CROSS JOIN automation_rules ar
+WHERE ar.user_id = $1
+  AND ar.is_active = true
+  AND ar.trigger_type = 'job_change'
+  AND pe.event_type = 'job_change'
+  AND pe.detected_at >= ar.created_at
+  AND (ar.country_code IS NULL OR loc.country_code = ar.country_code)
+  AND (ar.radius_km IS NULL OR distance_km(loc.latitude, loc.longitude, ar.near_latitude, ar.near_longitude) <= ar.radius_km)
+  AND (ar.min_seniority IS NULL OR seniority_rank(ph.seniority) >= seniority_rank(ar.min_seniority))
+  AND (ar.job_function IS NULL OR ph.job_function = ar.job_function)
+  AND (ar.min_network_score IS NULL OR COALESCE(pns.pagerank, 0) >= ar.min_network_score)
+  AND (ar.list_id IS NULL OR EXISTS (
+    SELECT 1 FROM profile_list_members plm WHERE plm.list_id = ar.list_id AND plm.profile_id = lp.id
+  ))
+  AND pe.profile_id IN (
+    SELECT tc.profile_id FROM tracked_connections tc WHERE tc.user_id = $1
+    UNION
+    SELECT cr.profile_a_id FROM connection_relationships cr WHERE cr.discovered_by_user_id = $1
+    UNION
+    SELECT cr.profile_b_id FROM connection_relationships cr WHERE cr.discovered_by_user_id = $1
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM rule_executions re
+    WHERE re.rule_id = ar.id AND re.profile_id = lp.id AND (re.status <> 'failed' OR re.approved_at IS NOT NULL OR re.attempts >= $2)
+  )
+ORDER BY pe.detected_at DESC, ar.created_at
+`
+
+type GetJobChangesMatchingRulesParams struct {
+	UserID      pgtype.UUID
+	MaxAttempts int32
+}
+
+type GetJobChangesMatchingRulesRow struct {
+	EventID          pgtype.UUID
+	DetectedAt       pgtype.Timestamp
+	ID               pgtype.UUID
+	LinkedinUrl      string
+	Name             string
+	Location         pgtype.Text
+	Headline         pgtype.Text
+	CompanyName      string
+	NewPosition      pgtype.Text
+	RuleID           pgtype.UUID
+	RuleName         string
+	ActionType       string
+	MessageTemplate  pgtype.Text
+	NetworkScore     float64
+	Condition        pgtype.Text
+	Degree           int32
+	ViaName          pgtype.Text
+	DiscoveredAt     pgtype.Timestamp
+	Tags             []string
+	RequiresApproval bool
+	RuleTimezone     pgtype.Text
+}
+
+// Candidates for the rules' structured filters; each rule's condition is evaluated on them in Go
+func (q *Queries) GetJobChangesMatchingRules(ctx context.Context, arg GetJobChangesMatchingRulesParams) ([]GetJobChangesMatchingRulesRow, error) {
+	rows, err := q.db.Query(ctx, getJobChangesMatchingRules, arg.UserID, arg.MaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetJobChangesMatchingRulesRow
+	for rows.Next() {
+		var i GetJobChangesMatchingRulesRow
+		if err := rows.Scan(
+			&i.EventID,
+			&i.DetectedAt,
+			&i.ID,
+			&i.LinkedinUrl,
+			&i.Name,
+			&i.Location,
+			&i.Headline,
+			&i.CompanyName,
+			&i.NewPosition,
+			&i.RuleID,
+			&i.RuleName,
+			&i.ActionType,
+			&i.MessageTemplate,
+			&i.NetworkScore,
+			&i.Condition,
+			&i.Degree,
+			&i.ViaName,
+			&i.DiscoveredAt,
+			&i.Tags,
+			&i.RequiresApproval,
+			&i.RuleTimezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLinkedInProfileByID = `-- name: GetLinkedInProfileByID :one
 SELECT id, linkedin_url, name, location, current_company_id, headline, created_at, updated_at
 FROM linkedin_profiles
@@ -715,31 +2058,234 @@ func (q *Queries) GetLinkedInProfileByURL(ctx context.Context, linkedinUrl strin
 	return i, err
 }
 
+const getNetworkCluster = `-- name: GetNetworkCluster :one
+SELECT id, user_id, label, size, top_companies, top_locations, computed_at
+FROM network_clusters
+WHERE id = $1 AND user_id = $2
+`
+
+type GetNetworkClusterParams struct {
+	ID     pgtype.UUID
+	UserID pgtype.UUID
+}
+
+func (q *Queries) GetNetworkCluster(ctx context.Context, arg GetNetworkClusterParams) (NetworkCluster, error) {
+	row := q.db.QueryRow(ctx, getNetworkCluster, arg.ID, arg.UserID)
+	var i NetworkCluster
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Label,
+		&i.Size,
+		&i.TopCompanies,
+		&i.TopLocations,
+		&i.ComputedAt,
+	)
+	return i, err
+}
+
+const getNetworkComposition = `-- name: GetNetworkComposition :many
+WITH counts AS (
+    SELECT date_trunc($1::text, nd.discovered_at) AS period_start,
+           CASE $2::text
+               WHEN 'company' THEN nd.company_name
+               WHEN 'country' THEN nd.country_code::text
+               ELSE nd.location
+           END AS value,
+           COUNT(*) AS profiles
+    FROM network_discoveries nd
+    WHERE nd.user_id = $3
+      AND nd.discovered_at >= date_trunc($1::text, $4::timestamp)
+      AND CASE WHEN $5::uuid IS NULL THEN nd.first_for_user
+               ELSE nd.tracked_profile_id = $5::uuid END
+    GROUP BY 1, 2
+),
+ranked AS (
+    SELECT c.period_start, c.value, c.profiles,
+           ROW_NUMBER() OVER (PARTITION BY c.period_start ORDER BY c.profiles DESC, c.value) AS position
+    FROM counts c
+    WHERE c.value IS NOT NULL
+)
+SELECT r.period_start::timestamp AS period_start, r.value::text AS value, r.profiles::int AS profiles
+FROM ranked r
+WHERE r.position <= $6::int
+ORDER BY r.period_start, r.profiles DESC, r.value
+`
+
+type GetNetworkCompositionParams struct {
+	Bucket           string
+	Dimension        string
+	UserID           pgtype.UUID
+	Since            pgtype.Timestamp
+	TrackedProfileID pgtype.UUID
+	Top              int32
+}
+
+type GetNetworkCompositionRow struct {
+	PeriodStart pgtype.Timestamp
+	Value       string
+	Profiles    int32
+}
+
+// The most common companies, locations or countries among the profiles discovered per day or week
+func (q *Queries) GetNetworkComposition(ctx context.Context, arg GetNetworkCompositionParams) ([]GetNetworkCompositionRow, error) {
+	rows, err := q.db.Query(ctx, getNetworkComposition,
+		arg.Bucket,
+		arg.Dimension,
+		arg.UserID,
+		arg.Since,
+		arg.TrackedProfileID,
+		arg.Top,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNetworkCompositionRow
+	for rows.Next() {
+		var i GetNetworkCompositionRow
+		if err := rows.Scan(
+			&i.PeriodStart,
+			&i.Value,
+			&i.Profiles,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNetworkGrowth = `-- name: GetNetworkGrowth :many
+WITH periods AS (
+    SELECT generate_series(
+        date_trunc($1::text, $2::timestamp),
+        date_trunc($1::text, $3::timestamp),
+        ('1 ' || $1::text)::interval
+    ) AS period_start
+),
+discovered AS (
+    SELECT date_trunc($1::text, nd.discovered_at) AS period_start,
+           COUNT(*) FILTER (WHERE nd.degree = 2) AS second_degree,
+           COUNT(*) FILTER (WHERE nd.degree = 3) AS third_degree
+    FROM network_discoveries nd
+    WHERE nd.user_id = $4
+      AND nd.discovered_at >= date_trunc($1::text, $2::timestamp)
+      AND CASE WHEN $5::uuid IS NULL THEN nd.first_for_user
+               ELSE nd.tracked_profile_id = $5::uuid END
+    GROUP BY 1
+),
+removed AS (
+    SELECT date_trunc($1::text, nr.removed_at) AS period_start,
+           COUNT(DISTINCT nr.event_id) AS removed
+    FROM network_removals nr
+    WHERE nr.user_id = $4
+      AND nr.removed_at >= date_trunc($1::text, $2::timestamp)
+      AND ($5::uuid IS NULL OR nr.tracked_profile_id = $5::uuid)
+    GROUP BY 1
+)
+SELECT p.period_start::timestamp AS period_start,
+       COALESCE(d.second_degree, 0)::int AS second_degree,
+       COALESCE(d.third_degree, 0)::int AS third_degree,
+       COALESCE(r.removed, 0)::int AS removed
+FROM periods p
+LEFT JOIN discovered d ON d.period_start = p.period_start
+LEFT JOIN removed r ON r.period_start = p.period_start
+ORDER BY p.period_start
+`
+
+type GetNetworkGrowthParams struct {
+	Bucket           string
+	Since            pgtype.Timestamp
+	Until            pgtype.Timestamp
+	UserID           pgtype.UUID
+	TrackedProfileID pgtype.UUID
+}
+
+type GetNetworkGrowthRow struct {
+	PeriodStart  pgtype.Timestamp
+	SecondDegree int32
+	ThirdDegree  int32
+	Removed      int32
+}
+
+// Profiles discovered and connections removed per day or week, with empty periods as zeros.
+// Without a tracked connection each profile counts once, when the user first discovered it.
+func (q *Queries) GetNetworkGrowth(ctx context.Context, arg GetNetworkGrowthParams) ([]GetNetworkGrowthRow, error) {
+	rows, err := q.db.Query(ctx, getNetworkGrowth,
+		arg.Bucket,
+		arg.Since,
+		arg.Until,
+		arg.UserID,
+		arg.TrackedProfileID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNetworkGrowthRow
+	for rows.Next() {
+		var i GetNetworkGrowthRow
+		if err := rows.Scan(
+			&i.PeriodStart,
+			&i.SecondDegree,
+			&i.ThirdDegree,
+			&i.Removed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getNewConnectionsForUser = `-- name: GetNewConnectionsForUser :many
 SELECT DISTINCT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline, lp.created_at,
        c.name as company_name,
-       MIN(cr.degree) as connection_degree
+       MIN(cr.degree) as connection_degree,
+       COALESCE(pns.degree, 0)::int as network_degree,
+       COALESCE(pns.betweenness, 0)::float8 as betweenness,
+       COALESCE(pns.pagerank, 0)::float8 as network_score,
+       pcl.cluster_id
 FROM linkedin_profiles lp
 LEFT JOIN companies c ON lp.current_company_id = c.id
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = $1
+LEFT JOIN profile_clusters pcl ON pcl.profile_id = lp.id AND pcl.user_id = $1
 JOIN connection_relationships cr ON (cr.profile_a_id = lp.id OR cr.profile_b_id = lp.id)
 JOIN tracked_connections tc ON (
     (cr.profile_a_id = tc.profile_id AND cr.profile_b_id = lp.id) OR
     (cr.profile_b_id = tc.profile_id AND cr.profile_a_id = lp.id)
 )
-WHERE tc.user_id = $1 
+WHERE tc.user_id = $1
   AND lp.id NOT IN (
     SELECT DISTINCT tc2.profile_id 
     FROM tracked_connections tc2 
     WHERE tc2.user_id = $1
   )
   AND cr.discovered_at > $2
-GROUP BY lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline, lp.created_at, c.name
-ORDER BY lp.created_at DESC
+  AND ($3::float8 IS NULL OR COALESCE(pns.pagerank, 0) >= $3::float8)
+  AND ($4::uuid IS NULL OR pcl.cluster_id = $4::uuid)
+GROUP BY lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline, lp.created_at, c.name,
+         pns.degree, pns.betweenness, pns.pagerank, pcl.cluster_id
+ORDER BY
+  CASE WHEN $5::text = 'network_score' THEN COALESCE(pns.pagerank, 0) END DESC,
+  CASE WHEN $5::text = 'degree' THEN COALESCE(pns.degree, 0) END DESC,
+  CASE WHEN $5::text = 'betweenness' THEN COALESCE(pns.betweenness, 0) END DESC,
+  lp.created_at DESC
 `
 
 type GetNewConnectionsForUserParams struct {
-	UserID       pgtype.UUID
-	DiscoveredAt pgtype.Timestamp
+	UserID          pgtype.UUID
+	DiscoveredAt    pgtype.Timestamp
+	MinNetworkScore pgtype.Float8
+	ClusterID       pgtype.UUID
+	SortBy          string
 }
 
 type GetNewConnectionsForUserRow struct {
@@ -751,11 +2297,21 @@ type GetNewConnectionsForUserRow struct {
 	CreatedAt        pgtype.Timestamp
 	CompanyName      pgtype.Text
 	ConnectionDegree interface{}
+	NetworkDegree    int32
+	Betweenness      float64
+	NetworkScore     float64
+	ClusterID        pgtype.UUID
 }
 
 // Business Logic queries
 func (q *Queries) GetNewConnectionsForUser(ctx context.Context, arg GetNewConnectionsForUserParams) ([]GetNewConnectionsForUserRow, error) {
-	rows, err := q.db.Query(ctx, getNewConnectionsForUser, arg.UserID, arg.DiscoveredAt)
+	rows, err := q.db.Query(ctx, getNewConnectionsForUser,
+		arg.UserID,
+		arg.DiscoveredAt,
+		arg.MinNetworkScore,
+		arg.ClusterID,
+		arg.SortBy,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -772,6 +2328,10 @@ func (q *Queries) GetNewConnectionsForUser(ctx context.Context, arg GetNewConnec
 			&i.CreatedAt,
 			&i.CompanyName,
 			&i.ConnectionDegree,
+			&i.NetworkDegree,
+			&i.Betweenness,
+			&i.NetworkScore,
+			&i.ClusterID,
 		); err != nil {
 			return nil, err
 		}
@@ -783,18 +2343,62 @@ func (q *Queries) GetNewConnectionsForUser(ctx context.Context, arg GetNewConnec
 	return items, nil
 }
 
-const getProfileCompanies = `-- name: GetProfileCompanies :many
-SELECT pc.id, pc.profile_id, pc.company_id, pc.position, pc.start_date, pc.end_date, pc.is_current, pc.created_at,
-       c.name as company_name, c.linkedin_url as company_linkedin_url
-FROM profile_companies pc
-JOIN companies c ON pc.company_id = c.id
-WHERE pc.profile_id = $1
-ORDER BY pc.start_date DESC
+const getPendingApproval = `-- name: GetPendingApproval :one
+SELECT re.id, re.message, re.executed_at as queued_at,
+       ar.id as rule_id, ar.name as rule_name, ar.action_type,
+       lp.id as profile_id, lp.name as profile_name, lp.linkedin_url
+FROM rule_executions re
+JOIN automation_rules ar ON re.rule_id = ar.id
+JOIN linkedin_profiles lp ON re.profile_id = lp.id
+WHERE re.id = $1 AND ar.user_id = $2 AND re.status = 'awaiting_approval'
 `
 
-type GetProfileCompaniesRow struct {
-	ID                 pgtype.UUID
-	ProfileID          pgtype.UUID
+type GetPendingApprovalParams struct {
+	ID     pgtype.UUID
+	UserID pgtype.UUID
+}
+
+type GetPendingApprovalRow struct {
+	ID          pgtype.UUID
+	Message     pgtype.Text
+	QueuedAt    pgtype.Timestamp
+	RuleID      pgtype.UUID
+	RuleName    string
+	ActionType  string
+	ProfileID   pgtype.UUID
+	ProfileName string
+	LinkedinUrl string
+}
+
+func (q *Queries) GetPendingApproval(ctx context.Context, arg GetPendingApprovalParams) (GetPendingApprovalRow, error) {
+	row := q.db.QueryRow(ctx, getPendingApproval, arg.ID, arg.UserID)
+	var i GetPendingApprovalRow
+	err := row.Scan(
+		&i.ID,
+		&i.Message,
+		&i.QueuedAt,
+		&i.RuleID,
+		&i.RuleName,
+		&i.ActionType,
+		&i.ProfileID,
+		&i.ProfileName,
+		&i.LinkedinUrl,
+	)
+	return i, err
+}
+
+const getProfileCompanies = `-- name: GetProfileCompanies :many
+SELECT pc.id, pc.profile_id, pc.company_id, pc.position, pc.start_date, pc.end_date, pc.is_current, pc.created_at,
+       c.name as company_name, c.linkedin_url as company_linkedin_url
+FROM profile_companies pc
+JOIN companies c ON pc.company_id = c.id
+WHERE pc.profile_id = $1
+ORDER BY pc.start_date DESC
+`
+
+type GetProfileCompaniesRow struct {
+	ID                 pgtype.UUID
+	ProfileID          pgtype.UUID
 	CompanyID          pgtype.UUID
 	Position           string
 	StartDate          pgtype.Date
@@ -837,39 +2441,158 @@ func (q *Queries) GetProfileCompanies(ctx context.Context, profileID pgtype.UUID
 	return items, nil
 }
 
+const getProfileList = `-- name: GetProfileList :one
+SELECT id, user_id, name, description, created_at, updated_at
+FROM profile_lists
+WHERE id = $1 AND user_id = $2
+`
+
+type GetProfileListParams struct {
+	ID     pgtype.UUID
+	UserID pgtype.UUID
+}
+
+func (q *Queries) GetProfileList(ctx context.Context, arg GetProfileListParams) (ProfileList, error) {
+	row := q.db.QueryRow(ctx, getProfileList, arg.ID, arg.UserID)
+	var i ProfileList
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getProfileListMembers = `-- name: GetProfileListMembers :many
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name, plm.added_at
+FROM profile_list_members plm
+JOIN linkedin_profiles lp ON plm.profile_id = lp.id
+LEFT JOIN companies c ON lp.current_company_id = c.id
+WHERE plm.list_id = $1
+ORDER BY plm.added_at DESC
+`
+
+type GetProfileListMembersRow struct {
+	ID          pgtype.UUID
+	LinkedinUrl string
+	Name        string
+	Location    pgtype.Text
+	Headline    pgtype.Text
+	CompanyName pgtype.Text
+	AddedAt     pgtype.Timestamp
+}
+
+func (q *Queries) GetProfileListMembers(ctx context.Context, listID pgtype.UUID) ([]GetProfileListMembersRow, error) {
+	rows, err := q.db.Query(ctx, getProfileListMembers, listID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProfileListMembersRow
+	for rows.Next() {
+		var i GetProfileListMembersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.LinkedinUrl,
+			&i.Name,
+			&i.Location,
+			&i.Headline,
+			&i.CompanyName,
+			&i.AddedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getProfilesMatchingRules = `-- name: GetProfilesMatchingRules :many
 SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
        c.name as company_name,
-       ar.id as rule_id, ar.name as rule_name, ar.action_type, ar.message_template
+       ar.id as rule_id, ar.name as rule_name, ar.action_type, ar.message_template,
+       COALESCE(pns.pagerank, 0)::float8 as network_score,
+       ar.condition, COALESCE(upd.degree, 0)::int as degree, via.name as via_name,
+       (SELECT MIN(cr.discovered_at) FROM connection_relationships cr
+        JOIN tracked_connections tc ON tc.user_id = $1 AND tc.profile_id IN (cr.profile_a_id, cr.profile_b_id)
+        WHERE lp.id IN (cr.profile_a_id, cr.profile_b_id))::timestamp as discovered_at,
+       ARRAY(SELECT pt.tag FROM profile_tags pt WHERE pt.user_id = $1 AND pt.profile_id = lp.id ORDER BY pt.tag)::text[] as tags,
+       ar.requires_approval, ar.timezone as rule_timezone
 FROM linkedin_profiles lp
 LEFT JOIN companies c ON lp.current_company_id = c.id
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = $1
+LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+LEFT JOIN user_profile_degrees upd ON upd.user_id = $1 AND upd.profile_id = lp.id
+LEFT JOIN linkedin_profiles via ON via.id = upd.via_profile_id
 CROSS JOIN automation_rules ar
 WHERE ar.user_id = $1 
   AND ar.is_active = true
-  AND (ar.company_filter IS NULL OR c.name ILIKE '%' || ar.company_filter || '%')
-  AND (ar.location_filter IS NULL OR lp.location ILIKE '%' || ar.location_filter || '%')
+  AND ar.trigger_type = 'new_connection'
+  AND (ar.country_code IS NULL OR loc.country_code = ar.country_code)
+  AND (ar.radius_km IS NULL OR distance_km(loc.latitude, loc.longitude, ar.near_latitude, ar.near_longitude) <= ar.radius_km)
+  AND (ar.min_seniority IS NULL OR seniority_rank(ph.seniority) >= seniority_rank(ar.min_seniority))
+  AND (ar.job_function IS NULL OR ph.job_function = ar.job_function)
+  AND (ar.min_network_score IS NULL OR COALESCE(pns.pagerank, 0) >= ar.min_network_score)
+  AND (ar.list_id IS NULL OR EXISTS (
+    SELECT 1 FROM profile_list_members plm WHERE plm.list_id = ar.list_id AND plm.profile_id = lp.id
+  ))
   AND lp.id NOT IN (
     SELECT DISTINCT tc.profile_id 
     FROM tracked_connections tc 
     WHERE tc.user_id = $1
   )
+  -- Only connections discovered since the rule was created are new to it
+  AND EXISTS (
+    SELECT 1 FROM connection_relationships cr
+    JOIN tracked_connections tc ON tc.user_id = $1
+     AND tc.profile_id IN (cr.profile_a_id, cr.profile_b_id)
+    WHERE lp.id IN (cr.profile_a_id, cr.profile_b_id)
+      AND cr.discovered_at >= ar.created_at
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM rule_executions re
+    WHERE re.rule_id = ar.id AND re.profile_id = lp.id AND (re.status <> 'failed' OR re.approved_at IS NOT NULL OR re.attempts >= $2)
+  )
+ORDER BY network_score DESC, ar.created_at
 `
 
+type GetProfilesMatchingRulesParams struct {
+	UserID      pgtype.UUID
+	MaxAttempts int32
+}
+
 type GetProfilesMatchingRulesRow struct {
-	ID              pgtype.UUID
-	LinkedinUrl     string
-	Name            string
-	Location        pgtype.Text
-	Headline        pgtype.Text
-	CompanyName     pgtype.Text
-	RuleID          pgtype.UUID
-	RuleName        string
-	ActionType      string
-	MessageTemplate pgtype.Text
+	ID               pgtype.UUID
+	LinkedinUrl      string
+	Name             string
+	Location         pgtype.Text
+	Headline         pgtype.Text
+	CompanyName      pgtype.Text
+	RuleID           pgtype.UUID
+	RuleName         string
+	ActionType       string
+	MessageTemplate  pgtype.Text
+	NetworkScore     float64
+	Condition        pgtype.Text
+	Degree           int32
+	ViaName          pgtype.Text
+	DiscoveredAt     pgtype.Timestamp
+	Tags             []string
+	RequiresApproval bool
+	RuleTimezone     pgtype.Text
 }
 
-func (q *Queries) GetProfilesMatchingRules(ctx context.Context, userID pgtype.UUID) ([]GetProfilesMatchingRulesRow, error) {
-	rows, err := q.db.Query(ctx, getProfilesMatchingRules, userID)
+// Candidates for the rules' structured filters; each rule's condition is evaluated on them in Go
+func (q *Queries) GetProfilesMatchingRules(ctx context.Context, arg GetProfilesMatchingRulesParams) ([]GetProfilesMatchingRulesRow, error) {
+	rows, err := q.db.Query(ctx, getProfilesMatchingRules, arg.UserID, arg.MaxAttempts)
 	if err != nil {
 		return nil, err
 	}
@@ -888,6 +2611,97 @@ func (q *Queries) GetProfilesMatchingRules(ctx context.Context, userID pgtype.UU
 			&i.RuleName,
 			&i.ActionType,
 			&i.MessageTemplate,
+			&i.NetworkScore,
+			&i.Condition,
+			&i.Degree,
+			&i.ViaName,
+			&i.DiscoveredAt,
+			&i.Tags,
+			&i.RequiresApproval,
+			&i.RuleTimezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRulePreviewCandidates = `-- name: GetRulePreviewCandidates :many
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name,
+       COALESCE(upd.degree, 0)::int as degree, via.name as via_name,
+       (SELECT MIN(cr.discovered_at) FROM connection_relationships cr
+        JOIN tracked_connections tc ON tc.user_id = $1 AND tc.profile_id IN (cr.profile_a_id, cr.profile_b_id)
+        WHERE lp.id IN (cr.profile_a_id, cr.profile_b_id))::timestamp as discovered_at,
+       ARRAY(SELECT pt.tag FROM profile_tags pt WHERE pt.user_id = $1 AND pt.profile_id = lp.id ORDER BY pt.tag)::text[] as tags
+FROM automation_rules ar
+JOIN user_profile_degrees upd ON upd.user_id = ar.user_id
+JOIN linkedin_profiles lp ON lp.id = upd.profile_id
+LEFT JOIN linkedin_profiles via ON via.id = upd.via_profile_id
+LEFT JOIN companies c ON lp.current_company_id = c.id
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = $1
+LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+WHERE ar.user_id = $1
+  AND ar.id = $2
+  AND upd.degree > 1
+  AND (ar.country_code IS NULL OR loc.country_code = ar.country_code)
+  AND (ar.radius_km IS NULL OR distance_km(loc.latitude, loc.longitude, ar.near_latitude, ar.near_longitude) <= ar.radius_km)
+  AND (ar.min_seniority IS NULL OR seniority_rank(ph.seniority) >= seniority_rank(ar.min_seniority))
+  AND (ar.job_function IS NULL OR ph.job_function = ar.job_function)
+  AND (ar.min_network_score IS NULL OR COALESCE(pns.pagerank, 0) >= ar.min_network_score)
+  AND (ar.list_id IS NULL OR EXISTS (
+    SELECT 1 FROM profile_list_members plm WHERE plm.list_id = ar.list_id AND plm.profile_id = lp.id
+  ))
+ORDER BY COALESCE(pns.pagerank, 0) DESC, lp.id
+LIMIT $3
+`
+
+type GetRulePreviewCandidatesParams struct {
+	UserID pgtype.UUID
+	ID     pgtype.UUID
+	Limit  int32
+}
+
+type GetRulePreviewCandidatesRow struct {
+	ID           pgtype.UUID
+	LinkedinUrl  string
+	Name         string
+	Location     pgtype.Text
+	Headline     pgtype.Text
+	CompanyName  pgtype.Text
+	Degree       int32
+	ViaName      pgtype.Text
+	DiscoveredAt pgtype.Timestamp
+	Tags         []string
+}
+
+// Profiles in the user's network passing a rule's structured filters, whether
+// or not the rule has actioned them; the condition is evaluated in Go
+func (q *Queries) GetRulePreviewCandidates(ctx context.Context, arg GetRulePreviewCandidatesParams) ([]GetRulePreviewCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, getRulePreviewCandidates, arg.UserID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRulePreviewCandidatesRow
+	for rows.Next() {
+		var i GetRulePreviewCandidatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.LinkedinUrl,
+			&i.Name,
+			&i.Location,
+			&i.Headline,
+			&i.CompanyName,
+			&i.Degree,
+			&i.ViaName,
+			&i.DiscoveredAt,
+			&i.Tags,
 		); err != nil {
 			return nil, err
 		}
@@ -899,6 +2713,279 @@ func (q *Queries) GetProfilesMatchingRules(ctx context.Context, userID pgtype.UU
 	return items, nil
 }
 
+const getRuleSimulationConnections = `-- name: GetRuleSimulationConnections :many
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name,
+       COALESCE(pns.pagerank, 0)::float8 as network_score,
+       COALESCE(upd.degree, 0)::int as degree, via.name as via_name,
+       (SELECT MIN(cr.discovered_at) FROM connection_relationships cr
+        JOIN tracked_connections tc ON tc.user_id = $1 AND tc.profile_id IN (cr.profile_a_id, cr.profile_b_id)
+        WHERE lp.id IN (cr.profile_a_id, cr.profile_b_id))::timestamp as discovered_at,
+       ARRAY(SELECT pt.tag FROM profile_tags pt WHERE pt.user_id = $1 AND pt.profile_id = lp.id ORDER BY pt.tag)::text[] as tags,
+       EXISTS (
+         SELECT 1 FROM rule_executions re
+         WHERE re.rule_id = ar.id AND re.profile_id = lp.id AND (re.status <> 'failed' OR re.approved_at IS NOT NULL OR re.attempts >= $2)
+       ) as actioned,
+       EXISTS (
+         SELECT 1 FROM rule_executions re
+         JOIN automation_rules other ON re.rule_id = other.id
+         WHERE other.user_id = ar.user_id AND other.action_type = ar.action_type
+           AND re.profile_id = lp.id AND re.status = 'skipped'
+       ) as blocked
+FROM automation_rules ar
+CROSS JOIN linkedin_profiles lp
+LEFT JOIN companies c ON lp.current_company_id = c.id
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = ar.user_id
+LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+LEFT JOIN user_profile_degrees upd ON upd.user_id = ar.user_id AND upd.profile_id = lp.id
+LEFT JOIN linkedin_profiles via ON via.id = upd.via_profile_id
+WHERE ar.user_id = $1
+  AND ar.id = $3
+  AND ar.trigger_type = 'new_connection'
+  AND (ar.country_code IS NULL OR loc.country_code = ar.country_code)
+  AND (ar.radius_km IS NULL OR distance_km(loc.latitude, loc.longitude, ar.near_latitude, ar.near_longitude) <= ar.radius_km)
+  AND (ar.min_seniority IS NULL OR seniority_rank(ph.seniority) >= seniority_rank(ar.min_seniority))
+  AND (ar.job_function IS NULL OR ph.job_function = ar.job_function)
+  AND (ar.min_network_score IS NULL OR COALESCE(pns.pagerank, 0) >= ar.min_network_score)
+  AND (ar.list_id IS NULL OR EXISTS (
+    SELECT 1 FROM profile_list_members plm WHERE plm.list_id = ar.list_id AND plm.profile_id = lp.id
+  ))
+  AND lp.id NOT IN (
+    SELECT tc.profile_id FROM tracked_connections tc WHERE tc.user_id = $1
+  )
+  AND EXISTS (
+    SELECT 1 FROM connection_relationships cr
+    JOIN tracked_connections tc ON tc.user_id = $1
+     AND tc.profile_id IN (cr.profile_a_id, cr.profile_b_id)
+    WHERE lp.id IN (cr.profile_a_id, cr.profile_b_id)
+      AND cr.discovered_at >= NOW() - make_interval(days => $4::int)
+  )
+ORDER BY network_score DESC, lp.id
+`
+
+type GetRuleSimulationConnectionsParams struct {
+	UserID      pgtype.UUID
+	MaxAttempts int32
+	RuleID      pgtype.UUID
+	Days        int32
+}
+
+type GetRuleSimulationConnectionsRow struct {
+	ID           pgtype.UUID
+	LinkedinUrl  string
+	Name         string
+	Location     pgtype.Text
+	Headline     pgtype.Text
+	CompanyName  pgtype.Text
+	NetworkScore float64
+	Degree       int32
+	ViaName      pgtype.Text
+	DiscoveredAt pgtype.Timestamp
+	Tags         []string
+	Actioned     bool
+	Blocked      bool
+}
+
+// Connections a rule would have matched had it been created days ago, as
+// GetProfilesMatchingRules matches them but whether or not the rule is active.
+// Profiles the rule has actioned, queued or given up on are flagged rather
+// than left out, as are those an executor skipped for another of the user's
+// rules with the same action, e.g. as already connected.
+func (q *Queries) GetRuleSimulationConnections(ctx context.Context, arg GetRuleSimulationConnectionsParams) ([]GetRuleSimulationConnectionsRow, error) {
+	rows, err := q.db.Query(ctx, getRuleSimulationConnections,
+		arg.UserID,
+		arg.MaxAttempts,
+		arg.RuleID,
+		arg.Days,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRuleSimulationConnectionsRow
+	for rows.Next() {
+		var i GetRuleSimulationConnectionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.LinkedinUrl,
+			&i.Name,
+			&i.Location,
+			&i.Headline,
+			&i.CompanyName,
+			&i.NetworkScore,
+			&i.Degree,
+			&i.ViaName,
+			&i.DiscoveredAt,
+			&i.Tags,
+			&i.Actioned,
+			&i.Blocked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRuleSimulationJobChanges = `-- name: GetRuleSimulationJobChanges :many
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       nc.name as company_name, pe.new_position,
+       COALESCE(pns.pagerank, 0)::float8 as network_score,
+       COALESCE(upd.degree, 0)::int as degree, via.name as via_name,
+       (SELECT MIN(cr.discovered_at) FROM connection_relationships cr
+        JOIN tracked_connections tc ON tc.user_id = $1 AND tc.profile_id IN (cr.profile_a_id, cr.profile_b_id)
+        WHERE lp.id IN (cr.profile_a_id, cr.profile_b_id))::timestamp as discovered_at,
+       ARRAY(SELECT pt.tag FROM profile_tags pt WHERE pt.user_id = $1 AND pt.profile_id = lp.id ORDER BY pt.tag)::text[] as tags,
+       EXISTS (
+         SELECT 1 FROM rule_executions re
+         WHERE re.rule_id = ar.id AND re.profile_id = lp.id AND (re.status <> 'failed' OR re.approved_at IS NOT NULL OR re.attempts >= $2)
+       ) as actioned,
+       EXISTS (
+         SELECT 1 FROM rule_executions re
+         JOIN automation_rules other ON re.rule_id = other.id
+         WHERE other.user_id = ar.user_id AND other.action_type = ar.action_type
+           AND re.profile_id = lp.id AND re.status = 'skipped'
+       ) as blocked
+FROM profile_events pe
+JOIN linkedin_profiles lp ON pe.profile_id = lp.id
+JOIN companies nc ON pe.new_company_id = nc.id
+JOIN automation_rules ar ON ar.user_id = $1 AND ar.id = $3
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = ar.user_id
+LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+LEFT JOIN user_profile_degrees upd ON upd.user_id = ar.user_id AND upd.profile_id = lp.id
+LEFT JOIN linkedin_profiles via ON via.id = upd.via_profile_id
+WHERE ar.trigger_type = 'job_change'
+  AND pe.event_type = 'job_change'
+  AND pe.detected_at >= NOW() - make_interval(days => $4::int)
+  AND (ar.country_code IS NULL OR loc.country_code = ar.country_code)
+  AND (ar.radius_km IS NULL OR distance_km(loc.latitude, loc.longitude, ar.near_latitude, ar.near_longitude) <= ar.radius_km)
+  AND (ar.min_seniority IS NULL OR seniority_rank(ph.seniority) >= seniority_rank(ar.min_seniority))
+  AND (ar.job_function IS NULL OR ph.job_function = ar.job_function)
+  AND (ar.min_network_score IS NULL OR COALESCE(pns.pagerank, 0) >= ar.min_network_score)
+  AND (ar.list_id IS NULL OR EXISTS (
+    SELECT 1 FROM profile_list_members plm WHERE plm.list_id = ar.list_id AND plm.profile_id = lp.id
+  ))
+  AND pe.profile_id IN (
+    SELECT tc.profile_id FROM tracked_connections tc WHERE tc.user_id = $1
+    UNION
+    SELECT cr.profile_a_id FROM connection_relationships cr WHERE cr.discovered_by_user_id = $1
+    UNION
+    SELECT cr.profile_b_id FROM connection_relationships cr WHERE cr.discovered_by_user_id = $1
+  )
+ORDER BY pe.detected_at DESC
+`
+
+type GetRuleSimulationJobChangesParams struct {
+	UserID      pgtype.UUID
+	MaxAttempts int32
+	RuleID      pgtype.UUID
+	Days        int32
+}
+
+type GetRuleSimulationJobChangesRow struct {
+	ID           pgtype.UUID
+	LinkedinUrl  string
+	Name         string
+	Location     pgtype.Text
+	Headline     pgtype.Text
+	CompanyName  string
+	NewPosition  pgtype.Text
+	NetworkScore float64
+	Degree       int32
+	ViaName      pgtype.Text
+	DiscoveredAt pgtype.Timestamp
+	Tags         []string
+	Actioned     bool
+	Blocked      bool
+}
+
+// Job changes a rule would have matched had it been created days ago, as
+// GetJobChangesMatchingRules matches them but whether or not the rule is
+// active, flagged as GetRuleSimulationConnections flags them
+func (q *Queries) GetRuleSimulationJobChanges(ctx context.Context, arg GetRuleSimulationJobChangesParams) ([]GetRuleSimulationJobChangesRow, error) {
+	rows, err := q.db.Query(ctx, getRuleSimulationJobChanges,
+		arg.UserID,
+		arg.MaxAttempts,
+		arg.RuleID,
+		arg.Days,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRuleSimulationJobChangesRow
+	for rows.Next() {
+		var i GetRuleSimulationJobChangesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.LinkedinUrl,
+			&i.Name,
+			&i.Location,
+			&i.Headline,
+			&i.CompanyName,
+			&i.NewPosition,
+			&i.NetworkScore,
+			&i.Degree,
+			&i.ViaName,
+			&i.DiscoveredAt,
+			&i.Tags,
+			&i.Actioned,
+			&i.Blocked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSavedSearch = `-- name: GetSavedSearch :one
+SELECT id, user_id, name, query, company_id, location, degree, tag, list_id, is_active, last_evaluated_at, created_at,
+       country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function
+FROM saved_searches
+WHERE id = $1 AND user_id = $2
+`
+
+type GetSavedSearchParams struct {
+	ID     pgtype.UUID
+	UserID pgtype.UUID
+}
+
+func (q *Queries) GetSavedSearch(ctx context.Context, arg GetSavedSearchParams) (SavedSearch, error) {
+	row := q.db.QueryRow(ctx, getSavedSearch, arg.ID, arg.UserID)
+	var i SavedSearch
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Query,
+		&i.CompanyID,
+		&i.Location,
+		&i.Degree,
+		&i.Tag,
+		&i.ListID,
+		&i.IsActive,
+		&i.LastEvaluatedAt,
+		&i.CreatedAt,
+		&i.CountryCode,
+		&i.Near,
+		&i.NearLatitude,
+		&i.NearLongitude,
+		&i.RadiusKm,
+		&i.MinSeniority,
+		&i.JobFunction,
+	)
+	return i, err
+}
+
 const getTrackedConnection = `-- name: GetTrackedConnection :one
 SELECT tc.id, tc.user_id, tc.profile_id, tc.created_at, tc.last_checked_at
 FROM tracked_connections tc
@@ -925,7 +3012,7 @@ func (q *Queries) GetTrackedConnection(ctx context.Context, arg GetTrackedConnec
 
 const getTrackedConnections = `-- name: GetTrackedConnections :many
 SELECT tc.id, tc.user_id, tc.profile_id, tc.created_at, tc.last_checked_at,
-       lp.linkedin_url, lp.name, lp.location, lp.headline,
+       lp.linkedin_url, lp.linkedin_id, lp.name, lp.location, lp.headline,
        c.name as company_name
 FROM tracked_connections tc
 JOIN linkedin_profiles lp ON tc.profile_id = lp.id
@@ -941,6 +3028,7 @@ type GetTrackedConnectionsRow struct {
 	CreatedAt     pgtype.Timestamp
 	LastCheckedAt pgtype.Timestamp
 	LinkedinUrl   string
+	LinkedinID    pgtype.Text
 	Name          string
 	Location      pgtype.Text
 	Headline      pgtype.Text
@@ -964,6 +3052,7 @@ func (q *Queries) GetTrackedConnections(ctx context.Context, userID pgtype.UUID)
 			&i.CreatedAt,
 			&i.LastCheckedAt,
 			&i.LinkedinUrl,
+			&i.LinkedinID,
 			&i.Name,
 			&i.Location,
 			&i.Headline,
@@ -980,7 +3069,7 @@ func (q *Queries) GetTrackedConnections(ctx context.Context, userID pgtype.UUID)
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at
+SELECT id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at, timezone
 FROM users
 WHERE email = $1
 `
@@ -1000,12 +3089,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
 
 const getUserByGoogleID = `-- name: GetUserByGoogleID :one
-SELECT id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at
+SELECT id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at, timezone
 FROM users
 WHERE google_id = $1
 `
@@ -1025,12 +3115,13 @@ func (q *Queries) GetUserByGoogleID(ctx context.Context, googleID pgtype.Text) (
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at
+SELECT id, email, name, password_hash, google_id, access_token, refresh_token, auth_type, is_active, created_at, updated_at, timezone
 FROM users
 WHERE id = $1
 `
@@ -1051,34 +3142,1860 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
 
-const listCompanies = `-- name: ListCompanies :many
-SELECT id, name, linkedin_url, industry, created_at, updated_at
-FROM companies
-ORDER BY name
+const getUserTimezone = `-- name: GetUserTimezone :one
+SELECT timezone FROM users WHERE id = $1
 `
 
-func (q *Queries) ListCompanies(ctx context.Context) ([]Company, error) {
-	rows, err := q.db.Query(ctx, listCompanies)
+// Execution Windows queries
+func (q *Queries) GetUserTimezone(ctx context.Context, id pgtype.UUID) (pgtype.Text, error) {
+	row := q.db.QueryRow(ctx, getUserTimezone, id)
+	var timezone pgtype.Text
+	err := row.Scan(&timezone)
+	return timezone, err
+}
+
+const listActiveEnrollments = `-- name: ListActiveEnrollments :many
+SELECT se.id, se.step, se.attempts,
+       (se.accepted_at IS NOT NULL)::bool as accepted,
+       (se.replied_at IS NOT NULL)::bool as replied,
+       (se.step_started_at + make_interval(days => COALESCE((ar.sequence -> (se.step - 1) ->> 'wait_days')::int, 0)) <= NOW())::bool as waited,
+       ar.id as rule_id, ar.user_id, ar.name as rule_name, ar.trigger_type, ar.sequence, ar.timezone as rule_timezone,
+       lp.id as profile_id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name,
+       COALESCE(pns.pagerank, 0)::float8 as network_score,
+       via.name as via_name
+FROM sequence_enrollments se
+JOIN automation_rules ar ON se.rule_id = ar.id
+JOIN linkedin_profiles lp ON se.profile_id = lp.id
+LEFT JOIN companies c ON lp.current_company_id = c.id
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = ar.user_id
+LEFT JOIN user_profile_degrees upd ON upd.user_id = ar.user_id AND upd.profile_id = lp.id
+LEFT JOIN linkedin_profiles via ON via.id = upd.via_profile_id
+WHERE se.status = 'active' AND ar.is_active = true
+ORDER BY se.step_started_at, se.id
+`
+
+type ListActiveEnrollmentsRow struct {
+	ID           pgtype.UUID
+	Step         int32
+	Attempts     int32
+	Accepted     bool
+	Replied      bool
+	Waited       bool
+	RuleID       pgtype.UUID
+	UserID       pgtype.UUID
+	RuleName     string
+	TriggerType  string
+	Sequence     []byte
+	RuleTimezone pgtype.Text
+	ProfileID    pgtype.UUID
+	LinkedinUrl  string
+	Name         string
+	Location     pgtype.Text
+	Headline     pgtype.Text
+	CompanyName  pgtype.Text
+	NetworkScore float64
+	ViaName      pgtype.Text
+}
+
+// Active enrollments of active rules, with whether the wait of the step they
+// are on is over, measured on the database clock like step_started_at
+func (q *Queries) ListActiveEnrollments(ctx context.Context) ([]ListActiveEnrollmentsRow, error) {
+	rows, err := q.db.Query(ctx, listActiveEnrollments)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Company
+	var items []ListActiveEnrollmentsRow
 	for rows.Next() {
-		var i Company
+		var i ListActiveEnrollmentsRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.Name,
+			&i.Step,
+			&i.Attempts,
+			&i.Accepted,
+			&i.Replied,
+			&i.Waited,
+			&i.RuleID,
+			&i.UserID,
+			&i.RuleName,
+			&i.TriggerType,
+			&i.Sequence,
+			&i.RuleTimezone,
+			&i.ProfileID,
 			&i.LinkedinUrl,
-			&i.Industry,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
+			&i.Name,
+			&i.Location,
+			&i.Headline,
+			&i.CompanyName,
+			&i.NetworkScore,
+			&i.ViaName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listActiveNotificationChannels = `-- name: ListActiveNotificationChannels :many
+SELECT id, user_id, channel_type, target, is_active, created_at
+FROM notification_channels
+WHERE user_id = $1 AND is_active = true
+ORDER BY created_at
+`
+
+func (q *Queries) ListActiveNotificationChannels(ctx context.Context, userID pgtype.UUID) ([]NotificationChannel, error) {
+	rows, err := q.db.Query(ctx, listActiveNotificationChannels, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NotificationChannel
+	for rows.Next() {
+		var i NotificationChannel
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ChannelType,
+			&i.Target,
+			&i.IsActive,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listActiveSavedSearches = `-- name: ListActiveSavedSearches :many
+SELECT id, user_id, name, query, company_id, location, degree, tag, list_id, is_active, last_evaluated_at, created_at,
+       country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function
+FROM saved_searches
+WHERE user_id = $1 AND is_active = true
+ORDER BY created_at
+`
+
+func (q *Queries) ListActiveSavedSearches(ctx context.Context, userID pgtype.UUID) ([]SavedSearch, error) {
+	rows, err := q.db.Query(ctx, listActiveSavedSearches, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SavedSearch
+	for rows.Next() {
+		var i SavedSearch
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Query,
+			&i.CompanyID,
+			&i.Location,
+			&i.Degree,
+			&i.Tag,
+			&i.ListID,
+			&i.IsActive,
+			&i.LastEvaluatedAt,
+			&i.CreatedAt,
+			&i.CountryCode,
+			&i.Near,
+			&i.NearLatitude,
+			&i.NearLongitude,
+			&i.RadiusKm,
+			&i.MinSeniority,
+			&i.JobFunction,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listApprovedRuleActions = `-- name: ListApprovedRuleActions :many
+SELECT re.id as execution_id, re.message,
+       ar.id as rule_id, ar.name as rule_name, ar.trigger_type, ar.action_type, ar.timezone as rule_timezone,
+       lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name,
+       COALESCE(pns.pagerank, 0)::float8 as network_score,
+       via.name as via_name
+FROM rule_executions re
+JOIN automation_rules ar ON re.rule_id = ar.id
+JOIN linkedin_profiles lp ON re.profile_id = lp.id
+LEFT JOIN companies c ON lp.current_company_id = c.id
+LEFT JOIN profile_network_scores pns ON pns.profile_id = lp.id AND pns.user_id = ar.user_id
+LEFT JOIN user_profile_degrees upd ON upd.user_id = ar.user_id AND upd.profile_id = lp.id
+LEFT JOIN linkedin_profiles via ON via.id = upd.via_profile_id
+WHERE ar.user_id = $1 AND ar.is_active = true
+  AND re.approved_at IS NOT NULL
+  AND (re.status = 'approved' OR (re.status = 'failed' AND re.attempts < $2::int))
+ORDER BY re.approved_at, re.id
+`
+
+type ListApprovedRuleActionsParams struct {
+	UserID      pgtype.UUID
+	MaxAttempts int32
+}
+
+type ListApprovedRuleActionsRow struct {
+	ExecutionID  pgtype.UUID
+	Message      pgtype.Text
+	RuleID       pgtype.UUID
+	RuleName     string
+	TriggerType  string
+	ActionType   string
+	RuleTimezone pgtype.Text
+	ID           pgtype.UUID
+	LinkedinUrl  string
+	Name         string
+	Location     pgtype.Text
+	Headline     pgtype.Text
+	CompanyName  pgtype.Text
+	NetworkScore float64
+	ViaName      pgtype.Text
+}
+
+// Approved actions of the user's active rules still to perform, oldest
+// approval first, with their profiles as the rules' matching queries return them
+func (q *Queries) ListApprovedRuleActions(ctx context.Context, arg ListApprovedRuleActionsParams) ([]ListApprovedRuleActionsRow, error) {
+	rows, err := q.db.Query(ctx, listApprovedRuleActions, arg.UserID, arg.MaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListApprovedRuleActionsRow
+	for rows.Next() {
+		var i ListApprovedRuleActionsRow
+		if err := rows.Scan(
+			&i.ExecutionID,
+			&i.Message,
+			&i.RuleID,
+			&i.RuleName,
+			&i.TriggerType,
+			&i.ActionType,
+			&i.RuleTimezone,
+			&i.ID,
+			&i.LinkedinUrl,
+			&i.Name,
+			&i.Location,
+			&i.Headline,
+			&i.CompanyName,
+			&i.NetworkScore,
+			&i.ViaName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCompanies = `-- name: ListCompanies :many
+SELECT id, name, linkedin_url, industry, created_at, updated_at, normalized_name
+FROM companies
+ORDER BY name
+`
+
+func (q *Queries) ListCompanies(ctx context.Context) ([]Company, error) {
+	rows, err := q.db.Query(ctx, listCompanies)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Company
+	for rows.Next() {
+		var i Company
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.LinkedinUrl,
+			&i.Industry,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.NormalizedName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCompanyAliases = `-- name: ListCompanyAliases :many
+SELECT id, company_id, alias, normalized_alias, created_at
+FROM company_aliases
+WHERE company_id = $1
+ORDER BY alias
+`
+
+func (q *Queries) ListCompanyAliases(ctx context.Context, companyID pgtype.UUID) ([]CompanyAlias, error) {
+	rows, err := q.db.Query(ctx, listCompanyAliases, companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CompanyAlias
+	for rows.Next() {
+		var i CompanyAlias
+		if err := rows.Scan(
+			&i.ID,
+			&i.CompanyID,
+			&i.Alias,
+			&i.NormalizedAlias,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCompanyWatches = `-- name: ListCompanyWatches :many
+SELECT c.id, c.name, c.linkedin_url, c.industry, cw.created_at as watched_at,
+       (SELECT COUNT(*) FROM company_watch_matches cwm
+        WHERE cwm.user_id = cw.user_id AND cwm.company_id = cw.company_id) as match_count
+FROM company_watches cw
+JOIN companies c ON c.id = cw.company_id
+WHERE cw.user_id = $1
+ORDER BY c.name
+`
+
+type ListCompanyWatchesRow struct {
+	ID          pgtype.UUID
+	Name        string
+	LinkedinUrl pgtype.Text
+	Industry    pgtype.Text
+	WatchedAt   pgtype.Timestamp
+	MatchCount  int64
+}
+
+// Company Watches queries
+func (q *Queries) ListCompanyWatches(ctx context.Context, userID pgtype.UUID) ([]ListCompanyWatchesRow, error) {
+	rows, err := q.db.Query(ctx, listCompanyWatches, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCompanyWatchesRow
+	for rows.Next() {
+		var i ListCompanyWatchesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.LinkedinUrl,
+			&i.Industry,
+			&i.WatchedAt,
+			&i.MatchCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDuplicateCandidates = `-- name: ListDuplicateCandidates :many
+SELECT dc.id, dc.score, dc.reasons, dc.status, dc.reviewed_at, dc.created_at,
+       p.id as profile_id, p.linkedin_url as profile_linkedin_url, p.name as profile_name,
+       p.location as profile_location, p.headline as profile_headline, pc.name as profile_company_name,
+       d.id as duplicate_id, d.linkedin_url as duplicate_linkedin_url, d.name as duplicate_name,
+       d.location as duplicate_location, d.headline as duplicate_headline, dco.name as duplicate_company_name
+FROM duplicate_candidates dc
+JOIN linkedin_profiles p ON dc.profile_id = p.id
+JOIN linkedin_profiles d ON dc.duplicate_id = d.id
+LEFT JOIN companies pc ON p.current_company_id = pc.id
+LEFT JOIN companies dco ON d.current_company_id = dco.id
+WHERE dc.status = $1
+ORDER BY dc.score DESC, dc.created_at, dc.id
+LIMIT $2
+`
+
+type ListDuplicateCandidatesParams struct {
+	Status string
+	Limit  int32
+}
+
+type ListDuplicateCandidatesRow struct {
+	ID                   pgtype.UUID
+	Score                float64
+	Reasons              []string
+	Status               string
+	ReviewedAt           pgtype.Timestamp
+	CreatedAt            pgtype.Timestamp
+	ProfileID            pgtype.UUID
+	ProfileLinkedinUrl   string
+	ProfileName          string
+	ProfileLocation      pgtype.Text
+	ProfileHeadline      pgtype.Text
+	ProfileCompanyName   pgtype.Text
+	DuplicateID          pgtype.UUID
+	DuplicateLinkedinUrl string
+	DuplicateName        string
+	DuplicateLocation    pgtype.Text
+	DuplicateHeadline    pgtype.Text
+	DuplicateCompanyName pgtype.Text
+}
+
+func (q *Queries) ListDuplicateCandidates(ctx context.Context, arg ListDuplicateCandidatesParams) ([]ListDuplicateCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, listDuplicateCandidates, arg.Status, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDuplicateCandidatesRow
+	for rows.Next() {
+		var i ListDuplicateCandidatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Score,
+			&i.Reasons,
+			&i.Status,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+			&i.ProfileID,
+			&i.ProfileLinkedinUrl,
+			&i.ProfileName,
+			&i.ProfileLocation,
+			&i.ProfileHeadline,
+			&i.ProfileCompanyName,
+			&i.DuplicateID,
+			&i.DuplicateLinkedinUrl,
+			&i.DuplicateName,
+			&i.DuplicateLocation,
+			&i.DuplicateHeadline,
+			&i.DuplicateCompanyName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEnrollmentStepRuns = `-- name: ListEnrollmentStepRuns :many
+SELECT enrollment_id, step, action_type, status, outcome, message, error, executed_at
+FROM sequence_step_runs
+WHERE enrollment_id = ANY($1::uuid[])
+ORDER BY executed_at, id
+`
+
+type ListEnrollmentStepRunsRow struct {
+	EnrollmentID pgtype.UUID
+	Step         int32
+	ActionType   string
+	Status       string
+	Outcome      pgtype.Text
+	Message      pgtype.Text
+	Error        pgtype.Text
+	ExecutedAt   pgtype.Timestamp
+}
+
+func (q *Queries) ListEnrollmentStepRuns(ctx context.Context, enrollmentIds []pgtype.UUID) ([]ListEnrollmentStepRunsRow, error) {
+	rows, err := q.db.Query(ctx, listEnrollmentStepRuns, enrollmentIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEnrollmentStepRunsRow
+	for rows.Next() {
+		var i ListEnrollmentStepRunsRow
+		if err := rows.Scan(
+			&i.EnrollmentID,
+			&i.Step,
+			&i.ActionType,
+			&i.Status,
+			&i.Outcome,
+			&i.Message,
+			&i.Error,
+			&i.ExecutedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJobChangesForUser = `-- name: ListJobChangesForUser :many
+WITH network AS (
+    SELECT tc.profile_id FROM tracked_connections tc WHERE tc.user_id = $1
+    UNION
+    SELECT cr.profile_a_id FROM connection_relationships cr WHERE cr.discovered_by_user_id = $1
+    UNION
+    SELECT cr.profile_b_id FROM connection_relationships cr WHERE cr.discovered_by_user_id = $1
+)
+SELECT pe.id, pe.profile_id, pe.old_company_id, pe.new_company_id, pe.old_position, pe.new_position, pe.detected_at,
+       lp.name as profile_name, lp.linkedin_url,
+       oc.name as old_company_name, nc.name as new_company_name
+FROM profile_events pe
+JOIN network n ON pe.profile_id = n.profile_id
+JOIN linkedin_profiles lp ON pe.profile_id = lp.id
+LEFT JOIN companies oc ON pe.old_company_id = oc.id
+LEFT JOIN companies nc ON pe.new_company_id = nc.id
+WHERE pe.event_type = 'job_change'
+  AND pe.detected_at > $2
+  AND ($3::uuid IS NULL OR pe.new_company_id = $3::uuid)
+ORDER BY pe.detected_at DESC, pe.id
+LIMIT $4
+`
+
+type ListJobChangesForUserParams struct {
+	UserID     pgtype.UUID
+	Since      pgtype.Timestamp
+	CompanyID  pgtype.UUID
+	MaxResults int32
+}
+
+type ListJobChangesForUserRow struct {
+	ID             pgtype.UUID
+	ProfileID      pgtype.UUID
+	OldCompanyID   pgtype.UUID
+	NewCompanyID   pgtype.UUID
+	OldPosition    pgtype.Text
+	NewPosition    pgtype.Text
+	DetectedAt     pgtype.Timestamp
+	ProfileName    string
+	LinkedinUrl    string
+	OldCompanyName pgtype.Text
+	NewCompanyName pgtype.Text
+}
+
+func (q *Queries) ListJobChangesForUser(ctx context.Context, arg ListJobChangesForUserParams) ([]ListJobChangesForUserRow, error) {
+	rows, err := q.db.Query(ctx, listJobChangesForUser,
+		arg.UserID,
+		arg.Since,
+		arg.CompanyID,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListJobChangesForUserRow
+	for rows.Next() {
+		var i ListJobChangesForUserRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProfileID,
+			&i.OldCompanyID,
+			&i.NewCompanyID,
+			&i.OldPosition,
+			&i.NewPosition,
+			&i.DetectedAt,
+			&i.ProfileName,
+			&i.LinkedinUrl,
+			&i.OldCompanyName,
+			&i.NewCompanyName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLinkedInProfiles = `-- name: ListLinkedInProfiles :many
+SELECT id, linkedin_url, name, location, current_company_id, headline, created_at, updated_at
+FROM linkedin_profiles
+ORDER BY name
+`
+
+func (q *Queries) ListLinkedInProfiles(ctx context.Context) ([]LinkedinProfile, error) {
+	rows, err := q.db.Query(ctx, listLinkedInProfiles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LinkedinProfile
+	for rows.Next() {
+		var i LinkedinProfile
+		if err := rows.Scan(
+			&i.ID,
+			&i.LinkedinUrl,
+			&i.Name,
+			&i.Location,
+			&i.CurrentCompanyID,
+			&i.Headline,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNetworkClusters = `-- name: ListNetworkClusters :many
+SELECT id, user_id, label, size, top_companies, top_locations, computed_at
+FROM network_clusters
+WHERE user_id = $1
+ORDER BY size DESC, label
+`
+
+func (q *Queries) ListNetworkClusters(ctx context.Context, userID pgtype.UUID) ([]NetworkCluster, error) {
+	rows, err := q.db.Query(ctx, listNetworkClusters, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NetworkCluster
+	for rows.Next() {
+		var i NetworkCluster
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Label,
+			&i.Size,
+			&i.TopCompanies,
+			&i.TopLocations,
+			&i.ComputedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotificationChannels = `-- name: ListNotificationChannels :many
+SELECT id, user_id, channel_type, target, is_active, created_at
+FROM notification_channels
+WHERE user_id = $1
+ORDER BY created_at
+`
+
+// Notification Channels queries
+func (q *Queries) ListNotificationChannels(ctx context.Context, userID pgtype.UUID) ([]NotificationChannel, error) {
+	rows, err := q.db.Query(ctx, listNotificationChannels, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NotificationChannel
+	for rows.Next() {
+		var i NotificationChannel
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ChannelType,
+			&i.Target,
+			&i.IsActive,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotifications = `-- name: ListNotifications :many
+SELECT id, user_id, kind, title, body, payload, created_at, read_at
+FROM notifications
+WHERE user_id = $1
+  AND (NOT $2::boolean OR read_at IS NULL)
+ORDER BY created_at DESC
+LIMIT $3
+`
+
+type ListNotificationsParams struct {
+	UserID     pgtype.UUID
+	UnreadOnly bool
+	MaxResults int32
+}
+
+func (q *Queries) ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]Notification, error) {
+	rows, err := q.db.Query(ctx, listNotifications, arg.UserID, arg.UnreadOnly, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Notification
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Kind,
+			&i.Title,
+			&i.Body,
+			&i.Payload,
+			&i.CreatedAt,
+			&i.ReadAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingApprovals = `-- name: ListPendingApprovals :many
+SELECT re.id, re.message, re.executed_at as queued_at,
+       ar.id as rule_id, ar.name as rule_name, ar.action_type,
+       lp.id as profile_id, lp.name as profile_name, lp.linkedin_url
+FROM rule_executions re
+JOIN automation_rules ar ON re.rule_id = ar.id
+JOIN linkedin_profiles lp ON re.profile_id = lp.id
+WHERE ar.user_id = $1 AND re.status = 'awaiting_approval'
+  AND ($2::uuid IS NULL OR ar.id = $2::uuid)
+ORDER BY re.executed_at, re.id
+LIMIT $3
+`
+
+type ListPendingApprovalsParams struct {
+	UserID     pgtype.UUID
+	RuleID     pgtype.UUID
+	LimitCount int32
+}
+
+type ListPendingApprovalsRow struct {
+	ID          pgtype.UUID
+	Message     pgtype.Text
+	QueuedAt    pgtype.Timestamp
+	RuleID      pgtype.UUID
+	RuleName    string
+	ActionType  string
+	ProfileID   pgtype.UUID
+	ProfileName string
+	LinkedinUrl string
+}
+
+// Approval Queue queries
+func (q *Queries) ListPendingApprovals(ctx context.Context, arg ListPendingApprovalsParams) ([]ListPendingApprovalsRow, error) {
+	rows, err := q.db.Query(ctx, listPendingApprovals, arg.UserID, arg.RuleID, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPendingApprovalsRow
+	for rows.Next() {
+		var i ListPendingApprovalsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Message,
+			&i.QueuedAt,
+			&i.RuleID,
+			&i.RuleName,
+			&i.ActionType,
+			&i.ProfileID,
+			&i.ProfileName,
+			&i.LinkedinUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProfileEvents = `-- name: ListProfileEvents :many
+SELECT pe.id, pe.event_type, pe.old_position, pe.new_position, pe.old_headline, pe.new_headline, pe.detected_at,
+       pe.old_company_id, oc.name as old_company_name, pe.new_company_id, nc.name as new_company_name,
+       pe.related_profile_id, rp.name as related_profile_name, rp.linkedin_url as related_profile_url
+FROM profile_events pe
+LEFT JOIN companies oc ON pe.old_company_id = oc.id
+LEFT JOIN companies nc ON pe.new_company_id = nc.id
+LEFT JOIN linkedin_profiles rp ON pe.related_profile_id = rp.id
+WHERE pe.profile_id = $1
+ORDER BY pe.detected_at DESC, pe.id
+`
+
+type ListProfileEventsRow struct {
+	ID                 pgtype.UUID
+	EventType          string
+	OldPosition        pgtype.Text
+	NewPosition        pgtype.Text
+	OldHeadline        pgtype.Text
+	NewHeadline        pgtype.Text
+	DetectedAt         pgtype.Timestamp
+	OldCompanyID       pgtype.UUID
+	OldCompanyName     pgtype.Text
+	NewCompanyID       pgtype.UUID
+	NewCompanyName     pgtype.Text
+	RelatedProfileID   pgtype.UUID
+	RelatedProfileName pgtype.Text
+	RelatedProfileUrl  pgtype.Text
+}
+
+// Every recorded change to a profile, newest first, with the companies and tracked connection it mentions
+func (q *Queries) ListProfileEvents(ctx context.Context, profileID pgtype.UUID) ([]ListProfileEventsRow, error) {
+	rows, err := q.db.Query(ctx, listProfileEvents, profileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProfileEventsRow
+	for rows.Next() {
+		var i ListProfileEventsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventType,
+			&i.OldPosition,
+			&i.NewPosition,
+			&i.OldHeadline,
+			&i.NewHeadline,
+			&i.DetectedAt,
+			&i.OldCompanyID,
+			&i.OldCompanyName,
+			&i.NewCompanyID,
+			&i.NewCompanyName,
+			&i.RelatedProfileID,
+			&i.RelatedProfileName,
+			&i.RelatedProfileUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProfileLists = `-- name: ListProfileLists :many
+SELECT pl.id, pl.user_id, pl.name, pl.description, pl.created_at, pl.updated_at,
+       COUNT(plm.profile_id) as member_count
+FROM profile_lists pl
+LEFT JOIN profile_list_members plm ON plm.list_id = pl.id
+WHERE pl.user_id = $1
+GROUP BY pl.id
+ORDER BY pl.name
+`
+
+type ListProfileListsRow struct {
+	ID          pgtype.UUID
+	UserID      pgtype.UUID
+	Name        string
+	Description pgtype.Text
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	MemberCount int64
+}
+
+// Profile Lists queries
+func (q *Queries) ListProfileLists(ctx context.Context, userID pgtype.UUID) ([]ListProfileListsRow, error) {
+	rows, err := q.db.Query(ctx, listProfileLists, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProfileListsRow
+	for rows.Next() {
+		var i ListProfileListsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.MemberCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProfileNotes = `-- name: ListProfileNotes :many
+SELECT id, user_id, profile_id, body, created_at, updated_at
+FROM profile_notes
+WHERE user_id = $1 AND profile_id = $2
+ORDER BY created_at DESC
+`
+
+type ListProfileNotesParams struct {
+	UserID    pgtype.UUID
+	ProfileID pgtype.UUID
+}
+
+// Profile Notes queries
+func (q *Queries) ListProfileNotes(ctx context.Context, arg ListProfileNotesParams) ([]ProfileNote, error) {
+	rows, err := q.db.Query(ctx, listProfileNotes, arg.UserID, arg.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProfileNote
+	for rows.Next() {
+		var i ProfileNote
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProfileID,
+			&i.Body,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProfileRuleExecutions = `-- name: ListProfileRuleExecutions :many
+SELECT re.id, re.rule_id, ar.name as rule_name, ar.action_type, re.status, re.outcome, re.error, re.executed_at
+FROM rule_executions re
+JOIN automation_rules ar ON re.rule_id = ar.id
+WHERE re.profile_id = $1 AND ar.user_id = $2
+ORDER BY re.executed_at DESC, re.id
+`
+
+type ListProfileRuleExecutionsParams struct {
+	ProfileID pgtype.UUID
+	UserID    pgtype.UUID
+}
+
+type ListProfileRuleExecutionsRow struct {
+	ID         pgtype.UUID
+	RuleID     pgtype.UUID
+	RuleName   string
+	ActionType string
+	Status     string
+	Outcome    pgtype.Text
+	Error      pgtype.Text
+	ExecutedAt pgtype.Timestamp
+}
+
+func (q *Queries) ListProfileRuleExecutions(ctx context.Context, arg ListProfileRuleExecutionsParams) ([]ListProfileRuleExecutionsRow, error) {
+	rows, err := q.db.Query(ctx, listProfileRuleExecutions, arg.ProfileID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProfileRuleExecutionsRow
+	for rows.Next() {
+		var i ListProfileRuleExecutionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RuleID,
+			&i.RuleName,
+			&i.ActionType,
+			&i.Status,
+			&i.Outcome,
+			&i.Error,
+			&i.ExecutedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProfileTags = `-- name: ListProfileTags :many
+SELECT tag FROM profile_tags
+WHERE user_id = $1 AND profile_id = $2
+ORDER BY tag
+`
+
+type ListProfileTagsParams struct {
+	UserID    pgtype.UUID
+	ProfileID pgtype.UUID
+}
+
+// Profile Tags queries
+func (q *Queries) ListProfileTags(ctx context.Context, arg ListProfileTagsParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, listProfileTags, arg.UserID, arg.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		items = append(items, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProfilesForDuplicateDetection = `-- name: ListProfilesForDuplicateDetection :many
+SELECT id, linkedin_url, linkedin_id, name, location, current_company_id, created_at
+FROM linkedin_profiles
+ORDER BY id
+`
+
+type ListProfilesForDuplicateDetectionRow struct {
+	ID               pgtype.UUID
+	LinkedinUrl      string
+	LinkedinID       pgtype.Text
+	Name             string
+	Location         pgtype.Text
+	CurrentCompanyID pgtype.UUID
+	CreatedAt        pgtype.Timestamp
+}
+
+// Duplicate Candidates queries
+func (q *Queries) ListProfilesForDuplicateDetection(ctx context.Context) ([]ListProfilesForDuplicateDetectionRow, error) {
+	rows, err := q.db.Query(ctx, listProfilesForDuplicateDetection)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProfilesForDuplicateDetectionRow
+	for rows.Next() {
+		var i ListProfilesForDuplicateDetectionRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.LinkedinUrl,
+			&i.LinkedinID,
+			&i.Name,
+			&i.Location,
+			&i.CurrentCompanyID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProfilesWithStaleHeadline = `-- name: ListProfilesWithStaleHeadline :many
+SELECT lp.id, lp.headline
+FROM linkedin_profiles lp
+LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+WHERE lp.headline IS NOT NULL
+  AND (ph.profile_id IS NULL OR ph.raw_headline <> lp.headline)
+ORDER BY lp.id
+LIMIT $1
+`
+
+type ListProfilesWithStaleHeadlineRow struct {
+	ID       pgtype.UUID
+	Headline pgtype.Text
+}
+
+// Profile Headlines queries
+func (q *Queries) ListProfilesWithStaleHeadline(ctx context.Context, limit int32) ([]ListProfilesWithStaleHeadlineRow, error) {
+	rows, err := q.db.Query(ctx, listProfilesWithStaleHeadline, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProfilesWithStaleHeadlineRow
+	for rows.Next() {
+		var i ListProfilesWithStaleHeadlineRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Headline,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProfilesWithStaleLocation = `-- name: ListProfilesWithStaleLocation :many
+SELECT lp.id, lp.location
+FROM linkedin_profiles lp
+LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+WHERE lp.location IS NOT NULL
+  AND (loc.profile_id IS NULL OR loc.raw_location <> lp.location)
+ORDER BY lp.id
+LIMIT $1
+`
+
+type ListProfilesWithStaleLocationRow struct {
+	ID       pgtype.UUID
+	Location pgtype.Text
+}
+
+// Profile Locations queries
+func (q *Queries) ListProfilesWithStaleLocation(ctx context.Context, limit int32) ([]ListProfilesWithStaleLocationRow, error) {
+	rows, err := q.db.Query(ctx, listProfilesWithStaleLocation, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProfilesWithStaleLocationRow
+	for rows.Next() {
+		var i ListProfilesWithStaleLocationRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Location,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRuleEnrollments = `-- name: ListRuleEnrollments :many
+SELECT se.id, se.profile_id, lp.name as profile_name, lp.linkedin_url,
+       se.step, se.step_started_at, se.status, se.exit_reason, se.attempts, se.error,
+       se.accepted_at, se.replied_at, se.created_at, se.updated_at
+FROM sequence_enrollments se
+JOIN automation_rules ar ON se.rule_id = ar.id
+JOIN linkedin_profiles lp ON se.profile_id = lp.id
+WHERE se.rule_id = $1 AND ar.user_id = $2
+  AND ($3::text IS NULL OR se.status = $3::text)
+ORDER BY se.updated_at DESC, se.id
+LIMIT $4
+`
+
+type ListRuleEnrollmentsParams struct {
+	RuleID     pgtype.UUID
+	UserID     pgtype.UUID
+	Status     pgtype.Text
+	LimitCount int32
+}
+
+type ListRuleEnrollmentsRow struct {
+	ID            pgtype.UUID
+	ProfileID     pgtype.UUID
+	ProfileName   string
+	LinkedinUrl   string
+	Step          int32
+	StepStartedAt pgtype.Timestamp
+	Status        string
+	ExitReason    pgtype.Text
+	Attempts      int32
+	Error         pgtype.Text
+	AcceptedAt    pgtype.Timestamp
+	RepliedAt     pgtype.Timestamp
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+}
+
+func (q *Queries) ListRuleEnrollments(ctx context.Context, arg ListRuleEnrollmentsParams) ([]ListRuleEnrollmentsRow, error) {
+	rows, err := q.db.Query(ctx, listRuleEnrollments,
+		arg.RuleID,
+		arg.UserID,
+		arg.Status,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRuleEnrollmentsRow
+	for rows.Next() {
+		var i ListRuleEnrollmentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProfileID,
+			&i.ProfileName,
+			&i.LinkedinUrl,
+			&i.Step,
+			&i.StepStartedAt,
+			&i.Status,
+			&i.ExitReason,
+			&i.Attempts,
+			&i.Error,
+			&i.AcceptedAt,
+			&i.RepliedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRuleExecutions = `-- name: ListRuleExecutions :many
+SELECT re.id, re.profile_id, lp.name as profile_name, lp.linkedin_url,
+       re.status, re.outcome, re.error, re.attempts, re.executed_at, re.message
+FROM rule_executions re
+JOIN automation_rules ar ON re.rule_id = ar.id
+JOIN linkedin_profiles lp ON re.profile_id = lp.id
+WHERE re.rule_id = $1 AND ar.user_id = $2
+ORDER BY re.executed_at DESC, re.id
+LIMIT $3
+`
+
+type ListRuleExecutionsParams struct {
+	RuleID pgtype.UUID
+	UserID pgtype.UUID
+	Limit  int32
+}
+
+type ListRuleExecutionsRow struct {
+	ID          pgtype.UUID
+	ProfileID   pgtype.UUID
+	ProfileName string
+	LinkedinUrl string
+	Status      string
+	Outcome     pgtype.Text
+	Error       pgtype.Text
+	Attempts    int32
+	ExecutedAt  pgtype.Timestamp
+	Message     pgtype.Text
+}
+
+func (q *Queries) ListRuleExecutions(ctx context.Context, arg ListRuleExecutionsParams) ([]ListRuleExecutionsRow, error) {
+	rows, err := q.db.Query(ctx, listRuleExecutions, arg.RuleID, arg.UserID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRuleExecutionsRow
+	for rows.Next() {
+		var i ListRuleExecutionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProfileID,
+			&i.ProfileName,
+			&i.LinkedinUrl,
+			&i.Status,
+			&i.Outcome,
+			&i.Error,
+			&i.Attempts,
+			&i.ExecutedAt,
+			&i.Message,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSavedSearchMatches = `-- name: ListSavedSearchMatches :many
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name, ssm.matched_at
+FROM saved_search_matches ssm
+JOIN linkedin_profiles lp ON ssm.profile_id = lp.id
+LEFT JOIN companies c ON lp.current_company_id = c.id
+WHERE ssm.saved_search_id = $1
+ORDER BY ssm.matched_at DESC, lp.name
+LIMIT $2
+`
+
+type ListSavedSearchMatchesParams struct {
+	SavedSearchID pgtype.UUID
+	Limit         int32
+}
+
+type ListSavedSearchMatchesRow struct {
+	ID          pgtype.UUID
+	LinkedinUrl string
+	Name        string
+	Location    pgtype.Text
+	Headline    pgtype.Text
+	CompanyName pgtype.Text
+	MatchedAt   pgtype.Timestamp
+}
+
+func (q *Queries) ListSavedSearchMatches(ctx context.Context, arg ListSavedSearchMatchesParams) ([]ListSavedSearchMatchesRow, error) {
+	rows, err := q.db.Query(ctx, listSavedSearchMatches, arg.SavedSearchID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSavedSearchMatchesRow
+	for rows.Next() {
+		var i ListSavedSearchMatchesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.LinkedinUrl,
+			&i.Name,
+			&i.Location,
+			&i.Headline,
+			&i.CompanyName,
+			&i.MatchedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSavedSearches = `-- name: ListSavedSearches :many
+SELECT id, user_id, name, query, company_id, location, degree, tag, list_id, is_active, last_evaluated_at, created_at,
+       country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function
+FROM saved_searches
+WHERE user_id = $1
+ORDER BY name
+`
+
+// Saved Searches queries
+func (q *Queries) ListSavedSearches(ctx context.Context, userID pgtype.UUID) ([]SavedSearch, error) {
+	rows, err := q.db.Query(ctx, listSavedSearches, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SavedSearch
+	for rows.Next() {
+		var i SavedSearch
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Query,
+			&i.CompanyID,
+			&i.Location,
+			&i.Degree,
+			&i.Tag,
+			&i.ListID,
+			&i.IsActive,
+			&i.LastEvaluatedAt,
+			&i.CreatedAt,
+			&i.CountryCode,
+			&i.Near,
+			&i.NearLatitude,
+			&i.NearLongitude,
+			&i.RadiusKm,
+			&i.MinSeniority,
+			&i.JobFunction,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserIDsWithActiveRules = `-- name: ListUserIDsWithActiveRules :many
+SELECT DISTINCT ar.user_id
+FROM automation_rules ar
+JOIN users u ON ar.user_id = u.id
+WHERE ar.is_active = true AND u.is_active = true
+`
+
+func (q *Queries) ListUserIDsWithActiveRules(ctx context.Context) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listUserIDsWithActiveRules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var user_id pgtype.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserIDsWithTrackedConnections = `-- name: ListUserIDsWithTrackedConnections :many
+SELECT DISTINCT tc.user_id
+FROM tracked_connections tc
+JOIN users u ON tc.user_id = u.id
+WHERE u.is_active = true
+`
+
+func (q *Queries) ListUserIDsWithTrackedConnections(ctx context.Context) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listUserIDsWithTrackedConnections)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var user_id pgtype.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserTags = `-- name: ListUserTags :many
+SELECT tag, COUNT(*) as profile_count
+FROM profile_tags
+WHERE user_id = $1
+GROUP BY tag
+ORDER BY tag
+`
+
+type ListUserTagsRow struct {
+	Tag          string
+	ProfileCount int64
+}
+
+func (q *Queries) ListUserTags(ctx context.Context, userID pgtype.UUID) ([]ListUserTagsRow, error) {
+	rows, err := q.db.Query(ctx, listUserTags, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserTagsRow
+	for rows.Next() {
+		var i ListUserTagsRow
+		if err := rows.Scan(
+			&i.Tag,
+			&i.ProfileCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, email, name, auth_type, is_active, created_at, updated_at
+FROM users
+ORDER BY created_at DESC
+`
+
+type ListUsersRow struct {
+	ID        pgtype.UUID
+	Email     string
+	Name      string
+	AuthType  string
+	IsActive  bool
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
+func (q *Queries) ListUsers(ctx context.Context) ([]ListUsersRow, error) {
+	rows, err := q.db.Query(ctx, listUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUsersRow
+	for rows.Next() {
+		var i ListUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.Name,
+			&i.AuthType,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markNotificationRead = `-- name: MarkNotificationRead :execrows
+UPDATE notifications
+SET read_at = COALESCE(read_at, NOW())
+WHERE id = $1 AND user_id = $2
+`
+
+type MarkNotificationReadParams struct {
+	ID     pgtype.UUID
+	UserID pgtype.UUID
+}
+
+func (q *Queries) MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markNotificationRead, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const markSavedSearchEvaluated = `-- name: MarkSavedSearchEvaluated :exec
+UPDATE saved_searches
+SET last_evaluated_at = $2
+WHERE id = $1
+`
+
+type MarkSavedSearchEvaluatedParams struct {
+	ID              pgtype.UUID
+	LastEvaluatedAt pgtype.Timestamp
+}
+
+func (q *Queries) MarkSavedSearchEvaluated(ctx context.Context, arg MarkSavedSearchEvaluatedParams) error {
+	_, err := q.db.Exec(ctx, markSavedSearchEvaluated, arg.ID, arg.LastEvaluatedAt)
+	return err
+}
+
+const mergeDuplicateProfileCompanies = `-- name: MergeDuplicateProfileCompanies :exec
+UPDATE profile_companies t
+SET is_current = true
+FROM profile_companies s
+WHERE t.company_id = $1 AND s.company_id = $2
+  AND t.profile_id = s.profile_id AND t.position = s.position AND t.start_date = s.start_date
+  AND s.is_current AND NOT t.is_current
+`
+
+type MergeDuplicateProfileCompaniesParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) MergeDuplicateProfileCompanies(ctx context.Context, arg MergeDuplicateProfileCompaniesParams) error {
+	_, err := q.db.Exec(ctx, mergeDuplicateProfileCompanies, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const moveCompanyAliases = `-- name: MoveCompanyAliases :exec
+UPDATE company_aliases
+SET company_id = $1
+WHERE company_id = $2
+`
+
+type MoveCompanyAliasesParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+// Company Merge queries
+func (q *Queries) MoveCompanyAliases(ctx context.Context, arg MoveCompanyAliasesParams) error {
+	_, err := q.db.Exec(ctx, moveCompanyAliases, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const pauseInvitations = `-- name: PauseInvitations :exec
+INSERT INTO invitation_quotas (user_id, daily_limit, weekly_limit, paused_until)
+VALUES ($1, $2, $3, NOW() + INTERVAL '1 day')
+ON CONFLICT (user_id) DO UPDATE
+SET paused_until = EXCLUDED.paused_until, updated_at = NOW()
+`
+
+type PauseInvitationsParams struct {
+	UserID      pgtype.UUID
+	DailyLimit  int32
+	WeeklyLimit int32
+}
+
+func (q *Queries) PauseInvitations(ctx context.Context, arg PauseInvitationsParams) error {
+	_, err := q.db.Exec(ctx, pauseInvitations, arg.UserID, arg.DailyLimit, arg.WeeklyLimit)
+	return err
+}
+
+const pingDb = `-- name: PingDb :one
+SELECT 1 as result
+`
+
+// Utility queries
+func (q *Queries) PingDb(ctx context.Context) (int32, error) {
+	row := q.db.QueryRow(ctx, pingDb)
+	var result int32
+	err := row.Scan(&result)
+	return result, err
+}
+
+const queueRuleExecution = `-- name: QueueRuleExecution :exec
+UPDATE rule_executions
+SET status = 'awaiting_approval', message = $2, executed_at = NOW()
+WHERE id = $1
+`
+
+type QueueRuleExecutionParams struct {
+	ID      pgtype.UUID
+	Message pgtype.Text
+}
+
+func (q *Queries) QueueRuleExecution(ctx context.Context, arg QueueRuleExecutionParams) error {
+	_, err := q.db.Exec(ctx, queueRuleExecution, arg.ID, arg.Message)
+	return err
+}
+
+const recordCompanyWatchMatches = `-- name: RecordCompanyWatchMatches :many
+WITH matches AS (
+    SELECT lp.id, lp.linkedin_url, lp.name, lp.headline,
+           c.id as company_id, c.name as company_name, upd.degree,
+           via.id as via_profile_id, via.name as via_name, via.linkedin_url as via_linkedin_url
+    FROM company_watches cw
+    JOIN companies c ON c.id = cw.company_id
+    JOIN linkedin_profiles lp ON lp.current_company_id = cw.company_id
+    JOIN user_profile_degrees upd ON upd.user_id = cw.user_id AND upd.profile_id = lp.id
+    JOIN linkedin_profiles via ON via.id = upd.via_profile_id
+    WHERE cw.user_id = $1
+      AND upd.degree IN (2, 3)
+      AND ($2::uuid IS NULL OR cw.company_id = $2::uuid)
+),
+inserted AS (
+    INSERT INTO company_watch_matches (user_id, company_id, profile_id)
+    SELECT $1, m.company_id, m.id FROM matches m
+    ON CONFLICT DO NOTHING
+    RETURNING company_id, profile_id
+)
+SELECT m.id, m.linkedin_url, m.name, m.headline, m.company_id, m.company_name, m.degree,
+       m.via_profile_id, m.via_name, m.via_linkedin_url
+FROM matches m
+JOIN inserted i ON i.company_id = m.company_id AND i.profile_id = m.id
+ORDER BY m.company_name, m.degree, m.name
+`
+
+type RecordCompanyWatchMatchesParams struct {
+	UserID    pgtype.UUID
+	CompanyID pgtype.UUID
+}
+
+type RecordCompanyWatchMatchesRow struct {
+	ID             pgtype.UUID
+	LinkedinUrl    string
+	Name           string
+	Headline       pgtype.Text
+	CompanyID      pgtype.UUID
+	CompanyName    string
+	Degree         int32
+	ViaProfileID   pgtype.UUID
+	ViaName        string
+	ViaLinkedinUrl string
+}
+
+// Records the 2nd and 3rd degree profiles currently at the user's watched companies
+// (or just one of them) and returns only those not recorded before, with the
+// tracked connection that bridges to each.
+func (q *Queries) RecordCompanyWatchMatches(ctx context.Context, arg RecordCompanyWatchMatchesParams) ([]RecordCompanyWatchMatchesRow, error) {
+	rows, err := q.db.Query(ctx, recordCompanyWatchMatches, arg.UserID, arg.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RecordCompanyWatchMatchesRow
+	for rows.Next() {
+		var i RecordCompanyWatchMatchesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.LinkedinUrl,
+			&i.Name,
+			&i.Headline,
+			&i.CompanyID,
+			&i.CompanyName,
+			&i.Degree,
+			&i.ViaProfileID,
+			&i.ViaName,
+			&i.ViaLinkedinUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordEnrollmentEvent = `-- name: RecordEnrollmentEvent :exec
+UPDATE sequence_enrollments
+SET accepted_at = CASE WHEN $1::text = 'accepted' THEN COALESCE(accepted_at, NOW()) ELSE accepted_at END,
+    replied_at = CASE WHEN $1::text = 'replied' THEN COALESCE(replied_at, NOW()) ELSE replied_at END,
+    updated_at = NOW()
+WHERE id = $2
+`
+
+type RecordEnrollmentEventParams struct {
+	Event string
+	ID    pgtype.UUID
+}
+
+// Records the first time an event of the enrollment's profile was observed
+func (q *Queries) RecordEnrollmentEvent(ctx context.Context, arg RecordEnrollmentEventParams) error {
+	_, err := q.db.Exec(ctx, recordEnrollmentEvent, arg.Event, arg.ID)
+	return err
+}
+
+const recordSavedSearchMatches = `-- name: RecordSavedSearchMatches :many
+WITH matches AS (
+    SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+           c.name as company_name, upd.degree
+    FROM linkedin_profiles lp
+    JOIN user_profile_degrees upd ON upd.profile_id = lp.id AND upd.user_id = $1
+    LEFT JOIN companies c ON lp.current_company_id = c.id
+    LEFT JOIN profile_search ps ON ps.profile_id = lp.id
+    LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+    LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+    WHERE ($2::text IS NULL OR ps.document @@ websearch_to_tsquery('english', $2::text))
+      AND ($3::uuid IS NULL OR lp.current_company_id = $3::uuid)
+      AND ($4::text IS NULL OR lp.location = $4::text)
+      AND ($5::int IS NULL OR upd.degree = $5::int)
+      AND ($6::uuid IS NULL OR EXISTS (
+        SELECT 1 FROM profile_list_members plm
+        WHERE plm.list_id = $6::uuid AND plm.profile_id = lp.id
+      ))
+      AND ($7::text IS NULL OR EXISTS (
+        SELECT 1 FROM profile_tags pt
+        WHERE pt.user_id = $1 AND pt.profile_id = lp.id AND pt.tag = $7::text
+      ))
+      AND ($8::text IS NULL OR loc.country_code = $8::text)
+      AND ($9::float8 IS NULL OR distance_km(loc.latitude, loc.longitude,
+          $9::float8, $10::float8) <= $11::float8)
+      AND ($12::text IS NULL OR seniority_rank(ph.seniority) >= seniority_rank($12::text))
+      AND ($13::text IS NULL OR ph.job_function = $13::text)
+),
+inserted AS (
+    INSERT INTO saved_search_matches (saved_search_id, profile_id)
+    SELECT $14, m.id FROM matches m
+    ON CONFLICT DO NOTHING
+    RETURNING profile_id
+)
+SELECT m.id, m.linkedin_url, m.name, m.location, m.headline, m.company_name, m.degree
+FROM matches m
+JOIN inserted i ON i.profile_id = m.id
+ORDER BY m.name, m.id
+`
+
+type RecordSavedSearchMatchesParams struct {
+	UserID        pgtype.UUID
+	Query         pgtype.Text
+	CompanyID     pgtype.UUID
+	Location      pgtype.Text
+	Degree        pgtype.Int4
+	ListID        pgtype.UUID
+	Tag           pgtype.Text
+	CountryCode   pgtype.Text
+	NearLatitude  pgtype.Float8
+	NearLongitude pgtype.Float8
+	RadiusKm      pgtype.Float8
+	MinSeniority  pgtype.Text
+	JobFunction   pgtype.Text
+	SavedSearchID pgtype.UUID
+}
+
+type RecordSavedSearchMatchesRow struct {
+	ID          pgtype.UUID
+	LinkedinUrl string
+	Name        string
+	Location    pgtype.Text
+	Headline    pgtype.Text
+	CompanyName pgtype.Text
+	Degree      int32
+}
+
+func (q *Queries) RecordSavedSearchMatches(ctx context.Context, arg RecordSavedSearchMatchesParams) ([]RecordSavedSearchMatchesRow, error) {
+	rows, err := q.db.Query(ctx, recordSavedSearchMatches,
+		arg.UserID,
+		arg.Query,
+		arg.CompanyID,
+		arg.Location,
+		arg.Degree,
+		arg.ListID,
+		arg.Tag,
+		arg.CountryCode,
+		arg.NearLatitude,
+		arg.NearLongitude,
+		arg.RadiusKm,
+		arg.MinSeniority,
+		arg.JobFunction,
+		arg.SavedSearchID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RecordSavedSearchMatchesRow
+	for rows.Next() {
+		var i RecordSavedSearchMatchesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.LinkedinUrl,
+			&i.Name,
+			&i.Location,
+			&i.Headline,
+			&i.CompanyName,
+			&i.Degree,
+		); err != nil {
+			return nil, err
 		}
 		items = append(items, i)
 	}
@@ -1088,30 +5005,530 @@ func (q *Queries) ListCompanies(ctx context.Context) ([]Company, error) {
 	return items, nil
 }
 
-const listLinkedInProfiles = `-- name: ListLinkedInProfiles :many
-SELECT id, linkedin_url, name, location, current_company_id, headline, created_at, updated_at
-FROM linkedin_profiles
-ORDER BY name
-`
+const recordSentInvitation = `-- name: RecordSentInvitation :exec
+INSERT INTO sent_invitations (user_id, profile_id)
+VALUES ($1, $2)
+`
+
+type RecordSentInvitationParams struct {
+	UserID    pgtype.UUID
+	ProfileID pgtype.UUID
+}
+
+func (q *Queries) RecordSentInvitation(ctx context.Context, arg RecordSentInvitationParams) error {
+	_, err := q.db.Exec(ctx, recordSentInvitation, arg.UserID, arg.ProfileID)
+	return err
+}
+
+const recordSequenceStepRun = `-- name: RecordSequenceStepRun :exec
+INSERT INTO sequence_step_runs (enrollment_id, step, action_type, status, outcome, message, error)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type RecordSequenceStepRunParams struct {
+	EnrollmentID pgtype.UUID
+	Step         int32
+	ActionType   string
+	Status       string
+	Outcome      pgtype.Text
+	Message      pgtype.Text
+	Error        pgtype.Text
+}
+
+func (q *Queries) RecordSequenceStepRun(ctx context.Context, arg RecordSequenceStepRunParams) error {
+	_, err := q.db.Exec(ctx, recordSequenceStepRun,
+		arg.EnrollmentID,
+		arg.Step,
+		arg.ActionType,
+		arg.Status,
+		arg.Outcome,
+		arg.Message,
+		arg.Error,
+	)
+	return err
+}
+
+const refreshNetworkDiscoveries = `-- name: RefreshNetworkDiscoveries :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY network_discoveries
+`
+
+// Network Analytics queries
+func (q *Queries) RefreshNetworkDiscoveries(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, refreshNetworkDiscoveries)
+	return err
+}
+
+const refreshNetworkRemovals = `-- name: RefreshNetworkRemovals :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY network_removals
+`
+
+func (q *Queries) RefreshNetworkRemovals(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, refreshNetworkRemovals)
+	return err
+}
+
+const rejectDuplicateCandidate = `-- name: RejectDuplicateCandidate :execrows
+UPDATE duplicate_candidates
+SET status = 'rejected', reviewed_by = $2, reviewed_at = NOW()
+WHERE id = $1 AND status = 'pending'
+`
+
+type RejectDuplicateCandidateParams struct {
+	ID         pgtype.UUID
+	ReviewedBy pgtype.UUID
+}
+
+func (q *Queries) RejectDuplicateCandidate(ctx context.Context, arg RejectDuplicateCandidateParams) (int64, error) {
+	result, err := q.db.Exec(ctx, rejectDuplicateCandidate, arg.ID, arg.ReviewedBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const removeProfileListMember = `-- name: RemoveProfileListMember :execrows
+DELETE FROM profile_list_members
+WHERE list_id = $1 AND profile_id = $2
+`
+
+type RemoveProfileListMemberParams struct {
+	ListID    pgtype.UUID
+	ProfileID pgtype.UUID
+}
+
+func (q *Queries) RemoveProfileListMember(ctx context.Context, arg RemoveProfileListMemberParams) (int64, error) {
+	result, err := q.db.Exec(ctx, removeProfileListMember, arg.ListID, arg.ProfileID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const repointCompanyWatchMatches = `-- name: RepointCompanyWatchMatches :exec
+UPDATE company_watch_matches s
+SET company_id = $1
+WHERE s.company_id = $2
+  AND NOT EXISTS (
+    SELECT 1 FROM company_watch_matches t
+    WHERE t.user_id = s.user_id AND t.company_id = $1 AND t.profile_id = s.profile_id
+  )
+`
+
+type RepointCompanyWatchMatchesParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) RepointCompanyWatchMatches(ctx context.Context, arg RepointCompanyWatchMatchesParams) error {
+	_, err := q.db.Exec(ctx, repointCompanyWatchMatches, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const repointCompanyWatches = `-- name: RepointCompanyWatches :exec
+UPDATE company_watches s
+SET company_id = $1
+WHERE s.company_id = $2
+  AND NOT EXISTS (
+    SELECT 1 FROM company_watches t
+    WHERE t.user_id = s.user_id AND t.company_id = $1
+  )
+`
+
+type RepointCompanyWatchesParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+// Watches and matches already held for the target are left to cascade with the source
+func (q *Queries) RepointCompanyWatches(ctx context.Context, arg RepointCompanyWatchesParams) error {
+	_, err := q.db.Exec(ctx, repointCompanyWatches, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const repointProfileCompanies = `-- name: RepointProfileCompanies :exec
+UPDATE profile_companies
+SET company_id = $1
+WHERE company_id = $2
+`
+
+type RepointProfileCompaniesParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) RepointProfileCompanies(ctx context.Context, arg RepointProfileCompaniesParams) error {
+	_, err := q.db.Exec(ctx, repointProfileCompanies, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const repointProfileEmployment = `-- name: RepointProfileEmployment :exec
+UPDATE profile_companies s
+SET profile_id = $1
+WHERE s.profile_id = $2
+  AND NOT EXISTS (
+    SELECT 1 FROM profile_companies t
+    WHERE t.profile_id = $1 AND t.company_id = s.company_id
+      AND t.position = s.position AND t.start_date = s.start_date
+  )
+`
+
+type RepointProfileEmploymentParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) RepointProfileEmployment(ctx context.Context, arg RepointProfileEmploymentParams) error {
+	_, err := q.db.Exec(ctx, repointProfileEmployment, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const repointProfileEventCompanies = `-- name: RepointProfileEventCompanies :exec
+UPDATE profile_events
+SET old_company_id = CASE WHEN old_company_id = $1 THEN $2 ELSE old_company_id END,
+    new_company_id = CASE WHEN new_company_id = $1 THEN $2 ELSE new_company_id END
+WHERE old_company_id = $1 OR new_company_id = $1
+`
+
+type RepointProfileEventCompaniesParams struct {
+	SourceID pgtype.UUID
+	TargetID pgtype.UUID
+}
+
+func (q *Queries) RepointProfileEventCompanies(ctx context.Context, arg RepointProfileEventCompaniesParams) error {
+	_, err := q.db.Exec(ctx, repointProfileEventCompanies, arg.SourceID, arg.TargetID)
+	return err
+}
+
+const repointProfileEventRelations = `-- name: RepointProfileEventRelations :exec
+UPDATE profile_events
+SET related_profile_id = $1
+WHERE related_profile_id = $2
+`
+
+type RepointProfileEventRelationsParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) RepointProfileEventRelations(ctx context.Context, arg RepointProfileEventRelationsParams) error {
+	_, err := q.db.Exec(ctx, repointProfileEventRelations, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const repointProfileEvents = `-- name: RepointProfileEvents :exec
+UPDATE profile_events
+SET profile_id = $1
+WHERE profile_id = $2
+`
+
+type RepointProfileEventsParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) RepointProfileEvents(ctx context.Context, arg RepointProfileEventsParams) error {
+	_, err := q.db.Exec(ctx, repointProfileEvents, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const repointProfileListMembers = `-- name: RepointProfileListMembers :exec
+UPDATE profile_list_members s
+SET profile_id = $1
+WHERE s.profile_id = $2
+  AND NOT EXISTS (
+    SELECT 1 FROM profile_list_members t
+    WHERE t.profile_id = $1 AND t.list_id = s.list_id
+  )
+`
+
+type RepointProfileListMembersParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) RepointProfileListMembers(ctx context.Context, arg RepointProfileListMembersParams) error {
+	_, err := q.db.Exec(ctx, repointProfileListMembers, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const repointProfileNotes = `-- name: RepointProfileNotes :exec
+UPDATE profile_notes
+SET profile_id = $1
+WHERE profile_id = $2
+`
+
+type RepointProfileNotesParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) RepointProfileNotes(ctx context.Context, arg RepointProfileNotesParams) error {
+	_, err := q.db.Exec(ctx, repointProfileNotes, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const repointProfileTags = `-- name: RepointProfileTags :exec
+UPDATE profile_tags s
+SET profile_id = $1
+WHERE s.profile_id = $2
+  AND NOT EXISTS (
+    SELECT 1 FROM profile_tags t
+    WHERE t.profile_id = $1 AND t.user_id = s.user_id AND t.tag = s.tag
+  )
+`
+
+type RepointProfileTagsParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) RepointProfileTags(ctx context.Context, arg RepointProfileTagsParams) error {
+	_, err := q.db.Exec(ctx, repointProfileTags, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const repointProfilesCurrentCompany = `-- name: RepointProfilesCurrentCompany :exec
+UPDATE linkedin_profiles
+SET current_company_id = $1, updated_at = NOW()
+WHERE current_company_id = $2
+`
+
+type RepointProfilesCurrentCompanyParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) RepointProfilesCurrentCompany(ctx context.Context, arg RepointProfilesCurrentCompanyParams) error {
+	_, err := q.db.Exec(ctx, repointProfilesCurrentCompany, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const repointRelationshipsFrom = `-- name: RepointRelationshipsFrom :exec
+UPDATE connection_relationships s
+SET profile_a_id = $1
+WHERE s.profile_a_id = $2
+  AND NOT EXISTS (
+    SELECT 1 FROM connection_relationships t
+    WHERE t.profile_a_id = $1 AND t.profile_b_id = s.profile_b_id AND t.degree = s.degree
+  )
+`
+
+type RepointRelationshipsFromParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+// Relationships the target already has are left to cascade with the source
+func (q *Queries) RepointRelationshipsFrom(ctx context.Context, arg RepointRelationshipsFromParams) error {
+	_, err := q.db.Exec(ctx, repointRelationshipsFrom, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const repointRelationshipsTo = `-- name: RepointRelationshipsTo :exec
+UPDATE connection_relationships s
+SET profile_b_id = $1
+WHERE s.profile_b_id = $2
+  AND NOT EXISTS (
+    SELECT 1 FROM connection_relationships t
+    WHERE t.profile_b_id = $1 AND t.profile_a_id = s.profile_a_id AND t.degree = s.degree
+  )
+`
+
+type RepointRelationshipsToParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) RepointRelationshipsTo(ctx context.Context, arg RepointRelationshipsToParams) error {
+	_, err := q.db.Exec(ctx, repointRelationshipsTo, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const repointRuleExecutions = `-- name: RepointRuleExecutions :exec
+UPDATE rule_executions s
+SET profile_id = $1
+WHERE s.profile_id = $2
+  AND NOT EXISTS (
+    SELECT 1 FROM rule_executions t
+    WHERE t.profile_id = $1 AND t.rule_id = s.rule_id
+  )
+`
+
+type RepointRuleExecutionsParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) RepointRuleExecutions(ctx context.Context, arg RepointRuleExecutionsParams) error {
+	_, err := q.db.Exec(ctx, repointRuleExecutions, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const repointSavedSearchCompanies = `-- name: RepointSavedSearchCompanies :exec
+UPDATE saved_searches
+SET company_id = $1
+WHERE company_id = $2
+`
+
+type RepointSavedSearchCompaniesParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) RepointSavedSearchCompanies(ctx context.Context, arg RepointSavedSearchCompaniesParams) error {
+	_, err := q.db.Exec(ctx, repointSavedSearchCompanies, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const repointSequenceEnrollments = `-- name: RepointSequenceEnrollments :exec
+UPDATE sequence_enrollments s
+SET profile_id = $1, updated_at = NOW()
+WHERE s.profile_id = $2
+  AND NOT EXISTS (
+    SELECT 1 FROM sequence_enrollments t
+    WHERE t.profile_id = $1 AND t.rule_id = s.rule_id
+  )
+`
+
+type RepointSequenceEnrollmentsParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) RepointSequenceEnrollments(ctx context.Context, arg RepointSequenceEnrollmentsParams) error {
+	_, err := q.db.Exec(ctx, repointSequenceEnrollments, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const repointTrackedConnections = `-- name: RepointTrackedConnections :exec
+UPDATE tracked_connections s
+SET profile_id = $1
+WHERE s.profile_id = $2
+  AND NOT EXISTS (
+    SELECT 1 FROM tracked_connections t
+    WHERE t.profile_id = $1 AND t.user_id = s.user_id
+  )
+`
+
+type RepointTrackedConnectionsParams struct {
+	TargetID pgtype.UUID
+	SourceID pgtype.UUID
+}
+
+func (q *Queries) RepointTrackedConnections(ctx context.Context, arg RepointTrackedConnectionsParams) error {
+	_, err := q.db.Exec(ctx, repointTrackedConnections, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const searchProfileFacets = `-- name: SearchProfileFacets :many
+WITH matches AS (
+    SELECT lp.current_company_id, c.name as company_name, lp.location, upd.degree, loc.country_code,
+           ph.seniority, ph.job_function
+    FROM profile_search ps
+    JOIN linkedin_profiles lp ON ps.profile_id = lp.id
+    LEFT JOIN companies c ON lp.current_company_id = c.id
+    LEFT JOIN user_profile_degrees upd ON upd.profile_id = lp.id AND upd.user_id = $1
+    LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+    LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+    WHERE ps.document @@ websearch_to_tsquery('english', $2)
+      AND ($3::uuid IS NULL OR lp.current_company_id = $3::uuid)
+      AND ($4::text IS NULL OR lp.location = $4::text)
+      AND ($5::int IS NULL OR upd.degree = $5::int)
+      AND ($6::uuid IS NULL OR EXISTS (
+          SELECT 1 FROM profile_list_members plm
+          JOIN profile_lists pl ON plm.list_id = pl.id
+          WHERE plm.list_id = $6::uuid AND pl.user_id = $1 AND plm.profile_id = lp.id
+        ))
+      AND ($7::text IS NULL OR EXISTS (
+          SELECT 1 FROM profile_tags pt
+          WHERE pt.user_id = $1 AND pt.profile_id = lp.id AND pt.tag = $7::text
+        ))
+      AND ($8::text IS NULL OR loc.country_code = $8::text)
+      AND ($9::float8 IS NULL OR distance_km(loc.latitude, loc.longitude,
+          $9::float8, $10::float8) <= $11::float8)
+      AND ($12::text IS NULL OR seniority_rank(ph.seniority) >= seniority_rank($12::text))
+      AND ($13::text IS NULL OR ph.job_function = $13::text)
+)
+SELECT 'company'::text as facet, current_company_id::text as value, COALESCE(company_name, '')::text as label, COUNT(*) as count
+FROM matches WHERE current_company_id IS NOT NULL
+GROUP BY current_company_id, company_name
+UNION ALL
+SELECT 'location'::text, location::text, location::text, COUNT(*)
+FROM matches WHERE location IS NOT NULL
+GROUP BY location
+UNION ALL
+SELECT 'degree'::text, degree::text, degree::text, COUNT(*)
+FROM matches WHERE degree IS NOT NULL
+GROUP BY degree
+UNION ALL
+SELECT 'country'::text, country_code::text, country_code::text, COUNT(*)
+FROM matches WHERE country_code IS NOT NULL
+GROUP BY country_code
+UNION ALL
+SELECT 'seniority'::text, seniority::text, seniority::text, COUNT(*)
+FROM matches WHERE seniority IS NOT NULL
+GROUP BY seniority
+UNION ALL
+SELECT 'function'::text, job_function::text, job_function::text, COUNT(*)
+FROM matches WHERE job_function IS NOT NULL
+GROUP BY job_function
+UNION ALL
+SELECT 'total'::text, ''::text, ''::text, COUNT(*)
+FROM matches
+ORDER BY facet, count DESC, label
+`
+
+type SearchProfileFacetsParams struct {
+	UserID        pgtype.UUID
+	Query         string
+	CompanyID     pgtype.UUID
+	Location      pgtype.Text
+	Degree        pgtype.Int4
+	ListID        pgtype.UUID
+	Tag           pgtype.Text
+	CountryCode   pgtype.Text
+	NearLatitude  pgtype.Float8
+	NearLongitude pgtype.Float8
+	RadiusKm      pgtype.Float8
+	MinSeniority  pgtype.Text
+	JobFunction   pgtype.Text
+}
+
+type SearchProfileFacetsRow struct {
+	Facet string
+	Value string
+	Label string
+	Count int64
+}
 
-func (q *Queries) ListLinkedInProfiles(ctx context.Context) ([]LinkedinProfile, error) {
-	rows, err := q.db.Query(ctx, listLinkedInProfiles)
+func (q *Queries) SearchProfileFacets(ctx context.Context, arg SearchProfileFacetsParams) ([]SearchProfileFacetsRow, error) {
+	rows, err := q.db.Query(ctx, searchProfileFacets,
+		arg.UserID,
+		arg.Query,
+		arg.CompanyID,
+		arg.Location,
+		arg.Degree,
+		arg.ListID,
+		arg.Tag,
+		arg.CountryCode,
+		arg.NearLatitude,
+		arg.NearLongitude,
+		arg.RadiusKm,
+		arg.MinSeniority,
+		arg.JobFunction,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []LinkedinProfile
+	var items []SearchProfileFacetsRow
 	for rows.Next() {
-		var i LinkedinProfile
+		var i SearchProfileFacetsRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.LinkedinUrl,
-			&i.Name,
-			&i.Location,
-			&i.CurrentCompanyID,
-			&i.Headline,
-			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.Facet,
+			&i.Value,
+			&i.Label,
+			&i.Count,
 		); err != nil {
 			return nil, err
 		}
@@ -1123,39 +5540,106 @@ func (q *Queries) ListLinkedInProfiles(ctx context.Context) ([]LinkedinProfile,
 	return items, nil
 }
 
-const listUsers = `-- name: ListUsers :many
-SELECT id, email, name, auth_type, is_active, created_at, updated_at
-FROM users
-ORDER BY created_at DESC
+const searchProfiles = `-- name: SearchProfiles :many
+SELECT lp.id, lp.linkedin_url, lp.name, lp.location, lp.headline,
+       c.name as company_name, upd.degree, ph.seniority, ph.job_function,
+       ts_rank(ps.document, websearch_to_tsquery('english', $1))::float8 as rank
+FROM profile_search ps
+JOIN linkedin_profiles lp ON ps.profile_id = lp.id
+LEFT JOIN companies c ON lp.current_company_id = c.id
+LEFT JOIN user_profile_degrees upd ON upd.profile_id = lp.id AND upd.user_id = $2
+LEFT JOIN profile_locations loc ON loc.profile_id = lp.id
+LEFT JOIN profile_headlines ph ON ph.profile_id = lp.id
+WHERE ps.document @@ websearch_to_tsquery('english', $1)
+  AND ($3::uuid IS NULL OR lp.current_company_id = $3::uuid)
+  AND ($4::text IS NULL OR lp.location = $4::text)
+  AND ($5::int IS NULL OR upd.degree = $5::int)
+  AND ($6::uuid IS NULL OR EXISTS (
+      SELECT 1 FROM profile_list_members plm
+      JOIN profile_lists pl ON plm.list_id = pl.id
+      WHERE plm.list_id = $6::uuid AND pl.user_id = $2 AND plm.profile_id = lp.id
+    ))
+  AND ($7::text IS NULL OR EXISTS (
+      SELECT 1 FROM profile_tags pt
+      WHERE pt.user_id = $2 AND pt.profile_id = lp.id AND pt.tag = $7::text
+    ))
+  AND ($8::text IS NULL OR loc.country_code = $8::text)
+  AND ($9::float8 IS NULL OR distance_km(loc.latitude, loc.longitude,
+      $9::float8, $10::float8) <= $11::float8)
+  AND ($12::text IS NULL OR seniority_rank(ph.seniority) >= seniority_rank($12::text))
+  AND ($13::text IS NULL OR ph.job_function = $13::text)
+ORDER BY rank DESC, lp.name, lp.id
+LIMIT $14 OFFSET $15
 `
 
-type ListUsersRow struct {
-	ID        pgtype.UUID
-	Email     string
-	Name      string
-	AuthType  string
-	IsActive  bool
-	CreatedAt pgtype.Timestamp
-	UpdatedAt pgtype.Timestamp
+type SearchProfilesParams struct {
+	Query         string
+	UserID        pgtype.UUID
+	CompanyID     pgtype.UUID
+	Location      pgtype.Text
+	Degree        pgtype.Int4
+	ListID        pgtype.UUID
+	Tag           pgtype.Text
+	CountryCode   pgtype.Text
+	NearLatitude  pgtype.Float8
+	NearLongitude pgtype.Float8
+	RadiusKm      pgtype.Float8
+	MinSeniority  pgtype.Text
+	JobFunction   pgtype.Text
+	MaxResults    int32
+	Skip          int32
 }
 
-func (q *Queries) ListUsers(ctx context.Context) ([]ListUsersRow, error) {
-	rows, err := q.db.Query(ctx, listUsers)
+type SearchProfilesRow struct {
+	ID          pgtype.UUID
+	LinkedinUrl string
+	Name        string
+	Location    pgtype.Text
+	Headline    pgtype.Text
+	CompanyName pgtype.Text
+	Degree      pgtype.Int4
+	Seniority   pgtype.Text
+	JobFunction pgtype.Text
+	Rank        float64
+}
+
+// Profile Search queries
+func (q *Queries) SearchProfiles(ctx context.Context, arg SearchProfilesParams) ([]SearchProfilesRow, error) {
+	rows, err := q.db.Query(ctx, searchProfiles,
+		arg.Query,
+		arg.UserID,
+		arg.CompanyID,
+		arg.Location,
+		arg.Degree,
+		arg.ListID,
+		arg.Tag,
+		arg.CountryCode,
+		arg.NearLatitude,
+		arg.NearLongitude,
+		arg.RadiusKm,
+		arg.MinSeniority,
+		arg.JobFunction,
+		arg.MaxResults,
+		arg.Skip,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListUsersRow
+	var items []SearchProfilesRow
 	for rows.Next() {
-		var i ListUsersRow
+		var i SearchProfilesRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.Email,
+			&i.LinkedinUrl,
 			&i.Name,
-			&i.AuthType,
-			&i.IsActive,
-			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.Location,
+			&i.Headline,
+			&i.CompanyName,
+			&i.Degree,
+			&i.Seniority,
+			&i.JobFunction,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -1167,60 +5651,148 @@ func (q *Queries) ListUsers(ctx context.Context) ([]ListUsersRow, error) {
 	return items, nil
 }
 
-const pingDb = `-- name: PingDb :one
-SELECT 1 as result
+const setUserTimezone = `-- name: SetUserTimezone :exec
+UPDATE users SET timezone = $2, updated_at = NOW() WHERE id = $1
 `
 
-// Utility queries
-func (q *Queries) PingDb(ctx context.Context) (int32, error) {
-	row := q.db.QueryRow(ctx, pingDb)
-	var result int32
-	err := row.Scan(&result)
-	return result, err
+type SetUserTimezoneParams struct {
+	ID       pgtype.UUID
+	Timezone pgtype.Text
+}
+
+func (q *Queries) SetUserTimezone(ctx context.Context, arg SetUserTimezoneParams) error {
+	_, err := q.db.Exec(ctx, setUserTimezone, arg.ID, arg.Timezone)
+	return err
+}
+
+const startApprovedRuleExecution = `-- name: StartApprovedRuleExecution :one
+UPDATE rule_executions
+SET status = 'pending', outcome = NULL, error = NULL, executed_at = NOW(),
+    attempts = CASE WHEN status = 'failed' THEN attempts + 1 ELSE attempts END
+WHERE id = $1 AND approved_at IS NOT NULL
+  AND (status = 'approved' OR (status = 'failed' AND attempts < $2::int))
+RETURNING id, rule_id, profile_id, status, error, attempts, executed_at, outcome, message, approved_at
+`
+
+type StartApprovedRuleExecutionParams struct {
+	ID          pgtype.UUID
+	MaxAttempts int32
+}
+
+// Claims an approved action, or retries one that failed. Returns no row when
+// it was claimed already or has failed max_attempts times.
+func (q *Queries) StartApprovedRuleExecution(ctx context.Context, arg StartApprovedRuleExecutionParams) (RuleExecution, error) {
+	row := q.db.QueryRow(ctx, startApprovedRuleExecution, arg.ID, arg.MaxAttempts)
+	var i RuleExecution
+	err := row.Scan(
+		&i.ID,
+		&i.RuleID,
+		&i.ProfileID,
+		&i.Status,
+		&i.Error,
+		&i.Attempts,
+		&i.ExecutedAt,
+		&i.Outcome,
+		&i.Message,
+		&i.ApprovedAt,
+	)
+	return i, err
 }
 
-const updateAutomationRule = `-- name: UpdateAutomationRule :exec
+const updateAutomationRule = `-- name: UpdateAutomationRule :one
 UPDATE automation_rules 
-SET name = $3, company_filter = $4, location_filter = $5, action_type = $6, message_template = $7, is_active = $8
+SET name = $3, condition = $4, action_type = $5, message_template = $6, is_active = $7, min_network_score = $8, trigger_type = $9, list_id = $10,
+    country_code = $11, near = $12, near_latitude = $13, near_longitude = $14, radius_km = $15,
+    min_seniority = $16, job_function = $17, requires_approval = $18, sequence = $19, timezone = $20
 WHERE id = $1 AND user_id = $2
+RETURNING id, user_id, name, action_type, message_template, is_active, created_at, min_network_score, trigger_type, list_id, country_code, near, near_latitude, near_longitude, radius_km, min_seniority, job_function, condition, requires_approval, sequence, timezone
 `
 
 type UpdateAutomationRuleParams struct {
-	ID              pgtype.UUID
-	UserID          pgtype.UUID
-	Name            string
-	CompanyFilter   pgtype.Text
-	LocationFilter  pgtype.Text
-	ActionType      string
-	MessageTemplate pgtype.Text
-	IsActive        bool
+	ID               pgtype.UUID
+	UserID           pgtype.UUID
+	Name             string
+	Condition        pgtype.Text
+	ActionType       string
+	MessageTemplate  pgtype.Text
+	IsActive         bool
+	MinNetworkScore  pgtype.Float8
+	TriggerType      string
+	ListID           pgtype.UUID
+	CountryCode      pgtype.Text
+	Near             pgtype.Text
+	NearLatitude     pgtype.Float8
+	NearLongitude    pgtype.Float8
+	RadiusKm         pgtype.Float8
+	MinSeniority     pgtype.Text
+	JobFunction      pgtype.Text
+	RequiresApproval bool
+	Sequence         []byte
+	Timezone         pgtype.Text
 }
 
-func (q *Queries) UpdateAutomationRule(ctx context.Context, arg UpdateAutomationRuleParams) error {
-	_, err := q.db.Exec(ctx, updateAutomationRule,
+func (q *Queries) UpdateAutomationRule(ctx context.Context, arg UpdateAutomationRuleParams) (AutomationRule, error) {
+	row := q.db.QueryRow(ctx, updateAutomationRule,
 		arg.ID,
 		arg.UserID,
 		arg.Name,
-		arg.CompanyFilter,
-		arg.LocationFilter,
+		arg.Condition,
 		arg.ActionType,
 		arg.MessageTemplate,
 		arg.IsActive,
+		arg.MinNetworkScore,
+		arg.TriggerType,
+		arg.ListID,
+		arg.CountryCode,
+		arg.Near,
+		arg.NearLatitude,
+		arg.NearLongitude,
+		arg.RadiusKm,
+		arg.MinSeniority,
+		arg.JobFunction,
+		arg.RequiresApproval,
+		arg.Sequence,
+		arg.Timezone,
 	)
-	return err
+	var i AutomationRule
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.ActionType,
+		&i.MessageTemplate,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.MinNetworkScore,
+		&i.TriggerType,
+		&i.ListID,
+		&i.CountryCode,
+		&i.Near,
+		&i.NearLatitude,
+		&i.NearLongitude,
+		&i.RadiusKm,
+		&i.MinSeniority,
+		&i.JobFunction,
+		&i.Condition,
+		&i.RequiresApproval,
+		&i.Sequence,
+		&i.Timezone,
+	)
+	return i, err
 }
 
 const updateCompany = `-- name: UpdateCompany :exec
 UPDATE companies 
-SET name = $2, linkedin_url = $3, industry = $4, updated_at = NOW()
+SET name = $2, linkedin_url = $3, industry = $4, normalized_name = $5, updated_at = NOW()
 WHERE id = $1
 `
 
 type UpdateCompanyParams struct {
-	ID          pgtype.UUID
-	Name        string
-	LinkedinUrl pgtype.Text
-	Industry    pgtype.Text
+	ID             pgtype.UUID
+	Name           string
+	LinkedinUrl    pgtype.Text
+	Industry       pgtype.Text
+	NormalizedName string
 }
 
 func (q *Queries) UpdateCompany(ctx context.Context, arg UpdateCompanyParams) error {
@@ -1229,10 +5801,28 @@ func (q *Queries) UpdateCompany(ctx context.Context, arg UpdateCompanyParams) er
 		arg.Name,
 		arg.LinkedinUrl,
 		arg.Industry,
+		arg.NormalizedName,
 	)
 	return err
 }
 
+const updateConnectionMissedChecks = `-- name: UpdateConnectionMissedChecks :exec
+UPDATE connection_relationships
+SET missed_checks = CASE WHEN profile_b_id = ANY($1::uuid[]) THEN 0 ELSE missed_checks + 1 END
+WHERE profile_a_id = $2 AND degree = 1
+`
+
+type UpdateConnectionMissedChecksParams struct {
+	SeenIds   []pgtype.UUID
+	ProfileID pgtype.UUID
+}
+
+// Resets the missed checks of the tracked profile's connections seen in a check and counts one more for the rest
+func (q *Queries) UpdateConnectionMissedChecks(ctx context.Context, arg UpdateConnectionMissedChecksParams) error {
+	_, err := q.db.Exec(ctx, updateConnectionMissedChecks, arg.SeenIds, arg.ProfileID)
+	return err
+}
+
 const updateLinkedInProfile = `-- name: UpdateLinkedInProfile :exec
 UPDATE linkedin_profiles 
 SET name = $2, location = $3, current_company_id = $4, headline = $5, updated_at = NOW()
@@ -1258,6 +5848,27 @@ func (q *Queries) UpdateLinkedInProfile(ctx context.Context, arg UpdateLinkedInP
 	return err
 }
 
+const updatePendingApprovalMessage = `-- name: UpdatePendingApprovalMessage :execrows
+UPDATE rule_executions re
+SET message = $3
+FROM automation_rules ar
+WHERE re.rule_id = ar.id AND re.id = $1 AND ar.user_id = $2 AND re.status = 'awaiting_approval'
+`
+
+type UpdatePendingApprovalMessageParams struct {
+	ID      pgtype.UUID
+	UserID  pgtype.UUID
+	Message pgtype.Text
+}
+
+func (q *Queries) UpdatePendingApprovalMessage(ctx context.Context, arg UpdatePendingApprovalMessageParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updatePendingApprovalMessage, arg.ID, arg.UserID, arg.Message)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateProfileCompany = `-- name: UpdateProfileCompany :exec
 UPDATE profile_companies 
 SET position = $3, start_date = $4, end_date = $5, is_current = $6
@@ -1285,6 +5896,72 @@ func (q *Queries) UpdateProfileCompany(ctx context.Context, arg UpdateProfileCom
 	return err
 }
 
+const updateProfileList = `-- name: UpdateProfileList :one
+UPDATE profile_lists
+SET name = $3, description = $4, updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING id, user_id, name, description, created_at, updated_at
+`
+
+type UpdateProfileListParams struct {
+	ID          pgtype.UUID
+	UserID      pgtype.UUID
+	Name        string
+	Description pgtype.Text
+}
+
+func (q *Queries) UpdateProfileList(ctx context.Context, arg UpdateProfileListParams) (ProfileList, error) {
+	row := q.db.QueryRow(ctx, updateProfileList,
+		arg.ID,
+		arg.UserID,
+		arg.Name,
+		arg.Description,
+	)
+	var i ProfileList
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateProfileNote = `-- name: UpdateProfileNote :one
+UPDATE profile_notes
+SET body = $4, updated_at = NOW()
+WHERE id = $1 AND user_id = $2 AND profile_id = $3
+RETURNING id, user_id, profile_id, body, created_at, updated_at
+`
+
+type UpdateProfileNoteParams struct {
+	ID        pgtype.UUID
+	UserID    pgtype.UUID
+	ProfileID pgtype.UUID
+	Body      string
+}
+
+func (q *Queries) UpdateProfileNote(ctx context.Context, arg UpdateProfileNoteParams) (ProfileNote, error) {
+	row := q.db.QueryRow(ctx, updateProfileNote,
+		arg.ID,
+		arg.UserID,
+		arg.ProfileID,
+		arg.Body,
+	)
+	var i ProfileNote
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProfileID,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateTrackedConnectionLastChecked = `-- name: UpdateTrackedConnectionLastChecked :exec
 UPDATE tracked_connections 
 SET last_checked_at = NOW()
@@ -1390,3 +6067,214 @@ func (q *Queries) UpdateUserTokens(ctx context.Context, arg UpdateUserTokensPara
 	_, err := q.db.Exec(ctx, updateUserTokens, arg.ID, arg.AccessToken, arg.RefreshToken)
 	return err
 }
+
+const upsertDuplicateCandidate = `-- name: UpsertDuplicateCandidate :exec
+INSERT INTO duplicate_candidates (profile_id, duplicate_id, score, reasons, detected_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (profile_id, duplicate_id) DO UPDATE
+SET score = EXCLUDED.score, reasons = EXCLUDED.reasons, detected_at = EXCLUDED.detected_at
+WHERE duplicate_candidates.status = 'pending'
+`
+
+type UpsertDuplicateCandidateParams struct {
+	ProfileID   pgtype.UUID
+	DuplicateID pgtype.UUID
+	Score       float64
+	Reasons     []string
+	DetectedAt  pgtype.Timestamp
+}
+
+// Rejected pairs keep their status; pending pairs get the new score
+func (q *Queries) UpsertDuplicateCandidate(ctx context.Context, arg UpsertDuplicateCandidateParams) error {
+	_, err := q.db.Exec(ctx, upsertDuplicateCandidate,
+		arg.ProfileID,
+		arg.DuplicateID,
+		arg.Score,
+		arg.Reasons,
+		arg.DetectedAt,
+	)
+	return err
+}
+
+const upsertExecutionWindow = `-- name: UpsertExecutionWindow :one
+INSERT INTO execution_windows (user_id, weekdays, start_time, end_time, holidays)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id) DO UPDATE
+SET weekdays = EXCLUDED.weekdays, start_time = EXCLUDED.start_time, end_time = EXCLUDED.end_time,
+    holidays = EXCLUDED.holidays, updated_at = NOW()
+RETURNING user_id, weekdays, start_time, end_time, holidays, updated_at
+`
+
+type UpsertExecutionWindowParams struct {
+	UserID    pgtype.UUID
+	Weekdays  []int32
+	StartTime pgtype.Time
+	EndTime   pgtype.Time
+	Holidays  []pgtype.Date
+}
+
+func (q *Queries) UpsertExecutionWindow(ctx context.Context, arg UpsertExecutionWindowParams) (ExecutionWindow, error) {
+	row := q.db.QueryRow(ctx, upsertExecutionWindow,
+		arg.UserID,
+		arg.Weekdays,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Holidays,
+	)
+	var i ExecutionWindow
+	err := row.Scan(
+		&i.UserID,
+		&i.Weekdays,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Holidays,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertInvitationQuota = `-- name: UpsertInvitationQuota :one
+INSERT INTO invitation_quotas (user_id, daily_limit, weekly_limit)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET daily_limit = EXCLUDED.daily_limit, weekly_limit = EXCLUDED.weekly_limit, paused_until = NULL, updated_at = NOW()
+RETURNING user_id, daily_limit, weekly_limit, paused_until, updated_at
+`
+
+type UpsertInvitationQuotaParams struct {
+	UserID      pgtype.UUID
+	DailyLimit  int32
+	WeeklyLimit int32
+}
+
+func (q *Queries) UpsertInvitationQuota(ctx context.Context, arg UpsertInvitationQuotaParams) (InvitationQuota, error) {
+	row := q.db.QueryRow(ctx, upsertInvitationQuota, arg.UserID, arg.DailyLimit, arg.WeeklyLimit)
+	var i InvitationQuota
+	err := row.Scan(
+		&i.UserID,
+		&i.DailyLimit,
+		&i.WeeklyLimit,
+		&i.PausedUntil,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertProfileCompany = `-- name: UpsertProfileCompany :exec
+INSERT INTO profile_companies (profile_id, company_id, position, start_date, end_date, is_current)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (profile_id, company_id, position, start_date)
+DO UPDATE SET end_date = EXCLUDED.end_date, is_current = EXCLUDED.is_current
+`
+
+type UpsertProfileCompanyParams struct {
+	ProfileID pgtype.UUID
+	CompanyID pgtype.UUID
+	Position  string
+	StartDate pgtype.Date
+	EndDate   pgtype.Date
+	IsCurrent bool
+}
+
+func (q *Queries) UpsertProfileCompany(ctx context.Context, arg UpsertProfileCompanyParams) error {
+	_, err := q.db.Exec(ctx, upsertProfileCompany,
+		arg.ProfileID,
+		arg.CompanyID,
+		arg.Position,
+		arg.StartDate,
+		arg.EndDate,
+		arg.IsCurrent,
+	)
+	return err
+}
+
+const upsertProfileHeadline = `-- name: UpsertProfileHeadline :exec
+INSERT INTO profile_headlines (profile_id, raw_headline, title, employer, seniority, job_function, parsed_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW())
+ON CONFLICT (profile_id) DO UPDATE
+SET raw_headline = EXCLUDED.raw_headline, title = EXCLUDED.title, employer = EXCLUDED.employer,
+    seniority = EXCLUDED.seniority, job_function = EXCLUDED.job_function, parsed_at = EXCLUDED.parsed_at
+`
+
+type UpsertProfileHeadlineParams struct {
+	ProfileID   pgtype.UUID
+	RawHeadline string
+	Title       pgtype.Text
+	Employer    pgtype.Text
+	Seniority   pgtype.Text
+	JobFunction pgtype.Text
+}
+
+func (q *Queries) UpsertProfileHeadline(ctx context.Context, arg UpsertProfileHeadlineParams) error {
+	_, err := q.db.Exec(ctx, upsertProfileHeadline,
+		arg.ProfileID,
+		arg.RawHeadline,
+		arg.Title,
+		arg.Employer,
+		arg.Seniority,
+		arg.JobFunction,
+	)
+	return err
+}
+
+const upsertProfileLocation = `-- name: UpsertProfileLocation :exec
+INSERT INTO profile_locations (profile_id, raw_location, city, region, country_code, latitude, longitude, parsed_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+ON CONFLICT (profile_id) DO UPDATE
+SET raw_location = EXCLUDED.raw_location, city = EXCLUDED.city, region = EXCLUDED.region,
+    country_code = EXCLUDED.country_code, latitude = EXCLUDED.latitude, longitude = EXCLUDED.longitude,
+    parsed_at = EXCLUDED.parsed_at
+`
+
+type UpsertProfileLocationParams struct {
+	ProfileID   pgtype.UUID
+	RawLocation string
+	City        pgtype.Text
+	Region      pgtype.Text
+	CountryCode pgtype.Text
+	Latitude    pgtype.Float8
+	Longitude   pgtype.Float8
+}
+
+func (q *Queries) UpsertProfileLocation(ctx context.Context, arg UpsertProfileLocationParams) error {
+	_, err := q.db.Exec(ctx, upsertProfileLocation,
+		arg.ProfileID,
+		arg.RawLocation,
+		arg.City,
+		arg.Region,
+		arg.CountryCode,
+		arg.Latitude,
+		arg.Longitude,
+	)
+	return err
+}
+
+const upsertProfileNetworkScore = `-- name: UpsertProfileNetworkScore :exec
+INSERT INTO profile_network_scores (user_id, profile_id, degree, betweenness, pagerank, computed_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (user_id, profile_id) DO UPDATE
+SET degree = EXCLUDED.degree, betweenness = EXCLUDED.betweenness,
+    pagerank = EXCLUDED.pagerank, computed_at = EXCLUDED.computed_at
+`
+
+type UpsertProfileNetworkScoreParams struct {
+	UserID      pgtype.UUID
+	ProfileID   pgtype.UUID
+	Degree      int32
+	Betweenness float64
+	Pagerank    float64
+	ComputedAt  pgtype.Timestamp
+}
+
+// Profile Network Scores queries
+func (q *Queries) UpsertProfileNetworkScore(ctx context.Context, arg UpsertProfileNetworkScoreParams) error {
+	_, err := q.db.Exec(ctx, upsertProfileNetworkScore,
+		arg.UserID,
+		arg.ProfileID,
+		arg.Degree,
+		arg.Betweenness,
+		arg.Pagerank,
+		arg.ComputedAt,
+	)
+	return err
+}
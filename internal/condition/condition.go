@@ -0,0 +1,139 @@
+// Package condition parses and evaluates the boolean expressions automation
+// rules use to pick the profiles they act on, such as
+//
+//	company in ["Acme", "Globex"] and (headline matches "founder|cto" or degree <= 2)
+//
+// Expressions are type-checked when they are parsed, so a parsed Condition
+// always evaluates. Text comparisons ignore case.
+package condition
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Profile holds the fields of a candidate profile a condition can refer to.
+// Zero values mean unknown: an unknown degree or discovery time fails every
+// comparison.
+type Profile struct {
+	Headline     string
+	Company      string
+	Location     string
+	Degree       int
+	Tags         []string
+	DiscoveredAt time.Time
+	// Via is the name of the tracked connection bridging to the profile
+	Via string
+}
+
+// Condition is a parsed and type-checked expression
+type Condition struct {
+	source string
+	eval   func(Profile) bool
+}
+
+// Error is a syntax or type error, with the column it was found at
+type Error struct {
+	Column int
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+}
+
+// errorf returns an Error for the rune at index pos
+func errorf(pos int, format string, args ...interface{}) *Error {
+	return &Error{Column: pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Parse parses and type-checks a condition
+func Parse(src string) (*Condition, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, errorf(0, "condition is empty")
+	}
+	eval, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, errorf(next.pos, "unexpected %q; join conditions with and/or", next.text)
+	}
+	return &Condition{source: src, eval: eval}, nil
+}
+
+// Match reports whether the profile satisfies the condition
+func (c *Condition) Match(profile Profile) bool {
+	return c.eval(profile)
+}
+
+// String returns the condition as written
+func (c *Condition) String() string {
+	return c.source
+}
+
+// Quote formats text as a string literal, for building conditions
+func Quote(text string) string {
+	return fmt.Sprintf("%q", text)
+}
+
+type fieldType int
+
+const (
+	typeText fieldType = iota
+	typeNumber
+	typeTime
+	typeTextList
+)
+
+type field struct {
+	typ   fieldType
+	text  func(Profile) string
+	num   func(Profile) int
+	time  func(Profile) time.Time
+	texts func(Profile) []string
+}
+
+// fields are the profile fields a condition can refer to
+var fields = map[string]field{
+	"headline":      {typ: typeText, text: func(p Profile) string { return p.Headline }},
+	"company":       {typ: typeText, text: func(p Profile) string { return p.Company }},
+	"location":      {typ: typeText, text: func(p Profile) string { return p.Location }},
+	"via":           {typ: typeText, text: func(p Profile) string { return p.Via }},
+	"degree":        {typ: typeNumber, num: func(p Profile) int { return p.Degree }},
+	"discovered_at": {typ: typeTime, time: func(p Profile) time.Time { return p.DiscoveredAt }},
+	"tags":          {typ: typeTextList, texts: func(p Profile) []string { return p.Tags }},
+}
+
+// Fields returns the names of the fields a condition can refer to
+func Fields() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// timeLayouts are the formats accepted for discovered_at comparisons
+var timeLayouts = []string{time.RFC3339, "2006-01-02"}
+
+func parseTime(text string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,114 @@
+package condition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	ada := Profile{
+		Headline:     "Founder & CTO at Acme",
+		Company:      "Acme",
+		Location:     "Madrid, Community of Madrid, Spain",
+		Degree:       2,
+		Tags:         []string{"investor", "speaker"},
+		DiscoveredAt: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC),
+		Via:          "Jane Doe",
+	}
+
+	tests := map[string]struct {
+		src  string
+		want bool
+	}{
+		"equal ignores case":        {`company == "acme"`, true},
+		"not equal":                 {`company != "Acme"`, false},
+		"contains":                  {`location contains "madrid"`, true},
+		"regex ignores case":        {`headline matches "\\b(cto|vp)\\b"`, true},
+		"regex no match":            {`headline matches "^engineer"`, false},
+		"in text list":              {`company in ["Globex", "ACME"]`, true},
+		"number comparison":         {`degree <= 2`, true},
+		"number in list":            {`degree in [3, 4]`, false},
+		"large number":              {`degree > -9223372036854775808`, true},
+		"large number below":        {`degree < 9223372036854775807`, true},
+		"tag contains":              {`tags contains "Investor"`, true},
+		"tags in list":              {`tags in ["hiring", "speaker"]`, true},
+		"date after":                {`discovered_at >= "2025-03-10"`, true},
+		"timestamp before":          {`discovered_at < "2025-03-10T08:00:00Z"`, false},
+		"bridging connection":       {`via == "Jane Doe"`, true},
+		"and binds tighter than or": {`company == "Globex" and degree == 2 or via contains "jane"`, true},
+		"parentheses":               {`company == "Globex" and (degree == 2 or via contains "jane")`, false},
+		"not":                       {`not tags contains "hiring"`, true},
+		"not not":                   {`not not degree == 2`, true},
+		"uppercase keywords":        {`company == "Acme" AND NOT degree > 2`, true},
+		"escaped quote":             {`headline contains "\"quoted\""`, false},
+	}
+
+	for name, tc := range tests {
+		c, err := Parse(tc.src)
+		if assert.NoError(t, err, name) {
+			assert.Equal(t, tc.want, c.Match(ada), name)
+		}
+	}
+}
+
+func TestMatch_UnknownValues(t *testing.T) {
+	var nobody Profile
+	for _, src := range []string{
+		`degree < 3`,
+		`degree in [1, 2, 3]`,
+		`discovered_at < "2030-01-01"`,
+		`discovered_at != "2030-01-01"`,
+		`company contains "a"`,
+		`tags contains "investor"`,
+	} {
+		c, err := Parse(src)
+		require.NoError(t, err, src)
+		assert.False(t, c.Match(nobody), src)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]struct {
+		src    string
+		column int
+	}{
+		"empty":                    {"   ", 1},
+		"unknown field":            {`title == "CTO"`, 1},
+		"missing operator":         {`company "Acme"`, 9},
+		"single equals":            {`company = "Acme"`, 9},
+		"unquoted text":            {`company == Acme`, 12},
+		"text ordering":            {`company < "B"`, 9},
+		"quoted number":            {`degree == "2"`, 11},
+		"number contains":          {`degree contains 2`, 8},
+		"invalid regex":            {`headline matches "("`, 18},
+		"invalid date":             {`discovered_at > "last week"`, 17},
+		"time in list":             {`discovered_at in ["2025-01-01"]`, 15},
+		"tags equality":            {`tags == "investor"`, 6},
+		"mixed list":               {`degree in [1, "2"]`, 15},
+		"in without list":          {`company in "Acme"`, 12},
+		"unclosed list":            {`company in ["Acme"`, 19},
+		"unclosed parenthesis":     {`(degree == 2`, 13},
+		"unterminated string":      {`company == "Acme`, 12},
+		"trailing comparison":      {`degree == 2 company == "Acme"`, 13},
+		"dangling and":             {`degree == 2 and`, 16},
+		"unexpected character":     {`degree == 2 && company == "Acme"`, 13},
+		"keyword instead of field": {`and degree == 2`, 1},
+	}
+
+	for name, tc := range tests {
+		_, err := Parse(tc.src)
+		var parseErr *Error
+		if assert.ErrorAs(t, err, &parseErr, name) {
+			assert.Equal(t, tc.column, parseErr.Column, "%s: %v", name, err)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	c, err := Parse(`company == ` + Quote(`Say "hi" \ Co`))
+	require.NoError(t, err)
+	assert.True(t, c.Match(Profile{Company: `say "HI" \ co`}))
+}
@@ -0,0 +1,127 @@
+package condition
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenLeftBracket
+	tokenRightBracket
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// keywords are matched case-insensitively, so "AND" and "and" are the same
+var keywords = map[string]bool{
+	"and":      true,
+	"or":       true,
+	"not":      true,
+	"in":       true,
+	"contains": true,
+	"matches":  true,
+}
+
+// lex splits a condition into tokens. String literals are unquoted and
+// keywords lowercased; identifiers keep their case for error messages.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenLeftParen, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenRightParen, ")", i})
+			i++
+		case r == '[':
+			tokens = append(tokens, token{tokenLeftBracket, "[", i})
+			i++
+		case r == ']':
+			tokens = append(tokens, token{tokenRightBracket, "]", i})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokenComma, ",", i})
+			i++
+		case r == '=' || r == '!' || r == '<' || r == '>':
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			if op == "=" || op == "!" {
+				return nil, errorf(i, "unexpected %q; use == or !=", op)
+			}
+			tokens = append(tokens, token{tokenOperator, op, i})
+			i += len(op)
+		case r == '"':
+			end, text, err := lexString(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{tokenString, text, i})
+			i = end
+		case unicode.IsDigit(r) || r == '-':
+			start := i
+			i++
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			text := string(runes[start:i])
+			if text == "-" {
+				return nil, errorf(start, "unexpected %q", text)
+			}
+			tokens = append(tokens, token{tokenNumber, text, start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			text := string(runes[start:i])
+			if lower := strings.ToLower(text); keywords[lower] {
+				tokens = append(tokens, token{tokenOperator, lower, start})
+			} else {
+				tokens = append(tokens, token{tokenIdent, text, start})
+			}
+		default:
+			return nil, errorf(i, "unexpected %q", string(r))
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(runes)}), nil
+}
+
+// lexString reads the double-quoted string starting at runes[start], with Go
+// escapes, and returns the index just past it and its unquoted text
+func lexString(runes []rune, start int) (int, string, error) {
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			i++
+		case '"':
+			text, err := strconv.Unquote(string(runes[start : i+1]))
+			if err != nil {
+				return 0, "", errorf(start, "invalid string %s", string(runes[start:i+1]))
+			}
+			return i + 1, text, nil
+		}
+	}
+	return 0, "", errorf(start, "unterminated string")
+}
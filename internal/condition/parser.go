@@ -0,0 +1,295 @@
+package condition
+
+import (
+	"cmp"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// predicate is a compiled condition
+type predicate func(Profile) bool
+
+// literal is a string or number operand
+type literal struct {
+	kind tokenKind
+	text string
+	num  int
+	pos  int
+}
+
+// parser is a recursive descent parser that type-checks each comparison and
+// compiles the condition into predicates as it goes:
+//
+//	or         = and { "or" and }
+//	and        = not { "and" not }
+//	not        = "not" not | "(" or ")" | comparison
+//	comparison = field operator ( literal | "[" literal { "," literal } "]" )
+type parser struct {
+	tokens []token
+	next   int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.next]
+	if t.kind != tokenEOF {
+		p.next++
+	}
+	return t
+}
+
+func (p *parser) isOperator(text string) bool {
+	t := p.peek()
+	return t.kind == tokenOperator && t.text == text
+}
+
+func (p *parser) parseOr() (predicate, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("or") {
+		p.advance()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(profile Profile) bool { return l(profile) || right(profile) }
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (predicate, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("and") {
+		p.advance()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(profile Profile) bool { return l(profile) && right(profile) }
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (predicate, error) {
+	if p.isOperator("not") {
+		p.advance()
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return func(profile Profile) bool { return !inner(profile) }, nil
+	}
+
+	if p.peek().kind == tokenLeftParen {
+		p.advance()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.advance(); t.kind != tokenRightParen {
+			return nil, errorf(t.pos, "expected \")\"")
+		}
+		return inner, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (predicate, error) {
+	name := p.advance()
+	if name.kind != tokenIdent {
+		return nil, errorf(name.pos, "expected a field (%s)", strings.Join(Fields(), ", "))
+	}
+	f, ok := fields[strings.ToLower(name.text)]
+	if !ok {
+		return nil, errorf(name.pos, "unknown field %q (fields: %s)", name.text, strings.Join(Fields(), ", "))
+	}
+
+	op := p.advance()
+	if op.kind != tokenOperator || op.text == "and" || op.text == "or" || op.text == "not" {
+		return nil, errorf(op.pos, "expected an operator after %s", name.text)
+	}
+
+	if op.text == "in" {
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return compileIn(name.text, f, op, list)
+	}
+	value, err := p.parseLiteral()
+	if err != nil {
+		return nil, err
+	}
+	return compileComparison(name.text, f, op, value)
+}
+
+func (p *parser) parseList() ([]literal, error) {
+	if t := p.advance(); t.kind != tokenLeftBracket {
+		return nil, errorf(t.pos, "expected a list such as [\"a\", \"b\"] after in")
+	}
+	var list []literal
+	for {
+		value, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		if len(list) > 0 && value.kind != list[0].kind {
+			return nil, errorf(value.pos, "list mixes text and numbers")
+		}
+		list = append(list, value)
+
+		switch t := p.advance(); t.kind {
+		case tokenComma:
+		case tokenRightBracket:
+			return list, nil
+		default:
+			return nil, errorf(t.pos, "expected \",\" or \"]\"")
+		}
+	}
+}
+
+func (p *parser) parseLiteral() (literal, error) {
+	t := p.advance()
+	switch t.kind {
+	case tokenString:
+		return literal{kind: tokenString, text: t.text, pos: t.pos}, nil
+	case tokenNumber:
+		num, err := strconv.Atoi(t.text)
+		if err != nil {
+			return literal{}, errorf(t.pos, "invalid number %s", t.text)
+		}
+		return literal{kind: tokenNumber, text: t.text, num: num, pos: t.pos}, nil
+	default:
+		return literal{}, errorf(t.pos, "expected a quoted string or a number")
+	}
+}
+
+// compileComparison type-checks and compiles a comparison with a single value
+func compileComparison(name string, f field, op token, value literal) (predicate, error) {
+	switch f.typ {
+	case typeText:
+		if value.kind != tokenString {
+			return nil, errorf(value.pos, "%s is text; quote the value", name)
+		}
+		switch op.text {
+		case "==":
+			return func(p Profile) bool { return strings.EqualFold(f.text(p), value.text) }, nil
+		case "!=":
+			return func(p Profile) bool { return !strings.EqualFold(f.text(p), value.text) }, nil
+		case "contains":
+			return func(p Profile) bool { return containsFold(f.text(p), value.text) }, nil
+		case "matches":
+			re, err := regexp.Compile("(?i)" + value.text)
+			if err != nil {
+				return nil, errorf(value.pos, "invalid regular expression: %v", err)
+			}
+			return func(p Profile) bool { return re.MatchString(f.text(p)) }, nil
+		}
+		return nil, errorf(op.pos, "%s is text and supports ==, !=, contains, matches and in, not %s", name, op.text)
+
+	case typeNumber:
+		if value.kind != tokenNumber {
+			return nil, errorf(value.pos, "%s is a number; do not quote the value", name)
+		}
+		compare, ok := compareOps[op.text]
+		if !ok {
+			return nil, errorf(op.pos, "%s is a number and supports ==, !=, <, <=, >, >= and in, not %s", name, op.text)
+		}
+		return func(p Profile) bool {
+			n := f.num(p)
+			return n != 0 && compare(cmp.Compare(n, value.num))
+		}, nil
+
+	case typeTime:
+		if value.kind != tokenString {
+			return nil, errorf(value.pos, "%s is a time; quote the date, e.g. \"2025-01-31\"", name)
+		}
+		at, ok := parseTime(value.text)
+		if !ok {
+			return nil, errorf(value.pos, "invalid date %q; use 2025-01-31 or 2025-01-31T09:00:00Z", value.text)
+		}
+		compare, ok := compareOps[op.text]
+		if !ok {
+			return nil, errorf(op.pos, "%s is a time and supports ==, !=, <, <=, > and >=, not %s", name, op.text)
+		}
+		return func(p Profile) bool {
+			t := f.time(p)
+			return !t.IsZero() && compare(t.Compare(at))
+		}, nil
+
+	default:
+		if value.kind != tokenString {
+			return nil, errorf(value.pos, "%s holds text; quote the value", name)
+		}
+		if op.text != "contains" {
+			return nil, errorf(op.pos, "%s is a list and supports contains and in, not %s", name, op.text)
+		}
+		return func(p Profile) bool { return anyFold(f.texts(p), []literal{value}) }, nil
+	}
+}
+
+// compileIn type-checks and compiles an in comparison. A list field is in the
+// given values when any of its items is.
+func compileIn(name string, f field, op token, list []literal) (predicate, error) {
+	switch f.typ {
+	case typeText, typeTextList:
+		if list[0].kind != tokenString {
+			return nil, errorf(list[0].pos, "%s holds text; quote the values", name)
+		}
+		if f.typ == typeText {
+			return func(p Profile) bool { return anyFold([]string{f.text(p)}, list) }, nil
+		}
+		return func(p Profile) bool { return anyFold(f.texts(p), list) }, nil
+
+	case typeNumber:
+		if list[0].kind != tokenNumber {
+			return nil, errorf(list[0].pos, "%s is a number; do not quote the values", name)
+		}
+		return func(p Profile) bool {
+			n := f.num(p)
+			for _, value := range list {
+				if n != 0 && n == value.num {
+					return true
+				}
+			}
+			return false
+		}, nil
+
+	default:
+		return nil, errorf(op.pos, "%s is a time and does not support in", name)
+	}
+}
+
+// compareOps turn the result of a Compare into a comparison
+var compareOps = map[string]func(int) bool{
+	"==": func(c int) bool { return c == 0 },
+	"!=": func(c int) bool { return c != 0 },
+	"<":  func(c int) bool { return c < 0 },
+	"<=": func(c int) bool { return c <= 0 },
+	">":  func(c int) bool { return c > 0 },
+	">=": func(c int) bool { return c >= 0 },
+}
+
+// anyFold reports whether any of the items equals any of the values, ignoring case
+func anyFold(items []string, values []literal) bool {
+	for _, item := range items {
+		for _, value := range values {
+			if strings.EqualFold(item, value.text) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"errors"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsController handles network analytics HTTP requests
+type AnalyticsController struct {
+	analyticsService *services.AnalyticsService
+}
+
+// NewAnalyticsController creates a new AnalyticsController with injected dependencies
+func NewAnalyticsController(analyticsService *services.AnalyticsService) *AnalyticsController {
+	return &AnalyticsController{
+		analyticsService: analyticsService,
+	}
+}
+
+// @Summary Get network growth over time
+// @Description New 2nd and 3rd degree profiles discovered and connections removed per day or week, oldest first. Across all tracked connections each profile counts once, when it was first discovered. Refreshed by the analytics_refresh job.
+// @Tags network
+// @Produce json
+// @Security BearerAuth
+// @Param interval query string false "Period length: day (default) or week"
+// @Param since query string false "Start of the series (RFC 3339, default 30 days or 12 weeks ago)"
+// @Param tracked_profile_id query string false "Only count what was found through this tracked connection"
+// @Success 200 {array} models.NetworkGrowthPoint
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/network/growth [get]
+func (ac *AnalyticsController) Growth(c *gin.Context) {
+	var query models.NetworkGrowthQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	points, err := ac.analyticsService.GetGrowth(c.Request.Context(), userID, query)
+	if err != nil {
+		analyticsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, points)
+}
+
+// @Summary Get network composition over time
+// @Description The most common current companies, locations or countries among the profiles discovered per day or week, oldest first. Periods without discoveries are left out.
+// @Tags network
+// @Produce json
+// @Security BearerAuth
+// @Param dimension query string true "company, location or country"
+// @Param interval query string false "Period length: day (default) or week"
+// @Param since query string false "Start of the series (RFC 3339, default 30 days or 12 weeks ago)"
+// @Param tracked_profile_id query string false "Only count what was found through this tracked connection"
+// @Param top query int false "Values per period (1-50, default 10)"
+// @Success 200 {array} models.NetworkCompositionPeriod
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/network/composition [get]
+func (ac *AnalyticsController) Composition(c *gin.Context) {
+	var query models.NetworkCompositionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	periods, err := ac.analyticsService.GetComposition(c.Request.Context(), userID, query)
+	if err != nil {
+		analyticsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, periods)
+}
+
+func analyticsError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidID), errors.Is(err, services.ErrRangeTooLong):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Tracked connection not found",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
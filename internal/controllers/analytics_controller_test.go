@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyticsController_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	analyticsController := NewAnalyticsController(services.NewAnalyticsService(nil))
+	router.GET("/api/v1/network/growth", withUser(testUserID, analyticsController.Growth))
+	router.GET("/api/v1/network/composition", withUser(testUserID, analyticsController.Composition))
+
+	for _, path := range []string{
+		"/api/v1/network/growth?interval=month",
+		"/api/v1/network/growth?since=yesterday",
+		"/api/v1/network/growth?since=2000-01-01T00:00:00Z",
+		"/api/v1/network/growth?tracked_profile_id=not-a-uuid",
+		"/api/v1/network/composition",
+		"/api/v1/network/composition?dimension=industry",
+		"/api/v1/network/composition?dimension=company&top=-1",
+		"/api/v1/network/composition?dimension=company&top=100",
+		"/api/v1/network/composition?dimension=country&interval=week&since=2000-01-01T00:00:00Z",
+	} {
+		request := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+}
@@ -0,0 +1,300 @@
+package controllers
+
+import (
+	"errors"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnnotationController handles profile tag and note HTTP requests
+type AnnotationController struct {
+	annotationService *services.AnnotationService
+}
+
+// NewAnnotationController creates a new AnnotationController with injected dependencies
+func NewAnnotationController(annotationService *services.AnnotationService) *AnnotationController {
+	return &AnnotationController{
+		annotationService: annotationService,
+	}
+}
+
+// @Summary List tags
+// @Description List every tag you have used with the number of profiles carrying it
+// @Tags annotations
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.TagCount
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/tags [get]
+func (ac *AnnotationController) ListTags(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	tags, err := ac.annotationService.ListTags(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// @Summary Get profile tags
+// @Description Get the tags you have put on a profile
+// @Tags annotations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Profile ID"
+// @Success 200 {object} models.ProfileTags
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/profiles/{id}/tags [get]
+func (ac *AnnotationController) GetTags(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	tags, err := ac.annotationService.GetTags(c.Request.Context(), userID, c.Param("id"))
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Profile not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// @Summary Set profile tags
+// @Description Replace the tags you have put on a profile. Tags are lowercased and de-duplicated; an empty list removes every tag
+// @Tags annotations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Profile ID"
+// @Param tags body models.SetProfileTagsRequest true "Tags to keep"
+// @Success 200 {object} models.ProfileTags
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/profiles/{id}/tags [put]
+func (ac *AnnotationController) SetTags(c *gin.Context) {
+	var req models.SetProfileTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	tags, err := ac.annotationService.SetTags(c.Request.Context(), userID, c.Param("id"), req)
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Profile not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// @Summary List profile notes
+// @Description List your private notes on a profile, newest first
+// @Tags annotations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Profile ID"
+// @Success 200 {array} models.ProfileNote
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/profiles/{id}/notes [get]
+func (ac *AnnotationController) ListNotes(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	notes, err := ac.annotationService.ListNotes(c.Request.Context(), userID, c.Param("id"))
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Profile not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, notes)
+}
+
+// @Summary Add a profile note
+// @Description Add a private note to a profile. Notes are only visible to you
+// @Tags annotations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Profile ID"
+// @Param note body models.ProfileNoteRequest true "Note"
+// @Success 201 {object} models.ProfileNote
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/profiles/{id}/notes [post]
+func (ac *AnnotationController) CreateNote(c *gin.Context) {
+	var req models.ProfileNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	note, err := ac.annotationService.CreateNote(c.Request.Context(), userID, c.Param("id"), req)
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Profile not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, note)
+}
+
+// @Summary Update a profile note
+// @Description Rewrite one of your private notes on a profile
+// @Tags annotations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Profile ID"
+// @Param noteId path string true "Note ID"
+// @Param note body models.ProfileNoteRequest true "Note"
+// @Success 200 {object} models.ProfileNote
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/profiles/{id}/notes/{noteId} [put]
+func (ac *AnnotationController) UpdateNote(c *gin.Context) {
+	var req models.ProfileNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	note, err := ac.annotationService.UpdateNote(c.Request.Context(), userID, c.Param("id"), c.Param("noteId"), req)
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Note not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, note)
+}
+
+// @Summary Delete a profile note
+// @Description Delete one of your private notes on a profile
+// @Tags annotations
+// @Security BearerAuth
+// @Param id path string true "Profile ID"
+// @Param noteId path string true "Note ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/profiles/{id}/notes/{noteId} [delete]
+func (ac *AnnotationController) DeleteNote(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	err := ac.annotationService.DeleteNote(c.Request.Context(), userID, c.Param("id"), c.Param("noteId"))
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Note not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnotationController_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	annotationController := NewAnnotationController(services.NewAnnotationService(nil, nil))
+	router.GET("/api/v1/profiles/:id/tags", withUser(testUserID, annotationController.GetTags))
+	router.PUT("/api/v1/profiles/:id/tags", withUser(testUserID, annotationController.SetTags))
+	router.POST("/api/v1/profiles/:id/notes", withUser(testUserID, annotationController.CreateNote))
+	router.PUT("/api/v1/profiles/:id/notes/:noteId", withUser(testUserID, annotationController.UpdateNote))
+	router.DELETE("/api/v1/profiles/:id/notes/:noteId", withUser(testUserID, annotationController.DeleteNote))
+
+	profileID := "00000000-0000-0000-0000-000000000002"
+	tests := map[string]struct {
+		method string
+		path   string
+		body   string
+	}{
+		"get tags invalid profile": {"GET", "/api/v1/profiles/ada/tags", ""},
+		"set tags invalid profile": {"PUT", "/api/v1/profiles/ada/tags", `{"tags": ["investor"]}`},
+		"set tags empty tag":       {"PUT", "/api/v1/profiles/" + profileID + "/tags", `{"tags": [""]}`},
+		"set tags long tag":        {"PUT", "/api/v1/profiles/" + profileID + "/tags", `{"tags": ["` + strings.Repeat("a", 51) + `"]}`},
+		"create note without body": {"POST", "/api/v1/profiles/" + profileID + "/notes", `{}`},
+		"update note invalid id":   {"PUT", "/api/v1/profiles/ada/notes/" + profileID, `{"body": "met at a conference"}`},
+		"delete note invalid id":   {"DELETE", "/api/v1/profiles/ada/notes/" + profileID, ""},
+	}
+
+	for name, tc := range tests {
+		request := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+}
@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ApprovalController handles the approval queue of automation rules
+type ApprovalController struct {
+	approvalService *services.ApprovalService
+}
+
+// NewApprovalController creates a new ApprovalController with injected dependencies
+func NewApprovalController(approvalService *services.ApprovalService) *ApprovalController {
+	return &ApprovalController{
+		approvalService: approvalService,
+	}
+}
+
+// @Summary List actions awaiting approval
+// @Description Actions queued by your rules that require approval, oldest first, with the message each will send
+// @Tags approvals
+// @Produce json
+// @Security BearerAuth
+// @Param rule_id query string false "Only list this rule's actions"
+// @Param limit query int false "Maximum number of actions (1-200, default 50)"
+// @Success 200 {array} models.PendingApproval
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/approvals [get]
+func (ac *ApprovalController) List(c *gin.Context) {
+	var query models.PendingApprovalsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	pending, err := ac.approvalService.ListPending(c.Request.Context(), userID, query)
+	if err != nil {
+		approvalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pending)
+}
+
+// @Summary Edit a queued message
+// @Description Replace the message an action awaiting approval will send. Connection notes must fit LinkedIn's 300 character limit
+// @Tags approvals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Queued action ID"
+// @Param request body models.PendingApprovalUpdate true "Edited message"
+// @Success 200 {object} models.PendingApproval
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/approvals/{id} [put]
+func (ac *ApprovalController) Update(c *gin.Context) {
+	var req models.PendingApprovalUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	pending, err := ac.approvalService.UpdateMessage(c.Request.Context(), userID, c.Param("id"), req)
+	if err != nil {
+		approvalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pending)
+}
+
+// @Summary Approve queued actions
+// @Description Approve actions awaiting approval. The next automation run performs them with their messages, within your invitation quota
+// @Tags approvals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ApprovalDecisionRequest true "Queued action IDs"
+// @Success 200 {object} models.ApprovalDecision
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/approvals/approve [post]
+func (ac *ApprovalController) Approve(c *gin.Context) {
+	ac.decide(c, ac.approvalService.Approve)
+}
+
+// @Summary Reject queued actions
+// @Description Reject actions awaiting approval; their rules will not action those profiles again
+// @Tags approvals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ApprovalDecisionRequest true "Queued action IDs"
+// @Success 200 {object} models.ApprovalDecision
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/approvals/reject [post]
+func (ac *ApprovalController) Reject(c *gin.Context) {
+	ac.decide(c, ac.approvalService.Reject)
+}
+
+func (ac *ApprovalController) decide(c *gin.Context, decide func(ctx context.Context, userID string, req models.ApprovalDecisionRequest) (*models.ApprovalDecision, error)) {
+	var req models.ApprovalDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	decision, err := decide(c.Request.Context(), userID, req)
+	if err != nil {
+		approvalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, decision)
+}
+
+func approvalError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidID), errors.Is(err, services.ErrMessageTooLong):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Queued action not found",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApprovalController_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	approvalController := NewApprovalController(services.NewApprovalService(nil))
+	router.GET("/api/v1/approvals", withUser(testUserID, approvalController.List))
+	router.PUT("/api/v1/approvals/:id", withUser(testUserID, approvalController.Update))
+	router.POST("/api/v1/approvals/approve", withUser(testUserID, approvalController.Approve))
+	router.POST("/api/v1/approvals/reject", withUser(testUserID, approvalController.Reject))
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{"GET", "/api/v1/approvals?rule_id=not-a-uuid", ""},
+		{"GET", "/api/v1/approvals?limit=500", ""},
+		{"PUT", "/api/v1/approvals/not-a-uuid", `{"message": "Hi Ada"}`},
+		{"PUT", "/api/v1/approvals/00000000-0000-0000-0000-000000000002", `{}`},
+		{"PUT", "/api/v1/approvals/00000000-0000-0000-0000-000000000002", `{"message": "` + strings.Repeat("a", 2001) + `"}`},
+		{"POST", "/api/v1/approvals/approve", `{}`},
+		{"POST", "/api/v1/approvals/approve", `{"ids": []}`},
+		{"POST", "/api/v1/approvals/approve", `{"ids": ["not-a-uuid"]}`},
+		{"POST", "/api/v1/approvals/reject", `{"ids": "00000000-0000-0000-0000-000000000002"}`},
+	}
+
+	for _, tc := range tests {
+		request := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, "%s %s %s", tc.method, tc.path, tc.body)
+	}
+}
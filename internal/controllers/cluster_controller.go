@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"errors"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClusterController handles network cluster HTTP requests
+type ClusterController struct {
+	clusterService *services.ClusterService
+}
+
+// NewClusterController creates a new ClusterController with injected dependencies
+func NewClusterController(clusterService *services.ClusterService) *ClusterController {
+	return &ClusterController{
+		clusterService: clusterService,
+	}
+}
+
+// @Summary List network clusters
+// @Description List the communities detected in your network, largest first, labelled by their dominant companies and locations
+// @Tags network
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.NetworkCluster
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/clusters [get]
+func (cc *ClusterController) List(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	clusters, err := cc.clusterService.ListClusters(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, clusters)
+}
+
+// @Summary List cluster profiles
+// @Description List the profiles assigned to one of your network clusters
+// @Tags network
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Cluster ID"
+// @Success 200 {array} models.ClusterProfile
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/clusters/{id}/profiles [get]
+func (cc *ClusterController) ListProfiles(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	profiles, err := cc.clusterService.ListClusterProfiles(c.Request.Context(), userID, c.Param("id"))
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Cluster not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, profiles)
+}
@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterController_ListProfiles_InvalidID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	clusterController := NewClusterController(services.NewClusterService(nil, nil))
+	router.GET("/api/v1/clusters/:id/profiles", func(c *gin.Context) {
+		c.Set("userID", "00000000-0000-0000-0000-000000000001")
+		clusterController.ListProfiles(c)
+	})
+
+	request := httptest.NewRequest("GET", "/api/v1/clusters/not-a-uuid/profiles", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, request)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,210 @@
+package controllers
+
+import (
+	"errors"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CompanyController handles company HTTP requests
+type CompanyController struct {
+	companyService *services.CompanyService
+}
+
+// NewCompanyController creates a new CompanyController with injected dependencies
+func NewCompanyController(companyService *services.CompanyService) *CompanyController {
+	return &CompanyController{
+		companyService: companyService,
+	}
+}
+
+// @Summary List companies
+// @Description List every known company ordered by name
+// @Tags companies
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Company
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/companies [get]
+func (cc *CompanyController) List(c *gin.Context) {
+	companies, err := cc.companyService.ListCompanies(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, companies)
+}
+
+// @Summary Add a company
+// @Description Add a company. Names are normalized (casing, punctuation and legal suffixes such as LLC or Inc.), so a name matching an existing company or alias returns that company with 409
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param company body models.CreateCompanyRequest true "Company data"
+// @Success 201 {object} models.Company
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /api/v1/companies [post]
+func (cc *CompanyController) Create(c *gin.Context) {
+	var req models.CreateCompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	company, err := cc.companyService.CreateCompany(c.Request.Context(), req)
+	if errors.Is(err, services.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "Company already exists",
+			"company": company,
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, company)
+}
+
+// @Summary Get company details
+// @Description Get a company with its aliases and number of known current employees
+// @Tags companies
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Company ID"
+// @Success 200 {object} models.CompanyDetail
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/companies/{id} [get]
+func (cc *CompanyController) Get(c *gin.Context) {
+	company, err := cc.companyService.GetCompany(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Company not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, company)
+}
+
+// @Summary Merge companies
+// @Description Merge a duplicate company into this one. Employment history, current employees and job change events move over, the duplicate's name becomes an alias and the duplicate is deleted
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Company ID to keep"
+// @Param merge body models.MergeCompanyRequest true "Company to merge"
+// @Success 200 {object} models.CompanyDetail
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/companies/{id}/merge [post]
+func (cc *CompanyController) Merge(c *gin.Context) {
+	var req models.MergeCompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	company, err := cc.companyService.MergeCompanies(c.Request.Context(), c.Param("id"), req)
+	if errors.Is(err, services.ErrInvalidID) || errors.Is(err, services.ErrMergeIntoSelf) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Company not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, company)
+}
+
+// @Summary List company people
+// @Description List every known profile currently at a company, and optionally former employees, with your best connection degree and the tracked connection that links you
+// @Tags companies
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Company ID"
+// @Param include_former query bool false "Include people who previously worked at the company"
+// @Success 200 {array} models.CompanyPerson
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/companies/{id}/people [get]
+func (cc *CompanyController) ListPeople(c *gin.Context) {
+	var query models.CompanyPeopleQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	people, err := cc.companyService.ListCompanyPeople(c.Request.Context(), userID, c.Param("id"), query)
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Company not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, people)
+}
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompanyController_ListPeople_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	companyController := NewCompanyController(services.NewCompanyService(nil, nil))
+	router.GET("/api/v1/companies/:id/people", func(c *gin.Context) {
+		c.Set("userID", "00000000-0000-0000-0000-000000000001")
+		companyController.ListPeople(c)
+	})
+
+	for _, path := range []string{
+		"/api/v1/companies/not-a-uuid/people",
+		"/api/v1/companies/00000000-0000-0000-0000-000000000002/people?include_former=maybe",
+	} {
+		request := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+}
+
+func TestCompanyController_Merge_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	companyController := NewCompanyController(services.NewCompanyService(nil, nil))
+	router.POST("/api/v1/companies/:id/merge", companyController.Merge)
+
+	companyID := "00000000-0000-0000-0000-000000000002"
+	tests := map[string]string{
+		"missing source":  `{}`,
+		"invalid source":  `{"source_company_id": "google"}`,
+		"merge into self": `{"source_company_id": "` + companyID + `"}`,
+	}
+
+	for name, body := range tests {
+		request := httptest.NewRequest("POST", "/api/v1/companies/"+companyID+"/merge", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+}
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConnectionController handles connection tracking HTTP requests
+type ConnectionController struct {
+	connectionService *services.ConnectionService
+}
+
+// NewConnectionController creates a new ConnectionController with injected dependencies
+func NewConnectionController(connectionService *services.ConnectionService) *ConnectionController {
+	return &ConnectionController{
+		connectionService: connectionService,
+	}
+}
+
+// @Summary List new connections
+// @Description List profiles newly discovered through your tracked connections, optionally ranked by how connected they are into your network
+// @Tags connections
+// @Produce json
+// @Security BearerAuth
+// @Param since query string false "Only include profiles discovered after this RFC3339 time (default: 7 days ago)"
+// @Param sort query string false "Sort order" Enums(recent, network_score, degree, betweenness) default(recent)
+// @Param min_network_score query number false "Minimum network score between 0 and 1"
+// @Param cluster_id query string false "Only include profiles in this network cluster"
+// @Success 200 {array} models.NewConnection
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/connections/new [get]
+func (cc *ConnectionController) ListNew(c *gin.Context) {
+	var query models.NewConnectionsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	connections, err := cc.connectionService.ListNewConnections(c.Request.Context(), userID, query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, connections)
+}
@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionController_ListNew_InvalidQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	connectionController := NewConnectionController(services.NewConnectionService(nil))
+	router.GET("/api/v1/connections/new", func(c *gin.Context) {
+		c.Set("userID", "00000000-0000-0000-0000-000000000001")
+		connectionController.ListNew(c)
+	})
+
+	for _, query := range []string{"sort=alphabetical", "min_network_score=2", "since=yesterday", "cluster_id=abc"} {
+		request := httptest.NewRequest("GET", "/api/v1/connections/new?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DuplicateController handles duplicate profile review HTTP requests
+type DuplicateController struct {
+	duplicateService *services.DuplicateService
+}
+
+// NewDuplicateController creates a new DuplicateController with injected dependencies
+func NewDuplicateController(duplicateService *services.DuplicateService) *DuplicateController {
+	return &DuplicateController{
+		duplicateService: duplicateService,
+	}
+}
+
+// @Summary List duplicate candidates
+// @Description List pairs of profiles that likely describe the same person, most likely first, with the signals that matched
+// @Tags duplicates
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Candidate status (default pending)" Enums(pending, rejected)
+// @Param limit query int false "Maximum number of candidates (1-200, default 50)"
+// @Success 200 {array} models.DuplicateCandidate
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/duplicates [get]
+func (dc *DuplicateController) List(c *gin.Context) {
+	var query models.DuplicateCandidatesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	candidates, err := dc.duplicateService.ListCandidates(c.Request.Context(), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, candidates)
+}
+
+// @Summary Confirm a duplicate candidate
+// @Description Merge the two profiles of a candidate. Relationships, tracked connections, employment history, events, tags, notes and list memberships move to the kept profile and the other profile is deleted
+// @Tags duplicates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Duplicate candidate ID"
+// @Param confirm body models.ConfirmDuplicateRequest false "Profile to keep"
+// @Success 200 {object} models.ProfileRef
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/duplicates/{id}/confirm [post]
+func (dc *DuplicateController) Confirm(c *gin.Context) {
+	var req models.ConfirmDuplicateRequest
+	// The body is optional
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	profile, err := dc.duplicateService.ConfirmCandidate(c.Request.Context(), c.Param("id"), req)
+	if errors.Is(err, services.ErrInvalidID) || errors.Is(err, services.ErrNotInPair) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Duplicate candidate not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// @Summary Reject a duplicate candidate
+// @Description Mark a pending candidate as two different people so it is not raised again
+// @Tags duplicates
+// @Security BearerAuth
+// @Param id path string true "Duplicate candidate ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/duplicates/{id}/reject [post]
+func (dc *DuplicateController) Reject(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	err := dc.duplicateService.RejectCandidate(c.Request.Context(), userID, c.Param("id"))
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Duplicate candidate not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
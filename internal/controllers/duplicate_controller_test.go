@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicateController_List_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	duplicateController := NewDuplicateController(services.NewDuplicateService(nil, nil))
+	router.GET("/api/v1/duplicates", duplicateController.List)
+
+	for _, path := range []string{
+		"/api/v1/duplicates?status=merged",
+		"/api/v1/duplicates?limit=-1",
+		"/api/v1/duplicates?limit=500",
+	} {
+		request := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+}
+
+func TestDuplicateController_Confirm_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	duplicateController := NewDuplicateController(services.NewDuplicateService(nil, nil))
+	router.POST("/api/v1/duplicates/:id/confirm", duplicateController.Confirm)
+
+	candidateID := "00000000-0000-0000-0000-000000000002"
+	tests := map[string]struct {
+		id   string
+		body string
+	}{
+		"invalid candidate id": {"not-a-uuid", ``},
+		"invalid keep id":      {candidateID, `{"keep_profile_id": "jane"}`},
+		"malformed body":       {candidateID, `{"keep_profile_id":`},
+	}
+
+	for name, tt := range tests {
+		request := httptest.NewRequest("POST", "/api/v1/duplicates/"+tt.id+"/confirm", strings.NewReader(tt.body))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+}
+
+func TestDuplicateController_Reject_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	duplicateController := NewDuplicateController(services.NewDuplicateService(nil, nil))
+	router.POST("/api/v1/duplicates/:id/reject", func(c *gin.Context) {
+		c.Set("userID", "00000000-0000-0000-0000-000000000001")
+		duplicateController.Reject(c)
+	})
+
+	request := httptest.NewRequest("POST", "/api/v1/duplicates/not-a-uuid/reject", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, request)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EventController handles profile event feed HTTP requests
+type EventController struct {
+	eventService *services.EventService
+}
+
+// NewEventController creates a new EventController with injected dependencies
+func NewEventController(eventService *services.EventService) *EventController {
+	return &EventController{
+		eventService: eventService,
+	}
+}
+
+// @Summary List job changes
+// @Description List the most recent job changes detected among the profiles in your network, newest first
+// @Tags events
+// @Produce json
+// @Security BearerAuth
+// @Param since query string false "Only include changes detected after this RFC3339 time (default: 30 days ago)"
+// @Param company_id query string false "Only include moves into this company"
+// @Param limit query int false "Maximum number of changes to return (1-200)" default(50)
+// @Success 200 {array} models.JobChange
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/events/job-changes [get]
+func (ec *EventController) ListJobChanges(c *gin.Context) {
+	var query models.JobChangesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	changes, err := ec.eventService.ListJobChanges(c.Request.Context(), userID, query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, changes)
+}
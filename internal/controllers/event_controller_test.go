@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventController_ListJobChanges_InvalidQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	eventController := NewEventController(services.NewEventService(nil))
+	router.GET("/api/v1/events/job-changes", func(c *gin.Context) {
+		c.Set("userID", "00000000-0000-0000-0000-000000000001")
+		eventController.ListJobChanges(c)
+	})
+
+	for _, query := range []string{"company_id=acme", "limit=-1", "limit=500", "since=last-week"} {
+		request := httptest.NewRequest("GET", "/api/v1/events/job-changes?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
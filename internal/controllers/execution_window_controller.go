@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"errors"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExecutionWindowController handles execution window HTTP requests
+type ExecutionWindowController struct {
+	windowService *services.ExecutionWindowService
+}
+
+// NewExecutionWindowController creates a new ExecutionWindowController with injected dependencies
+func NewExecutionWindowController(windowService *services.ExecutionWindowService) *ExecutionWindowController {
+	return &ExecutionWindowController{
+		windowService: windowService,
+	}
+}
+
+// @Summary Get your execution window
+// @Description The weekdays and hours your rules act in, the holidays they skip and your timezone, with whether the window is open now and when it next opens. Without a window your rules act at any time.
+// @Tags execution-window
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.ExecutionWindow
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/execution-window [get]
+func (wc *ExecutionWindowController) Get(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	window, err := wc.windowService.GetWindow(c.Request.Context(), userID)
+	if err != nil {
+		executionWindowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, window)
+}
+
+// @Summary Set your execution window
+// @Description Sets the weekdays and hours your rules act in and the holidays they skip, and optionally your timezone. Actions due outside the window wait for it to open; rules with their own timezone evaluate the window in it.
+// @Tags execution-window
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ExecutionWindowRequest true "Execution window"
+// @Success 200 {object} models.ExecutionWindow
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/execution-window [put]
+func (wc *ExecutionWindowController) Update(c *gin.Context) {
+	var req models.ExecutionWindowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	window, err := wc.windowService.UpdateWindow(c.Request.Context(), userID, req)
+	if err != nil {
+		executionWindowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, window)
+}
+
+// @Summary Remove your execution window
+// @Description Removes your execution window, so your rules act at any time
+// @Tags execution-window
+// @Security BearerAuth
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/execution-window [delete]
+func (wc *ExecutionWindowController) Delete(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	if err := wc.windowService.DeleteWindow(c.Request.Context(), userID); err != nil {
+		executionWindowError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func executionWindowError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidID),
+		errors.Is(err, services.ErrInvalidTimezone),
+		errors.Is(err, services.ErrInvalidWindow):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Execution window not found",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutionWindowController_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	windowController := NewExecutionWindowController(services.NewExecutionWindowService(nil, time.UTC))
+	router.GET("/api/v1/execution-window", withUser("not-a-uuid", windowController.Get))
+	router.DELETE("/api/v1/execution-window", withUser("not-a-uuid", windowController.Delete))
+	router.PUT("/api/v1/execution-window", withUser(testUserID, windowController.Update))
+
+	for _, method := range []string{"GET", "DELETE"} {
+		request := httptest.NewRequest(method, "/api/v1/execution-window", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+		assert.Equal(t, http.StatusBadRequest, w.Code, method)
+	}
+
+	for _, body := range []string{
+		`{}`,
+		`{"weekdays": [], "start": "09:00", "end": "18:00"}`,
+		`{"weekdays": [0], "start": "09:00", "end": "18:00"}`,
+		`{"weekdays": [1, 8], "start": "09:00", "end": "18:00"}`,
+		`{"weekdays": [1], "start": "9am", "end": "18:00"}`,
+		`{"weekdays": [1], "start": "09:00", "end": "24:00"}`,
+		`{"weekdays": [1], "start": "18:00", "end": "09:00"}`,
+		`{"weekdays": [1], "start": "09:00", "end": "09:00"}`,
+		`{"weekdays": [1], "start": "09:00", "end": "18:00", "holidays": ["25/12/2026"]}`,
+		`{"weekdays": [1], "start": "09:00", "end": "18:00", "timezone": "Mars/Olympus"}`,
+		`not json`,
+	} {
+		request := httptest.NewRequest("PUT", "/api/v1/execution-window", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
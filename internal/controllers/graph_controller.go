@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"fmt"
+	"linkedin-watcher/infra/logger"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GraphController handles network graph HTTP requests
+type GraphController struct {
+	graphService *services.GraphService
+}
+
+// NewGraphController creates a new GraphController with injected dependencies
+func NewGraphController(graphService *services.GraphService) *GraphController {
+	return &GraphController{
+		graphService: graphService,
+	}
+}
+
+// @Summary Export network graph
+// @Description Stream the user's network (tracked and discovered profiles with their relationships) as GraphML, GEXF, Graphviz DOT or node-link JSON
+// @Tags network
+// @Produce application/graphml+xml
+// @Produce application/gexf+xml
+// @Produce text/vnd.graphviz
+// @Produce json
+// @Security BearerAuth
+// @Param format query string false "Export format" Enums(graphml, gexf, dot, json) default(graphml)
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/network/export [get]
+func (gc *GraphController) Export(c *gin.Context) {
+	format := models.GraphFormat(c.DefaultQuery("format", string(models.GraphFormatGraphML)))
+	if !format.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("unsupported format %q, use graphml, gexf, dot or json", format),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="network.%s"`, format.FileExtension()))
+	c.Status(http.StatusOK)
+
+	// The body is streamed, so once writing starts errors can only be logged
+	if err := gc.graphService.ExportNetwork(c.Request.Context(), userID, format, c.Writer); err != nil {
+		logger.Errorf("network export failed for user %s: %v", userID, err)
+		_ = c.Error(err)
+	}
+}
@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphController_Export_InvalidFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	graphController := NewGraphController(services.NewGraphService(nil))
+	router.GET("/api/v1/network/export", func(c *gin.Context) {
+		c.Set("userID", "00000000-0000-0000-0000-000000000001")
+		graphController.Export(c)
+	})
+
+	request := httptest.NewRequest("GET", "/api/v1/network/export?format=csv", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, request)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
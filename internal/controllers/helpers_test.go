@@ -0,0 +1,14 @@
+package controllers
+
+import "github.com/gin-gonic/gin"
+
+// testUserID is the authenticated user of controller tests
+const testUserID = "00000000-0000-0000-0000-000000000001"
+
+// withUser runs handler as if AuthMiddleware had authenticated userID
+func withUser(userID string, handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("userID", userID)
+		handler(c)
+	}
+}
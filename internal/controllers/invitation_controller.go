@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"errors"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InvitationController handles invitation quota HTTP requests
+type InvitationController struct {
+	invitationService *services.InvitationService
+}
+
+// NewInvitationController creates a new InvitationController with injected dependencies
+func NewInvitationController(invitationService *services.InvitationService) *InvitationController {
+	return &InvitationController{
+		invitationService: invitationService,
+	}
+}
+
+// @Summary Get your invitation quota
+// @Description Daily and weekly limits on the connection requests your rules send, what remains of them and when pacing allows the next one. The day runs midnight to midnight in your timezone and the week is the last seven days.
+// @Tags invitations
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.InvitationQuota
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/invitations/quota [get]
+func (ic *InvitationController) GetQuota(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	quota, err := ic.invitationService.GetQuota(c.Request.Context(), userID)
+	if err != nil {
+		invitationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quota)
+}
+
+// @Summary Set your invitation quota
+// @Description Sets the daily and weekly limits on the connection requests your rules send. Requests are spread evenly across the hours your execution window is open each day; a zero limit stops them. Setting the quota lifts the day's pause after LinkedIn reports its limit.
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.InvitationQuotaRequest true "Invitation limits"
+// @Success 200 {object} models.InvitationQuota
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/invitations/quota [put]
+func (ic *InvitationController) UpdateQuota(c *gin.Context) {
+	var req models.InvitationQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	quota, err := ic.invitationService.UpdateQuota(c.Request.Context(), userID, req)
+	if err != nil {
+		invitationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quota)
+}
+
+func invitationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidID):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
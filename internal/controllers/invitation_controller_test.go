@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"linkedin-watcher/config"
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvitationController_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	invitationController := NewInvitationController(services.NewInvitationService(nil, config.InvitationQuotaConfiguration{}, nil))
+	router.GET("/api/v1/invitations/quota", withUser("not-a-uuid", invitationController.GetQuota))
+	router.PUT("/api/v1/invitations/quota", withUser(testUserID, invitationController.UpdateQuota))
+
+	request := httptest.NewRequest("GET", "/api/v1/invitations/quota", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, request)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	for _, body := range []string{
+		`{}`,
+		`{"daily_limit": 20}`,
+		`{"daily_limit": -1, "weekly_limit": 100}`,
+		`{"daily_limit": 101, "weekly_limit": 100}`,
+		`{"daily_limit": 20, "weekly_limit": 201}`,
+		`not json`,
+	} {
+		request := httptest.NewRequest("PUT", "/api/v1/invitations/quota", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
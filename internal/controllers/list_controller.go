@@ -0,0 +1,297 @@
+package controllers
+
+import (
+	"errors"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListController handles named profile list HTTP requests
+type ListController struct {
+	listService *services.ListService
+}
+
+// NewListController creates a new ListController with injected dependencies
+func NewListController(listService *services.ListService) *ListController {
+	return &ListController{
+		listService: listService,
+	}
+}
+
+// @Summary List profile lists
+// @Description List your named profile lists with their member counts
+// @Tags lists
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.ProfileList
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/lists [get]
+func (lc *ListController) List(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	lists, err := lc.listService.ListLists(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, lists)
+}
+
+// @Summary Create a profile list
+// @Description Create a named list of profiles, e.g. "Q3 hiring targets". Names are unique per user
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param list body models.ProfileListRequest true "List data"
+// @Success 201 {object} models.ProfileList
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /api/v1/lists [post]
+func (lc *ListController) Create(c *gin.Context) {
+	var req models.ProfileListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	list, err := lc.listService.CreateList(c.Request.Context(), userID, req)
+	if errors.Is(err, services.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "A list with this name already exists",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, list)
+}
+
+// @Summary Get a profile list
+// @Description Get one of your lists with its members, most recently added first
+// @Tags lists
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "List ID"
+// @Success 200 {object} models.ProfileListDetail
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/lists/{id} [get]
+func (lc *ListController) Get(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	list, err := lc.listService.GetList(c.Request.Context(), userID, c.Param("id"))
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "List not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// @Summary Update a profile list
+// @Description Rename one of your lists or change its description
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "List ID"
+// @Param list body models.ProfileListRequest true "List data"
+// @Success 200 {object} models.ProfileList
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /api/v1/lists/{id} [put]
+func (lc *ListController) Update(c *gin.Context) {
+	var req models.ProfileListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	list, err := lc.listService.UpdateList(c.Request.Context(), userID, c.Param("id"), req)
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "List not found",
+		})
+		return
+	}
+	if errors.Is(err, services.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "A list with this name already exists",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// @Summary Delete a profile list
+// @Description Delete one of your lists. Automation rules filtering on the list stop filtering
+// @Tags lists
+// @Security BearerAuth
+// @Param id path string true "List ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/lists/{id} [delete]
+func (lc *ListController) Delete(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	err := lc.listService.DeleteList(c.Request.Context(), userID, c.Param("id"))
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "List not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Add a profile to a list
+// @Description Add a profile to one of your lists. Adding a profile that is already a member does nothing
+// @Tags lists
+// @Accept json
+// @Security BearerAuth
+// @Param id path string true "List ID"
+// @Param member body models.ListMemberRequest true "Profile to add"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/lists/{id}/profiles [post]
+func (lc *ListController) AddMember(c *gin.Context) {
+	var req models.ListMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	err := lc.listService.AddMember(c.Request.Context(), userID, c.Param("id"), req)
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "List or profile not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Remove a profile from a list
+// @Description Remove a profile from one of your lists
+// @Tags lists
+// @Security BearerAuth
+// @Param id path string true "List ID"
+// @Param profileId path string true "Profile ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/lists/{id}/profiles/{profileId} [delete]
+func (lc *ListController) RemoveMember(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	err := lc.listService.RemoveMember(c.Request.Context(), userID, c.Param("id"), c.Param("profileId"))
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "List member not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
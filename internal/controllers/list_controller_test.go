@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newListTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	listController := NewListController(services.NewListService(nil))
+	router.POST("/api/v1/lists", withUser(testUserID, listController.Create))
+	router.GET("/api/v1/lists/:id", withUser(testUserID, listController.Get))
+	router.PUT("/api/v1/lists/:id", withUser(testUserID, listController.Update))
+	router.DELETE("/api/v1/lists/:id", withUser(testUserID, listController.Delete))
+	router.POST("/api/v1/lists/:id/profiles", withUser(testUserID, listController.AddMember))
+	router.DELETE("/api/v1/lists/:id/profiles/:profileId", withUser(testUserID, listController.RemoveMember))
+	return router
+}
+
+func TestListController_BadRequest(t *testing.T) {
+	router := newListTestRouter()
+
+	listID := "00000000-0000-0000-0000-000000000002"
+	tests := map[string]struct {
+		method string
+		path   string
+		body   string
+	}{
+		"create without name":    {"POST", "/api/v1/lists", `{"description": "people to hire"}`},
+		"create with long name":  {"POST", "/api/v1/lists", `{"name": "` + strings.Repeat("a", 256) + `"}`},
+		"get invalid id":         {"GET", "/api/v1/lists/not-a-uuid", ""},
+		"update without name":    {"PUT", "/api/v1/lists/" + listID, `{}`},
+		"update invalid id":      {"PUT", "/api/v1/lists/not-a-uuid", `{"name": "Q3 hiring targets"}`},
+		"delete invalid id":      {"DELETE", "/api/v1/lists/not-a-uuid", ""},
+		"add without profile":    {"POST", "/api/v1/lists/" + listID + "/profiles", `{}`},
+		"add invalid profile":    {"POST", "/api/v1/lists/" + listID + "/profiles", `{"profile_id": "ada"}`},
+		"add to invalid list":    {"POST", "/api/v1/lists/not-a-uuid/profiles", `{"profile_id": "` + listID + `"}`},
+		"remove invalid list":    {"DELETE", "/api/v1/lists/not-a-uuid/profiles/" + listID, ""},
+		"remove invalid profile": {"DELETE", "/api/v1/lists/" + listID + "/profiles/ada", ""},
+	}
+
+	for name, tc := range tests {
+		request := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+}
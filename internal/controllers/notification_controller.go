@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"errors"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationController handles notification channel and inbox HTTP requests
+type NotificationController struct {
+	notificationService *services.NotificationService
+}
+
+// NewNotificationController creates a new NotificationController with injected dependencies
+func NewNotificationController(notificationService *services.NotificationService) *NotificationController {
+	return &NotificationController{
+		notificationService: notificationService,
+	}
+}
+
+// @Summary List notification channels
+// @Description List the webhooks and email addresses your alerts are delivered to
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.NotificationChannel
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/notification-channels [get]
+func (nc *NotificationController) ListChannels(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	channels, err := nc.notificationService.ListChannels(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, channels)
+}
+
+// @Summary Create a notification channel
+// @Description Deliver alerts to a webhook (JSON POST) or an email address, in addition to the in-app inbox
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param channel body models.CreateNotificationChannelRequest true "Channel data"
+// @Success 201 {object} models.NotificationChannel
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /api/v1/notification-channels [post]
+func (nc *NotificationController) CreateChannel(c *gin.Context) {
+	var req models.CreateNotificationChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	channel, err := nc.notificationService.CreateChannel(c.Request.Context(), userID, req)
+	if errors.Is(err, services.ErrInvalidChannelTarget) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "This channel already exists",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, channel)
+}
+
+// @Summary Delete a notification channel
+// @Description Stop delivering alerts to one of your channels
+// @Tags notifications
+// @Security BearerAuth
+// @Param id path string true "Channel ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notification-channels/{id} [delete]
+func (nc *NotificationController) DeleteChannel(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	err := nc.notificationService.DeleteChannel(c.Request.Context(), userID, c.Param("id"))
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Notification channel not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List notifications
+// @Description List the alerts in your in-app inbox, newest first
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param unread_only query bool false "Only unread notifications"
+// @Param limit query int false "Maximum number of notifications (1-200, default 50)"
+// @Success 200 {array} models.Notification
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/notifications [get]
+func (nc *NotificationController) List(c *gin.Context) {
+	var query models.NotificationsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	notifications, err := nc.notificationService.ListNotifications(c.Request.Context(), userID, query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+// @Summary Mark a notification read
+// @Description Mark one of your notifications as read
+// @Tags notifications
+// @Security BearerAuth
+// @Param id path string true "Notification ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notifications/{id}/read [post]
+func (nc *NotificationController) MarkRead(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	err := nc.notificationService.MarkRead(c.Request.Context(), userID, c.Param("id"))
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Notification not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
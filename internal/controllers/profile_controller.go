@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"errors"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProfileController handles profile HTTP requests
+type ProfileController struct {
+	profileService *services.ProfileService
+}
+
+// NewProfileController creates a new ProfileController with injected dependencies
+func NewProfileController(profileService *services.ProfileService) *ProfileController {
+	return &ProfileController{
+		profileService: profileService,
+	}
+}
+
+// @Summary Sync a re-scraped profile
+// @Description Store a profile as just scraped from LinkedIn, replacing its name, location, headline and employment history. A job_change event is recorded when its current company or position changed, and a headline_change event when its headline did; both show in the job change feed, the profile timeline and job_change rules
+// @Tags profiles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Profile ID"
+// @Param snapshot body models.ProfileSnapshot true "Scraped profile"
+// @Success 200 {object} models.ProfileSync
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/profiles/{id}/snapshot [put]
+func (pc *ProfileController) Sync(c *gin.Context) {
+	var snapshot models.ProfileSnapshot
+	if err := c.ShouldBindJSON(&snapshot); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := pc.profileService.SyncProfile(c.Request.Context(), c.Param("id"), snapshot)
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Profile not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileController_Sync_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	profileController := NewProfileController(services.NewProfileService(nil, nil))
+	router.PUT("/api/v1/profiles/:id/snapshot", withUser(testUserID, profileController.Sync))
+
+	profileID := "00000000-0000-0000-0000-000000000002"
+	tests := map[string]struct {
+		path string
+		body string
+	}{
+		"invalid profile":          {"/api/v1/profiles/ada/snapshot", `{"name": "Ada Lovelace", "positions": []}`},
+		"missing name":             {"/api/v1/profiles/" + profileID + "/snapshot", `{"positions": []}`},
+		"position without company": {"/api/v1/profiles/" + profileID + "/snapshot", `{"name": "Ada Lovelace", "positions": [{"position": "Engineer", "start_date": "2024-01-01T00:00:00Z", "is_current": true}]}`},
+		"position without start":   {"/api/v1/profiles/" + profileID + "/snapshot", `{"name": "Ada Lovelace", "positions": [{"company": "Acme", "is_current": true}]}`},
+	}
+
+	for name, tc := range tests {
+		request := httptest.NewRequest(http.MethodPut, tc.path, strings.NewReader(tc.body))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+}
@@ -0,0 +1,387 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RuleController handles automation rule HTTP requests
+type RuleController struct {
+	ruleService *services.RuleService
+}
+
+// NewRuleController creates a new RuleController with injected dependencies
+func NewRuleController(ruleService *services.RuleService) *RuleController {
+	return &RuleController{
+		ruleService: ruleService,
+	}
+}
+
+// @Summary List automation rules
+// @Description List your automation rules, newest first
+// @Tags rules
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.AutomationRule
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/rules [get]
+func (rc *RuleController) List(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	rules, err := rc.ruleService.ListRules(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// @Summary Create an automation rule
+// @Description Create a rule that acts on new connections or job changes matching its filters.
+// @Description A rule needs at least one filter, and send_connection_request rules need a message_template that renders within 300 characters without profile values.
+// @Description sequence lists up to 10 follow-up steps for the profiles the rule's action succeeded on: each waits wait_days days, or until its until event (accepted or replied), then performs its action; send_message steps need a message_template. An exit_on event observed while a step waits ends the sequence
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body models.AutomationRuleRequest true "Rule data"
+// @Success 201 {object} models.AutomationRule
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/rules [post]
+func (rc *RuleController) Create(c *gin.Context) {
+	var req models.AutomationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	rule, err := rc.ruleService.CreateRule(c.Request.Context(), userID, req)
+	if isInvalidRule(err) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// @Summary Update an automation rule
+// @Description Replace one of your automation rules. Omitting is_active re-activates the rule
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rule ID"
+// @Param rule body models.AutomationRuleRequest true "Rule data"
+// @Success 200 {object} models.AutomationRule
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/rules/{id} [put]
+func (rc *RuleController) Update(c *gin.Context) {
+	var req models.AutomationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	rule, err := rc.ruleService.UpdateRule(c.Request.Context(), userID, c.Param("id"), req)
+	if isInvalidRule(err) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Rule not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// @Summary Delete an automation rule
+// @Description Delete one of your automation rules
+// @Tags rules
+// @Security BearerAuth
+// @Param id path string true "Rule ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/rules/{id} [delete]
+func (rc *RuleController) Delete(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	err := rc.ruleService.DeleteRule(c.Request.Context(), userID, c.Param("id"))
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Rule not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List a rule's executions
+// @Description What one of your rules has done, newest first: each profile it actioned, whether the action succeeded, and the error of the last failed attempt
+// @Tags rules
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rule ID"
+// @Param limit query int false "Maximum number of executions (1-200, default 50)"
+// @Success 200 {array} models.RuleExecution
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/rules/{id}/executions [get]
+func (rc *RuleController) ListExecutions(c *gin.Context) {
+	var query models.RuleExecutionsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	executions, err := rc.ruleService.ListExecutions(c.Request.Context(), userID, c.Param("id"), query)
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Rule not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, executions)
+}
+
+// @Summary List a rule's sequence enrollments
+// @Description The profiles going through one of your rule's sequence, most recently updated first: the step each waits on, the accepted and replied events observed, why it exited, and the steps performed so far
+// @Tags rules
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rule ID"
+// @Param status query string false "Only enrollments with this status: active, completed, exited or failed"
+// @Param limit query int false "Maximum number of enrollments (1-200, default 50)"
+// @Success 200 {array} models.SequenceEnrollment
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/rules/{id}/enrollments [get]
+func (rc *RuleController) ListEnrollments(c *gin.Context) {
+	var query models.RuleEnrollmentsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	enrollments, err := rc.ruleService.ListEnrollments(c.Request.Context(), userID, c.Param("id"), query)
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Rule not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollments)
+}
+
+// @Summary Preview a rule's message
+// @Description Render a message template for up to limit profiles in your network the rule matches, including ones it already actioned.
+// @Description Templates refer to first_name, last_name, full_name, company, headline, location, mutual_connection and mutual_first_name in double braces, with an optional quoted fallback after a pipe.
+// @Description Omit message_template to preview the rule's own. too_long flags notes over LinkedIn's 300 character limit
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rule ID"
+// @Param preview body models.RulePreviewRequest false "Template to preview and number of profiles (1-20, default 5)"
+// @Success 200 {array} models.RulePreview
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/rules/{id}/preview [post]
+func (rc *RuleController) Preview(c *gin.Context) {
+	var req models.RulePreviewRequest
+	// The body is optional
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	previews, err := rc.ruleService.PreviewRule(c.Request.Context(), userID, c.Param("id"), req)
+	if errors.Is(err, services.ErrInvalidID) || errors.Is(err, services.ErrInvalidTemplate) || errors.Is(err, services.ErrNothingToPreview) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Rule not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, previews)
+}
+
+// @Summary Simulate a rule
+// @Description Dry-run a rule, active or not, as if it had been created days ago: how many profiles discovered or job changes detected since it would match, how many it has actioned already, how many notes would be too long, how many profiles are blocked because an action of the same kind was skipped on them before (e.g. already connected) and how many connection requests would wait for invitation quota, with a sample of rendered messages. Nothing is sent or recorded
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rule ID"
+// @Param simulation body models.RuleSimulationRequest false "Days to look back (1-90, default 30) and messages to render (1-20, default 5)"
+// @Success 200 {object} models.RuleSimulation
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/rules/{id}/simulate [post]
+func (rc *RuleController) Simulate(c *gin.Context) {
+	var req models.RuleSimulationRequest
+	// The body is optional
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	simulation, err := rc.ruleService.SimulateRule(c.Request.Context(), userID, c.Param("id"), req)
+	if errors.Is(err, services.ErrInvalidID) || errors.Is(err, services.ErrInvalidTemplate) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Rule not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, simulation)
+}
+
+// isInvalidRule reports whether err rejects the rule request itself
+func isInvalidRule(err error) bool {
+	for _, invalid := range []error{
+		services.ErrInvalidID,
+		services.ErrUnknownPlace,
+		services.ErrUnknownList,
+		services.ErrMissingTemplate,
+		services.ErrTemplateTooLong,
+		services.ErrNoRuleFilters,
+		services.ErrInvalidCondition,
+		services.ErrInvalidTemplate,
+		services.ErrInvalidSequence,
+		services.ErrInvalidTimezone,
+	} {
+		if errors.Is(err, invalid) {
+			return true
+		}
+	}
+	return false
+}
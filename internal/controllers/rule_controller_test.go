@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleController_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	ruleController := NewRuleController(services.NewRuleService(nil, nil))
+	router.POST("/api/v1/rules", withUser(testUserID, ruleController.Create))
+	router.PUT("/api/v1/rules/:id", withUser(testUserID, ruleController.Update))
+	router.DELETE("/api/v1/rules/:id", withUser(testUserID, ruleController.Delete))
+	router.GET("/api/v1/rules/:id/executions", withUser(testUserID, ruleController.ListExecutions))
+	router.POST("/api/v1/rules/:id/preview", withUser(testUserID, ruleController.Preview))
+	router.POST("/api/v1/rules/:id/simulate", withUser(testUserID, ruleController.Simulate))
+	router.GET("/api/v1/rules/:id/enrollments", withUser(testUserID, ruleController.ListEnrollments))
+
+	ruleID := "00000000-0000-0000-0000-000000000002"
+	tests := map[string]struct {
+		method string
+		path   string
+		body   string
+	}{
+		"create without name":         {"POST", "/api/v1/rules", `{"action_type": "notify", "condition": "company == \"Acme\""}`},
+		"create without action":       {"POST", "/api/v1/rules", `{"name": "Acme", "condition": "company == \"Acme\""}`},
+		"create with unknown action":  {"POST", "/api/v1/rules", `{"name": "Acme", "action_type": "send_inmail", "condition": "company == \"Acme\""}`},
+		"create with unknown trigger": {"POST", "/api/v1/rules", `{"name": "Acme", "action_type": "notify", "trigger_type": "birthday", "condition": "company == \"Acme\""}`},
+		"create without filters":      {"POST", "/api/v1/rules", `{"name": "Everyone", "action_type": "notify"}`},
+		"create with blank filter":    {"POST", "/api/v1/rules", `{"name": "Everyone", "action_type": "notify", "condition": "  "}`},
+		"connect without template":    {"POST", "/api/v1/rules", `{"name": "Acme", "action_type": "send_connection_request", "condition": "company == \"Acme\""}`},
+		"connect with long template":  {"POST", "/api/v1/rules", `{"name": "Acme", "action_type": "send_connection_request", "condition": "company == \"Acme\"", "message_template": "` + strings.Repeat("a", 301) + `"}`},
+		"create with bad condition":   {"POST", "/api/v1/rules", `{"name": "Acme", "action_type": "notify", "condition": "company = \"Acme\""}`},
+		"create with bad list":        {"POST", "/api/v1/rules", `{"name": "Acme", "action_type": "notify", "list_id": "hiring"}`},
+		"create near unknown place":   {"POST", "/api/v1/rules", `{"name": "Acme", "action_type": "notify", "near": "Atlantis"}`},
+		"create with bad seniority":   {"POST", "/api/v1/rules", `{"name": "Acme", "action_type": "notify", "min_seniority": "boss"}`},
+		"update invalid id":           {"PUT", "/api/v1/rules/not-a-uuid", `{"name": "Acme", "action_type": "notify", "condition": "company == \"Acme\""}`},
+		"update without filters":      {"PUT", "/api/v1/rules/" + ruleID, `{"name": "Everyone", "action_type": "notify"}`},
+		"delete invalid id":           {"DELETE", "/api/v1/rules/not-a-uuid", ""},
+		"executions invalid id":       {"GET", "/api/v1/rules/not-a-uuid/executions", ""},
+		"create with bad template":    {"POST", "/api/v1/rules", `{"name": "Acme", "action_type": "notify", "condition": "degree == 2", "message_template": "Hi {{nickname}}"}`},
+		"preview invalid id":          {"POST", "/api/v1/rules/not-a-uuid/preview", ""},
+		"preview bad template":        {"POST", "/api/v1/rules/" + ruleID + "/preview", `{"message_template": "Hi {{first_name"}`},
+		"preview limit too large":     {"POST", "/api/v1/rules/" + ruleID + "/preview", `{"limit": 100}`},
+		"executions limit too large":  {"GET", "/api/v1/rules/" + ruleID + "/executions?limit=500", ""},
+		"simulate invalid id":         {"POST", "/api/v1/rules/not-a-uuid/simulate", ""},
+		"simulate too many days":      {"POST", "/api/v1/rules/" + ruleID + "/simulate", `{"days": 365}`},
+		"simulate sample too large":   {"POST", "/api/v1/rules/" + ruleID + "/simulate", `{"sample": 50}`},
+		"step with unknown action":    {"POST", "/api/v1/rules", `{"name": "Acme", "action_type": "notify", "condition": "degree == 2", "sequence": [{"action_type": "send_connection_request"}]}`},
+		"step waiting too long":       {"POST", "/api/v1/rules", `{"name": "Acme", "action_type": "notify", "condition": "degree == 2", "sequence": [{"wait_days": 365, "action_type": "notify"}]}`},
+		"step with unknown event":     {"POST", "/api/v1/rules", `{"name": "Acme", "action_type": "notify", "condition": "degree == 2", "sequence": [{"until": "viewed", "action_type": "notify"}]}`},
+		"message step no template":    {"POST", "/api/v1/rules", `{"name": "Acme", "action_type": "notify", "condition": "degree == 2", "sequence": [{"action_type": "send_message"}]}`},
+		"unknown timezone":            {"POST", "/api/v1/rules", `{"name": "Acme", "action_type": "notify", "condition": "degree == 2", "timezone": "Mars/Olympus"}`},
+		"enrollments invalid id":      {"GET", "/api/v1/rules/not-a-uuid/enrollments", ""},
+		"enrollments unknown status":  {"GET", "/api/v1/rules/" + ruleID + "/enrollments?status=paused", ""},
+	}
+
+	for name, tc := range tests {
+		request := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+}
@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"errors"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SavedSearchController handles saved search HTTP requests
+type SavedSearchController struct {
+	savedSearchService *services.SavedSearchService
+}
+
+// NewSavedSearchController creates a new SavedSearchController with injected dependencies
+func NewSavedSearchController(savedSearchService *services.SavedSearchService) *SavedSearchController {
+	return &SavedSearchController{
+		savedSearchService: savedSearchService,
+	}
+}
+
+// @Summary List saved searches
+// @Description List your saved profile searches
+// @Tags saved-searches
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.SavedSearch
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/saved-searches [get]
+func (sc *SavedSearchController) List(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	searches, err := sc.savedSearchService.ListSavedSearches(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, searches)
+}
+
+// @Summary Create a saved search
+// @Description Save a profile search that is re-evaluated after every connection check.
+// @Description Profiles matching when it is created are not alerted; later matches are sent to your notification channels
+// @Tags saved-searches
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param search body models.CreateSavedSearchRequest true "Search criteria"
+// @Success 201 {object} models.SavedSearch
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /api/v1/saved-searches [post]
+func (sc *SavedSearchController) Create(c *gin.Context) {
+	var req models.CreateSavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	search, err := sc.savedSearchService.CreateSavedSearch(c.Request.Context(), userID, req)
+	if errors.Is(err, services.ErrInvalidID) || errors.Is(err, services.ErrUnknownPlace) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "List not found",
+		})
+		return
+	}
+	if errors.Is(err, services.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "A saved search with this name already exists",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, search)
+}
+
+// @Summary Delete a saved search
+// @Description Delete one of your saved searches and its recorded matches
+// @Tags saved-searches
+// @Security BearerAuth
+// @Param id path string true "Saved search ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/saved-searches/{id} [delete]
+func (sc *SavedSearchController) Delete(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	err := sc.savedSearchService.DeleteSavedSearch(c.Request.Context(), userID, c.Param("id"))
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Saved search not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List saved search matches
+// @Description List the profiles a saved search has matched, most recent first
+// @Tags saved-searches
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Saved search ID"
+// @Param limit query int false "Maximum number of matches (1-200, default 50)"
+// @Success 200 {array} models.SavedSearchMatch
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/saved-searches/{id}/matches [get]
+func (sc *SavedSearchController) ListMatches(c *gin.Context) {
+	var query models.SavedSearchMatchesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	matches, err := sc.savedSearchService.ListMatches(c.Request.Context(), userID, c.Param("id"), query)
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Saved search not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, matches)
+}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"linkedin-watcher/config"
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSavedSearchTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	notificationService := services.NewNotificationService(nil, config.SMTPConfiguration{})
+	savedSearchController := NewSavedSearchController(services.NewSavedSearchService(nil, notificationService))
+	notificationController := NewNotificationController(notificationService)
+	router.POST("/api/v1/saved-searches", withUser(testUserID, savedSearchController.Create))
+	router.DELETE("/api/v1/saved-searches/:id", withUser(testUserID, savedSearchController.Delete))
+	router.GET("/api/v1/saved-searches/:id/matches", withUser(testUserID, savedSearchController.ListMatches))
+	router.POST("/api/v1/notification-channels", withUser(testUserID, notificationController.CreateChannel))
+	router.DELETE("/api/v1/notification-channels/:id", withUser(testUserID, notificationController.DeleteChannel))
+	router.GET("/api/v1/notifications", withUser(testUserID, notificationController.List))
+	router.POST("/api/v1/notifications/:id/read", withUser(testUserID, notificationController.MarkRead))
+	return router
+}
+
+func TestSavedSearchController_BadRequest(t *testing.T) {
+	router := newSavedSearchTestRouter()
+
+	searchID := "00000000-0000-0000-0000-000000000002"
+	tests := map[string]struct {
+		method string
+		path   string
+		body   string
+	}{
+		"create without name":        {"POST", "/api/v1/saved-searches", `{"q": "platform engineer"}`},
+		"create with bad degree":     {"POST", "/api/v1/saved-searches", `{"name": "Engineers", "degree": 5}`},
+		"create with bad company":    {"POST", "/api/v1/saved-searches", `{"name": "Engineers", "company_id": "acme"}`},
+		"create with bad list":       {"POST", "/api/v1/saved-searches", `{"name": "Engineers", "list_id": "hiring"}`},
+		"create with long tag":       {"POST", "/api/v1/saved-searches", `{"name": "Engineers", "tag": "` + strings.Repeat("a", 51) + `"}`},
+		"create with bad country":    {"POST", "/api/v1/saved-searches", `{"name": "Engineers", "country": "ESP"}`},
+		"create radius without near": {"POST", "/api/v1/saved-searches", `{"name": "Engineers", "radius_km": 25}`},
+		"create near unknown place":  {"POST", "/api/v1/saved-searches", `{"name": "Engineers", "near": "Atlantis"}`},
+		"create with bad seniority":  {"POST", "/api/v1/saved-searches", `{"name": "Leaders", "min_seniority": "boss"}`},
+		"create with bad function":   {"POST", "/api/v1/saved-searches", `{"name": "Leaders", "function": "plumbing"}`},
+		"delete invalid id":          {"DELETE", "/api/v1/saved-searches/not-a-uuid", ""},
+		"matches invalid id":         {"GET", "/api/v1/saved-searches/not-a-uuid/matches", ""},
+		"matches limit too large":    {"GET", "/api/v1/saved-searches/" + searchID + "/matches?limit=500", ""},
+		"channel without target":     {"POST", "/api/v1/notification-channels", `{"channel_type": "webhook"}`},
+		"channel with unknown type":  {"POST", "/api/v1/notification-channels", `{"channel_type": "sms", "target": "+34600000000"}`},
+		"webhook with bad url":       {"POST", "/api/v1/notification-channels", `{"channel_type": "webhook", "target": "ftp://example.com/hook"}`},
+		"email with bad address":     {"POST", "/api/v1/notification-channels", `{"channel_type": "email", "target": "not-an-email"}`},
+		"delete channel invalid id":  {"DELETE", "/api/v1/notification-channels/not-a-uuid", ""},
+		"notifications limit large":  {"GET", "/api/v1/notifications?limit=201", ""},
+		"notifications bad unread":   {"GET", "/api/v1/notifications?unread_only=maybe", ""},
+		"mark read invalid id":       {"POST", "/api/v1/notifications/not-a-uuid/read", ""},
+	}
+
+	for name, tc := range tests {
+		request := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"errors"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchController handles profile search HTTP requests
+type SearchController struct {
+	searchService *services.SearchService
+}
+
+// NewSearchController creates a new SearchController with injected dependencies
+func NewSearchController(searchService *services.SearchService) *SearchController {
+	return &SearchController{
+		searchService: searchService,
+	}
+}
+
+// @Summary Search profiles
+// @Description Full-text search over profile names, headlines, locations and current companies. Results are ranked by relevance and paginated, with match counts by company, location, country, connection degree, seniority and job function
+// @Tags profiles
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Search terms (supports quoted phrases, OR and -exclusions)"
+// @Param company_id query string false "Only include profiles currently at this company"
+// @Param location query string false "Only include profiles in this exact location"
+// @Param degree query int false "Only include profiles at this connection degree (1-4)"
+// @Param list_id query string false "Only include profiles in this list"
+// @Param tag query string false "Only include profiles with this tag"
+// @Param country query string false "Only include profiles whose parsed location is in this country (ISO 3166-1 alpha-2 code)"
+// @Param near query string false "Only include profiles within radius_km of this city, e.g. Madrid or Cambridge, Massachusetts"
+// @Param radius_km query number false "Radius around near, in kilometres (requires near)" default(50)
+// @Param min_seniority query string false "Only include profiles whose headline is at least this senior" Enums(intern, entry, senior, lead, manager, director, vp, c_level)
+// @Param function query string false "Only include profiles whose headline is in this job function" Enums(engineering, data, product, design, sales, marketing, recruiting, people, finance, operations, legal, customer_success, research, consulting)
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Number of results to skip" default(0)
+// @Success 200 {object} models.ProfileSearchResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/profiles/search [get]
+func (sc *SearchController) SearchProfiles(c *gin.Context) {
+	var query models.ProfileSearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	response, err := sc.searchService.SearchProfiles(c.Request.Context(), userID, query)
+	if errors.Is(err, services.ErrUnknownPlace) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,33 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchController_SearchProfiles_InvalidQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	searchController := NewSearchController(services.NewSearchService(nil))
+	router.GET("/api/v1/profiles/search", func(c *gin.Context) {
+		c.Set("userID", "00000000-0000-0000-0000-000000000001")
+		searchController.SearchProfiles(c)
+	})
+
+	for _, query := range []string{"", "q=go&degree=7", "q=go&limit=1000", "q=go&offset=-1", "q=go&company_id=acme", "q=go&list_id=hiring",
+		"q=go&country=ESP", "q=go&country=1A", "q=go&radius_km=25", "q=go&near=Madrid&radius_km=-5", "q=go&near=Madrid&radius_km=50000", "q=go&near=Atlantis",
+		"q=go&min_seniority=boss", "q=go&function=plumbing"} {
+		request := httptest.NewRequest("GET", "/api/v1/profiles/search?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"errors"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimelineController handles profile timeline HTTP requests
+type TimelineController struct {
+	timelineService *services.TimelineService
+}
+
+// NewTimelineController creates a new TimelineController with injected dependencies
+func NewTimelineController(timelineService *services.TimelineService) *TimelineController {
+	return &TimelineController{
+		timelineService: timelineService,
+	}
+}
+
+// @Summary Get a profile's timeline
+// @Description Everything known about a profile, newest first: when it was discovered and through which tracked connection, connections gained and lost, job and headline changes, and your notes
+// @Tags profiles
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Profile ID"
+// @Param limit query int false "Maximum number of entries (1-500, default 100)"
+// @Success 200 {array} models.TimelineEntry
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/profiles/{id}/timeline [get]
+func (tc *TimelineController) Get(c *gin.Context) {
+	var query models.ProfileTimelineQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	timeline, err := tc.timelineService.GetTimeline(c.Request.Context(), userID, c.Param("id"), query)
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Profile not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, timeline)
+}
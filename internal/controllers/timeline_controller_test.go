@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimelineController_Get_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	timelineController := NewTimelineController(services.NewTimelineService(nil))
+	router.GET("/api/v1/profiles/:id/timeline", func(c *gin.Context) {
+		c.Set("userID", "00000000-0000-0000-0000-000000000001")
+		timelineController.Get(c)
+	})
+
+	for _, path := range []string{
+		"/api/v1/profiles/not-a-uuid/timeline",
+		"/api/v1/profiles/00000000-0000-0000-0000-000000000002/timeline?limit=-1",
+		"/api/v1/profiles/00000000-0000-0000-0000-000000000002/timeline?limit=1000",
+	} {
+		request := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"errors"
+	"linkedin-watcher/internal/models"
+	"linkedin-watcher/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WatchlistController handles company watchlist HTTP requests
+type WatchlistController struct {
+	watchlistService *services.WatchlistService
+}
+
+// NewWatchlistController creates a new WatchlistController with injected dependencies
+func NewWatchlistController(watchlistService *services.WatchlistService) *WatchlistController {
+	return &WatchlistController{
+		watchlistService: watchlistService,
+	}
+}
+
+// @Summary List watched companies
+// @Description List the companies on your watchlist with how many 2nd and 3rd degree profiles are known there
+// @Tags watchlist
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.WatchedCompany
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/watchlist [get]
+func (wc *WatchlistController) List(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	companies, err := wc.watchlistService.ListWatchedCompanies(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, companies)
+}
+
+// @Summary Watch a company
+// @Description Add a company to your watchlist. Whenever a connection check discovers a new 2nd or 3rd degree profile
+// @Description currently working there, you are alerted with the tracked connection that bridges to them
+// @Tags watchlist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param company body models.WatchCompanyRequest true "Company to watch"
+// @Success 201 {object} models.WatchedCompany
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /api/v1/watchlist [post]
+func (wc *WatchlistController) Watch(c *gin.Context) {
+	var req models.WatchCompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+
+	company, err := wc.watchlistService.WatchCompany(c.Request.Context(), userID, req)
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Company not found",
+		})
+		return
+	}
+	if errors.Is(err, services.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Company is already on your watchlist",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, company)
+}
+
+// @Summary Unwatch a company
+// @Description Remove a company from your watchlist
+// @Tags watchlist
+// @Security BearerAuth
+// @Param companyId path string true "Company ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/watchlist/{companyId} [delete]
+func (wc *WatchlistController) Unwatch(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+
+	err := wc.watchlistService.UnwatchCompany(c.Request.Context(), userID, c.Param("companyId"))
+	if errors.Is(err, services.ErrInvalidID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Company is not on your watchlist",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
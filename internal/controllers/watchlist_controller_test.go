@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"linkedin-watcher/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchlistController_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	watchlistController := NewWatchlistController(services.NewWatchlistService(nil, nil))
+	router.POST("/api/v1/watchlist", withUser(testUserID, watchlistController.Watch))
+	router.DELETE("/api/v1/watchlist/:companyId", withUser(testUserID, watchlistController.Unwatch))
+
+	tests := map[string]struct {
+		method string
+		path   string
+		body   string
+	}{
+		"watch without company":   {"POST", "/api/v1/watchlist", `{}`},
+		"watch invalid company":   {"POST", "/api/v1/watchlist", `{"company_id": "acme"}`},
+		"unwatch invalid company": {"DELETE", "/api/v1/watchlist/acme", ""},
+	}
+
+	for name, tc := range tests {
+		request := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		request.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, request)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+}
@@ -0,0 +1,333 @@
+// Package geo resolves free-text LinkedIn locations such as "Greater Madrid
+// Metropolitan Area" against an embedded offline gazetteer of cities,
+// first-level regions and countries. Cities and regions come from GeoNames,
+// see gen.go; countries and the aliases LinkedIn uses are maintained by hand.
+package geo
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:generate go run gen.go
+
+//go:embed cities.tsv regions.tsv countries.tsv
+var data embed.FS
+
+// Location is a parsed location. City, Latitude and Longitude are only set
+// when the text resolved to a city; a text naming just a region or a country
+// resolves to that level only.
+type Location struct {
+	City        string
+	Region      string
+	CountryCode string
+	Latitude    float64
+	Longitude   float64
+}
+
+// HasCoordinates reports whether the location resolved to a city with coordinates
+func (l Location) HasCoordinates() bool {
+	return l.City != ""
+}
+
+type city struct {
+	name       string
+	region     string
+	country    string
+	latitude   float64
+	longitude  float64
+	population int
+}
+
+type region struct {
+	name    string
+	country string
+}
+
+type gazetteer struct {
+	cities    map[string][]*city
+	regions   map[string][]*region
+	countries map[string]string
+}
+
+var (
+	loadOnce sync.Once
+	loaded   *gazetteer
+)
+
+// defaultGazetteer loads the embedded data on first use
+func defaultGazetteer() *gazetteer {
+	loadOnce.Do(func() {
+		g, err := load()
+		if err != nil {
+			panic(fmt.Sprintf("geo: invalid embedded gazetteer: %v", err))
+		}
+		loaded = g
+	})
+	return loaded
+}
+
+// Parse resolves a free-text location to the most specific place it names.
+// It reports false when nothing in the text is known.
+func Parse(raw string) (Location, bool) {
+	return defaultGazetteer().parse(raw)
+}
+
+// FindCity resolves a place name such as "Madrid" or "Cambridge, Massachusetts"
+// to a city, reporting false when it does not name a known city.
+func FindCity(name string) (Location, bool) {
+	location, ok := Parse(name)
+	if !ok || !location.HasCoordinates() {
+		return Location{}, false
+	}
+	return location, true
+}
+
+// metroPrefixes and metroSuffixes wrap a city name in LinkedIn's metro area names
+var (
+	metroPrefixes = []string{"greater "}
+	metroSuffixes = []string{
+		" metropolitan area", " metropolitan region", " metro area", " metroplex",
+		" bay area", " and surroundings", " y alrededores", " area",
+	}
+)
+
+func (g *gazetteer) parse(raw string) (Location, bool) {
+	var parts []string
+	for _, part := range strings.Split(raw, ",") {
+		if part = normalize(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return Location{}, false
+	}
+
+	qualifiers := parts[1:]
+	for _, name := range cityNames(parts[0]) {
+		if c := g.bestCity(name, qualifiers); c != nil {
+			return Location{
+				City:        c.name,
+				Region:      c.region,
+				CountryCode: c.country,
+				Latitude:    c.latitude,
+				Longitude:   c.longitude,
+			}, true
+		}
+	}
+
+	// No city: fall back to the most specific region or country mentioned
+	country := ""
+	for i := len(parts) - 1; i >= 0; i-- {
+		if code, ok := g.countries[parts[i]]; ok {
+			country = code
+			break
+		}
+	}
+	for _, part := range append(cityNames(parts[0]), qualifiers...) {
+		if r := g.findRegion(part, country); r != nil {
+			return Location{Region: r.name, CountryCode: r.country}, true
+		}
+	}
+	if country != "" {
+		return Location{CountryCode: country}, true
+	}
+
+	return Location{}, false
+}
+
+// cityNames returns the names a location's first part may give a city by,
+// most literal first: as written, without metro area wording, and the first
+// city of a hyphenated metro such as "Dallas-Fort Worth".
+func cityNames(part string) []string {
+	names := []string{part}
+	stripped := part
+	for _, prefix := range metroPrefixes {
+		stripped = strings.TrimPrefix(stripped, prefix)
+	}
+	for _, suffix := range metroSuffixes {
+		stripped = strings.TrimSuffix(stripped, suffix)
+	}
+	if stripped != part && stripped != "" {
+		names = append(names, stripped)
+	}
+	if first, _, found := strings.Cut(stripped, "-"); found && strings.TrimSpace(first) != "" {
+		names = append(names, strings.TrimSpace(first))
+	}
+	return names
+}
+
+// bestCity returns the most populous city with the name that no qualifier
+// contradicts. Qualifiers that name no known region or country are ignored.
+func (g *gazetteer) bestCity(name string, qualifiers []string) *city {
+	var best *city
+	for _, c := range g.cities[name] {
+		if !g.consistent(c, qualifiers) {
+			continue
+		}
+		if best == nil || c.population > best.population {
+			best = c
+		}
+	}
+	return best
+}
+
+func (g *gazetteer) consistent(c *city, qualifiers []string) bool {
+	for _, q := range qualifiers {
+		code, isCountry := g.countries[q]
+		regions, isRegion := g.regions[q]
+		if !isCountry && !isRegion {
+			continue
+		}
+		if isCountry && code == c.country {
+			continue
+		}
+		matched := false
+		for _, r := range regions {
+			if r.country == c.country && r.name == c.region {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// findRegion returns the region known by the name, within the country when one is given
+func (g *gazetteer) findRegion(name, country string) *region {
+	for _, r := range g.regions[name] {
+		if country == "" || r.country == country {
+			return r
+		}
+	}
+	return nil
+}
+
+// accentFolds maps the accented letters found in European and Latin American
+// place names to ASCII, so "Málaga" and "Malaga" compare equal
+var accentFolds = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a", "ă", "a", "ā", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e", "ě", "e", "ē", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i", "ī", "i", "ı", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o", "ø", "o", "ō", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u", "ů", "u", "ū", "u",
+	"ñ", "n", "ń", "n", "ç", "c", "ć", "c", "č", "c", "ł", "l", "ś", "s", "š", "s",
+	"ș", "s", "ş", "s", "ț", "t", "ţ", "t", "ř", "r", "ý", "y", "ź", "z", "ż", "z", "ž", "z",
+	"ß", "ss", "æ", "ae", "œ", "oe", "i̇", "i",
+)
+
+// normalize lowercases a name, folds accents, drops periods and collapses whitespace
+func normalize(name string) string {
+	name = accentFolds.Replace(strings.ToLower(name))
+	name = strings.ReplaceAll(name, ".", "")
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// load parses the embedded TSV files
+func load() (*gazetteer, error) {
+	g := &gazetteer{
+		cities:    make(map[string][]*city),
+		regions:   make(map[string][]*region),
+		countries: make(map[string]string),
+	}
+
+	err := readTSV("countries.tsv", 3, func(fields []string) error {
+		for _, name := range names(fields[1], fields[2]) {
+			g.countries[name] = fields[0]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readTSV("regions.tsv", 3, func(fields []string) error {
+		r := &region{name: fields[1], country: fields[0]}
+		for _, name := range names(fields[1], fields[2]) {
+			g.regions[name] = append(g.regions[name], r)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readTSV("cities.tsv", 7, func(fields []string) error {
+		latitude, err := strconv.ParseFloat(fields[4], 64)
+		if err != nil {
+			return err
+		}
+		longitude, err := strconv.ParseFloat(fields[5], 64)
+		if err != nil {
+			return err
+		}
+		population, err := strconv.Atoi(fields[6])
+		if err != nil {
+			return err
+		}
+		c := &city{
+			name:       fields[0],
+			region:     fields[2],
+			country:    fields[3],
+			latitude:   latitude,
+			longitude:  longitude,
+			population: population,
+		}
+		for _, name := range names(fields[0], fields[1]) {
+			g.cities[name] = append(g.cities[name], c)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// names returns the normalized primary name and comma separated alternates
+func names(primary, alternates string) []string {
+	result := []string{normalize(primary)}
+	seen := map[string]bool{result[0]: true}
+	for _, alternate := range strings.Split(alternates, ",") {
+		if alternate = normalize(alternate); alternate != "" && !seen[alternate] {
+			seen[alternate] = true
+			result = append(result, alternate)
+		}
+	}
+	return result
+}
+
+// readTSV calls fn with the fields of every non-comment line of an embedded file
+func readTSV(file string, columns int, fn func(fields []string) error) error {
+	f, err := data.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if len(fields) != columns {
+			return fmt.Errorf("%s:%d: expected %d columns, got %d", file, line, columns, len(fields))
+		}
+		if err := fn(fields); err != nil {
+			return fmt.Errorf("%s:%d: %w", file, line, err)
+		}
+	}
+	return scanner.Err()
+}
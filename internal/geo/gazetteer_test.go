@@ -0,0 +1,131 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	g, err := load()
+	require.NoError(t, err)
+
+	// Every city's region must be a known region of its country
+	for _, cities := range g.cities {
+		for _, c := range cities {
+			r := g.findRegion(normalize(c.region), c.country)
+			if assert.NotNil(t, r, "%s has unknown region %s", c.name, c.region) {
+				assert.Equal(t, c.region, r.name, c.name)
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		raw     string
+		city    string
+		region  string
+		country string
+	}{
+		"metro area":            {"Greater Madrid Metropolitan Area", "Madrid", "Madrid", "ES"},
+		"city region country":   {"Madrid, Community of Madrid, Spain", "Madrid", "Madrid", "ES"},
+		"accents":               {"Málaga, Andalucía, España", "Málaga", "Andalusia", "ES"},
+		"without accents":       {"Malaga", "Málaga", "Andalusia", "ES"},
+		"alternate name":        {"Donostia", "San Sebastián", "Basque Country", "ES"},
+		"bay area":              {"San Francisco Bay Area", "San Francisco", "California", "US"},
+		"state abbreviation":    {"Cambridge, MA", "Cambridge", "Massachusetts", "US"},
+		"qualified by country":  {"Cambridge, England, United Kingdom", "Cambridge", "England", "GB"},
+		"most populous":         {"Córdoba", "Córdoba", "Cordoba", "AR"},
+		"qualified homonym":     {"Córdoba, Spain", "Córdoba", "Andalusia", "ES"},
+		"hyphenated metro":      {"Washington DC-Baltimore Area", "Washington", "District of Columbia", "US"},
+		"metroplex":             {"Dallas-Fort Worth Metroplex", "Dallas", "Texas", "US"},
+		"new york city":         {"New York City Metropolitan Area", "New York", "New York", "US"},
+		"unknown qualifier":     {"Barcelona, Earth", "Barcelona", "Catalonia", "ES"},
+		"region only":           {"Catalonia, Spain", "", "Catalonia", "ES"},
+		"region alternate only": {"Comunidad Valenciana", "", "Valencia", "ES"},
+		"country only":          {"Spain", "", "", "ES"},
+		"unknown city":          {"Villarriba, Spain", "", "", "ES"},
+		"turkish dotted i":      {"İstanbul, Türkiye", "Istanbul", "Istanbul", "TR"},
+	}
+
+	for name, tc := range tests {
+		location, ok := Parse(tc.raw)
+
+		assert.True(t, ok, name)
+		assert.Equal(t, tc.city, location.City, name)
+		assert.Equal(t, tc.region, location.Region, name)
+		assert.Equal(t, tc.country, location.CountryCode, name)
+		assert.Equal(t, tc.city != "", location.HasCoordinates(), name)
+	}
+}
+
+// TestParse_LinkedInSample parses locations as LinkedIn profiles show them.
+// Cities are named as GeoNames names them, so the cases also guard a
+// regenerated gazetteer against homonyms taking over.
+func TestParse_LinkedInSample(t *testing.T) {
+	tests := []struct {
+		raw     string
+		city    string
+		region  string
+		country string
+	}{
+		{"London, England, United Kingdom", "London", "England", "GB"},
+		{"Greater London", "London", "England", "GB"},
+		{"Berlin, Berlin, Germany", "Berlin", "Berlin", "DE"},
+		{"Munich, Bavaria, Germany", "Munich", "Bavaria", "DE"},
+		{"Greater Paris Metropolitan Region", "Paris", "Île-de-France", "FR"},
+		{"Amsterdam, North Holland, Netherlands", "Amsterdam", "North Holland", "NL"},
+		{"Dublin, County Dublin, Ireland", "Dublin", "Leinster", "IE"},
+		{"Lisbon, Lisbon, Portugal", "Lisbon", "Lisbon", "PT"},
+		{"Barcelona, Catalonia, Spain", "Barcelona", "Catalonia", "ES"},
+		{"Valencia, Valencian Community, Spain", "Valencia", "Valencia", "ES"},
+		{"Seville, Andalusia, Spain", "Seville", "Andalusia", "ES"},
+		{"Warsaw, Mazowieckie, Poland", "Warsaw", "Masovia", "PL"},
+		{"Stockholm, Stockholm County, Sweden", "Stockholm", "Stockholm", "SE"},
+		{"Greater Seattle Area", "Seattle", "Washington", "US"},
+		{"Greater Boston", "Boston", "Massachusetts", "US"},
+		{"New York, New York, United States", "New York", "New York", "US"},
+		{"Austin, Texas, United States", "Austin", "Texas", "US"},
+		{"Portland, Oregon Metropolitan Area", "Portland", "Oregon", "US"},
+		{"Toronto, Ontario, Canada", "Toronto", "Ontario", "CA"},
+		{"Greater Sydney Area", "Sydney", "New South Wales", "AU"},
+		{"São Paulo, São Paulo, Brazil", "São Paulo", "São Paulo", "BR"},
+		{"Bengaluru, Karnataka, India", "Bengaluru", "Karnataka", "IN"},
+		{"Tel Aviv-Yafo, Tel Aviv District, Israel", "Tel Aviv", "Tel Aviv", "IL"},
+		{"Dubai, United Arab Emirates", "Dubai", "Dubai", "AE"},
+		{"Cape Town, Western Cape, South Africa", "Cape Town", "Western Cape", "ZA"},
+		{"Singapore", "Singapore", "Central Singapore", "SG"},
+		{"Bavaria, Germany", "", "Bavaria", "DE"},
+		{"England, United Kingdom", "", "England", "GB"},
+		{"Netherlands", "", "", "NL"},
+	}
+
+	for _, tc := range tests {
+		location, ok := Parse(tc.raw)
+
+		if assert.True(t, ok, tc.raw) {
+			assert.Equal(t, tc.city, location.City, tc.raw)
+			assert.Equal(t, tc.region, location.Region, tc.raw)
+			assert.Equal(t, tc.country, location.CountryCode, tc.raw)
+		}
+	}
+}
+
+func TestParse_Unknown(t *testing.T) {
+	for _, raw := range []string{"", " , ", "Remote", "Villarriba"} {
+		_, ok := Parse(raw)
+		assert.False(t, ok, raw)
+	}
+}
+
+func TestFindCity(t *testing.T) {
+	location, ok := FindCity("Madrid")
+	require.True(t, ok)
+	assert.InDelta(t, 40.4165, location.Latitude, 0.0001)
+	assert.InDelta(t, -3.7026, location.Longitude, 0.0001)
+
+	_, ok = FindCity("Spain")
+	assert.False(t, ok)
+}
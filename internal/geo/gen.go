@@ -0,0 +1,276 @@
+//go:build ignore
+
+// Command gen rebuilds cities.tsv and regions.tsv from the GeoNames dumps at
+// https://download.geonames.org/export/dump/:
+//
+//	go generate ./internal/geo
+//
+// Cities come from a citiesNNNNN extract, cities15000 by default, and regions
+// from admin1CodesASCII.txt. Only the columns the parser reads are kept, and
+// of the alternate names only those written in Latin script. The aliases
+// already in the files, such as US state abbreviations or "CABA", are not in
+// GeoNames and are carried over. Pass -dir to read dumps downloaded beforehand.
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"cmp"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const attribution = "# Contains data from GeoNames (https://www.geonames.org), licensed under CC BY 4.0\n"
+
+var (
+	extract = flag.String("extract", "cities15000", "GeoNames cities extract: cities500, cities1000, cities5000 or cities15000")
+	dumpURL = flag.String("url", "https://download.geonames.org/export/dump/", "base URL of the GeoNames dumps")
+	dir     = flag.String("dir", "", "directory holding the dumps, instead of downloading them")
+)
+
+// GeoNames columns, see the readme of the dumps
+const (
+	colName       = 1
+	colAlternates = 3
+	colLatitude   = 4
+	colLongitude  = 5
+	colCountry    = 8
+	colAdmin1     = 10
+	colPopulation = 14
+	citiesColumns = 19
+	admin1Columns = 4
+)
+
+type city struct {
+	name, alternates, region, country, latitude, longitude, population string
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen: ")
+	flag.Parse()
+
+	regionAliases, err := aliases("regions.tsv", 0, 1, 2)
+	if err != nil {
+		log.Fatal(err)
+	}
+	cityAliases, err := aliases("cities.tsv", 3, 0, 1)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Region names by country and admin1 code, e.g. "ES.29" for Madrid
+	regions := make(map[string]string)
+	admin1, err := fetch("admin1CodesASCII.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = eachLine(bytes.NewReader(admin1), admin1Columns, func(fields []string) {
+		regions[fields[0]] = fields[1]
+	})
+	if err != nil {
+		log.Fatalf("admin1CodesASCII.txt: %v", err)
+	}
+
+	archive, err := fetch(*extract + ".zip")
+	if err != nil {
+		log.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		log.Fatalf("%s.zip: %v", *extract, err)
+	}
+	f, err := zr.Open(*extract + ".txt")
+	if err != nil {
+		log.Fatalf("%s.zip: %v", *extract, err)
+	}
+	defer f.Close()
+
+	var cities []city
+	aliased := make(map[string]bool)
+	skipped := 0
+	err = eachLine(f, citiesColumns, func(fields []string) {
+		country := fields[colCountry]
+		region, ok := regions[country+"."+fields[colAdmin1]]
+		if !ok {
+			// Every city needs a region the parser knows, see TestLoad
+			skipped++
+			return
+		}
+		key := country + "\t" + fields[colName]
+		aliased[key] = true
+		alternates := latinNames(fields[colName], strings.Split(fields[colAlternates], ","))
+		alternates = merge(alternates, cityAliases[key])
+		cities = append(cities, city{
+			name:       fields[colName],
+			alternates: strings.Join(alternates, ","),
+			region:     region,
+			country:    country,
+			latitude:   fields[colLatitude],
+			longitude:  fields[colLongitude],
+			population: fields[colPopulation],
+		})
+	})
+	if err != nil {
+		log.Fatalf("%s.txt: %v", *extract, err)
+	}
+	slices.SortFunc(cities, func(a, b city) int {
+		return cmp.Or(cmp.Compare(a.country, b.country), cmp.Compare(a.name, b.name), cmp.Compare(a.region, b.region))
+	})
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "# Cities from the GeoNames %s extract, trimmed by gen.go:\n", *extract)
+	out.WriteString("# name, alternate names (comma separated), admin1 region, country code, latitude, longitude, population\n")
+	out.WriteString(attribution)
+	for _, c := range cities {
+		fmt.Fprintf(&out, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", c.name, c.alternates, c.region, c.country, c.latitude, c.longitude, c.population)
+	}
+	if err := os.WriteFile("cities.tsv", out.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	// Regions without a city are kept too, so a location naming only the
+	// region still resolves
+	keys := make([]string, 0, len(regions))
+	for key := range regions {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		countryA, _, _ := strings.Cut(a, ".")
+		countryB, _, _ := strings.Cut(b, ".")
+		return cmp.Or(cmp.Compare(countryA, countryB), cmp.Compare(regions[a], regions[b]))
+	})
+	out.Reset()
+	out.WriteString("# First-level administrative divisions (GeoNames admin1 names): country code, name, alternate names (comma separated)\n")
+	out.WriteString(attribution)
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		country, _, _ := strings.Cut(key, ".")
+		name := country + "\t" + regions[key]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		fmt.Fprintf(&out, "%s\t%s\n", name, strings.Join(regionAliases[name], ","))
+	}
+	if err := os.WriteFile("regions.tsv", out.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	for name := range regionAliases {
+		if !seen[name] {
+			log.Printf("aliases of region %q dropped: not in GeoNames", name)
+		}
+	}
+	for name := range cityAliases {
+		if !aliased[name] {
+			log.Printf("aliases of city %q dropped: not in %s", name, *extract)
+		}
+	}
+	log.Printf("%d cities, %d regions; %d cities without a known region skipped", len(cities), len(seen), skipped)
+}
+
+// fetch reads a dump from -dir, or downloads it
+func fetch(name string) ([]byte, error) {
+	if *dir != "" {
+		return os.ReadFile(filepath.Join(*dir, name))
+	}
+	resp, err := http.Get(*dumpURL + name)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", name, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// aliases reads the alternate names of the current file, by country and name
+func aliases(file string, countryCol, nameCol, aliasCol int) (map[string][]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	result := make(map[string][]string)
+	err = eachLine(f, 0, func(fields []string) {
+		if len(fields) <= aliasCol || fields[aliasCol] == "" {
+			return
+		}
+		key := fields[countryCol] + "\t" + fields[nameCol]
+		result[key] = merge(result[key], strings.Split(fields[aliasCol], ","))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return result, nil
+}
+
+// eachLine calls fn with the fields of every non-comment line, checking
+// their number unless columns is 0
+func eachLine(r io.Reader, columns int, fn func(fields []string)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if columns != 0 && len(fields) != columns {
+			return fmt.Errorf("line %d: expected %d columns, got %d", line, columns, len(fields))
+		}
+		fn(fields)
+	}
+	return scanner.Err()
+}
+
+// latinNames returns the alternate names written in Latin script, other than
+// the name itself. Short upper case names are airport and other codes, which
+// would shadow region abbreviations such as "CA".
+func latinNames(name string, alternates []string) []string {
+	var result []string
+	for _, alternate := range alternates {
+		alternate = strings.TrimSpace(alternate)
+		if alternate == "" || alternate == name || strings.Contains(alternate, "\t") {
+			continue
+		}
+		if len(alternate) <= 4 && strings.ToUpper(alternate) == alternate {
+			continue
+		}
+		latin := true
+		for _, r := range alternate {
+			if !unicode.In(r, unicode.Latin) && !strings.ContainsRune(" -'’.()", r) {
+				latin = false
+				break
+			}
+		}
+		if latin {
+			result = append(result, alternate)
+		}
+	}
+	return merge(nil, result)
+}
+
+// merge appends the names not in names yet
+func merge(names, more []string) []string {
+	for _, name := range more {
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,80 @@
+package jobs
+
+import (
+	"context"
+	"linkedin-watcher/infra/logger"
+	"sync"
+	"time"
+)
+
+// Job is a unit of background work run periodically by the Scheduler
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs in the background, each on its own interval.
+// A job never overlaps with itself: the next run starts only after the previous one returns.
+type Scheduler struct {
+	jobs []Job
+	wg   sync.WaitGroup
+}
+
+// NewScheduler creates an empty Scheduler
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Register adds a job to the scheduler. Jobs must be registered before Start.
+func (s *Scheduler) Register(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start launches every registered job. Each job runs once immediately and then
+// on every tick of its interval until ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func(job Job) {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}(job)
+		logger.Infof("Scheduled job %s every %s", job.Name, job.Interval)
+	}
+}
+
+// Wait blocks until every job loop has exited after ctx is cancelled
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf("Job %s panicked: %v", job.Name, r)
+		}
+	}()
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		logger.Errorf("Job %s failed after %s: %v", job.Name, time.Since(start), err)
+		return
+	}
+	logger.Infof("Job %s completed in %s", job.Name, time.Since(start))
+}
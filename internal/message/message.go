@@ -0,0 +1,228 @@
+// Package message renders the notes automation rules attach to connection
+// requests. Templates refer to profile variables in double braces, with an
+// optional quoted fallback for profiles missing the value:
+//
+//	Hi {{first_name | "there"}}, {{mutual_connection}} suggested I reach out.
+//
+// Templates are checked when they are parsed, so a parsed Template always
+// renders.
+package message
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxNoteLength is the longest note LinkedIn accepts on a connection request
+const MaxNoteLength = 300
+
+// Vars are the profile values a template can refer to. Empty values are
+// missing and use the variable's fallback.
+type Vars struct {
+	Name     string
+	Company  string
+	Headline string
+	Location string
+	// Mutual is the name of the tracked connection bridging to the profile
+	Mutual string
+}
+
+// Rendered is a template rendered for one profile
+type Rendered struct {
+	Text string
+	// Length is the note's length in characters, as LinkedIn counts it
+	Length int
+	// TooLong reports whether the note exceeds MaxNoteLength
+	TooLong bool
+	// Missing lists the variables the profile had no value for and that had no fallback
+	Missing []string
+}
+
+// Template is a parsed note template
+type Template struct {
+	parts []part
+}
+
+// part is literal text, or a variable when name is set
+type part struct {
+	text     string
+	name     string
+	fallback string
+}
+
+// Error is a template syntax error, with the column it was found at
+type Error struct {
+	Column int
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+}
+
+// variables are the names a template can refer to
+var variables = map[string]func(Vars) string{
+	"first_name":        func(v Vars) string { return FirstName(v.Name) },
+	"last_name":         func(v Vars) string { return lastName(v.Name) },
+	"full_name":         func(v Vars) string { return displayName(v.Name) },
+	"company":           func(v Vars) string { return v.Company },
+	"headline":          func(v Vars) string { return v.Headline },
+	"location":          func(v Vars) string { return v.Location },
+	"mutual_connection": func(v Vars) string { return displayName(v.Mutual) },
+	"mutual_first_name": func(v Vars) string { return FirstName(v.Mutual) },
+}
+
+// Variables returns the names a template can refer to
+func Variables() []string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// placeholder matches the inside of {{ }}: a variable and an optional fallback
+var placeholder = regexp.MustCompile(`^\s*([A-Za-z_]+)\s*(?:\|\s*("(?:[^"\\]|\\.)*")\s*)?$`)
+
+// Parse parses a template, rejecting unknown variables and unbalanced braces
+func Parse(src string) (*Template, error) {
+	t := &Template{}
+	rest := src
+	offset := 0
+	for rest != "" {
+		open := strings.Index(rest, "{{")
+		if close := strings.Index(rest, "}}"); close >= 0 && (open < 0 || close < open) {
+			return nil, errorAt(src, offset+close, "unexpected \"}}\"")
+		}
+		if open < 0 {
+			t.parts = append(t.parts, part{text: rest})
+			break
+		}
+		if open > 0 {
+			t.parts = append(t.parts, part{text: rest[:open]})
+		}
+
+		end := strings.Index(rest[open:], "}}")
+		if end < 0 {
+			return nil, errorAt(src, offset+open, "unclosed \"{{\"")
+		}
+		inner := rest[open+2 : open+end]
+		m := placeholder.FindStringSubmatch(inner)
+		if m == nil {
+			return nil, errorAt(src, offset+open, "expected {{variable}} or {{variable | \"fallback\"}}")
+		}
+		name := strings.ToLower(m[1])
+		if _, ok := variables[name]; !ok {
+			return nil, errorAt(src, offset+open, fmt.Sprintf("unknown variable %q (variables: %s)", m[1], strings.Join(Variables(), ", ")))
+		}
+		fallback := ""
+		if m[2] != "" {
+			var err error
+			if fallback, err = strconv.Unquote(m[2]); err != nil {
+				return nil, errorAt(src, offset+open, fmt.Sprintf("invalid fallback %s", m[2]))
+			}
+		}
+		t.parts = append(t.parts, part{name: name, fallback: fallback})
+
+		next := open + end + 2
+		rest = rest[next:]
+		offset += next
+	}
+	return t, nil
+}
+
+// errorAt returns an Error for the byte at index pos of src
+func errorAt(src string, pos int, msg string) *Error {
+	return &Error{Column: utf8.RuneCountInString(src[:pos]) + 1, Msg: msg}
+}
+
+// Render renders the template for a profile. Missing values without a
+// fallback render empty, and the spaces left around them are tidied away.
+func (t *Template) Render(vars Vars) Rendered {
+	var b strings.Builder
+	var missing []string
+	for _, p := range t.parts {
+		if p.name == "" {
+			b.WriteString(p.text)
+			continue
+		}
+		value := strings.TrimSpace(variables[p.name](vars))
+		if value == "" {
+			value = p.fallback
+			if value == "" {
+				missing = append(missing, p.name)
+			}
+		}
+		b.WriteString(value)
+	}
+
+	text := tidy(b.String())
+	length := utf8.RuneCountInString(text)
+	return Rendered{
+		Text:    text,
+		Length:  length,
+		TooLong: length > MaxNoteLength,
+		Missing: missing,
+	}
+}
+
+var (
+	repeatedSpaces   = regexp.MustCompile(`[ \t]{2,}`)
+	spaceBeforePunct = regexp.MustCompile(`[ \t]+([,.!?;:])`)
+)
+
+// tidy collapses the spaces a missing value leaves behind, as in "Hi , I saw"
+func tidy(text string) string {
+	text = repeatedSpaces.ReplaceAllString(text, " ")
+	text = spaceBeforePunct.ReplaceAllString(text, "$1")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// honorifics are dropped from the start of a name before taking the first name
+var honorifics = map[string]bool{
+	"dr": true, "dr.": true, "mr": true, "mr.": true, "mrs": true, "mrs.": true,
+	"ms": true, "ms.": true, "prof": true, "prof.": true,
+}
+
+// displayName drops the credentials LinkedIn users add after a comma, as in "Ada Lovelace, PhD"
+func displayName(name string) string {
+	if i := strings.Index(name, ","); i >= 0 {
+		name = name[:i]
+	}
+	return strings.TrimSpace(name)
+}
+
+// nameParts returns the words of a display name without leading honorifics
+func nameParts(name string) []string {
+	words := strings.Fields(displayName(name))
+	for len(words) > 1 && honorifics[strings.ToLower(words[0])] {
+		words = words[1:]
+	}
+	return words
+}
+
+// FirstName returns the first name of a LinkedIn display name
+func FirstName(name string) string {
+	words := nameParts(name)
+	if len(words) == 0 {
+		return ""
+	}
+	return words[0]
+}
+
+func lastName(name string) string {
+	words := nameParts(name)
+	if len(words) < 2 {
+		return ""
+	}
+	return words[len(words)-1]
+}
@@ -0,0 +1,91 @@
+package message
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	ada := Vars{
+		Name:     "Dr. Ada Lovelace, PhD",
+		Company:  "Acme",
+		Headline: "Founder & CTO at Acme",
+		Mutual:   "Jane Doe",
+	}
+
+	tests := map[string]struct {
+		src  string
+		vars Vars
+		want string
+	}{
+		"variables":         {`Hi {{first_name}}, {{mutual_first_name}} said you run {{company}}.`, ada, "Hi Ada, Jane said you run Acme."},
+		"full names":        {`{{full_name}} / {{last_name}} / {{mutual_connection}}`, ada, "Dr. Ada Lovelace / Lovelace / Jane Doe"},
+		"spaces and case":   {`Hi {{ First_Name }}!`, ada, "Hi Ada!"},
+		"fallback":          {`Hi {{first_name | "there"}}, love what {{company | "your team"}} does`, Vars{}, "Hi there, love what your team does"},
+		"fallback unused":   {`Hi {{first_name | "there"}}`, ada, "Hi Ada"},
+		"missing tidied":    {`Hi {{first_name}}, I saw {{company}} is hiring`, Vars{Name: "Ada"}, "Hi Ada, I saw is hiring"},
+		"missing before ,":  {`Hi {{first_name}}, welcome`, Vars{}, "Hi, welcome"},
+		"lines kept":        {"Hi {{first_name}},\n\nLet's connect", ada, "Hi Ada,\n\nLet's connect"},
+		"single braces":     {`{ not a variable }`, ada, "{ not a variable }"},
+		"escaped fallback":  {`Hi {{first_name | "\"friend\""}}`, Vars{}, `Hi "friend"`},
+		"headline location": {`{{headline}} in {{location | "town"}}`, ada, "Founder & CTO at Acme in town"},
+	}
+
+	for name, tc := range tests {
+		tmpl, err := Parse(tc.src)
+		if assert.NoError(t, err, name) {
+			assert.Equal(t, tc.want, tmpl.Render(tc.vars).Text, name)
+		}
+	}
+}
+
+func TestRender_MissingAndLength(t *testing.T) {
+	tmpl, err := Parse(`Hi {{first_name}}, {{mutual_connection}} mentioned {{company | "you"}}`)
+	require.NoError(t, err)
+
+	rendered := tmpl.Render(Vars{Name: "Ada"})
+	assert.Equal(t, []string{"mutual_connection"}, rendered.Missing)
+	assert.False(t, rendered.TooLong)
+
+	tmpl, err = Parse(strings.Repeat("ñ", MaxNoteLength-4) + " {{first_name}}")
+	require.NoError(t, err)
+
+	rendered = tmpl.Render(Vars{Name: "Ada"})
+	assert.Equal(t, MaxNoteLength, rendered.Length)
+	assert.False(t, rendered.TooLong)
+	rendered = tmpl.Render(Vars{Name: "Grace"})
+	assert.Equal(t, MaxNoteLength+2, rendered.Length)
+	assert.True(t, rendered.TooLong)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]struct {
+		src    string
+		column int
+	}{
+		"unknown variable":   {`Hi {{nickname}}`, 4},
+		"unclosed":           {`Hi {{first_name`, 4},
+		"stray close":        {`Hi first_name}}`, 14},
+		"empty placeholder":  {`Hi {{ }}`, 4},
+		"unquoted fallback":  {`Hi {{first_name | there}}`, 4},
+		"column after multi": {`¡Hola {{first_name}}! {{title}}`, 23},
+	}
+
+	for name, tc := range tests {
+		_, err := Parse(tc.src)
+		var parseErr *Error
+		if assert.ErrorAs(t, err, &parseErr, name) {
+			assert.Equal(t, tc.column, parseErr.Column, "%s: %v", name, err)
+		}
+	}
+}
+
+func TestFirstName(t *testing.T) {
+	assert.Equal(t, "Ada", FirstName("Ada Lovelace"))
+	assert.Equal(t, "Ada", FirstName("Prof. Ada Lovelace, MBA"))
+	assert.Equal(t, "Cher", FirstName("Cher"))
+	assert.Equal(t, "", FirstName(" "))
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// NetworkGrowthQuery represents the options for the network growth series
+type NetworkGrowthQuery struct {
+	Interval         string    `form:"interval" binding:"omitempty,oneof=day week"`
+	Since            time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	TrackedProfileID string    `form:"tracked_profile_id" binding:"omitempty,uuid"`
+}
+
+// NetworkGrowthPoint represents how the network changed over one day or week
+type NetworkGrowthPoint struct {
+	PeriodStart  time.Time `json:"period_start"`
+	SecondDegree int       `json:"second_degree"`
+	ThirdDegree  int       `json:"third_degree"`
+	Removed      int       `json:"removed"`
+}
+
+// NetworkCompositionQuery represents the options for the network composition series
+type NetworkCompositionQuery struct {
+	Dimension        string    `form:"dimension" binding:"required,oneof=company location country"`
+	Interval         string    `form:"interval" binding:"omitempty,oneof=day week"`
+	Since            time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	TrackedProfileID string    `form:"tracked_profile_id" binding:"omitempty,uuid"`
+	Top              int       `form:"top" binding:"omitempty,min=1,max=50"`
+}
+
+// NetworkCompositionPeriod represents the most common companies, locations or
+// countries among the profiles discovered over one day or week
+type NetworkCompositionPeriod struct {
+	PeriodStart time.Time                 `json:"period_start"`
+	Values      []NetworkCompositionValue `json:"values"`
+}
+
+// NetworkCompositionValue represents how many discovered profiles share a company, location or country
+type NetworkCompositionValue struct {
+	Value    string `json:"value"`
+	Profiles int    `json:"profiles"`
+}
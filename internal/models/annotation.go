@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// SetProfileTagsRequest represents the full set of tags to keep on a profile
+type SetProfileTagsRequest struct {
+	Tags []string `json:"tags" binding:"max=50,dive,required,max=50"`
+}
+
+// ProfileTags represents the tags a user has put on a profile
+type ProfileTags struct {
+	ProfileID string   `json:"profile_id"`
+	Tags      []string `json:"tags"`
+}
+
+// TagCount represents a tag and how many profiles carry it
+type TagCount struct {
+	Tag          string `json:"tag"`
+	ProfileCount int    `json:"profile_count"`
+}
+
+// ProfileNoteRequest represents the data needed to write a private note
+type ProfileNoteRequest struct {
+	Body string `json:"body" binding:"required,max=10000"`
+}
+
+// ProfileNote represents a private note on a profile
+type ProfileNote struct {
+	ID        string    `json:"id"`
+	ProfileID string    `json:"profile_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
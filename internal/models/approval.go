@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// PendingApprovalsQuery represents the filter and paging for your approval queue
+type PendingApprovalsQuery struct {
+	RuleID string `form:"rule_id" binding:"omitempty,uuid"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// PendingApproval represents an action a rule requiring approval queued for
+// you, with the message it will send. TooLong flags connection notes over
+// LinkedIn's 300 character limit, which fail unless shortened.
+type PendingApproval struct {
+	ID         string     `json:"id"`
+	RuleID     string     `json:"rule_id"`
+	RuleName   string     `json:"rule_name"`
+	ActionType string     `json:"action_type"`
+	Profile    ProfileRef `json:"profile"`
+	Message    string     `json:"message,omitempty"`
+	Length     int        `json:"length"`
+	TooLong    bool       `json:"too_long"`
+	QueuedAt   time.Time  `json:"queued_at"`
+}
+
+// PendingApprovalUpdate represents an edited message for a queued action
+type PendingApprovalUpdate struct {
+	Message *string `json:"message" binding:"required,max=2000"`
+}
+
+// ApprovalDecisionRequest represents queued actions to approve or reject
+type ApprovalDecisionRequest struct {
+	IDs []string `json:"ids" binding:"required,min=1,max=100,dive,uuid"`
+}
+
+// ApprovalDecision represents the queued actions a decision applied to. IDs
+// left out were not awaiting approval, e.g. because they were decided already.
+type ApprovalDecision struct {
+	IDs []string `json:"ids"`
+}
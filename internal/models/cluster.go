@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// NetworkCluster represents a community detected in the user's network
+type NetworkCluster struct {
+	ID           string    `json:"id"`
+	Label        string    `json:"label"`
+	Size         int       `json:"size"`
+	TopCompanies []string  `json:"top_companies"`
+	TopLocations []string  `json:"top_locations"`
+	ComputedAt   time.Time `json:"computed_at"`
+}
+
+// ClusterProfile represents a profile assigned to a network cluster
+type ClusterProfile struct {
+	ID          string `json:"id"`
+	LinkedinURL string `json:"linkedin_url"`
+	Name        string `json:"name"`
+	Location    string `json:"location,omitempty"`
+	Headline    string `json:"headline,omitempty"`
+	Company     string `json:"company,omitempty"`
+}
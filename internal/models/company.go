@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// CreateCompanyRequest represents the data needed to add a company
+type CreateCompanyRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	LinkedinURL string `json:"linkedin_url" binding:"omitempty,url,max=500"`
+	Industry    string `json:"industry" binding:"omitempty,max=255"`
+}
+
+// MergeCompanyRequest represents a duplicate company to fold into another
+type MergeCompanyRequest struct {
+	SourceCompanyID string `json:"source_company_id" binding:"required,uuid"`
+}
+
+// Company represents a company
+type Company struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	NormalizedName string    `json:"normalized_name"`
+	LinkedinURL    string    `json:"linkedin_url,omitempty"`
+	Industry       string    `json:"industry,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// CompanyDetail represents a company with its aliases and headcount
+type CompanyDetail struct {
+	Company
+	Aliases          []string `json:"aliases"`
+	CurrentEmployees int      `json:"current_employees"`
+}
+
+// CompanyPeopleQuery represents the filters for listing the people at a company
+type CompanyPeopleQuery struct {
+	IncludeFormer bool `form:"include_former"`
+}
+
+// ProfileRef represents a short reference to a LinkedIn profile
+type ProfileRef struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	LinkedinURL string `json:"linkedin_url"`
+}
+
+// CompanyPerson represents a known profile currently or formerly at a company
+type CompanyPerson struct {
+	ID          string     `json:"id"`
+	LinkedinURL string     `json:"linkedin_url"`
+	Name        string     `json:"name"`
+	Location    string     `json:"location,omitempty"`
+	Headline    string     `json:"headline,omitempty"`
+	Position    string     `json:"position,omitempty"`
+	StartDate   *time.Time `json:"start_date,omitempty"`
+	EndDate     *time.Time `json:"end_date,omitempty"`
+	IsCurrent   bool       `json:"is_current"`
+	// BestDegree is the closest connection degree to the user, absent when unconnected
+	BestDegree *int `json:"best_degree,omitempty"`
+	// ConnectedVia is the tracked connection that links the user to this person
+	ConnectedVia *ProfileRef `json:"connected_via,omitempty"`
+}
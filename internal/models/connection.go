@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// NewConnectionSort represents the ordering of new connection listings
+type NewConnectionSort string
+
+const (
+	NewConnectionSortRecent       NewConnectionSort = "recent"
+	NewConnectionSortNetworkScore NewConnectionSort = "network_score"
+	NewConnectionSortDegree       NewConnectionSort = "degree"
+	NewConnectionSortBetweenness  NewConnectionSort = "betweenness"
+)
+
+// NewConnectionsQuery represents the filters for listing newly discovered connections
+type NewConnectionsQuery struct {
+	Since           time.Time         `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Sort            NewConnectionSort `form:"sort" binding:"omitempty,oneof=recent network_score degree betweenness"`
+	MinNetworkScore *float64          `form:"min_network_score" binding:"omitempty,min=0,max=1"`
+	ClusterID       string            `form:"cluster_id" binding:"omitempty,uuid"`
+}
+
+// NewConnection represents a profile newly discovered through the user's tracked connections
+type NewConnection struct {
+	ID               string    `json:"id"`
+	LinkedinURL      string    `json:"linkedin_url"`
+	Name             string    `json:"name"`
+	Location         string    `json:"location,omitempty"`
+	Headline         string    `json:"headline,omitempty"`
+	Company          string    `json:"company,omitempty"`
+	ConnectionDegree int       `json:"connection_degree"`
+	NetworkDegree    int       `json:"network_degree"`
+	Betweenness      float64   `json:"betweenness"`
+	NetworkScore     float64   `json:"network_score"`
+	ClusterID        string    `json:"cluster_id,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// DuplicateCandidatesQuery represents the filters for listing duplicate candidates
+type DuplicateCandidatesQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending rejected"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// DuplicateProfile represents one of the two profiles of a duplicate candidate
+type DuplicateProfile struct {
+	ID          string `json:"id"`
+	LinkedinURL string `json:"linkedin_url"`
+	Name        string `json:"name"`
+	Location    string `json:"location,omitempty"`
+	Headline    string `json:"headline,omitempty"`
+	Company     string `json:"company,omitempty"`
+}
+
+// DuplicateCandidate represents two profiles that likely describe the same person
+type DuplicateCandidate struct {
+	ID    string  `json:"id"`
+	Score float64 `json:"score"`
+	// Reasons lists the signals that matched, e.g. same_name, similar_url, same_company
+	Reasons    []string         `json:"reasons"`
+	Status     string           `json:"status"`
+	Profile    DuplicateProfile `json:"profile"`
+	Duplicate  DuplicateProfile `json:"duplicate"`
+	ReviewedAt *time.Time       `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// ConfirmDuplicateRequest represents which profile of a confirmed pair to keep.
+// When omitted, the profile with a LinkedIn ID, or else the older one, is kept.
+type ConfirmDuplicateRequest struct {
+	KeepProfileID string `json:"keep_profile_id" binding:"omitempty,uuid"`
+}
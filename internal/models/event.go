@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// JobChangesQuery represents the filters for the job change feed
+type JobChangesQuery struct {
+	Since     time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	CompanyID string    `form:"company_id" binding:"omitempty,uuid"`
+	Limit     int       `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// JobChange represents a profile in the user's network moving to a new company or position
+type JobChange struct {
+	ID           string    `json:"id"`
+	ProfileID    string    `json:"profile_id"`
+	ProfileName  string    `json:"profile_name"`
+	LinkedinURL  string    `json:"linkedin_url"`
+	OldCompanyID string    `json:"old_company_id,omitempty"`
+	OldCompany   string    `json:"old_company,omitempty"`
+	OldPosition  string    `json:"old_position,omitempty"`
+	NewCompanyID string    `json:"new_company_id,omitempty"`
+	NewCompany   string    `json:"new_company,omitempty"`
+	NewPosition  string    `json:"new_position,omitempty"`
+	DetectedAt   time.Time `json:"detected_at"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// ExecutionWindowRequest represents the hours your rules may act in. Weekdays
+// are ISO day numbers, 1 for Monday to 7 for Sunday, Start and End are HH:MM
+// and Holidays are YYYY-MM-DD dates skipped entirely. Timezone is an IANA
+// name such as Europe/Madrid; omitting it keeps your current one.
+type ExecutionWindowRequest struct {
+	Timezone string   `json:"timezone" binding:"omitempty,max=64"`
+	Weekdays []int    `json:"weekdays" binding:"required,min=1,max=7,dive,min=1,max=7"`
+	Start    string   `json:"start" binding:"required,datetime=15:04"`
+	End      string   `json:"end" binding:"required,datetime=15:04"`
+	Holidays []string `json:"holidays" binding:"omitempty,max=366,dive,datetime=2006-01-02"`
+}
+
+// ExecutionWindow represents when your rules act. Without a window, Restricted
+// is false and they act at any time. The window is evaluated in Timezone, or
+// in a rule's own timezone for that rule; OpenNow and NextOpenAt are for yours.
+type ExecutionWindow struct {
+	Timezone   string     `json:"timezone"`
+	Restricted bool       `json:"restricted"`
+	Weekdays   []int      `json:"weekdays,omitempty"`
+	Start      string     `json:"start,omitempty"`
+	End        string     `json:"end,omitempty"`
+	Holidays   []string   `json:"holidays,omitempty"`
+	OpenNow    bool       `json:"open_now"`
+	NextOpenAt *time.Time `json:"next_open_at,omitempty"`
+}
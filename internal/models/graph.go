@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// GraphFormat represents a supported network export format
+type GraphFormat string
+
+const (
+	GraphFormatGraphML GraphFormat = "graphml"
+	GraphFormatGEXF    GraphFormat = "gexf"
+	GraphFormatDOT     GraphFormat = "dot"
+	GraphFormatJSON    GraphFormat = "json"
+)
+
+// IsValid reports whether the format is one we know how to export
+func (f GraphFormat) IsValid() bool {
+	switch f {
+	case GraphFormatGraphML, GraphFormatGEXF, GraphFormatDOT, GraphFormatJSON:
+		return true
+	}
+	return false
+}
+
+// ContentType returns the MIME type used when serving the format
+func (f GraphFormat) ContentType() string {
+	switch f {
+	case GraphFormatGraphML:
+		return "application/graphml+xml"
+	case GraphFormatGEXF:
+		return "application/gexf+xml"
+	case GraphFormatDOT:
+		return "text/vnd.graphviz"
+	default:
+		return "application/json"
+	}
+}
+
+// FileExtension returns the conventional file extension for the format
+func (f GraphFormat) FileExtension() string {
+	switch f {
+	case GraphFormatGraphML:
+		return "graphml"
+	case GraphFormatGEXF:
+		return "gexf"
+	case GraphFormatDOT:
+		return "dot"
+	default:
+		return "json"
+	}
+}
+
+// GraphNode represents a profile in an exported network graph
+type GraphNode struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	LinkedinURL string   `json:"linkedin_url"`
+	Headline    string   `json:"headline,omitempty"`
+	Company     string   `json:"company,omitempty"`
+	Location    string   `json:"location,omitempty"`
+	Tracked     bool     `json:"tracked"`
+	Tags        []string `json:"tags,omitempty"`
+	Lists       []string `json:"lists,omitempty"`
+}
+
+// GraphEdge represents a connection relationship in an exported network graph
+type GraphEdge struct {
+	ID           string    `json:"id"`
+	Source       string    `json:"source"`
+	Target       string    `json:"target"`
+	Degree       int32     `json:"degree"`
+	DiscoveredAt time.Time `json:"discovered_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// InvitationQuotaRequest represents the invitation limits of your LinkedIn account
+type InvitationQuotaRequest struct {
+	DailyLimit  *int `json:"daily_limit" binding:"required,min=0,max=100"`
+	WeeklyLimit *int `json:"weekly_limit" binding:"required,min=0,max=200"`
+}
+
+// InvitationQuota represents how many connection requests your rules may still
+// send. The day runs midnight to midnight in your timezone and the week is
+// the last seven days, as LinkedIn counts it. NextSendAt is when pacing
+// allows the next request, and is omitted while a limit is zero.
+type InvitationQuota struct {
+	DailyLimit        int        `json:"daily_limit"`
+	WeeklyLimit       int        `json:"weekly_limit"`
+	SentToday         int        `json:"sent_today"`
+	SentThisWeek      int        `json:"sent_this_week"`
+	RemainingToday    int        `json:"remaining_today"`
+	RemainingThisWeek int        `json:"remaining_this_week"`
+	NextSendAt        *time.Time `json:"next_send_at,omitempty"`
+	CanSendNow        bool       `json:"can_send_now"`
+	PausedUntil       *time.Time `json:"paused_until,omitempty"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// ProfileListRequest represents the data needed to create or rename a list
+type ProfileListRequest struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description" binding:"omitempty,max=2000"`
+}
+
+// ListMemberRequest represents a profile to add to a list
+type ListMemberRequest struct {
+	ProfileID string `json:"profile_id" binding:"required,uuid"`
+}
+
+// ProfileList represents a named list of profiles
+type ProfileList struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	MemberCount int       `json:"member_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ListMember represents a profile in a list
+type ListMember struct {
+	ID          string    `json:"id"`
+	LinkedinURL string    `json:"linkedin_url"`
+	Name        string    `json:"name"`
+	Location    string    `json:"location,omitempty"`
+	Headline    string    `json:"headline,omitempty"`
+	Company     string    `json:"company,omitempty"`
+	AddedAt     time.Time `json:"added_at"`
+}
+
+// ProfileListDetail represents a list with its members, most recently added first
+type ProfileListDetail struct {
+	ProfileList
+	Members []ListMember `json:"members"`
+}
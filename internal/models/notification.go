@@ -0,0 +1,38 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CreateNotificationChannelRequest represents a webhook URL or email address to deliver alerts to
+type CreateNotificationChannelRequest struct {
+	ChannelType string `json:"channel_type" binding:"required,oneof=webhook email"`
+	Target      string `json:"target" binding:"required,max=500"`
+}
+
+// NotificationChannel represents where a user's alerts are delivered
+type NotificationChannel struct {
+	ID          string    `json:"id"`
+	ChannelType string    `json:"channel_type"`
+	Target      string    `json:"target"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NotificationsQuery represents the filters for listing the in-app inbox
+type NotificationsQuery struct {
+	UnreadOnly bool `form:"unread_only"`
+	Limit      int  `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// Notification represents an alert in the in-app inbox
+type Notification struct {
+	ID        string          `json:"id"`
+	Kind      string          `json:"kind"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body"`
+	Payload   json.RawMessage `json:"payload" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at"`
+	ReadAt    *time.Time      `json:"read_at,omitempty"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// ProfileSnapshot represents a profile as scraped from LinkedIn
+type ProfileSnapshot struct {
+	Name      string             `json:"name" binding:"required"`
+	Location  string             `json:"location,omitempty"`
+	Headline  string             `json:"headline,omitempty"`
+	Positions []PositionSnapshot `json:"positions" binding:"dive"`
+}
+
+// PositionSnapshot represents one entry of a scraped profile's employment history
+type PositionSnapshot struct {
+	Company   string     `json:"company" binding:"required"`
+	Position  string     `json:"position"`
+	StartDate time.Time  `json:"start_date" binding:"required"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	IsCurrent bool       `json:"is_current"`
+}
+
+// ProfileSync reports the changes a re-scraped profile showed
+type ProfileSync struct {
+	JobChange      bool `json:"job_change"`
+	HeadlineChange bool `json:"headline_change"`
+}
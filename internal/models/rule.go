@@ -0,0 +1,166 @@
+package models
+
+import "time"
+
+// AutomationRuleRequest represents an automation rule to create or replace. A
+// rule needs at least one filter, so it never fires for every new profile.
+// Condition is an expression over the candidate profile, such as
+// company in ["Acme", "Globex"] and (headline matches "founder|cto" or degree <= 2).
+// RequiresApproval queues the rule's actions for your approval instead of
+// performing them. Sequence lists follow-up steps for the profiles the rule's
+// action succeeded on. Timezone is an IANA name such as Europe/Madrid to
+// evaluate your execution window in for this rule, instead of yours.
+type AutomationRuleRequest struct {
+	Name             string         `json:"name" binding:"required,max=255"`
+	TriggerType      string         `json:"trigger_type" binding:"omitempty,oneof=new_connection job_change"`
+	ActionType       string         `json:"action_type" binding:"required,oneof=send_connection_request save_profile notify"`
+	MessageTemplate  string         `json:"message_template"`
+	IsActive         *bool          `json:"is_active"`
+	Condition        string         `json:"condition" binding:"omitempty,max=1000"`
+	MinNetworkScore  *float64       `json:"min_network_score" binding:"omitempty,min=0"`
+	ListID           string         `json:"list_id" binding:"omitempty,uuid"`
+	Country          string         `json:"country" binding:"omitempty,len=2,alpha"`
+	Near             string         `json:"near" binding:"omitempty,max=255"`
+	RadiusKm         float64        `json:"radius_km" binding:"omitempty,gt=0,max=20000,excluded_without=Near"`
+	MinSeniority     string         `json:"min_seniority" binding:"omitempty,oneof=intern entry senior lead manager director vp c_level"`
+	Function         string         `json:"function" binding:"omitempty,oneof=engineering data product design sales marketing recruiting people finance operations legal customer_success research consulting"`
+	RequiresApproval bool           `json:"requires_approval"`
+	Sequence         []SequenceStep `json:"sequence" binding:"omitempty,max=10,dive"`
+	Timezone         string         `json:"timezone" binding:"omitempty,max=64"`
+}
+
+// SequenceStep represents a follow-up step of a rule's sequence. The step waits
+// WaitDays days after the previous one, or until its Until event if that comes
+// first, then performs its action; any ExitOn event observed meanwhile ends the
+// sequence instead. Events are accepted, once the profile accepts the
+// connection request, and replied, once they answer a message.
+type SequenceStep struct {
+	WaitDays        int      `json:"wait_days" binding:"min=0,max=90"`
+	Until           string   `json:"until,omitempty" binding:"omitempty,oneof=accepted replied"`
+	ExitOn          []string `json:"exit_on,omitempty" binding:"omitempty,max=2,dive,oneof=accepted replied"`
+	ActionType      string   `json:"action_type" binding:"required,oneof=send_message save_profile notify"`
+	MessageTemplate string   `json:"message_template,omitempty"`
+}
+
+// AutomationRule represents an action taken for profiles matching a rule's filters
+type AutomationRule struct {
+	ID               string         `json:"id"`
+	Name             string         `json:"name"`
+	TriggerType      string         `json:"trigger_type"`
+	ActionType       string         `json:"action_type"`
+	MessageTemplate  string         `json:"message_template,omitempty"`
+	IsActive         bool           `json:"is_active"`
+	Condition        string         `json:"condition,omitempty"`
+	MinNetworkScore  *float64       `json:"min_network_score,omitempty"`
+	ListID           string         `json:"list_id,omitempty"`
+	Country          string         `json:"country,omitempty"`
+	Near             string         `json:"near,omitempty"`
+	RadiusKm         float64        `json:"radius_km,omitempty"`
+	MinSeniority     string         `json:"min_seniority,omitempty"`
+	Function         string         `json:"function,omitempty"`
+	RequiresApproval bool           `json:"requires_approval"`
+	Sequence         []SequenceStep `json:"sequence,omitempty"`
+	Timezone         string         `json:"timezone,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+}
+
+// RuleExecutionsQuery represents the paging for a rule's executions
+type RuleExecutionsQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// RuleExecution represents a rule's action on a profile. Status is pending,
+// succeeded, failed or skipped, or for rules requiring approval
+// awaiting_approval, approved or rejected; failed actions are retried up to
+// three times in total. Outcome records what the action found, e.g.
+// already_connected. Message is the note queued for approval.
+type RuleExecution struct {
+	ID         string     `json:"id"`
+	Profile    ProfileRef `json:"profile"`
+	Status     string     `json:"status"`
+	Message    string     `json:"message,omitempty"`
+	Outcome    string     `json:"outcome,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	Attempts   int        `json:"attempts"`
+	ExecutedAt time.Time  `json:"executed_at"`
+}
+
+// RuleEnrollmentsQuery represents the filters and paging for a rule's sequence enrollments
+type RuleEnrollmentsQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=active completed exited failed"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// SequenceEnrollment represents a profile going through a rule's sequence.
+// Step is the step it waits on, since StepStartedAt. Status is active,
+// completed, exited, with the event as ExitReason, or failed once a step used
+// up its three attempts. Steps lists the steps performed so far.
+type SequenceEnrollment struct {
+	ID            string            `json:"id"`
+	Profile       ProfileRef        `json:"profile"`
+	Status        string            `json:"status"`
+	Step          int               `json:"step"`
+	StepStartedAt time.Time         `json:"step_started_at"`
+	ExitReason    string            `json:"exit_reason,omitempty"`
+	Attempts      int               `json:"attempts"`
+	Error         string            `json:"error,omitempty"`
+	AcceptedAt    *time.Time        `json:"accepted_at,omitempty"`
+	RepliedAt     *time.Time        `json:"replied_at,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	Steps         []SequenceStepRun `json:"steps"`
+}
+
+// SequenceStepRun represents one performed step of a sequence enrollment
+type SequenceStepRun struct {
+	Step       int       `json:"step"`
+	ActionType string    `json:"action_type"`
+	Status     string    `json:"status"`
+	Outcome    string    `json:"outcome,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	ExecutedAt time.Time `json:"executed_at"`
+}
+
+// RulePreviewRequest represents a rule preview. MessageTemplate previews an
+// edited template instead of the rule's own.
+type RulePreviewRequest struct {
+	MessageTemplate string `json:"message_template" binding:"max=2000"`
+	Limit           int    `json:"limit" binding:"omitempty,min=1,max=20"`
+}
+
+// RulePreview represents a rule's message rendered for one matching profile.
+// TooLong flags notes over LinkedIn's 300 character limit, and Missing lists
+// the variables the profile had no value or fallback for.
+type RulePreview struct {
+	Profile ProfileRef `json:"profile"`
+	Message string     `json:"message"`
+	Length  int        `json:"length"`
+	TooLong bool       `json:"too_long"`
+	Missing []string   `json:"missing,omitempty"`
+}
+
+// RuleSimulationRequest represents a dry run of a rule over the last Days days
+// of discoveries and job changes, rendering Sample of the messages it would send
+type RuleSimulationRequest struct {
+	Days   int `json:"days" binding:"omitempty,min=1,max=90"`
+	Sample int `json:"sample" binding:"omitempty,min=1,max=20"`
+}
+
+// RuleSimulation represents what a rule would do had it been created Days days
+// ago. Matched counts the profiles passing its filters and condition, and
+// AlreadyActioned those the rule has actioned or queued already. Of the
+// WouldAction others, NotesTooLong would fail over LinkedIn's note limit,
+// Blocked would be skipped as they were for another of your rules with the
+// same action, e.g. as already connected, and OverQuota connection requests
+// would wait for later invitation quota windows.
+type RuleSimulation struct {
+	Days            int           `json:"days"`
+	Matched         int           `json:"matched"`
+	AlreadyActioned int           `json:"already_actioned"`
+	WouldAction     int           `json:"would_action"`
+	NotesTooLong    int           `json:"notes_too_long"`
+	Blocked         int           `json:"blocked"`
+	OverQuota       int           `json:"over_quota"`
+	Sample          []RulePreview `json:"sample"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// CreateSavedSearchRequest represents a standing profile search. Every criterion
+// is optional; a search without criteria matches every new profile in the network.
+type CreateSavedSearchRequest struct {
+	Name         string  `json:"name" binding:"required,max=255"`
+	Query        string  `json:"q" binding:"omitempty,max=200"`
+	CompanyID    string  `json:"company_id" binding:"omitempty,uuid"`
+	Location     string  `json:"location" binding:"omitempty,max=255"`
+	Degree       *int    `json:"degree" binding:"omitempty,min=1,max=4"`
+	Tag          string  `json:"tag" binding:"omitempty,max=50"`
+	ListID       string  `json:"list_id" binding:"omitempty,uuid"`
+	Country      string  `json:"country" binding:"omitempty,len=2,alpha"`
+	Near         string  `json:"near" binding:"omitempty,max=255"`
+	RadiusKm     float64 `json:"radius_km" binding:"omitempty,gt=0,max=20000,excluded_without=Near"`
+	MinSeniority string  `json:"min_seniority" binding:"omitempty,oneof=intern entry senior lead manager director vp c_level"`
+	Function     string  `json:"function" binding:"omitempty,oneof=engineering data product design sales marketing recruiting people finance operations legal customer_success research consulting"`
+}
+
+// SavedSearch represents a standing profile search
+type SavedSearch struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	Query           string     `json:"q,omitempty"`
+	CompanyID       string     `json:"company_id,omitempty"`
+	Location        string     `json:"location,omitempty"`
+	Degree          *int       `json:"degree,omitempty"`
+	Tag             string     `json:"tag,omitempty"`
+	ListID          string     `json:"list_id,omitempty"`
+	Country         string     `json:"country,omitempty"`
+	Near            string     `json:"near,omitempty"`
+	RadiusKm        float64    `json:"radius_km,omitempty"`
+	MinSeniority    string     `json:"min_seniority,omitempty"`
+	Function        string     `json:"function,omitempty"`
+	IsActive        bool       `json:"is_active"`
+	LastEvaluatedAt *time.Time `json:"last_evaluated_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// SavedSearchMatchesQuery represents the paging for a saved search's matches
+type SavedSearchMatchesQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// SavedSearchMatch represents a profile matched by a saved search
+type SavedSearchMatch struct {
+	ID          string    `json:"id"`
+	LinkedinURL string    `json:"linkedin_url"`
+	Name        string    `json:"name"`
+	Location    string    `json:"location,omitempty"`
+	Headline    string    `json:"headline,omitempty"`
+	Company     string    `json:"company,omitempty"`
+	MatchedAt   time.Time `json:"matched_at"`
+}
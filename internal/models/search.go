@@ -0,0 +1,59 @@
+package models
+
+// ProfileSearchQuery represents a full-text profile search with optional facet filters
+type ProfileSearchQuery struct {
+	Q            string  `form:"q" binding:"required,max=200"`
+	CompanyID    string  `form:"company_id" binding:"omitempty,uuid"`
+	Location     string  `form:"location" binding:"omitempty,max=255"`
+	Degree       *int    `form:"degree" binding:"omitempty,min=1,max=4"`
+	ListID       string  `form:"list_id" binding:"omitempty,uuid"`
+	Tag          string  `form:"tag" binding:"omitempty,max=50"`
+	Country      string  `form:"country" binding:"omitempty,len=2,alpha"`
+	Near         string  `form:"near" binding:"omitempty,max=255"`
+	RadiusKm     float64 `form:"radius_km" binding:"omitempty,gt=0,max=20000,excluded_without=Near"`
+	MinSeniority string  `form:"min_seniority" binding:"omitempty,oneof=intern entry senior lead manager director vp c_level"`
+	Function     string  `form:"function" binding:"omitempty,oneof=engineering data product design sales marketing recruiting people finance operations legal customer_success research consulting"`
+	Limit        int     `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset       int     `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ProfileSearchResult represents a profile matching a search, with its relevance
+type ProfileSearchResult struct {
+	ID          string  `json:"id"`
+	LinkedinURL string  `json:"linkedin_url"`
+	Name        string  `json:"name"`
+	Location    string  `json:"location,omitempty"`
+	Headline    string  `json:"headline,omitempty"`
+	Company     string  `json:"company,omitempty"`
+	Degree      *int    `json:"degree,omitempty"`
+	Seniority   string  `json:"seniority,omitempty"`
+	Function    string  `json:"function,omitempty"`
+	Rank        float64 `json:"rank"`
+}
+
+// FacetCount represents the number of matches sharing a facet value
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// ProfileSearchFacets represents match counts by company, location, country,
+// connection degree, seniority and job function
+type ProfileSearchFacets struct {
+	Companies   []FacetCount `json:"companies"`
+	Locations   []FacetCount `json:"locations"`
+	Countries   []FacetCount `json:"countries"`
+	Degrees     []FacetCount `json:"degrees"`
+	Seniorities []FacetCount `json:"seniorities"`
+	Functions   []FacetCount `json:"functions"`
+}
+
+// ProfileSearchResponse represents a page of search results with facet counts
+type ProfileSearchResponse struct {
+	Results []ProfileSearchResult `json:"results"`
+	Total   int                   `json:"total"`
+	Limit   int                   `json:"limit"`
+	Offset  int                   `json:"offset"`
+	Facets  ProfileSearchFacets   `json:"facets"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// ProfileTimelineQuery represents the options for a profile's timeline
+type ProfileTimelineQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+// TimelineEntry represents one thing that happened to a profile. Kind is one of
+// discovered, connection_added, connection_removed, job_change, headline_change,
+// note or automation, and decides which of the optional fields are set.
+type TimelineEntry struct {
+	Kind       string    `json:"kind"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Summary    string    `json:"summary"`
+	// Via is the tracked connection a discovery or connection event is with
+	Via         *ProfileRef `json:"via,omitempty"`
+	OldCompany  string      `json:"old_company,omitempty"`
+	OldPosition string      `json:"old_position,omitempty"`
+	NewCompany  string      `json:"new_company,omitempty"`
+	NewPosition string      `json:"new_position,omitempty"`
+	OldHeadline string      `json:"old_headline,omitempty"`
+	NewHeadline string      `json:"new_headline,omitempty"`
+	NoteID      string      `json:"note_id,omitempty"`
+	Note        string      `json:"note,omitempty"`
+	// The Rule fields describe an automation rule's action on the profile
+	RuleID      string `json:"rule_id,omitempty"`
+	RuleName    string `json:"rule_name,omitempty"`
+	RuleStatus  string `json:"rule_status,omitempty"`
+	RuleOutcome string `json:"rule_outcome,omitempty"`
+	RuleError   string `json:"rule_error,omitempty"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// WatchCompanyRequest represents a company to add to the watchlist
+type WatchCompanyRequest struct {
+	CompanyID string `json:"company_id" binding:"required,uuid"`
+}
+
+// WatchedCompany represents a company on the user's watchlist
+type WatchedCompany struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	LinkedinURL string    `json:"linkedin_url,omitempty"`
+	Industry    string    `json:"industry,omitempty"`
+	WatchedAt   time.Time `json:"watched_at"`
+	// KnownProfiles is how many 2nd and 3rd degree profiles have been seen there since watching
+	KnownProfiles int `json:"known_profiles"`
+}
+
+// WatchedCompanyProfile represents a newly discovered profile at a watched company
+type WatchedCompanyProfile struct {
+	ID          string `json:"id"`
+	LinkedinURL string `json:"linkedin_url"`
+	Name        string `json:"name"`
+	Headline    string `json:"headline,omitempty"`
+	Degree      int    `json:"degree"`
+	// ConnectedVia is the tracked connection that bridges the user to this profile
+	ConnectedVia ProfileRef `json:"connected_via"`
+}
@@ -1,7 +1,7 @@
 package routers
 
 import (
-	"linkedin-watcher/db"
+	"linkedin-watcher/config"
 	"linkedin-watcher/internal/controllers"
 	"linkedin-watcher/internal/middleware"
 	"linkedin-watcher/internal/services"
@@ -13,12 +13,52 @@ import (
 )
 
 // RegisterRoutes add all routing list here automatically get main router
-func RegisterRoutes(route *gin.Engine, queries *db.Queries, jwtSecret string) {
+func RegisterRoutes(route *gin.Engine, deps *RouterDependencies) {
+	queries := deps.Queries
+
 	// Initialize services
-	authService := services.NewAuthService(queries, jwtSecret)
+	authService := services.NewAuthService(queries, deps.JWTSecret)
+	graphService := services.NewGraphService(queries)
+	connectionService := services.NewConnectionService(queries)
+	clusterService := services.NewClusterService(deps.Pool, queries)
+	eventService := services.NewEventService(queries)
+	companyService := services.NewCompanyService(deps.Pool, queries)
+	searchService := services.NewSearchService(queries)
+	annotationService := services.NewAnnotationService(deps.Pool, queries)
+	listService := services.NewListService(queries)
+	notificationService := services.NewNotificationService(queries, config.SMTPConfig())
+	savedSearchService := services.NewSavedSearchService(queries, notificationService)
+	watchlistService := services.NewWatchlistService(queries, notificationService)
+	duplicateService := services.NewDuplicateService(deps.Pool, queries)
+	timelineService := services.NewTimelineService(queries)
+	profileService := services.NewProfileService(deps.Pool, queries)
+	analyticsService := services.NewAnalyticsService(queries)
+	windowService := services.NewExecutionWindowService(queries, config.DefaultTimezone())
+	invitationService := services.NewInvitationService(queries, config.InvitationQuotaConfig(), windowService)
+	ruleService := services.NewRuleService(queries, invitationService)
+	approvalService := services.NewApprovalService(queries)
 
 	// Initialize controllers with injected dependencies
 	authController := controllers.NewAuthController(authService)
+	graphController := controllers.NewGraphController(graphService)
+	connectionController := controllers.NewConnectionController(connectionService)
+	clusterController := controllers.NewClusterController(clusterService)
+	eventController := controllers.NewEventController(eventService)
+	companyController := controllers.NewCompanyController(companyService)
+	searchController := controllers.NewSearchController(searchService)
+	annotationController := controllers.NewAnnotationController(annotationService)
+	listController := controllers.NewListController(listService)
+	savedSearchController := controllers.NewSavedSearchController(savedSearchService)
+	notificationController := controllers.NewNotificationController(notificationService)
+	watchlistController := controllers.NewWatchlistController(watchlistService)
+	duplicateController := controllers.NewDuplicateController(duplicateService)
+	timelineController := controllers.NewTimelineController(timelineService)
+	profileController := controllers.NewProfileController(profileService)
+	analyticsController := controllers.NewAnalyticsController(analyticsService)
+	ruleController := controllers.NewRuleController(ruleService)
+	invitationController := controllers.NewInvitationController(invitationService)
+	approvalController := controllers.NewApprovalController(approvalService)
+	windowController := controllers.NewExecutionWindowController(windowService)
 
 	// Health check endpoint
 	route.GET("/health", controllers.HealthCheck)
@@ -43,12 +83,71 @@ func RegisterRoutes(route *gin.Engine, queries *db.Queries, jwtSecret string) {
 	}
 
 	// API v1 routes
-	// v1 := route.Group("/api/v1")
-	// {
-	// 	// Add future API endpoints here
-	// 	// v1.GET("/users", handlers.GetUsers)
-	// 	// v1.POST("/users", handlers.CreateUser)
-	// }
+	v1 := route.Group("/api/v1")
+	v1.Use(middleware.AuthMiddleware(authService))
+	{
+		v1.GET("/connections/new", connectionController.ListNew)
+		v1.GET("/clusters", clusterController.List)
+		v1.GET("/clusters/:id/profiles", clusterController.ListProfiles)
+		v1.GET("/events/job-changes", eventController.ListJobChanges)
+		v1.GET("/companies", companyController.List)
+		v1.POST("/companies", companyController.Create)
+		v1.GET("/companies/:id", companyController.Get)
+		v1.POST("/companies/:id/merge", companyController.Merge)
+		v1.GET("/companies/:id/people", companyController.ListPeople)
+		v1.GET("/profiles/search", searchController.SearchProfiles)
+		v1.GET("/profiles/:id/tags", annotationController.GetTags)
+		v1.PUT("/profiles/:id/tags", annotationController.SetTags)
+		v1.GET("/profiles/:id/notes", annotationController.ListNotes)
+		v1.POST("/profiles/:id/notes", annotationController.CreateNote)
+		v1.PUT("/profiles/:id/notes/:noteId", annotationController.UpdateNote)
+		v1.DELETE("/profiles/:id/notes/:noteId", annotationController.DeleteNote)
+		v1.GET("/profiles/:id/timeline", timelineController.Get)
+		v1.PUT("/profiles/:id/snapshot", profileController.Sync)
+		v1.GET("/tags", annotationController.ListTags)
+		v1.GET("/lists", listController.List)
+		v1.POST("/lists", listController.Create)
+		v1.GET("/lists/:id", listController.Get)
+		v1.PUT("/lists/:id", listController.Update)
+		v1.DELETE("/lists/:id", listController.Delete)
+		v1.POST("/lists/:id/profiles", listController.AddMember)
+		v1.DELETE("/lists/:id/profiles/:profileId", listController.RemoveMember)
+		v1.GET("/saved-searches", savedSearchController.List)
+		v1.POST("/saved-searches", savedSearchController.Create)
+		v1.DELETE("/saved-searches/:id", savedSearchController.Delete)
+		v1.GET("/saved-searches/:id/matches", savedSearchController.ListMatches)
+		v1.GET("/watchlist", watchlistController.List)
+		v1.POST("/watchlist", watchlistController.Watch)
+		v1.DELETE("/watchlist/:companyId", watchlistController.Unwatch)
+		v1.GET("/duplicates", duplicateController.List)
+		v1.POST("/duplicates/:id/confirm", duplicateController.Confirm)
+		v1.POST("/duplicates/:id/reject", duplicateController.Reject)
+		v1.GET("/rules", ruleController.List)
+		v1.POST("/rules", ruleController.Create)
+		v1.PUT("/rules/:id", ruleController.Update)
+		v1.DELETE("/rules/:id", ruleController.Delete)
+		v1.GET("/rules/:id/executions", ruleController.ListExecutions)
+		v1.POST("/rules/:id/preview", ruleController.Preview)
+		v1.POST("/rules/:id/simulate", ruleController.Simulate)
+		v1.GET("/rules/:id/enrollments", ruleController.ListEnrollments)
+		v1.GET("/approvals", approvalController.List)
+		v1.PUT("/approvals/:id", approvalController.Update)
+		v1.POST("/approvals/approve", approvalController.Approve)
+		v1.POST("/approvals/reject", approvalController.Reject)
+		v1.GET("/invitations/quota", invitationController.GetQuota)
+		v1.PUT("/invitations/quota", invitationController.UpdateQuota)
+		v1.GET("/execution-window", windowController.Get)
+		v1.PUT("/execution-window", windowController.Update)
+		v1.DELETE("/execution-window", windowController.Delete)
+		v1.GET("/notification-channels", notificationController.ListChannels)
+		v1.POST("/notification-channels", notificationController.CreateChannel)
+		v1.DELETE("/notification-channels/:id", notificationController.DeleteChannel)
+		v1.GET("/notifications", notificationController.List)
+		v1.POST("/notifications/:id/read", notificationController.MarkRead)
+		v1.GET("/network/export", graphController.Export)
+		v1.GET("/network/growth", analyticsController.Growth)
+		v1.GET("/network/composition", analyticsController.Composition)
+	}
 
 	// 404 handler
 	route.NoRoute(func(ctx *gin.Context) {
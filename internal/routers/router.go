@@ -5,11 +5,13 @@ import (
 	"linkedin-watcher/internal/middleware"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/spf13/viper"
 )
 
 // RouterDependencies holds all the dependencies needed for routing
 type RouterDependencies struct {
+	Pool      *pgxpool.Pool
 	Queries   *db.Queries
 	JWTSecret string
 }
@@ -35,7 +37,7 @@ func SetupRoute(deps *RouterDependencies) *gin.Engine {
 
 	// Only register auth routes if database is available
 	if deps != nil && deps.Queries != nil {
-		RegisterRoutes(router, deps.Queries, deps.JWTSecret)
+		RegisterRoutes(router, deps)
 	} else {
 		RegisterRoutesWithoutDB(router)
 	}
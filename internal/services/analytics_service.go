@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrRangeTooLong is returned when an analytics series would span too many periods
+var ErrRangeTooLong = errors.New("since is too far back: a series can span at most 366 days or weeks")
+
+// Analytics series intervals
+const (
+	analyticsDay  = "day"
+	analyticsWeek = "week"
+)
+
+const (
+	// maxAnalyticsPeriods caps how many days or weeks a series can span
+	maxAnalyticsPeriods = 366
+
+	// defaultCompositionTop is how many values per period are returned when no top is given
+	defaultCompositionTop = 10
+)
+
+// defaultAnalyticsPeriods is how many days or weeks a series goes back when no date is given
+var defaultAnalyticsPeriods = map[string]int{
+	analyticsDay:  30,
+	analyticsWeek: 12,
+}
+
+type AnalyticsService struct {
+	queries *db.Queries
+}
+
+func NewAnalyticsService(queries *db.Queries) *AnalyticsService {
+	return &AnalyticsService{
+		queries: queries,
+	}
+}
+
+// Refresh recomputes the materialized views the analytics series are read from
+func (s *AnalyticsService) Refresh(ctx context.Context) error {
+	if err := s.queries.RefreshNetworkDiscoveries(ctx); err != nil {
+		return fmt.Errorf("failed to refresh network discoveries: %w", err)
+	}
+	if err := s.queries.RefreshNetworkRemovals(ctx); err != nil {
+		return fmt.Errorf("failed to refresh network removals: %w", err)
+	}
+	return nil
+}
+
+// GetGrowth returns how many 2nd and 3rd degree profiles the user discovered
+// and how many connections were removed, per day or week. With a tracked
+// connection only what was found through it is counted; otherwise each profile
+// counts once, when it was first discovered.
+func (s *AnalyticsService) GetGrowth(ctx context.Context, userID string, query models.NetworkGrowthQuery) ([]models.NetworkGrowthPoint, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	interval, since, err := analyticsRange(query.Interval, query.Since, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	trackedProfileID, err := s.trackedFilter(ctx, userUUID, query.TrackedProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.GetNetworkGrowth(ctx, db.GetNetworkGrowthParams{
+		Bucket:           interval,
+		Since:            pgtype.Timestamp{Time: since, Valid: true},
+		Until:            pgtype.Timestamp{Time: time.Now(), Valid: true},
+		UserID:           userUUID,
+		TrackedProfileID: trackedProfileID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network growth: %w", err)
+	}
+
+	points := make([]models.NetworkGrowthPoint, 0, len(rows))
+	for _, row := range rows {
+		points = append(points, models.NetworkGrowthPoint{
+			PeriodStart:  row.PeriodStart.Time,
+			SecondDegree: int(row.SecondDegree),
+			ThirdDegree:  int(row.ThirdDegree),
+			Removed:      int(row.Removed),
+		})
+	}
+
+	return points, nil
+}
+
+// GetComposition returns the most common current companies, locations or
+// countries among the profiles discovered per day or week. Periods without
+// discoveries are left out.
+func (s *AnalyticsService) GetComposition(ctx context.Context, userID string, query models.NetworkCompositionQuery) ([]models.NetworkCompositionPeriod, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	interval, since, err := analyticsRange(query.Interval, query.Since, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	trackedProfileID, err := s.trackedFilter(ctx, userUUID, query.TrackedProfileID)
+	if err != nil {
+		return nil, err
+	}
+	top := query.Top
+	if top == 0 {
+		top = defaultCompositionTop
+	}
+
+	rows, err := s.queries.GetNetworkComposition(ctx, db.GetNetworkCompositionParams{
+		Bucket:           interval,
+		Dimension:        query.Dimension,
+		UserID:           userUUID,
+		Since:            pgtype.Timestamp{Time: since, Valid: true},
+		TrackedProfileID: trackedProfileID,
+		Top:              int32(top),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network composition: %w", err)
+	}
+
+	// Rows are ordered by period, then by count
+	periods := []models.NetworkCompositionPeriod{}
+	for _, row := range rows {
+		if len(periods) == 0 || !periods[len(periods)-1].PeriodStart.Equal(row.PeriodStart.Time) {
+			periods = append(periods, models.NetworkCompositionPeriod{PeriodStart: row.PeriodStart.Time})
+		}
+		last := &periods[len(periods)-1]
+		last.Values = append(last.Values, models.NetworkCompositionValue{
+			Value:    row.Value,
+			Profiles: int(row.Profiles),
+		})
+	}
+
+	return periods, nil
+}
+
+// analyticsRange defaults the interval to days and the start of a series to a
+// few periods back, and returns ErrRangeTooLong when the series would span
+// more than maxAnalyticsPeriods
+func analyticsRange(interval string, since, now time.Time) (string, time.Time, error) {
+	if interval == "" {
+		interval = analyticsDay
+	}
+	period := 24 * time.Hour
+	if interval == analyticsWeek {
+		period = 7 * 24 * time.Hour
+	}
+
+	if since.IsZero() {
+		since = now.Add(-time.Duration(defaultAnalyticsPeriods[interval]) * period)
+	}
+	if now.Sub(since) > maxAnalyticsPeriods*period {
+		return "", time.Time{}, ErrRangeTooLong
+	}
+	return interval, since, nil
+}
+
+// trackedFilter parses the tracked connection a series is restricted to, if
+// any, and returns ErrNotFound unless the user tracks it
+func (s *AnalyticsService) trackedFilter(ctx context.Context, userID pgtype.UUID, trackedProfileID string) (pgtype.UUID, error) {
+	if trackedProfileID == "" {
+		return pgtype.UUID{}, nil
+	}
+	profileID, err := parseUUID(trackedProfileID)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+
+	_, err = s.queries.GetTrackedConnection(ctx, db.GetTrackedConnectionParams{
+		UserID:    userID,
+		ProfileID: profileID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pgtype.UUID{}, ErrNotFound
+	}
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("failed to get tracked connection: %w", err)
+	}
+	return profileID, nil
+}
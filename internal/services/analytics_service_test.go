@@ -0,0 +1,35 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyticsRange(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	interval, since, err := analyticsRange("", time.Time{}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, analyticsDay, interval)
+	assert.Equal(t, now.Add(-30*day), since)
+
+	interval, since, err = analyticsRange(analyticsWeek, time.Time{}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, analyticsWeek, interval)
+	assert.Equal(t, now.Add(-12*7*day), since)
+
+	start := now.Add(-366 * day)
+	_, since, err = analyticsRange(analyticsDay, start, now)
+	assert.NoError(t, err)
+	assert.Equal(t, start, since)
+
+	_, _, err = analyticsRange(analyticsDay, now.Add(-367*day), now)
+	assert.ErrorIs(t, err, ErrRangeTooLong)
+
+	// The same start is fine when counted in weeks
+	_, _, err = analyticsRange(analyticsWeek, now.Add(-367*day), now)
+	assert.NoError(t, err)
+}
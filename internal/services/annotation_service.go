@@ -0,0 +1,262 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AnnotationService struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewAnnotationService(pool *pgxpool.Pool, queries *db.Queries) *AnnotationService {
+	return &AnnotationService{
+		pool:    pool,
+		queries: queries,
+	}
+}
+
+// normalizeTag lowercases a tag and collapses its whitespace so "Hiring  Target"
+// and "hiring target" are the same tag
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
+
+// normalizeTags normalizes, de-duplicates and sorts tags, dropping empty ones
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// parseProfileRef parses the user and profile IDs and checks the profile exists
+func parseProfileRef(ctx context.Context, queries *db.Queries, userID, profileID string) (pgtype.UUID, pgtype.UUID, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, err
+	}
+	profileUUID, err := parseUUID(profileID)
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, err
+	}
+
+	_, err = queries.GetLinkedInProfileByID(ctx, profileUUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pgtype.UUID{}, pgtype.UUID{}, ErrNotFound
+	}
+	if err != nil {
+		return pgtype.UUID{}, pgtype.UUID{}, fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	return userUUID, profileUUID, nil
+}
+
+// GetTags returns the user's tags on a profile
+func (s *AnnotationService) GetTags(ctx context.Context, userID, profileID string) (*models.ProfileTags, error) {
+	userUUID, profileUUID, err := parseProfileRef(ctx, s.queries, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.queries.ListProfileTags(ctx, db.ListProfileTagsParams{
+		UserID:    userUUID,
+		ProfileID: profileUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return &models.ProfileTags{ProfileID: profileID, Tags: tags}, nil
+}
+
+// SetTags replaces the user's tags on a profile
+func (s *AnnotationService) SetTags(ctx context.Context, userID, profileID string, req models.SetProfileTagsRequest) (*models.ProfileTags, error) {
+	userUUID, profileUUID, err := parseProfileRef(ctx, s.queries, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	tags := normalizeTags(req.Tags)
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	err = qtx.DeleteProfileTags(ctx, db.DeleteProfileTagsParams{
+		UserID:    userUUID,
+		ProfileID: profileUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear tags: %w", err)
+	}
+	for _, tag := range tags {
+		err := qtx.CreateProfileTag(ctx, db.CreateProfileTagParams{
+			UserID:    userUUID,
+			ProfileID: profileUUID,
+			Tag:       tag,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add tag: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit tags: %w", err)
+	}
+
+	return &models.ProfileTags{ProfileID: profileID, Tags: tags}, nil
+}
+
+// ListTags returns every tag the user has used with the number of profiles carrying it
+func (s *AnnotationService) ListTags(ctx context.Context, userID string) ([]models.TagCount, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListUserTags(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	tags := make([]models.TagCount, 0, len(rows))
+	for _, row := range rows {
+		tags = append(tags, models.TagCount{
+			Tag:          row.Tag,
+			ProfileCount: int(row.ProfileCount),
+		})
+	}
+
+	return tags, nil
+}
+
+// ListNotes returns the user's notes on a profile, newest first
+func (s *AnnotationService) ListNotes(ctx context.Context, userID, profileID string) ([]models.ProfileNote, error) {
+	userUUID, profileUUID, err := parseProfileRef(ctx, s.queries, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListProfileNotes(ctx, db.ListProfileNotesParams{
+		UserID:    userUUID,
+		ProfileID: profileUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes: %w", err)
+	}
+
+	notes := make([]models.ProfileNote, 0, len(rows))
+	for _, row := range rows {
+		notes = append(notes, profileNoteModel(row))
+	}
+
+	return notes, nil
+}
+
+// CreateNote adds a private note to a profile
+func (s *AnnotationService) CreateNote(ctx context.Context, userID, profileID string, req models.ProfileNoteRequest) (*models.ProfileNote, error) {
+	userUUID, profileUUID, err := parseProfileRef(ctx, s.queries, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	note, err := s.queries.CreateProfileNote(ctx, db.CreateProfileNoteParams{
+		UserID:    userUUID,
+		ProfileID: profileUUID,
+		Body:      strings.TrimSpace(req.Body),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create note: %w", err)
+	}
+
+	result := profileNoteModel(note)
+	return &result, nil
+}
+
+// UpdateNote rewrites one of the user's notes on a profile
+func (s *AnnotationService) UpdateNote(ctx context.Context, userID, profileID, noteID string, req models.ProfileNoteRequest) (*models.ProfileNote, error) {
+	userUUID, profileUUID, err := parseProfileRef(ctx, s.queries, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	noteUUID, err := parseUUID(noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	note, err := s.queries.UpdateProfileNote(ctx, db.UpdateProfileNoteParams{
+		ID:        noteUUID,
+		UserID:    userUUID,
+		ProfileID: profileUUID,
+		Body:      strings.TrimSpace(req.Body),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update note: %w", err)
+	}
+
+	result := profileNoteModel(note)
+	return &result, nil
+}
+
+// DeleteNote removes one of the user's notes on a profile
+func (s *AnnotationService) DeleteNote(ctx context.Context, userID, profileID, noteID string) error {
+	userUUID, profileUUID, err := parseProfileRef(ctx, s.queries, userID, profileID)
+	if err != nil {
+		return err
+	}
+	noteUUID, err := parseUUID(noteID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.queries.DeleteProfileNote(ctx, db.DeleteProfileNoteParams{
+		ID:        noteUUID,
+		UserID:    userUUID,
+		ProfileID: profileUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete note: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func profileNoteModel(row db.ProfileNote) models.ProfileNote {
+	return models.ProfileNote{
+		ID:        uuidString(row.ID),
+		ProfileID: uuidString(row.ProfileID),
+		Body:      row.Body,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
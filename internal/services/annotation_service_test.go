@@ -0,0 +1,19 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	assert.Equal(t, "hiring target", normalizeTag("  Hiring   Target "))
+	assert.Equal(t, "", normalizeTag("   "))
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags := normalizeTags([]string{"Mentor", "investor", " mentor ", "", "Q3  Targets"})
+
+	assert.Equal(t, []string{"investor", "mentor", "q3 targets"}, tags)
+	assert.Empty(t, normalizeTags(nil))
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/message"
+	"linkedin-watcher/internal/models"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// defaultPendingApprovalsLimit is the number of queued actions returned when no limit is given
+const defaultPendingApprovalsLimit = 50
+
+// ErrMessageTooLong is returned when an edited connection note cannot fit LinkedIn's note limit
+var ErrMessageTooLong = fmt.Errorf("message must be at most %d characters to fit a LinkedIn connection note", message.MaxNoteLength)
+
+type ApprovalService struct {
+	queries *db.Queries
+}
+
+func NewApprovalService(queries *db.Queries) *ApprovalService {
+	return &ApprovalService{
+		queries: queries,
+	}
+}
+
+// ListPending returns the actions awaiting the user's approval, oldest first
+func (s *ApprovalService) ListPending(ctx context.Context, userID string, query models.PendingApprovalsQuery) ([]models.PendingApproval, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	var ruleUUID pgtype.UUID
+	if query.RuleID != "" {
+		ruleUUID, err = parseUUID(query.RuleID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultPendingApprovalsLimit
+	}
+	rows, err := s.queries.ListPendingApprovals(ctx, db.ListPendingApprovalsParams{
+		UserID:     userUUID,
+		RuleID:     ruleUUID,
+		LimitCount: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending approvals: %w", err)
+	}
+
+	pending := make([]models.PendingApproval, 0, len(rows))
+	for _, row := range rows {
+		pending = append(pending, pendingApprovalModel(db.GetPendingApprovalRow(row)))
+	}
+
+	return pending, nil
+}
+
+// UpdateMessage replaces the message a queued action will send
+func (s *ApprovalService) UpdateMessage(ctx context.Context, userID, id string, req models.PendingApprovalUpdate) (*models.PendingApproval, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	idUUID, err := parseUUID(id)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimSpace(*req.Message)
+
+	row, err := s.queries.GetPendingApproval(ctx, db.GetPendingApprovalParams{
+		ID:     idUUID,
+		UserID: userUUID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending approval: %w", err)
+	}
+	if row.ActionType == RuleActionSendConnectionRequest && utf8.RuneCountInString(text) > message.MaxNoteLength {
+		return nil, ErrMessageTooLong
+	}
+
+	row.Message = pgtype.Text{String: text, Valid: true}
+	updated, err := s.queries.UpdatePendingApprovalMessage(ctx, db.UpdatePendingApprovalMessageParams{
+		ID:      idUUID,
+		UserID:  userUUID,
+		Message: row.Message,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pending approval: %w", err)
+	}
+	// Decided in the meantime
+	if updated == 0 {
+		return nil, ErrNotFound
+	}
+
+	result := pendingApprovalModel(row)
+	return &result, nil
+}
+
+// Approve approves queued actions, which the next automation run performs
+// with their messages
+func (s *ApprovalService) Approve(ctx context.Context, userID string, req models.ApprovalDecisionRequest) (*models.ApprovalDecision, error) {
+	return s.decide(ctx, userID, req.IDs, RuleExecutionApproved)
+}
+
+// Reject rejects queued actions; their rules never action those profiles
+func (s *ApprovalService) Reject(ctx context.Context, userID string, req models.ApprovalDecisionRequest) (*models.ApprovalDecision, error) {
+	return s.decide(ctx, userID, req.IDs, RuleExecutionRejected)
+}
+
+func (s *ApprovalService) decide(ctx context.Context, userID string, ids []string, status string) (*models.ApprovalDecision, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	idUUIDs := make([]pgtype.UUID, 0, len(ids))
+	for _, id := range ids {
+		idUUID, err := parseUUID(id)
+		if err != nil {
+			return nil, err
+		}
+		idUUIDs = append(idUUIDs, idUUID)
+	}
+
+	decided, err := s.queries.DecidePendingApprovals(ctx, db.DecidePendingApprovalsParams{
+		Status: status,
+		UserID: userUUID,
+		Ids:    idUUIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decide pending approvals: %w", err)
+	}
+
+	decision := &models.ApprovalDecision{IDs: make([]string, 0, len(decided))}
+	for _, id := range decided {
+		decision.IDs = append(decision.IDs, uuidString(id))
+	}
+	return decision, nil
+}
+
+func pendingApprovalModel(row db.GetPendingApprovalRow) models.PendingApproval {
+	text := textValue(row.Message)
+	length := utf8.RuneCountInString(text)
+	return models.PendingApproval{
+		ID:         uuidString(row.ID),
+		RuleID:     uuidString(row.RuleID),
+		RuleName:   row.RuleName,
+		ActionType: row.ActionType,
+		Profile: models.ProfileRef{
+			ID:          uuidString(row.ProfileID),
+			Name:        row.ProfileName,
+			LinkedinURL: row.LinkedinUrl,
+		},
+		Message:  text,
+		Length:   length,
+		TooLong:  row.ActionType == RuleActionSendConnectionRequest && length > message.MaxNoteLength,
+		QueuedAt: row.QueuedAt.Time,
+	}
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestApprovalFlow covers how the services move an action through approval.
+// The state transitions of the queries themselves are not covered: they run
+// against fakeRuleExecutions, not the database.
+func TestApprovalFlow(t *testing.T) {
+	ctx := context.Background()
+	userID := testProfileID(100)
+	rule := testProfileID(1)
+	candidate := func(profile pgtype.UUID, name string) db.GetProfilesMatchingRulesRow {
+		return db.GetProfilesMatchingRulesRow{
+			ID:               profile,
+			LinkedinUrl:      "https://www.linkedin.com/in/" + name,
+			Name:             name,
+			RuleID:           rule,
+			RuleName:         "Founders",
+			ActionType:       RuleActionSaveProfile,
+			MessageTemplate:  pgtype.Text{String: "Hi {{first_name}}", Valid: true},
+			RequiresApproval: true,
+		}
+	}
+	database := &fakeRuleExecutions{candidates: []db.GetProfilesMatchingRulesRow{
+		candidate(testProfileID(2), "Ada"),
+		candidate(testProfileID(3), "Grace"),
+	}}
+	queries := db.New(database)
+
+	automation := NewAutomationService(queries, nil, nil, NewExecutionWindowService(queries, time.UTC))
+	var performed []RuleAction
+	automation.Register(RuleActionSaveProfile, func(_ context.Context, action RuleAction) (string, error) {
+		performed = append(performed, action)
+		return "", nil
+	})
+	approvals := NewApprovalService(queries)
+	run := func() {
+		t.Helper()
+		require.NoError(t, automation.RunForUser(ctx, userID, time.Now()))
+	}
+
+	// Matches of a rule requiring approval are queued with their message, not performed
+	run()
+	assert.Empty(t, performed)
+	require.Len(t, database.executions, 2)
+	ada, grace := database.executions[0], database.executions[1]
+	assert.Equal(t, RuleExecutionAwaitingApproval, ada.Status)
+	assert.Equal(t, "Hi Ada", ada.Message.String)
+	assert.Equal(t, RuleExecutionAwaitingApproval, grace.Status)
+
+	// Later runs leave queued actions alone
+	run()
+	assert.Empty(t, performed)
+	assert.Len(t, database.executions, 2)
+
+	decision, err := approvals.Approve(ctx, uuidString(userID), models.ApprovalDecisionRequest{IDs: []string{uuidString(ada.ID)}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{uuidString(ada.ID)}, decision.IDs)
+	decision, err = approvals.Reject(ctx, uuidString(userID), models.ApprovalDecisionRequest{IDs: []string{uuidString(grace.ID), uuidString(ada.ID)}})
+	require.NoError(t, err)
+	// Ada was decided already
+	assert.Equal(t, []string{uuidString(grace.ID)}, decision.IDs)
+	assert.Equal(t, RuleExecutionApproved, ada.Status)
+	assert.True(t, ada.ApprovedAt.Valid)
+	assert.Equal(t, RuleExecutionRejected, grace.Status)
+
+	// The approved action is performed with its approved message; the
+	// rejected one is neither performed nor queued again
+	run()
+	require.Len(t, performed, 1)
+	assert.Equal(t, ada.ProfileID, performed[0].ProfileID)
+	assert.Equal(t, "Hi Ada", performed[0].Note)
+	assert.Equal(t, RuleExecutionSucceeded, ada.Status)
+	assert.Equal(t, RuleExecutionRejected, grace.Status)
+
+	// Decided actions are never performed or queued again
+	run()
+	assert.Len(t, performed, 1)
+	assert.Len(t, database.executions, 2)
+	assert.Equal(t, RuleExecutionSucceeded, ada.Status)
+	assert.Equal(t, RuleExecutionRejected, grace.Status)
+}
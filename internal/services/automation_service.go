@@ -0,0 +1,606 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/infra/logger"
+	"linkedin-watcher/internal/condition"
+	"linkedin-watcher/internal/message"
+	"linkedin-watcher/internal/models"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// NotificationKindRuleMatch is the notification kind for profiles matched by notify rules
+const NotificationKindRuleMatch = "rule_match"
+
+// Rule execution statuses stored in rule_executions.status
+const (
+	RuleExecutionPending   = "pending"
+	RuleExecutionSucceeded = "succeeded"
+	RuleExecutionFailed    = "failed"
+	RuleExecutionSkipped   = "skipped"
+	// Actions of rules requiring approval wait for the user's decision
+	RuleExecutionAwaitingApproval = "awaiting_approval"
+	RuleExecutionApproved         = "approved"
+	RuleExecutionRejected         = "rejected"
+)
+
+const (
+	// maxRuleAttempts is how many times a rule tries to action the same profile
+	maxRuleAttempts = 3
+
+	// savedProfileTag is the tag save_profile rules put on the profiles they match
+	savedProfileTag = "saved"
+)
+
+// ErrActionSkipped is returned by executors when the action cannot be performed
+// on the profile and retrying would not help
+var ErrActionSkipped = errors.New("action skipped")
+
+// RuleAction is one profile matched by one automation rule
+type RuleAction struct {
+	UserID          pgtype.UUID
+	RuleID          pgtype.UUID
+	RuleName        string
+	TriggerType     string
+	ActionType      string
+	MessageTemplate string
+	ProfileID       pgtype.UUID
+	LinkedinURL     string
+	Name            string
+	Location        string
+	Headline        string
+	Company         string
+	NetworkScore    float64
+	// NewPosition is the position a job change trigger moved the profile to
+	NewPosition string
+	// Condition is the rule's condition expression, empty when it has none
+	Condition    string
+	Degree       int
+	Tags         []string
+	DiscoveredAt time.Time
+	// Via is the tracked connection bridging to the profile
+	Via string
+	// Note is MessageTemplate rendered for the profile
+	Note string
+	// RequiresApproval queues the action for the user instead of performing it
+	RequiresApproval bool
+	// Approved marks an action the user approved; Note is then the approved
+	// message and ExecutionID the execution holding it
+	Approved    bool
+	ExecutionID pgtype.UUID
+	// Timezone is the rule's own timezone for its execution window, empty for the user's
+	Timezone string
+	// SequenceStep is the step of the rule's sequence the action performs,
+	// 0 for the rule's own action
+	SequenceStep int
+}
+
+// ActionExecutor performs a rule's action on a matched profile. The outcome,
+// if not empty, records what it found or did, e.g. "already_connected".
+type ActionExecutor func(ctx context.Context, action RuleAction) (outcome string, err error)
+
+type AutomationService struct {
+	queries     *db.Queries
+	notifier    *NotificationService
+	invitations *InvitationService
+	windows     *ExecutionWindowService
+	executors   map[string]ActionExecutor
+	checks      map[string]EventCheck
+	// mu serializes runs and sequence passes, so that two runs cannot both
+	// spend the same quota and one browser session acts at a time
+	mu sync.Mutex
+}
+
+func NewAutomationService(queries *db.Queries, notifier *NotificationService, invitations *InvitationService, windows *ExecutionWindowService) *AutomationService {
+	s := &AutomationService{
+		queries:     queries,
+		notifier:    notifier,
+		invitations: invitations,
+		windows:     windows,
+		executors:   make(map[string]ActionExecutor),
+		checks:      make(map[string]EventCheck),
+	}
+	s.Register(RuleActionNotify, s.notify)
+	s.Register(RuleActionSaveProfile, s.saveProfile)
+	return s
+}
+
+// Register sets the executor for an action type, replacing any registered before
+func (s *AutomationService) Register(actionType string, executor ActionExecutor) {
+	s.executors[actionType] = executor
+}
+
+// RunAll runs the active rules of every user. It runs more often than
+// connection checks, so that connection requests are spread across the day.
+func (s *AutomationService) RunAll(ctx context.Context) error {
+	userIDs, err := s.queries.ListUserIDsWithActiveRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		if err := s.RunForUser(ctx, userID, time.Now()); err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", uuidString(userID), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// RunForUser actions the profiles newly matching the user's active rules and
+// their conditions:
+// connections discovered and job changes detected since each rule was created.
+// Rules requiring approval queue their actions instead, and the actions the
+// user approved since the last run are performed. Actions are only performed
+// while the user's execution window is open in their rule's timezone; the
+// others stay unclaimed or approved for a later run.
+// It is run after every connection check and by RunAll.
+func (s *AutomationService) RunForUser(ctx context.Context, userID pgtype.UUID, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	window, err := s.windows.window(ctx, userID)
+	if err != nil {
+		return err
+	}
+	actions, err := s.matchRules(ctx, userID)
+	if err != nil {
+		return err
+	}
+	approved, err := s.approvedActions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	actions = append(actions, approved...)
+
+	var errs []error
+	var invitations []RuleAction
+	held := 0
+	for _, action := range actions {
+		switch {
+		case action.RequiresApproval:
+			if err := s.queue(ctx, action); err != nil {
+				errs = append(errs, err)
+			}
+		case !window.openAt(now, window.location(action.Timezone)):
+			held++
+		case action.ActionType == RuleActionSendConnectionRequest:
+			invitations = append(invitations, action)
+		default:
+			if _, err := s.execute(ctx, action); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if held > 0 {
+		logger.Infof("%d actions of user %s wait for their execution window", held, uuidString(userID))
+	}
+	if err := s.sendInvitations(ctx, userID, invitations); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// sendInvitations sends the connection requests the user's quota and pacing
+// allow, approved ones first, then highest network score first. The rest stay
+// unclaimed or approved and are picked up by later runs, so they roll over to
+// the next window in the same order.
+func (s *AutomationService) sendInvitations(ctx context.Context, userID pgtype.UUID, actions []RuleAction) error {
+	if len(actions) == 0 {
+		return nil
+	}
+	if _, ok := s.executors[RuleActionSendConnectionRequest]; !ok {
+		logger.Debugf("Skipping %d connection requests: no executor for %s", len(actions), RuleActionSendConnectionRequest)
+		return nil
+	}
+
+	quota, err := s.invitations.quota(ctx, userID)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(actions, func(i, j int) bool {
+		if actions[i].Approved != actions[j].Approved {
+			return actions[i].Approved
+		}
+		return actions[i].NetworkScore > actions[j].NetworkScore
+	})
+
+	var errs []error
+	for i, action := range actions {
+		if !quota.CanSendNow {
+			logger.Infof("%d connection requests of user %s wait for invitation quota", len(actions)-i, uuidString(userID))
+			break
+		}
+
+		outcome, err := s.execute(ctx, action)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		switch outcome {
+		case ConnectOutcomeSent:
+			if err := s.invitations.recordSent(ctx, userID, action.ProfileID); err != nil {
+				errs = append(errs, err)
+			}
+			// Pacing holds the next one back for a later run
+			quota.CanSendNow = false
+		case ConnectOutcomeLimitReached:
+			if err := s.invitations.pause(ctx, userID); err != nil {
+				errs = append(errs, err)
+			}
+			quota.CanSendNow = false
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// matchRules returns one action per rule and matched profile, new connections first
+func (s *AutomationService) matchRules(ctx context.Context, userID pgtype.UUID) ([]RuleAction, error) {
+	connections, err := s.queries.GetProfilesMatchingRules(ctx, db.GetProfilesMatchingRulesParams{
+		UserID:      userID,
+		MaxAttempts: maxRuleAttempts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to match new connections against rules: %w", err)
+	}
+	jobChanges, err := s.queries.GetJobChangesMatchingRules(ctx, db.GetJobChangesMatchingRulesParams{
+		UserID:      userID,
+		MaxAttempts: maxRuleAttempts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to match job changes against rules: %w", err)
+	}
+
+	actions := make([]RuleAction, 0, len(connections)+len(jobChanges))
+	for _, row := range connections {
+		actions = append(actions, RuleAction{
+			UserID:           userID,
+			RuleID:           row.RuleID,
+			RuleName:         row.RuleName,
+			TriggerType:      RuleTriggerNewConnection,
+			ActionType:       row.ActionType,
+			MessageTemplate:  textValue(row.MessageTemplate),
+			ProfileID:        row.ID,
+			LinkedinURL:      row.LinkedinUrl,
+			Name:             row.Name,
+			Location:         textValue(row.Location),
+			Headline:         textValue(row.Headline),
+			Company:          textValue(row.CompanyName),
+			NetworkScore:     row.NetworkScore,
+			Condition:        textValue(row.Condition),
+			Degree:           int(row.Degree),
+			Tags:             row.Tags,
+			DiscoveredAt:     row.DiscoveredAt.Time,
+			Via:              textValue(row.ViaName),
+			RequiresApproval: row.RequiresApproval,
+			Timezone:         textValue(row.RuleTimezone),
+		})
+	}
+
+	// A profile may have changed jobs more than once since the rule was
+	// created; its latest change, listed first, is the one evaluated
+	type ruleProfile struct{ rule, profile pgtype.UUID }
+	seen := make(map[ruleProfile]bool, len(jobChanges))
+	for _, row := range jobChanges {
+		key := ruleProfile{row.RuleID, row.ID}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		actions = append(actions, RuleAction{
+			UserID:           userID,
+			RuleID:           row.RuleID,
+			RuleName:         row.RuleName,
+			TriggerType:      RuleTriggerJobChange,
+			ActionType:       row.ActionType,
+			MessageTemplate:  textValue(row.MessageTemplate),
+			ProfileID:        row.ID,
+			LinkedinURL:      row.LinkedinUrl,
+			Name:             row.Name,
+			Location:         textValue(row.Location),
+			Headline:         textValue(row.Headline),
+			Company:          row.CompanyName,
+			NetworkScore:     row.NetworkScore,
+			NewPosition:      textValue(row.NewPosition),
+			Condition:        textValue(row.Condition),
+			Degree:           int(row.Degree),
+			Tags:             row.Tags,
+			DiscoveredAt:     row.DiscoveredAt.Time,
+			Via:              textValue(row.ViaName),
+			RequiresApproval: row.RequiresApproval,
+			Timezone:         textValue(row.RuleTimezone),
+		})
+	}
+
+	return matchConditions(actions), nil
+}
+
+// approvedActions returns the actions the user approved that are still to be
+// performed, including approved actions to retry after failing
+func (s *AutomationService) approvedActions(ctx context.Context, userID pgtype.UUID) ([]RuleAction, error) {
+	rows, err := s.queries.ListApprovedRuleActions(ctx, db.ListApprovedRuleActionsParams{
+		UserID:      userID,
+		MaxAttempts: maxRuleAttempts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approved actions: %w", err)
+	}
+
+	actions := make([]RuleAction, 0, len(rows))
+	for _, row := range rows {
+		actions = append(actions, RuleAction{
+			UserID:       userID,
+			RuleID:       row.RuleID,
+			RuleName:     row.RuleName,
+			TriggerType:  row.TriggerType,
+			ActionType:   row.ActionType,
+			ProfileID:    row.ID,
+			LinkedinURL:  row.LinkedinUrl,
+			Name:         row.Name,
+			Location:     textValue(row.Location),
+			Headline:     textValue(row.Headline),
+			Company:      textValue(row.CompanyName),
+			NetworkScore: row.NetworkScore,
+			Via:          textValue(row.ViaName),
+			Note:         textValue(row.Message),
+			Approved:     true,
+			ExecutionID:  row.ExecutionID,
+			Timezone:     textValue(row.RuleTimezone),
+		})
+	}
+	return actions, nil
+}
+
+// matchConditions keeps the actions whose profile satisfies their rule's
+// condition. Each condition is parsed once; conditions are validated when
+// rules are saved, so one that no longer parses skips its rule.
+func matchConditions(actions []RuleAction) []RuleAction {
+	conditions := make(map[pgtype.UUID]*condition.Condition)
+	matched := actions[:0]
+	for _, action := range actions {
+		if action.Condition == "" {
+			matched = append(matched, action)
+			continue
+		}
+		cond, ok := conditions[action.RuleID]
+		if !ok {
+			var err error
+			cond, err = condition.Parse(action.Condition)
+			if err != nil {
+				logger.Warnf("Skipping rule %q: %v", action.RuleName, err)
+			}
+			conditions[action.RuleID] = cond
+		}
+		if cond != nil && cond.Match(conditionProfile(action)) {
+			matched = append(matched, action)
+		}
+	}
+	return matched
+}
+
+// conditionProfile returns the fields of the action's profile a condition can
+// refer to. For job changes, company is the company the profile moved to.
+func conditionProfile(action RuleAction) condition.Profile {
+	return condition.Profile{
+		Headline:     action.Headline,
+		Company:      action.Company,
+		Location:     action.Location,
+		Degree:       action.Degree,
+		Tags:         action.Tags,
+		DiscoveredAt: action.DiscoveredAt,
+		Via:          action.Via,
+	}
+}
+
+// execute claims an action, performs it and records whether it succeeded,
+// returning the executor's outcome. A failing executor is recorded rather than
+// returned, so it is retried by later runs unless it skipped the action.
+// Actions without an executor are left unclaimed until one is registered.
+func (s *AutomationService) execute(ctx context.Context, action RuleAction) (string, error) {
+	executor, ok := s.executors[action.ActionType]
+	if !ok {
+		logger.Debugf("Skipping rule %q: no executor for %s", action.RuleName, action.ActionType)
+		return "", nil
+	}
+
+	execution, err := s.claim(ctx, action)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to claim rule execution: %w", err)
+	}
+
+	var outcome string
+	err = renderNote(&action)
+	if err == nil {
+		outcome, err = executor(ctx, action)
+	}
+
+	if err := s.finish(ctx, action, execution, outcome, err); err != nil {
+		return "", err
+	}
+	return outcome, nil
+}
+
+// queue claims an action of a rule requiring approval and holds it, with its
+// rendered message, until the user approves or rejects it
+func (s *AutomationService) queue(ctx context.Context, action RuleAction) error {
+	execution, err := s.claim(ctx, action)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to claim rule execution: %w", err)
+	}
+
+	// An over-long note is queued all the same, for the user to shorten
+	rendered, err := renderMessage(action)
+	if err != nil {
+		return s.finish(ctx, action, execution, "", err)
+	}
+
+	err = s.queries.QueueRuleExecution(ctx, db.QueueRuleExecutionParams{
+		ID:      execution.ID,
+		Message: pgtype.Text{String: rendered.Text, Valid: action.MessageTemplate != ""},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to queue rule execution: %w", err)
+	}
+	logger.Infof("Rule %q queued %s for approval", action.RuleName, action.Name)
+	return nil
+}
+
+// claim claims the execution of an action: the approved one, or a new or
+// failed one. It returns pgx.ErrNoRows when there is nothing to claim.
+func (s *AutomationService) claim(ctx context.Context, action RuleAction) (db.RuleExecution, error) {
+	if action.Approved {
+		return s.queries.StartApprovedRuleExecution(ctx, db.StartApprovedRuleExecutionParams{
+			ID:          action.ExecutionID,
+			MaxAttempts: maxRuleAttempts,
+		})
+	}
+	return s.queries.ClaimRuleExecution(ctx, db.ClaimRuleExecutionParams{
+		RuleID:      action.RuleID,
+		ProfileID:   action.ProfileID,
+		MaxAttempts: maxRuleAttempts,
+	})
+}
+
+// finish records how a claimed execution ended, and starts the rule's
+// sequence for the profile if it succeeded
+func (s *AutomationService) finish(ctx context.Context, action RuleAction, execution db.RuleExecution, outcome string, err error) error {
+	status, failure := executionStatus(err)
+	switch status {
+	case RuleExecutionSkipped:
+		logger.Infof("Rule %q skipped %s: %v", action.RuleName, action.Name, err)
+	case RuleExecutionFailed:
+		logger.Warnf("Rule %q failed on %s (attempt %d): %v", action.RuleName, action.Name, execution.Attempts, err)
+	}
+
+	err = s.queries.FinishRuleExecution(ctx, db.FinishRuleExecutionParams{
+		ID:      execution.ID,
+		Status:  status,
+		Outcome: pgtype.Text{String: outcome, Valid: outcome != ""},
+		Error:   failure,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record rule execution: %w", err)
+	}
+	if status == RuleExecutionSucceeded {
+		return s.enroll(ctx, action)
+	}
+	return nil
+}
+
+// executionStatus returns the status an executor's error records, and the error to store
+func executionStatus(err error) (string, pgtype.Text) {
+	switch {
+	case errors.Is(err, ErrActionSkipped):
+		return RuleExecutionSkipped, pgtype.Text{String: err.Error(), Valid: true}
+	case err != nil:
+		return RuleExecutionFailed, pgtype.Text{String: err.Error(), Valid: true}
+	}
+	return RuleExecutionSucceeded, pgtype.Text{}
+}
+
+// renderNote renders the rule's message template into the action's note,
+// unless the user approved the note already. A connection note LinkedIn would
+// reject fails the action rather than being cut.
+func renderNote(action *RuleAction) error {
+	if !action.Approved {
+		rendered, err := renderMessage(*action)
+		if err != nil {
+			return err
+		}
+		action.Note = rendered.Text
+	}
+	length := utf8.RuneCountInString(action.Note)
+	if action.ActionType == RuleActionSendConnectionRequest && length > message.MaxNoteLength {
+		return fmt.Errorf("note is %d characters, over LinkedIn's %d character limit", length, message.MaxNoteLength)
+	}
+	return nil
+}
+
+// renderMessage renders the rule's message template for the action's profile
+func renderMessage(action RuleAction) (message.Rendered, error) {
+	if action.MessageTemplate == "" {
+		return message.Rendered{}, nil
+	}
+	tmpl, err := message.Parse(action.MessageTemplate)
+	if err != nil {
+		return message.Rendered{}, fmt.Errorf("invalid message template: %w", err)
+	}
+	return tmpl.Render(messageVars(action)), nil
+}
+
+// messageVars returns the values of the action's profile a message template can refer to
+func messageVars(action RuleAction) message.Vars {
+	return message.Vars{
+		Name:     action.Name,
+		Company:  action.Company,
+		Headline: action.Headline,
+		Location: action.Location,
+		Mutual:   action.Via,
+	}
+}
+
+// notify alerts the user to the matched profile
+func (s *AutomationService) notify(ctx context.Context, action RuleAction) (string, error) {
+	return "", s.notifier.Notify(ctx, action.UserID, ruleAlert(action))
+}
+
+// saveProfile tags the matched profile as saved for the user
+func (s *AutomationService) saveProfile(ctx context.Context, action RuleAction) (string, error) {
+	err := s.queries.CreateProfileTag(ctx, db.CreateProfileTagParams{
+		UserID:    action.UserID,
+		ProfileID: action.ProfileID,
+		Tag:       savedProfileTag,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to tag profile: %w", err)
+	}
+	return "", nil
+}
+
+// ruleAlert builds the notification for a profile matched by a notify rule
+func ruleAlert(action RuleAction) Alert {
+	title := fmt.Sprintf("%s matched %q", action.Name, action.RuleName)
+	body := action.Name
+	if action.SequenceStep > 0 {
+		title = fmt.Sprintf("%s reached step %d of %q", action.Name, action.SequenceStep, action.RuleName)
+	} else if action.TriggerType == RuleTriggerJobChange {
+		title = fmt.Sprintf("%s changed jobs, matching %q", action.Name, action.RuleName)
+		if role := roleLabel(action.NewPosition, action.Company); role != "" {
+			body += " is now " + role
+		}
+	} else if action.Headline != "" {
+		body += " (" + action.Headline + ")"
+	}
+
+	return Alert{
+		Kind:  NotificationKindRuleMatch,
+		Title: title,
+		Body:  body + " " + action.LinkedinURL,
+		Payload: map[string]interface{}{
+			"rule_id": uuidString(action.RuleID),
+			"profile": models.ProfileRef{
+				ID:          uuidString(action.ProfileID),
+				Name:        action.Name,
+				LinkedinURL: action.LinkedinURL,
+			},
+		},
+	}
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleAlert(t *testing.T) {
+	action := RuleAction{
+		RuleID:      testProfileID(1),
+		RuleName:    "Madrid engineers",
+		TriggerType: RuleTriggerNewConnection,
+		ProfileID:   testProfileID(2),
+		LinkedinURL: "https://www.linkedin.com/in/ada",
+		Name:        "Ada Lovelace",
+		Headline:    "Platform Engineer",
+	}
+
+	alert := ruleAlert(action)
+
+	assert.Equal(t, NotificationKindRuleMatch, alert.Kind)
+	assert.Equal(t, `Ada Lovelace matched "Madrid engineers"`, alert.Title)
+	assert.Equal(t, "Ada Lovelace (Platform Engineer) https://www.linkedin.com/in/ada", alert.Body)
+	payload := alert.Payload.(map[string]interface{})
+	assert.Equal(t, uuidString(testProfileID(1)), payload["rule_id"])
+	assert.Equal(t, uuidString(testProfileID(2)), payload["profile"].(models.ProfileRef).ID)
+
+	action.TriggerType = RuleTriggerJobChange
+	action.NewPosition = "CTO"
+	action.Company = "Globex"
+	alert = ruleAlert(action)
+
+	assert.Equal(t, `Ada Lovelace changed jobs, matching "Madrid engineers"`, alert.Title)
+	assert.Equal(t, "Ada Lovelace is now CTO at Globex https://www.linkedin.com/in/ada", alert.Body)
+
+	action.SequenceStep = 2
+	alert = ruleAlert(action)
+
+	assert.Equal(t, `Ada Lovelace reached step 2 of "Madrid engineers"`, alert.Title)
+}
+
+func TestAutomationService_SkipsActionsWithoutExecutor(t *testing.T) {
+	service := NewAutomationService(nil, nil, nil, nil)
+	delete(service.executors, RuleActionNotify)
+
+	// Without an executor nothing is claimed, so the nil queries are never used
+	_, err := service.execute(context.Background(), RuleAction{ActionType: RuleActionSendConnectionRequest})
+	assert.NoError(t, err)
+	_, err = service.execute(context.Background(), RuleAction{ActionType: RuleActionNotify})
+	assert.NoError(t, err)
+	// Nor is the invitation quota loaded
+	err = service.sendInvitations(context.Background(), testProfileID(1), []RuleAction{{ActionType: RuleActionSendConnectionRequest}})
+	assert.NoError(t, err)
+}
+
+func TestAutomationService_ActionsEachMatchOnce(t *testing.T) {
+	ctx := context.Background()
+	userID := testProfileID(100)
+	rule := testProfileID(1)
+	candidate := func(profile pgtype.UUID, name string) db.GetProfilesMatchingRulesRow {
+		return db.GetProfilesMatchingRulesRow{
+			ID:          profile,
+			LinkedinUrl: "https://www.linkedin.com/in/" + name,
+			Name:        name,
+			RuleID:      rule,
+			RuleName:    "Founders",
+			ActionType:  RuleActionSaveProfile,
+		}
+	}
+	database := &fakeRuleExecutions{candidates: []db.GetProfilesMatchingRulesRow{
+		candidate(testProfileID(2), "Ada"),
+		candidate(testProfileID(3), "Grace"),
+		candidate(testProfileID(4), "Linus"),
+	}}
+	queries := db.New(database)
+
+	automation := NewAutomationService(queries, nil, nil, NewExecutionWindowService(queries, time.UTC))
+	performed := map[string]int{}
+	automation.Register(RuleActionSaveProfile, func(_ context.Context, action RuleAction) (string, error) {
+		performed[action.Name]++
+		switch action.Name {
+		case "Grace":
+			return "", errors.New("profile unavailable")
+		case "Linus":
+			return "", fmt.Errorf("%w: already saved", ErrActionSkipped)
+		}
+		return "", nil
+	})
+	status := func(name string) string {
+		for _, row := range database.candidates {
+			if row.Name == name {
+				return database.find(rule, row.ID).Status
+			}
+		}
+		return ""
+	}
+
+	// Each match is performed and its outcome recorded
+	require.NoError(t, automation.RunForUser(ctx, userID, time.Now()))
+	assert.Equal(t, map[string]int{"Ada": 1, "Grace": 1, "Linus": 1}, performed)
+	assert.Equal(t, RuleExecutionSucceeded, status("Ada"))
+	assert.Equal(t, RuleExecutionFailed, status("Grace"))
+	assert.Equal(t, RuleExecutionSkipped, status("Linus"))
+
+	// Only the failure is retried, until it has used up its attempts
+	for range maxRuleAttempts - 1 {
+		require.NoError(t, automation.RunForUser(ctx, userID, time.Now()))
+	}
+	assert.Equal(t, map[string]int{"Ada": 1, "Grace": maxRuleAttempts, "Linus": 1}, performed)
+	assert.Equal(t, int32(maxRuleAttempts), database.find(rule, testProfileID(3)).Attempts)
+
+	// After that it is no longer a candidate at all
+	require.NoError(t, automation.RunForUser(ctx, userID, time.Now()))
+	assert.Equal(t, map[string]int{"Ada": 1, "Grace": maxRuleAttempts, "Linus": 1}, performed)
+	assert.Zero(t, database.matched)
+	assert.Len(t, database.executions, 3)
+}
+
+func TestMatchConditions(t *testing.T) {
+	actions := []RuleAction{
+		{RuleID: testProfileID(1), Name: "No condition"},
+		{RuleID: testProfileID(2), Name: "Ada", Company: "Acme", Degree: 2, Condition: `company == "acme" and degree <= 2`},
+		{RuleID: testProfileID(2), Name: "Grace", Company: "Globex", Degree: 2, Condition: `company == "acme" and degree <= 2`},
+		{RuleID: testProfileID(3), Name: "Linus", Tags: []string{"investor"}, Condition: `tags contains "investor"`},
+		{RuleID: testProfileID(4), Name: "Broken", Condition: `company = "Acme"`},
+	}
+
+	var names []string
+	for _, action := range matchConditions(actions) {
+		names = append(names, action.Name)
+	}
+	assert.Equal(t, []string{"No condition", "Ada", "Linus"}, names)
+}
+
+func TestRenderNote(t *testing.T) {
+	action := RuleAction{
+		ActionType:      RuleActionSendConnectionRequest,
+		MessageTemplate: `Hi {{first_name | "there"}}, {{mutual_first_name}} suggested we connect`,
+		Name:            "Ada Lovelace",
+		Via:             "Jane Doe",
+	}
+	assert.NoError(t, renderNote(&action))
+	assert.Equal(t, "Hi Ada, Jane suggested we connect", action.Note)
+
+	action.MessageTemplate = strings.Repeat("a", 295) + " {{company}}"
+	action.Company = "Globex Corporation"
+	assert.ErrorContains(t, renderNote(&action), "over LinkedIn's 300 character limit")
+
+	// Only connection notes are limited
+	action.ActionType = RuleActionNotify
+	assert.NoError(t, renderNote(&action))
+
+	// Approved notes are sent as the user edited them, within the limit
+	action = RuleAction{
+		ActionType:      RuleActionSendConnectionRequest,
+		MessageTemplate: `Hi {{first_name}}`,
+		Name:            "Ada Lovelace",
+		Note:            "Hi Ada, great talk yesterday",
+		Approved:        true,
+	}
+	assert.NoError(t, renderNote(&action))
+	assert.Equal(t, "Hi Ada, great talk yesterday", action.Note)
+	action.Note = strings.Repeat("ñ", 301)
+	assert.ErrorContains(t, renderNote(&action), "301 characters")
+}
@@ -0,0 +1,142 @@
+package services
+
+import "math"
+
+const (
+	pageRankDamping       = 0.85
+	pageRankMaxIterations = 100
+	pageRankTolerance     = 1e-9
+)
+
+// degreeCentrality returns the number of distinct neighbours of every node
+func degreeCentrality(g *networkGraph) []int {
+	degrees := make([]int, g.size())
+	for i, neighbours := range g.adjacency {
+		degrees[i] = len(neighbours)
+	}
+	return degrees
+}
+
+// betweennessCentrality computes normalized betweenness with Brandes' algorithm.
+// Scores are in [0, 1]: the fraction of shortest paths between other node pairs
+// that pass through the node.
+func betweennessCentrality(g *networkGraph) []float64 {
+	n := g.size()
+	scores := make([]float64, n)
+
+	stack := make([]int, 0, n)
+	queue := make([]int, 0, n)
+	predecessors := make([][]int, n)
+	sigma := make([]float64, n)
+	distance := make([]int, n)
+	delta := make([]float64, n)
+
+	for s := 0; s < n; s++ {
+		stack = stack[:0]
+		queue = queue[:0]
+		for i := 0; i < n; i++ {
+			predecessors[i] = predecessors[i][:0]
+			sigma[i] = 0
+			distance[i] = -1
+			delta[i] = 0
+		}
+		sigma[s] = 1
+		distance[s] = 0
+		queue = append(queue, s)
+
+		for head := 0; head < len(queue); head++ {
+			v := queue[head]
+			stack = append(stack, v)
+			for _, w := range g.adjacency[v] {
+				if distance[w] < 0 {
+					distance[w] = distance[v] + 1
+					queue = append(queue, w)
+				}
+				if distance[w] == distance[v]+1 {
+					sigma[w] += sigma[v]
+					predecessors[w] = append(predecessors[w], v)
+				}
+			}
+		}
+
+		for i := len(stack) - 1; i >= 0; i-- {
+			w := stack[i]
+			for _, v := range predecessors[w] {
+				delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+			}
+			if w != s {
+				scores[w] += delta[w]
+			}
+		}
+	}
+
+	// Every undirected path was counted from both ends, so dividing by
+	// (n-1)(n-2) rather than (n-1)(n-2)/2 also halves the raw sums
+	if n > 2 {
+		scale := 1 / float64((n-1)*(n-2))
+		for i := range scores {
+			scores[i] *= scale
+		}
+	}
+
+	return scores
+}
+
+// pageRank runs power iteration over the undirected graph and scales the result
+// so the highest-ranked node scores 1. Rank held by isolated nodes is spread evenly.
+func pageRank(g *networkGraph) []float64 {
+	n := g.size()
+	if n == 0 {
+		return nil
+	}
+
+	rank := make([]float64, n)
+	next := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+
+	for iter := 0; iter < pageRankMaxIterations; iter++ {
+		dangling := 0.0
+		for i, neighbours := range g.adjacency {
+			if len(neighbours) == 0 {
+				dangling += rank[i]
+			}
+		}
+
+		base := (1-pageRankDamping)/float64(n) + pageRankDamping*dangling/float64(n)
+		for i := range next {
+			next[i] = base
+		}
+		for i, neighbours := range g.adjacency {
+			if len(neighbours) == 0 {
+				continue
+			}
+			share := pageRankDamping * rank[i] / float64(len(neighbours))
+			for _, j := range neighbours {
+				next[j] += share
+			}
+		}
+
+		diff := 0.0
+		for i := range rank {
+			diff += math.Abs(next[i] - rank[i])
+		}
+		rank, next = next, rank
+		if diff < pageRankTolerance {
+			break
+		}
+	}
+
+	highest := 0.0
+	for _, r := range rank {
+		highest = math.Max(highest, r)
+	}
+	if highest > 0 {
+		for i := range rank {
+			rank[i] /= highest
+		}
+	}
+
+	return rank
+}
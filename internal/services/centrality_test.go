@@ -0,0 +1,80 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func testProfileID(n byte) pgtype.UUID {
+	return pgtype.UUID{Bytes: [16]byte{15: n}, Valid: true}
+}
+
+// buildTestGraph creates a graph with nodes 0..n-1 and the given edges
+func buildTestGraph(n int, edges [][2]int) *networkGraph {
+	g := newNetworkGraph()
+	for i := 0; i < n; i++ {
+		g.addNode(testProfileID(byte(i)))
+	}
+	for _, e := range edges {
+		g.addEdge(testProfileID(byte(e[0])), testProfileID(byte(e[1])))
+	}
+	return g
+}
+
+func TestNetworkGraph_IgnoresDuplicateEdgesAndSelfLoops(t *testing.T) {
+	g := buildTestGraph(2, [][2]int{{0, 1}, {1, 0}, {0, 0}})
+
+	assert.Equal(t, 2, g.size())
+	assert.Equal(t, []int{1, 1}, degreeCentrality(g))
+}
+
+func TestBetweennessCentrality_Star(t *testing.T) {
+	// Node 0 sits on every shortest path between the leaves
+	g := buildTestGraph(4, [][2]int{{0, 1}, {0, 2}, {0, 3}})
+
+	scores := betweennessCentrality(g)
+
+	assert.InDelta(t, 1.0, scores[0], 1e-9)
+	for _, leaf := range scores[1:] {
+		assert.InDelta(t, 0.0, leaf, 1e-9)
+	}
+}
+
+func TestBetweennessCentrality_Path(t *testing.T) {
+	// 0 - 1 - 2 - 3 - 4: the middle node bridges 4 of the 6 pairs it is not part of
+	g := buildTestGraph(5, [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}})
+
+	scores := betweennessCentrality(g)
+
+	assert.InDelta(t, 0.0, scores[0], 1e-9)
+	assert.InDelta(t, 0.5, scores[1], 1e-9)
+	assert.InDelta(t, 4.0/6.0, scores[2], 1e-9)
+	assert.InDelta(t, scores[1], scores[3], 1e-9)
+}
+
+func TestPageRank_HubRanksHighest(t *testing.T) {
+	g := buildTestGraph(5, [][2]int{{0, 1}, {0, 2}, {0, 3}, {3, 4}})
+
+	ranks := pageRank(g)
+
+	assert.InDelta(t, 1.0, ranks[0], 1e-9)
+	for _, r := range ranks[1:] {
+		assert.Less(t, r, ranks[0])
+		assert.Greater(t, r, 0.0)
+	}
+	assert.InDelta(t, ranks[1], ranks[2], 1e-9)
+}
+
+func TestPageRank_IsolatedNodes(t *testing.T) {
+	g := buildTestGraph(3, nil)
+
+	ranks := pageRank(g)
+
+	assert.Equal(t, []float64{1, 1, 1}, ranks)
+}
+
+func TestPageRank_EmptyGraph(t *testing.T) {
+	assert.Empty(t, pageRank(newNetworkGraph()))
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/infra/logger"
+	"linkedin-watcher/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ClusterService struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewClusterService(pool *pgxpool.Pool, queries *db.Queries) *ClusterService {
+	return &ClusterService{
+		pool:    pool,
+		queries: queries,
+	}
+}
+
+// RecomputeAll re-detects communities for every active user with tracked connections.
+// A failure for one user is logged and does not stop the others.
+func (s *ClusterService) RecomputeAll(ctx context.Context) error {
+	userIDs, err := s.queries.ListUserIDsWithTrackedConnections(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		if err := s.RecomputeForUser(ctx, userID); err != nil {
+			logger.Errorf("community detection failed for user %s: %v", uuidString(userID), err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// RecomputeForUser detects communities in the user's stored graph and replaces
+// the user's clusters in a single transaction, so readers never see a partial set.
+func (s *ClusterService) RecomputeForUser(ctx context.Context, userID pgtype.UUID) error {
+	graph, err := loadNetworkGraph(ctx, s.queries, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load network graph: %w", err)
+	}
+
+	clusters := detectClusters(graph)
+	computedAt := pgtype.Timestamp{Time: time.Now(), Valid: true}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	// Memberships are removed with their clusters
+	if err := qtx.DeleteNetworkClustersForUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete clusters: %w", err)
+	}
+
+	for _, cluster := range clusters {
+		saved, err := qtx.CreateNetworkCluster(ctx, db.CreateNetworkClusterParams{
+			UserID:       userID,
+			Label:        cluster.label,
+			Size:         int32(len(cluster.members)),
+			TopCompanies: cluster.topCompanies,
+			TopLocations: cluster.topLocations,
+			ComputedAt:   computedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create cluster: %w", err)
+		}
+
+		for _, i := range cluster.members {
+			err := qtx.CreateProfileCluster(ctx, db.CreateProfileClusterParams{
+				UserID:    userID,
+				ProfileID: graph.profileIDs[i],
+				ClusterID: saved.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to assign profile to cluster: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit clusters: %w", err)
+	}
+
+	logger.Infof("communities detected for user %s: %d clusters over %d profiles", uuidString(userID), len(clusters), graph.size())
+	return nil
+}
+
+// ListClusters returns the user's clusters, largest first
+func (s *ClusterService) ListClusters(ctx context.Context, userID string) ([]models.NetworkCluster, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListNetworkClusters(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	clusters := make([]models.NetworkCluster, 0, len(rows))
+	for _, row := range rows {
+		clusters = append(clusters, networkClusterModel(row))
+	}
+
+	return clusters, nil
+}
+
+// ListClusterProfiles returns the profiles assigned to one of the user's clusters
+func (s *ClusterService) ListClusterProfiles(ctx context.Context, userID, clusterID string) ([]models.ClusterProfile, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	clusterUUID, err := parseUUID(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.queries.GetNetworkCluster(ctx, db.GetNetworkClusterParams{
+		ID:     clusterUUID,
+		UserID: userUUID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	rows, err := s.queries.GetClusterProfiles(ctx, db.GetClusterProfilesParams{
+		ClusterID: clusterUUID,
+		UserID:    userUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster profiles: %w", err)
+	}
+
+	profiles := make([]models.ClusterProfile, 0, len(rows))
+	for _, row := range rows {
+		profiles = append(profiles, models.ClusterProfile{
+			ID:          uuidString(row.ID),
+			LinkedinURL: row.LinkedinUrl,
+			Name:        row.Name,
+			Location:    textValue(row.Location),
+			Headline:    textValue(row.Headline),
+			Company:     textValue(row.CompanyName),
+		})
+	}
+
+	return profiles, nil
+}
+
+func networkClusterModel(row db.NetworkCluster) models.NetworkCluster {
+	return models.NetworkCluster{
+		ID:           uuidString(row.ID),
+		Label:        row.Label,
+		Size:         int(row.Size),
+		TopCompanies: row.TopCompanies,
+		TopLocations: row.TopLocations,
+		ComputedAt:   row.ComputedAt.Time,
+	}
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// minClusterSize is the smallest community persisted as a cluster; smaller
+	// groups are isolated profiles rather than circles worth labelling.
+	minClusterSize = 2
+
+	// clusterTopValues is how many companies and locations are kept per cluster
+	clusterTopValues = 3
+
+	// minDominantShare is the fraction of a cluster that must share a company or
+	// location before it is used in the cluster label.
+	minDominantShare = 0.25
+
+	// fallbackClusterLabel names clusters without a dominant company or location
+	fallbackClusterLabel = "Mixed network"
+
+	// modularityEpsilon guards against moves driven by floating point noise
+	modularityEpsilon = 1e-12
+)
+
+// louvain detects communities by greedily moving nodes to the neighbouring
+// community with the best modularity gain, then collapsing each community into
+// a single node and repeating until no move improves modularity. Nodes are
+// visited in index order and ties go to the lowest community, so the result
+// is deterministic. It returns a community index per node.
+func louvain(g *networkGraph) []int {
+	n := g.size()
+	membership := make([]int, n)
+	for i := range membership {
+		membership[i] = i
+	}
+
+	weights := make([]map[int]float64, n)
+	for i, neighbours := range g.adjacency {
+		weights[i] = make(map[int]float64, len(neighbours))
+		for _, j := range neighbours {
+			weights[i][j] = 1
+		}
+	}
+
+	for {
+		community, moved := louvainLocalMoving(weights)
+		if !moved {
+			break
+		}
+		community, count := renumberCommunities(community)
+		for i := range membership {
+			membership[i] = community[membership[i]]
+		}
+		weights = aggregateCommunities(weights, community, count)
+	}
+
+	membership, _ = renumberCommunities(membership)
+	return membership
+}
+
+// louvainLocalMoving runs the first Louvain phase over a weighted graph whose
+// diagonal holds twice the weight of edges collapsed inside a node.
+func louvainLocalMoving(weights []map[int]float64) ([]int, bool) {
+	n := len(weights)
+	community := make([]int, n)
+	degree := make([]float64, n)
+	total := make([]float64, n)
+	var twiceEdgeWeight float64
+	for i := range weights {
+		community[i] = i
+		for _, w := range weights[i] {
+			degree[i] += w
+		}
+		total[i] = degree[i]
+		twiceEdgeWeight += degree[i]
+	}
+	if twiceEdgeWeight == 0 {
+		return community, false
+	}
+
+	moved := false
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < n; i++ {
+			current := community[i]
+			total[current] -= degree[i]
+
+			// Weight from i into each neighbouring community
+			links := make(map[int]float64)
+			for j, w := range weights[i] {
+				if j != i {
+					links[community[j]] += w
+				}
+			}
+
+			candidates := make([]int, 0, len(links))
+			for c := range links {
+				candidates = append(candidates, c)
+			}
+			sort.Ints(candidates)
+
+			// Stay put unless another community is strictly better
+			best := current
+			bestGain := links[current] - total[current]*degree[i]/twiceEdgeWeight
+			for _, c := range candidates {
+				gain := links[c] - total[c]*degree[i]/twiceEdgeWeight
+				if gain > bestGain+modularityEpsilon {
+					best, bestGain = c, gain
+				}
+			}
+
+			community[i] = best
+			total[best] += degree[i]
+			if best != current {
+				improved, moved = true, true
+			}
+		}
+	}
+
+	return community, moved
+}
+
+// renumberCommunities maps community indices onto 0..count-1 in order of first appearance
+func renumberCommunities(community []int) ([]int, int) {
+	ids := make(map[int]int)
+	renumbered := make([]int, len(community))
+	for i, c := range community {
+		id, ok := ids[c]
+		if !ok {
+			id = len(ids)
+			ids[c] = id
+		}
+		renumbered[i] = id
+	}
+	return renumbered, len(ids)
+}
+
+// aggregateCommunities collapses each community into a single weighted node
+func aggregateCommunities(weights []map[int]float64, community []int, count int) []map[int]float64 {
+	aggregated := make([]map[int]float64, count)
+	for c := range aggregated {
+		aggregated[c] = make(map[int]float64)
+	}
+	for i, neighbours := range weights {
+		for j, w := range neighbours {
+			aggregated[community[i]][community[j]] += w
+		}
+	}
+	return aggregated
+}
+
+// detectedCluster is a community of profiles, identified by graph index
+type detectedCluster struct {
+	members      []int
+	label        string
+	topCompanies []string
+	topLocations []string
+}
+
+// detectClusters groups the graph into labelled clusters, largest first.
+// Communities smaller than minClusterSize are dropped.
+func detectClusters(g *networkGraph) []detectedCluster {
+	membership := louvain(g)
+
+	groups := make(map[int][]int)
+	for i, c := range membership {
+		groups[c] = append(groups[c], i)
+	}
+
+	var clusters []detectedCluster
+	for _, members := range groups {
+		if len(members) < minClusterSize {
+			continue
+		}
+		companies := make([]string, len(members))
+		locations := make([]string, len(members))
+		for k, i := range members {
+			companies[k] = g.companies[i]
+			locations[k] = g.locations[i]
+		}
+		clusters = append(clusters, detectedCluster{
+			members:      members,
+			label:        clusterLabel(companies, locations),
+			topCompanies: topValues(companies, clusterTopValues),
+			topLocations: topValues(locations, clusterTopValues),
+		})
+	}
+
+	sort.Slice(clusters, func(a, b int) bool {
+		if len(clusters[a].members) != len(clusters[b].members) {
+			return len(clusters[a].members) > len(clusters[b].members)
+		}
+		return clusters[a].members[0] < clusters[b].members[0]
+	})
+	return clusters
+}
+
+// clusterLabel names a cluster after its dominant company and location,
+// e.g. "Acme · Madrid", falling back to a generic label.
+func clusterLabel(companies, locations []string) string {
+	var parts []string
+	if company := dominantValue(companies); company != "" {
+		parts = append(parts, company)
+	}
+	if location := dominantValue(locations); location != "" {
+		parts = append(parts, location)
+	}
+	if len(parts) == 0 {
+		return fallbackClusterLabel
+	}
+	return strings.Join(parts, " · ")
+}
+
+// dominantValue returns the most common value if enough of the cluster shares it
+func dominantValue(values []string) string {
+	top := topValues(values, 1)
+	if len(top) == 0 {
+		return ""
+	}
+	count := 0
+	for _, v := range values {
+		if strings.TrimSpace(v) == top[0] {
+			count++
+		}
+	}
+	if count < 2 || float64(count) < minDominantShare*float64(len(values)) {
+		return ""
+	}
+	return top[0]
+}
+
+// topValues returns up to limit non-empty values, most common first and
+// alphabetical among equals.
+func topValues(values []string, limit int) []string {
+	counts := make(map[string]int)
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			counts[v]++
+		}
+	}
+
+	top := make([]string, 0, len(counts))
+	for v := range counts {
+		top = append(top, v)
+	}
+	sort.Slice(top, func(a, b int) bool {
+		if counts[top[a]] != counts[top[b]] {
+			return counts[top[a]] > counts[top[b]]
+		}
+		return top[a] < top[b]
+	})
+
+	if len(top) > limit {
+		top = top[:limit]
+	}
+	return top
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLouvain_TwoTrianglesJoinedByBridge(t *testing.T) {
+	g := buildTestGraph(6, [][2]int{{0, 1}, {1, 2}, {0, 2}, {3, 4}, {4, 5}, {3, 5}, {2, 3}})
+
+	communities := louvain(g)
+
+	assert.Equal(t, communities[0], communities[1])
+	assert.Equal(t, communities[0], communities[2])
+	assert.Equal(t, communities[3], communities[4])
+	assert.Equal(t, communities[3], communities[5])
+	assert.NotEqual(t, communities[0], communities[3])
+}
+
+func TestLouvain_DisconnectedComponents(t *testing.T) {
+	g := buildTestGraph(5, [][2]int{{0, 1}, {2, 3}})
+
+	communities := louvain(g)
+
+	assert.Equal(t, communities[0], communities[1])
+	assert.Equal(t, communities[2], communities[3])
+	assert.NotEqual(t, communities[0], communities[2])
+	assert.NotEqual(t, communities[4], communities[0])
+	assert.NotEqual(t, communities[4], communities[2])
+}
+
+func TestDetectClusters_SkipsIsolatedProfilesAndLabels(t *testing.T) {
+	g := buildTestGraph(6, [][2]int{{0, 1}, {1, 2}, {0, 2}, {3, 4}})
+	g.companies = []string{"Acme", "Acme", "Globex", "", "", "Acme"}
+	g.locations = []string{"Madrid", "Madrid", "Barcelona", "", "", ""}
+
+	clusters := detectClusters(g)
+
+	if assert.Len(t, clusters, 2) {
+		assert.Equal(t, []int{0, 1, 2}, clusters[0].members)
+		assert.Equal(t, "Acme · Madrid", clusters[0].label)
+		assert.Equal(t, []string{"Acme", "Globex"}, clusters[0].topCompanies)
+		assert.Equal(t, []string{"Madrid", "Barcelona"}, clusters[0].topLocations)
+		assert.Equal(t, fallbackClusterLabel, clusters[1].label)
+	}
+}
+
+func TestClusterLabel_RequiresDominantValue(t *testing.T) {
+	assert.Equal(t, "Madrid", clusterLabel([]string{"A", "B", "C"}, []string{"Madrid", "Madrid", "Paris"}))
+	assert.Equal(t, fallbackClusterLabel, clusterLabel([]string{"A", "B"}, []string{"", ""}))
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+)
+
+// legalSuffixes are trailing words dropped when normalizing company names.
+// Keep in sync with the backfill in db/migrations/006_company_aliases.up.sql.
+var legalSuffixes = map[string]struct{}{
+	"inc": {}, "incorporated": {}, "llc": {}, "ltd": {}, "limited": {},
+	"corp": {}, "corporation": {}, "co": {}, "company": {},
+	"gmbh": {}, "ag": {}, "sa": {}, "sl": {}, "slu": {}, "plc": {},
+	"bv": {}, "nv": {}, "srl": {}, "spa": {}, "pty": {}, "llp": {}, "lp": {},
+}
+
+// normalizeCompanyName lowercases a company name, drops dots, treats commas
+// and parentheses as spaces and strips trailing legal suffixes, so that
+// "Google", "Google LLC" and "Google, Inc." all normalize to "google".
+// A name made only of suffixes is kept as is.
+func normalizeCompanyName(name string) string {
+	name = strings.ToLower(strings.ReplaceAll(name, ".", ""))
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ',' || r == '(' || r == ')'
+	})
+
+	end := len(words)
+	for end > 1 {
+		if _, ok := legalSuffixes[words[end-1]]; !ok {
+			break
+		}
+		end--
+	}
+
+	return strings.Join(words[:end], " ")
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeCompanyName(t *testing.T) {
+	tests := map[string]string{
+		"Google":                  "google",
+		"Google LLC":              "google",
+		"Google Inc.":             "google",
+		"  Google,   Inc. ":       "google",
+		"Telefónica S.A.":         "telefónica",
+		"Acme Holdings Co. Ltd":   "acme holdings",
+		"Siemens AG (Germany)":    "siemens ag germany",
+		"LLC":                     "llc",
+		"Procter & Gamble Co":     "procter & gamble",
+		"Amazon Web Services Inc": "amazon web services",
+	}
+
+	for input, want := range tests {
+		assert.Equal(t, want, normalizeCompanyName(input), input)
+	}
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/infra/logger"
+	"linkedin-watcher/internal/models"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrMergeIntoSelf is returned when a company is merged into itself
+var ErrMergeIntoSelf = errors.New("cannot merge a company into itself")
+
+type CompanyService struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewCompanyService(pool *pgxpool.Pool, queries *db.Queries) *CompanyService {
+	return &CompanyService{
+		pool:    pool,
+		queries: queries,
+	}
+}
+
+// ListCompanies returns every company ordered by name
+func (s *CompanyService) ListCompanies(ctx context.Context) ([]models.Company, error) {
+	rows, err := s.queries.ListCompanies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list companies: %w", err)
+	}
+
+	companies := make([]models.Company, 0, len(rows))
+	for _, row := range rows {
+		companies = append(companies, companyModel(row))
+	}
+
+	return companies, nil
+}
+
+// CreateCompany adds a company unless one with the same normalized name or
+// alias exists, in which case the existing company is returned with ErrAlreadyExists.
+func (s *CompanyService) CreateCompany(ctx context.Context, req models.CreateCompanyRequest) (*models.Company, error) {
+	name := strings.TrimSpace(req.Name)
+	normalized := normalizeCompanyName(name)
+	if normalized == "" {
+		return nil, errors.New("company name is empty")
+	}
+
+	existing, err := s.queries.FindCompanyByNormalizedName(ctx, normalized)
+	if err == nil {
+		company := companyModel(existing)
+		return &company, ErrAlreadyExists
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+
+	created, err := s.queries.CreateCompany(ctx, db.CreateCompanyParams{
+		Name:           name,
+		LinkedinUrl:    pgtype.Text{String: req.LinkedinURL, Valid: req.LinkedinURL != ""},
+		Industry:       pgtype.Text{String: req.Industry, Valid: req.Industry != ""},
+		NormalizedName: normalized,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create company: %w", err)
+	}
+
+	company := companyModel(created)
+	return &company, nil
+}
+
+// GetCompany returns a company with its aliases and current headcount
+func (s *CompanyService) GetCompany(ctx context.Context, companyID string) (*models.CompanyDetail, error) {
+	companyUUID, err := parseUUID(companyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.companyDetail(ctx, companyUUID)
+}
+
+// MergeCompanies folds the source company into the target: employment history,
+// current companies and job change events are repointed, the source's name and
+// aliases become aliases of the target, and the source is deleted.
+func (s *CompanyService) MergeCompanies(ctx context.Context, targetID string, req models.MergeCompanyRequest) (*models.CompanyDetail, error) {
+	targetUUID, err := parseUUID(targetID)
+	if err != nil {
+		return nil, err
+	}
+	sourceUUID, err := parseUUID(req.SourceCompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if targetUUID == sourceUUID {
+		return nil, ErrMergeIntoSelf
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	target, err := qtx.GetCompanyByID(ctx, targetUUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	source, err := qtx.GetCompanyByID(ctx, sourceUUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+
+	// Identical positions at both companies collapse into the target's row
+	err = qtx.MergeDuplicateProfileCompanies(ctx, db.MergeDuplicateProfileCompaniesParams{TargetID: targetUUID, SourceID: sourceUUID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge duplicate positions: %w", err)
+	}
+	err = qtx.DeleteDuplicateProfileCompanies(ctx, db.DeleteDuplicateProfileCompaniesParams{SourceID: sourceUUID, TargetID: targetUUID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete duplicate positions: %w", err)
+	}
+	err = qtx.RepointProfileCompanies(ctx, db.RepointProfileCompaniesParams{TargetID: targetUUID, SourceID: sourceUUID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move employment history: %w", err)
+	}
+	err = qtx.RepointProfilesCurrentCompany(ctx, db.RepointProfilesCurrentCompanyParams{TargetID: targetUUID, SourceID: sourceUUID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move current employees: %w", err)
+	}
+	err = qtx.RepointProfileEventCompanies(ctx, db.RepointProfileEventCompaniesParams{SourceID: sourceUUID, TargetID: targetUUID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move profile events: %w", err)
+	}
+	err = qtx.RepointSavedSearchCompanies(ctx, db.RepointSavedSearchCompaniesParams{TargetID: targetUUID, SourceID: sourceUUID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move saved searches: %w", err)
+	}
+	err = qtx.RepointCompanyWatches(ctx, db.RepointCompanyWatchesParams{TargetID: targetUUID, SourceID: sourceUUID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move company watches: %w", err)
+	}
+	err = qtx.RepointCompanyWatchMatches(ctx, db.RepointCompanyWatchMatchesParams{TargetID: targetUUID, SourceID: sourceUUID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move company watch matches: %w", err)
+	}
+	err = qtx.MoveCompanyAliases(ctx, db.MoveCompanyAliasesParams{TargetID: targetUUID, SourceID: sourceUUID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move aliases: %w", err)
+	}
+
+	// The source name keeps resolving to the target unless it already normalizes to it
+	if source.NormalizedName != target.NormalizedName {
+		err = qtx.CreateCompanyAlias(ctx, db.CreateCompanyAliasParams{
+			CompanyID:       targetUUID,
+			Alias:           source.Name,
+			NormalizedAlias: source.NormalizedName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create alias: %w", err)
+		}
+	}
+
+	if err := qtx.DeleteCompany(ctx, sourceUUID); err != nil {
+		return nil, fmt.Errorf("failed to delete merged company: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit merge: %w", err)
+	}
+
+	logger.Infof("company %s merged into %s", source.Name, target.Name)
+	return s.companyDetail(ctx, targetUUID)
+}
+
+func (s *CompanyService) companyDetail(ctx context.Context, companyID pgtype.UUID) (*models.CompanyDetail, error) {
+	company, err := s.queries.GetCompanyByID(ctx, companyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+
+	aliases, err := s.queries.ListCompanyAliases(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list aliases: %w", err)
+	}
+	employees, err := s.queries.CountCompanyCurrentEmployees(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count employees: %w", err)
+	}
+
+	detail := &models.CompanyDetail{
+		Company:          companyModel(company),
+		Aliases:          make([]string, 0, len(aliases)),
+		CurrentEmployees: int(employees),
+	}
+	for _, alias := range aliases {
+		detail.Aliases = append(detail.Aliases, alias.Alias)
+	}
+
+	return detail, nil
+}
+
+func companyModel(row db.Company) models.Company {
+	return models.Company{
+		ID:             uuidString(row.ID),
+		Name:           row.Name,
+		NormalizedName: row.NormalizedName,
+		LinkedinURL:    textValue(row.LinkedinUrl),
+		Industry:       textValue(row.Industry),
+		CreatedAt:      row.CreatedAt.Time,
+	}
+}
+
+// ListCompanyPeople returns every known profile currently at the company, and
+// optionally those who worked there before, with how the user can reach them.
+func (s *CompanyService) ListCompanyPeople(ctx context.Context, userID, companyID string, query models.CompanyPeopleQuery) ([]models.CompanyPerson, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	companyUUID, err := parseUUID(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.queries.GetCompanyByID(ctx, companyUUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+
+	rows, err := s.queries.GetCompanyPeople(ctx, db.GetCompanyPeopleParams{
+		CompanyID:     companyUUID,
+		IncludeFormer: query.IncludeFormer,
+		UserID:        userUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list company people: %w", err)
+	}
+
+	people := make([]models.CompanyPerson, 0, len(rows))
+	for _, row := range rows {
+		person := models.CompanyPerson{
+			ID:          uuidString(row.ID),
+			LinkedinURL: row.LinkedinUrl,
+			Name:        row.Name,
+			Location:    textValue(row.Location),
+			Headline:    textValue(row.Headline),
+			Position:    textValue(row.Position),
+			StartDate:   dateValue(row.StartDate),
+			EndDate:     dateValue(row.EndDate),
+			IsCurrent:   row.IsCurrent,
+		}
+		if row.BestDegree.Valid {
+			degree := int(row.BestDegree.Int32)
+			person.BestDegree = &degree
+		}
+		if row.ViaProfileID.Valid {
+			person.ConnectedVia = &models.ProfileRef{
+				ID:          uuidString(row.ViaProfileID),
+				Name:        textValue(row.ViaProfileName),
+				LinkedinURL: textValue(row.ViaProfileUrl),
+			}
+		}
+		people = append(people, person)
+	}
+
+	return people, nil
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/config"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Connection request outcomes recorded on rule executions
+const (
+	ConnectOutcomeSent             = "sent"
+	ConnectOutcomeAlreadyConnected = "already_connected"
+	ConnectOutcomePending          = "already_pending"
+	ConnectOutcomeEmailRequired    = "email_required"
+	ConnectOutcomeUnavailable      = "connect_unavailable"
+	ConnectOutcomeLimitReached     = "limit_reached"
+)
+
+// ErrInvitationLimit is returned when LinkedIn refuses an invitation because
+// the account reached its weekly limit
+var ErrInvitationLimit = errors.New("LinkedIn's weekly invitation limit was reached")
+
+// Selectors of the profile page the connect flow relies on. They use the
+// labels LinkedIn gives its buttons for screen readers, which change less
+// often than its class names.
+const (
+	topCardSelector       = `main section`
+	connectSelector       = `button[aria-label^="Invite"][aria-label$="to connect"]`
+	connectItemSelector   = `[role="button"][aria-label^="Invite"][aria-label$="to connect"]`
+	pendingSelector       = `[aria-label^="Pending"]`
+	removeSelector        = `[aria-label^="Remove your connection"]`
+	degreeSelector        = `.dist-value`
+	moreSelector          = `button[aria-label="More actions"]`
+	dialogSelector        = `div[role="dialog"]`
+	addNoteSelector       = `button[aria-label="Add a note"]`
+	noteSelector          = `textarea[name="message"]`
+	sendSelector          = `button[aria-label="Send now"], button[aria-label="Send invitation"]`
+	sendWithoutSelector   = `button[aria-label="Send without a note"]`
+	dismissSelector       = `button[aria-label="Dismiss"]`
+	emailSelector         = `input[type="email"]`
+	signedOutSelector     = `form.login__form, #session_key, #username`
+	invitationLimitPhrase = "weekly invitation limit"
+)
+
+// Profile states the top card script reports
+const (
+	profileStateConnect     = "connect"
+	profileStateMore        = "more"
+	profileStateConnected   = "connected"
+	profileStatePending     = "pending"
+	profileStateUnavailable = "unavailable"
+	profileStateSignedOut   = "signed_out"
+)
+
+// topCardScript reports the profile's connection state, or "" until the top
+// card has rendered. The More menu keeps its items in the page while closed,
+// so a pending invitation or a connection is found there too.
+var topCardScript = fmt.Sprintf(`(() => {
+	if (document.querySelector(%[1]q)) return %[2]q;
+	const card = document.querySelector(%[3]q);
+	if (!card) return "";
+	if (card.querySelector(%[4]q)) return %[5]q;
+	const degree = card.querySelector(%[6]q);
+	if ((degree && degree.textContent.trim() === "1st") || card.querySelector(%[7]q)) return %[8]q;
+	if (card.querySelector(%[9]q)) return %[10]q;
+	if (card.querySelector(%[11]q)) return %[12]q;
+	return %[13]q;
+})()`,
+	signedOutSelector, profileStateSignedOut,
+	topCardSelector,
+	pendingSelector, profileStatePending,
+	degreeSelector, removeSelector, profileStateConnected,
+	connectSelector, profileStateConnect,
+	moreSelector, profileStateMore,
+	profileStateUnavailable,
+)
+
+// menuScript reports whether the opened More menu offers Connect, or "" until it shows
+var menuScript = fmt.Sprintf(`(() => {
+	const item = document.querySelector(%[1]q);
+	if (item && item.offsetParent !== null) return %[2]q;
+	const menu = document.querySelector(%[3]q + '[aria-expanded="true"]');
+	return menu ? %[4]q : "";
+})()`,
+	connectItemSelector, profileStateConnect,
+	moreSelector, profileStateUnavailable,
+)
+
+// inviteScript reports what the invitation dialog asks for, or "" until it shows
+var inviteScript = fmt.Sprintf(`(() => {
+	const dialog = document.querySelector(%[1]q);
+	if (!dialog) return "";
+	if (dialog.querySelector(%[2]q)) return "email";
+	return dialog.querySelector(%[3]q + "," + %[4]q + "," + %[5]q) ? "invite" : "";
+})()`,
+	dialogSelector, emailSelector, addNoteSelector, sendSelector, sendWithoutSelector,
+)
+
+// sentScript reports "sent" once the invitation dialog closes, or "limit" if
+// LinkedIn answers with its weekly limit instead
+var sentScript = fmt.Sprintf(`(() => {
+	const dialog = document.querySelector(%[1]q);
+	if (!dialog) return "sent";
+	return dialog.textContent.toLowerCase().includes(%[2]q) ? "limit" : "";
+})()`,
+	dialogSelector, invitationLimitPhrase,
+)
+
+// ConnectExecutor sends connection requests from the user's LinkedIn session
+// in Chrome, with the rule's rendered note
+type ConnectExecutor struct {
+	linkedinBrowser
+}
+
+func NewConnectExecutor(browser config.BrowserConfiguration) *ConnectExecutor {
+	return &ConnectExecutor{linkedinBrowser: newLinkedinBrowser(browser)}
+}
+
+// Execute opens the matched profile and sends it a connection request. A
+// profile that is already connected, already invited, only accepts
+// invitations with its email address or cannot be invited is skipped.
+func (e *ConnectExecutor) Execute(ctx context.Context, action RuleAction) (string, error) {
+	tab, cancel, err := e.open(ctx, action.LinkedinURL)
+	if err != nil {
+		return "", err
+	}
+	defer cancel()
+
+	return e.connect(tab, action.Note)
+}
+
+// Accepted reports whether the profile is connected to the user, checking the
+// accepted sequence event
+func (e *ConnectExecutor) Accepted(ctx context.Context, action RuleAction) (bool, error) {
+	tab, cancel, err := e.open(ctx, action.LinkedinURL)
+	if err != nil {
+		return false, err
+	}
+	defer cancel()
+
+	state, err := poll(tab, topCardScript)
+	if err != nil {
+		return false, fmt.Errorf("profile did not load: %w", err)
+	}
+	if state == profileStateSignedOut {
+		return false, errSignedOut
+	}
+	return state == profileStateConnected, nil
+}
+
+// connect runs the invitation flow on an open profile page
+func (e *ConnectExecutor) connect(ctx context.Context, note string) (string, error) {
+	state, err := poll(ctx, topCardScript)
+	if err != nil {
+		return "", fmt.Errorf("profile did not load: %w", err)
+	}
+
+	switch state {
+	case profileStateSignedOut:
+		return "", errSignedOut
+	case profileStateConnected:
+		return ConnectOutcomeAlreadyConnected, fmt.Errorf("%w: already connected", ErrActionSkipped)
+	case profileStatePending:
+		return ConnectOutcomePending, fmt.Errorf("%w: an invitation is already pending", ErrActionSkipped)
+	case profileStateUnavailable:
+		return ConnectOutcomeUnavailable, fmt.Errorf("%w: the profile offers no Connect button", ErrActionSkipped)
+	case profileStateMore:
+		e.pause()
+		if err := chromedp.Run(ctx, chromedp.Click(moreSelector, chromedp.ByQuery)); err != nil {
+			return "", fmt.Errorf("failed to open the More menu: %w", err)
+		}
+		menu, err := poll(ctx, menuScript)
+		if err != nil {
+			return "", fmt.Errorf("More menu did not open: %w", err)
+		}
+		if menu != profileStateConnect {
+			return ConnectOutcomeUnavailable, fmt.Errorf("%w: the profile offers no Connect button", ErrActionSkipped)
+		}
+		e.pause()
+		if err := chromedp.Run(ctx, chromedp.Click(connectItemSelector, chromedp.ByQuery)); err != nil {
+			return "", fmt.Errorf("failed to click Connect: %w", err)
+		}
+	default:
+		e.pause()
+		if err := chromedp.Run(ctx, chromedp.Click(connectSelector, chromedp.ByQuery)); err != nil {
+			return "", fmt.Errorf("failed to click Connect: %w", err)
+		}
+	}
+
+	invite, err := poll(ctx, inviteScript)
+	if err != nil {
+		return "", fmt.Errorf("invitation dialog did not open: %w", err)
+	}
+	if invite == "email" {
+		// Closing the dialog is a courtesy; the outcome is the same if it fails
+		_ = chromedp.Run(ctx, chromedp.Click(dismissSelector, chromedp.ByQuery))
+		return ConnectOutcomeEmailRequired, fmt.Errorf("%w: LinkedIn asks for the profile's email address to connect", ErrActionSkipped)
+	}
+
+	e.pause()
+	var send chromedp.Tasks
+	if note != "" {
+		send = chromedp.Tasks{
+			chromedp.Click(addNoteSelector, chromedp.ByQuery),
+			chromedp.WaitVisible(noteSelector, chromedp.ByQuery),
+			chromedp.SendKeys(noteSelector, note, chromedp.ByQuery),
+			chromedp.ActionFunc(func(context.Context) error { e.pause(); return nil }),
+			chromedp.Click(sendSelector, chromedp.ByQuery),
+		}
+	} else {
+		send = chromedp.Tasks{chromedp.Click(sendWithoutSelector+", "+sendSelector, chromedp.ByQuery)}
+	}
+	if err := chromedp.Run(ctx, send); err != nil {
+		return "", fmt.Errorf("failed to send the invitation: %w", err)
+	}
+
+	sent, err := poll(ctx, sentScript)
+	if err != nil {
+		return "", fmt.Errorf("invitation was not confirmed: %w", err)
+	}
+	if sent == "limit" {
+		return ConnectOutcomeLimitReached, ErrInvitationLimit
+	}
+	return ConnectOutcomeSent, nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"sync"
+	"testing"
+
+	"linkedin-watcher/config"
+
+	"github.com/chromedp/chromedp"
+	"github.com/stretchr/testify/assert"
+)
+
+// testConnectExecutor returns an executor driving a headless Chrome, found
+// through CHROME_PATH or on the PATH, and skips the test without one
+func testConnectExecutor(t *testing.T) *ConnectExecutor {
+	t.Helper()
+	execPath := os.Getenv("CHROME_PATH")
+	if execPath == "" {
+		for _, name := range []string{"google-chrome", "chromium", "chromium-browser", "headless-shell"} {
+			if path, err := exec.LookPath(name); err == nil {
+				execPath = path
+				break
+			}
+		}
+	}
+	if execPath == "" {
+		t.Skip("Chrome not found; set CHROME_PATH to run the browser tests")
+	}
+
+	executor := NewConnectExecutor(config.BrowserConfiguration{ExecPath: execPath, Headless: true})
+	executor.options = append(executor.options, chromedp.NoSandbox)
+	executor.pause = func() {}
+	return executor
+}
+
+func TestConnectExecutor_FixturePages(t *testing.T) {
+	executor := testConnectExecutor(t)
+
+	var mu sync.Mutex
+	var sent []string
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.Dir("testdata/connect")))
+	mux.HandleFunc("POST /sent", func(w http.ResponseWriter, r *http.Request) {
+		note, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		sent = append(sent, string(note))
+		mu.Unlock()
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := map[string]struct {
+		page    string
+		note    string
+		outcome string
+		skipped bool
+		sent    []string
+	}{
+		"connect with note":    {page: "connect.html", note: "Hi Ada, Jane suggested we connect", outcome: ConnectOutcomeSent, sent: []string{"Hi Ada, Jane suggested we connect"}},
+		"connect without note": {page: "connect.html", outcome: ConnectOutcomeSent, sent: []string{""}},
+		"connect under more":   {page: "more.html", note: "Hi Grace", outcome: ConnectOutcomeSent, sent: []string{"Hi Grace"}},
+		"already connected":    {page: "connected.html", outcome: ConnectOutcomeAlreadyConnected, skipped: true},
+		"already pending":      {page: "pending.html", outcome: ConnectOutcomePending, skipped: true},
+		"email required":       {page: "email.html", outcome: ConnectOutcomeEmailRequired, skipped: true},
+		"follow only":          {page: "follow.html", outcome: ConnectOutcomeUnavailable, skipped: true},
+	}
+
+	for name, tc := range tests {
+		sent = nil
+		outcome, err := executor.Execute(context.Background(), RuleAction{
+			LinkedinURL: server.URL + "/" + tc.page,
+			Note:        tc.note,
+		})
+
+		assert.Equal(t, tc.outcome, outcome, name)
+		if tc.skipped {
+			assert.ErrorIs(t, err, ErrActionSkipped, name)
+		} else {
+			assert.NoError(t, err, name)
+		}
+		mu.Lock()
+		assert.Equal(t, tc.sent, sent, name)
+		mu.Unlock()
+	}
+
+	outcome, err := executor.Execute(context.Background(), RuleAction{LinkedinURL: server.URL + "/limit.html"})
+	assert.Equal(t, ConnectOutcomeLimitReached, outcome)
+	assert.ErrorIs(t, err, ErrInvitationLimit)
+
+	_, err = executor.Execute(context.Background(), RuleAction{LinkedinURL: server.URL + "/login.html"})
+	assert.ErrorContains(t, err, "not signed in")
+
+	for page, want := range map[string]bool{"connected.html": true, "pending.html": false, "connect.html": false} {
+		accepted, err := executor.Accepted(context.Background(), RuleAction{LinkedinURL: server.URL + "/" + page})
+		assert.NoError(t, err, page)
+		assert.Equal(t, want, accepted, page)
+	}
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/infra/logger"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// linkedinProfileURL matches the public profile URLs returned by the scraper
+var linkedinProfileURL = regexp.MustCompile(`^https?://(www\.)?linkedin\.com/in/[a-zA-Z0-9-]+/?$`)
+
+// maxMissedChecks is how many consecutive checks may miss a connection before
+// it is considered removed, so a single incomplete scrape removes nothing
+const maxMissedChecks = 3
+
+// ConnectionScraper returns the 1st degree connections of a LinkedIn member
+type ConnectionScraper func(ctx context.Context, linkedinID string) ([]LinkedInConnection, error)
+
+// CheckHook runs after a user's tracked connections have been checked
+type CheckHook func(ctx context.Context, userID pgtype.UUID, checkedAt time.Time) error
+
+type ConnectionCheckService struct {
+	queries *db.Queries
+	scrape  ConnectionScraper
+	hooks   []CheckHook
+}
+
+func NewConnectionCheckService(queries *db.Queries, scrape ConnectionScraper, hooks ...CheckHook) *ConnectionCheckService {
+	return &ConnectionCheckService{
+		queries: queries,
+		scrape:  scrape,
+		hooks:   hooks,
+	}
+}
+
+// CheckAll checks the tracked connections of every user that has any
+func (s *ConnectionCheckService) CheckAll(ctx context.Context) error {
+	userIDs, err := s.queries.ListUserIDsWithTrackedConnections(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		if err := s.CheckUser(ctx, userID); err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", uuidString(userID), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// CheckUser scrapes the connections of each of the user's tracked connections,
+// records any new ones, then runs the post-check hooks.
+func (s *ConnectionCheckService) CheckUser(ctx context.Context, userID pgtype.UUID) error {
+	tracked, err := s.queries.GetTrackedConnections(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get tracked connections: %w", err)
+	}
+
+	var errs []error
+	for _, connection := range tracked {
+		if !connection.LinkedinID.Valid || connection.LinkedinID.String == "" {
+			logger.Debugf("Skipping tracked connection %s without a LinkedIn ID", uuidString(connection.ProfileID))
+			continue
+		}
+
+		discovered, err := s.checkConnection(ctx, userID, connection)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if discovered > 0 {
+			logger.Infof("Discovered %d new connections of %s", discovered, connection.Name)
+		}
+	}
+
+	checkedAt := time.Now()
+	for _, hook := range s.hooks {
+		if err := hook(ctx, userID, checkedAt); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkConnection records the scraped connections of one tracked connection
+// and returns how many relationships were new. Connections missing from
+// maxMissedChecks consecutive checks are removed.
+func (s *ConnectionCheckService) checkConnection(ctx context.Context, userID pgtype.UUID, tracked db.GetTrackedConnectionsRow) (int, error) {
+	scraped, err := s.scrape(ctx, tracked.LinkedinID.String)
+	if err != nil {
+		return 0, fmt.Errorf("failed to scrape connections of %s: %w", tracked.Name, err)
+	}
+
+	discovered := 0
+	var seen []pgtype.UUID
+	for _, connection := range scraped {
+		profileURL := strings.TrimSpace(connection.ProfileURL)
+		if !linkedinProfileURL.MatchString(profileURL) {
+			continue
+		}
+
+		profile, err := s.findOrCreateProfile(ctx, profileURL, connection)
+		if err != nil {
+			return discovered, err
+		}
+		if profile.ID == tracked.ProfileID {
+			continue
+		}
+		seen = append(seen, profile.ID)
+
+		_, err = s.queries.CheckConnectionExists(ctx, db.CheckConnectionExistsParams{
+			ProfileAID: tracked.ProfileID,
+			ProfileBID: profile.ID,
+			Degree:     1,
+		})
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return discovered, fmt.Errorf("failed to check connection: %w", err)
+		}
+
+		_, err = s.queries.CreateConnectionRelationship(ctx, db.CreateConnectionRelationshipParams{
+			ProfileAID:         tracked.ProfileID,
+			ProfileBID:         profile.ID,
+			Degree:             1,
+			DiscoveredByUserID: userID,
+		})
+		if err != nil {
+			return discovered, fmt.Errorf("failed to create connection: %w", err)
+		}
+		if err := s.recordConnectionEvent(ctx, profile.ID, tracked.ProfileID, ProfileEventConnectionAdded); err != nil {
+			return discovered, err
+		}
+		discovered++
+	}
+
+	// A scrape that found nobody says nothing about who is still connected
+	if len(seen) > 0 {
+		if err := s.removeMissingConnections(ctx, tracked, seen); err != nil {
+			return discovered, err
+		}
+	}
+
+	if err := s.queries.UpdateTrackedConnectionLastChecked(ctx, tracked.ID); err != nil {
+		return discovered, fmt.Errorf("failed to update last checked: %w", err)
+	}
+
+	return discovered, nil
+}
+
+// removeMissingConnections counts a missed check for each of the tracked
+// profile's connections that were not seen, and removes those missed too often
+func (s *ConnectionCheckService) removeMissingConnections(ctx context.Context, tracked db.GetTrackedConnectionsRow, seen []pgtype.UUID) error {
+	err := s.queries.UpdateConnectionMissedChecks(ctx, db.UpdateConnectionMissedChecksParams{
+		SeenIds:   seen,
+		ProfileID: tracked.ProfileID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update missed checks: %w", err)
+	}
+
+	removed, err := s.queries.DeleteMissingConnections(ctx, db.DeleteMissingConnectionsParams{
+		ProfileID:       tracked.ProfileID,
+		MaxMissedChecks: maxMissedChecks,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete missing connections: %w", err)
+	}
+	for _, profileID := range removed {
+		if err := s.recordConnectionEvent(ctx, profileID, tracked.ProfileID, ProfileEventConnectionRemoved); err != nil {
+			return err
+		}
+	}
+	if len(removed) > 0 {
+		logger.Infof("%d connections of %s were removed", len(removed), tracked.Name)
+	}
+
+	return nil
+}
+
+// recordConnectionEvent records a profile gaining or losing a tracked profile as a connection
+func (s *ConnectionCheckService) recordConnectionEvent(ctx context.Context, profileID, trackedProfileID pgtype.UUID, eventType string) error {
+	_, err := s.queries.CreateProfileEvent(ctx, db.CreateProfileEventParams{
+		ProfileID:        profileID,
+		EventType:        eventType,
+		RelatedProfileID: trackedProfileID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record %s event: %w", eventType, err)
+	}
+	return nil
+}
+
+func (s *ConnectionCheckService) findOrCreateProfile(ctx context.Context, profileURL string, connection LinkedInConnection) (db.LinkedinProfile, error) {
+	profile, err := s.queries.GetLinkedInProfileByURL(ctx, profileURL)
+	if err == nil {
+		return profile, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return db.LinkedinProfile{}, fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	location := strings.TrimSpace(connection.Location)
+	profile, err = s.queries.CreateLinkedInProfile(ctx, db.CreateLinkedInProfileParams{
+		LinkedinUrl: profileURL,
+		Name:        strings.TrimSpace(connection.Name),
+		Location:    pgtype.Text{String: location, Valid: location != ""},
+	})
+	if err != nil {
+		return db.LinkedinProfile{}, fmt.Errorf("failed to create profile: %w", err)
+	}
+
+	return profile, nil
+}
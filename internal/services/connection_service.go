@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// defaultNewConnectionsWindow is how far back new connections are listed when no date is given
+const defaultNewConnectionsWindow = 7 * 24 * time.Hour
+
+type ConnectionService struct {
+	queries *db.Queries
+}
+
+func NewConnectionService(queries *db.Queries) *ConnectionService {
+	return &ConnectionService{
+		queries: queries,
+	}
+}
+
+// ListNewConnections returns profiles discovered through the user's tracked
+// connections since the given time, optionally ranked and filtered by network score or cluster.
+func (s *ConnectionService) ListNewConnections(ctx context.Context, userID string, query models.NewConnectionsQuery) ([]models.NewConnection, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	since := query.Since
+	if since.IsZero() {
+		since = time.Now().Add(-defaultNewConnectionsWindow)
+	}
+	sort := query.Sort
+	if sort == "" {
+		sort = models.NewConnectionSortRecent
+	}
+	var minScore pgtype.Float8
+	if query.MinNetworkScore != nil {
+		minScore = pgtype.Float8{Float64: *query.MinNetworkScore, Valid: true}
+	}
+	var clusterID pgtype.UUID
+	if query.ClusterID != "" {
+		if clusterID, err = parseUUID(query.ClusterID); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := s.queries.GetNewConnectionsForUser(ctx, db.GetNewConnectionsForUserParams{
+		UserID:          userUUID,
+		DiscoveredAt:    pgtype.Timestamp{Time: since, Valid: true},
+		MinNetworkScore: minScore,
+		ClusterID:       clusterID,
+		SortBy:          string(sort),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list new connections: %w", err)
+	}
+
+	connections := make([]models.NewConnection, 0, len(rows))
+	for _, row := range rows {
+		connections = append(connections, models.NewConnection{
+			ID:               uuidString(row.ID),
+			LinkedinURL:      row.LinkedinUrl,
+			Name:             row.Name,
+			Location:         textValue(row.Location),
+			Headline:         textValue(row.Headline),
+			Company:          textValue(row.CompanyName),
+			ConnectionDegree: intValue(row.ConnectionDegree),
+			NetworkDegree:    int(row.NetworkDegree),
+			Betweenness:      row.Betweenness,
+			NetworkScore:     row.NetworkScore,
+			ClusterID:        uuidString(row.ClusterID),
+			CreatedAt:        row.CreatedAt.Time,
+		})
+	}
+
+	return connections, nil
+}
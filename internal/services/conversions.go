@@ -0,0 +1,85 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrNotFound is returned when a requested record does not exist or is not owned by the user
+var ErrNotFound = errors.New("not found")
+
+// ErrAlreadyExists is returned when creating a record that duplicates an existing one
+var ErrAlreadyExists = errors.New("already exists")
+
+// ErrInvalidID is returned when an ID supplied by the client is not a valid UUID
+var ErrInvalidID = errors.New("invalid ID")
+
+// uniqueViolation is the Postgres error code for a unique constraint violation
+const uniqueViolation = "23505"
+
+// isUniqueViolation reports whether err was caused by a duplicate key
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
+
+// parseUUID converts a string ID into the pgtype.UUID used by the queries
+func parseUUID(id string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, ErrInvalidID
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
+
+// uuidString formats a pgtype.UUID, returning an empty string when it is NULL
+func uuidString(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return uuid.UUID(id.Bytes).String()
+}
+
+// textValue returns the string held by a pgtype.Text, or an empty string when it is NULL
+func textValue(t pgtype.Text) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.String
+}
+
+// intValue converts the untyped integers sqlc returns for aggregates such as MIN()
+func intValue(v interface{}) int {
+	switch n := v.(type) {
+	case int16:
+		return int(n)
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case int:
+		return n
+	default:
+		return 0
+	}
+}
+
+// dateValue returns the time held by a pgtype.Date, or nil when it is NULL
+func dateValue(d pgtype.Date) *time.Time {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Time
+}
+
+// timeValue returns the time held by a pgtype.Timestamp, or nil when it is NULL
+func timeValue(t pgtype.Timestamp) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// duplicateThreshold is the minimum score for a pair to be raised for review
+	duplicateThreshold = 0.75
+
+	// similarNameThreshold is the minimum name similarity for two profiles to be compared
+	similarNameThreshold = 0.85
+
+	// maxDuplicateBlock caps how many profiles sharing a name key are compared
+	// pairwise, so very common names do not make detection quadratic
+	maxDuplicateBlock = 200
+)
+
+// Reasons recorded on a duplicate candidate
+const (
+	reasonSameLinkedinID = "same_linkedin_id"
+	reasonSimilarURL     = "similar_url"
+	reasonSameName       = "same_name"
+	reasonSimilarName    = "similar_name"
+	reasonSameCompany    = "same_company"
+	reasonSameLocation   = "same_location"
+)
+
+// nameNoise are honorifics, credentials and suffixes ignored when comparing names
+var nameNoise = map[string]struct{}{
+	"mr": {}, "mrs": {}, "ms": {}, "dr": {}, "prof": {}, "phd": {}, "mba": {}, "msc": {},
+	"bsc": {}, "cpa": {}, "pmp": {}, "cfa": {}, "jr": {}, "sr": {}, "ii": {}, "iii": {},
+}
+
+// duplicateProfile is the part of a profile compared when looking for duplicates
+type duplicateProfile struct {
+	ID         pgtype.UUID
+	URL        string
+	LinkedinID string
+	Name       string
+	Location   string
+	CompanyID  pgtype.UUID
+}
+
+// duplicatePair is a likely duplicate, lower profile ID first
+type duplicatePair struct {
+	ProfileID   pgtype.UUID
+	DuplicateID pgtype.UUID
+	Score       float64
+	Reasons     []string
+}
+
+// findDuplicates returns the pairs of profiles scoring at least
+// duplicateThreshold. Only profiles sharing a LinkedIn ID, a vanity URL or
+// a surname and first initial are compared.
+func findDuplicates(profiles []duplicateProfile) []duplicatePair {
+	blocks := make(map[string][]int)
+	for i, p := range profiles {
+		for _, key := range duplicateKeys(p) {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	seen := make(map[[2]int]bool)
+	var pairs []duplicatePair
+	for _, members := range blocks {
+		if len(members) < 2 || len(members) > maxDuplicateBlock {
+			continue
+		}
+		for x := 0; x < len(members); x++ {
+			for y := x + 1; y < len(members); y++ {
+				key := [2]int{members[x], members[y]}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				a, b := profiles[members[x]], profiles[members[y]]
+				score, reasons := scoreDuplicate(a, b)
+				if score < duplicateThreshold {
+					continue
+				}
+				if bytes.Compare(a.ID.Bytes[:], b.ID.Bytes[:]) > 0 {
+					a, b = b, a
+				}
+				pairs = append(pairs, duplicatePair{ProfileID: a.ID, DuplicateID: b.ID, Score: score, Reasons: reasons})
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Score != pairs[j].Score {
+			return pairs[i].Score > pairs[j].Score
+		}
+		return bytes.Compare(pairs[i].ProfileID.Bytes[:], pairs[j].ProfileID.Bytes[:]) < 0
+	})
+	return pairs
+}
+
+// duplicateKeys returns the blocking keys of a profile
+func duplicateKeys(p duplicateProfile) []string {
+	var keys []string
+	if p.LinkedinID != "" {
+		keys = append(keys, "id:"+p.LinkedinID)
+	}
+	if slug := profileURLSlug(p.URL); slug != "" {
+		keys = append(keys, "url:"+slug)
+	}
+	tokens := nameTokens(p.Name)
+	if len(tokens) > 1 {
+		// Every later token may be the surname: "María García López" and "Maria Garcia" share "m garcia"
+		for _, token := range tokens[1:] {
+			keys = append(keys, "name:"+string([]rune(tokens[0])[0])+" "+token)
+		}
+	}
+	return keys
+}
+
+// scoreDuplicate rates how likely two profiles are the same person, from 0 to 1.
+// Different LinkedIn IDs rule a pair out; the same LinkedIn ID settles it.
+// Otherwise the same name at the same company is enough to raise a pair, while
+// the same name in the same city is not.
+func scoreDuplicate(a, b duplicateProfile) (float64, []string) {
+	if a.LinkedinID != "" && b.LinkedinID != "" {
+		if a.LinkedinID != b.LinkedinID {
+			return 0, nil
+		}
+		return 1, []string{reasonSameLinkedinID}
+	}
+
+	var reasons []string
+	score := 0.0
+
+	similarURL := profileURLSlug(a.URL) != "" && profileURLSlug(a.URL) == profileURLSlug(b.URL)
+	name := nameSimilarity(a.Name, b.Name)
+	if name < similarNameThreshold && !similarURL {
+		return 0, nil
+	}
+
+	if similarURL {
+		score += 0.25
+		reasons = append(reasons, reasonSimilarURL)
+	}
+	score += 0.6 * name
+	switch {
+	case name == 1:
+		reasons = append(reasons, reasonSameName)
+	case name >= similarNameThreshold:
+		reasons = append(reasons, reasonSimilarName)
+	}
+	if a.CompanyID.Valid && a.CompanyID == b.CompanyID {
+		score += 0.15
+		reasons = append(reasons, reasonSameCompany)
+	}
+	if a.Location != "" && foldName(a.Location) == foldName(b.Location) {
+		score += 0.1
+		reasons = append(reasons, reasonSameLocation)
+	}
+
+	return min(score, 1), reasons
+}
+
+// profileURLSlug returns the vanity part of a profile URL without the
+// numeric suffix LinkedIn adds to common names, so that
+// linkedin.com/in/jane-doe and linkedin.com/in/jane-doe-4b2a91 compare equal
+func profileURLSlug(profileURL string) string {
+	_, slug, found := strings.Cut(strings.ToLower(profileURL), "/in/")
+	if !found {
+		return ""
+	}
+	slug = strings.Trim(slug, "/")
+	if i := strings.LastIndex(slug, "-"); i > 0 && strings.ContainsAny(slug[i+1:], "0123456789") {
+		slug = slug[:i]
+	}
+	return slug
+}
+
+// nameSimilarity compares two names token by token, from 0 to 1. Tokens match
+// exactly, as an initial ("J." and "John") or when spelled alike ("Jon" and
+// "John"). A name with extra tokens, such as a second surname, scores slightly
+// lower than an identical one.
+func nameSimilarity(a, b string) float64 {
+	ta, tb := nameTokens(a), nameTokens(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	if len(ta) > len(tb) {
+		ta, tb = tb, ta
+	}
+
+	used := make([]bool, len(tb))
+	matched := 0
+	for _, x := range ta {
+		for j, y := range tb {
+			if !used[j] && tokensMatch(x, y) {
+				used[j] = true
+				matched++
+				break
+			}
+		}
+	}
+
+	similarity := float64(matched) / float64(len(ta))
+	if len(ta) != len(tb) {
+		similarity *= 0.9
+	}
+	return similarity
+}
+
+func tokensMatch(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 1 || len(rb) == 1 {
+		return ra[0] == rb[0]
+	}
+	return jaroWinkler(a, b) >= 0.9
+}
+
+// nameTokens folds a name to lowercase unaccented words, dropping honorifics,
+// credentials and anything in parentheses, e.g. "(She/Her)"
+func nameTokens(name string) []string {
+	if i := strings.Index(name, "("); i >= 0 {
+		name = name[:i]
+	}
+	var tokens []string
+	for _, token := range strings.FieldsFunc(foldName(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	}) {
+		token = strings.Trim(token, "'")
+		if _, noise := nameNoise[token]; !noise && token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// foldName lowercases text and strips accents, so "José" and "Jose" compare equal
+func foldName(text string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), text)
+	if err != nil {
+		folded = text
+	}
+	return strings.ToLower(strings.TrimSpace(folded))
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of two strings, from 0 to 1
+func jaroWinkler(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := max(len(ra), len(rb))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		for j := max(0, i-window); j < min(len(rb), i+window+1); j++ {
+			if !matchedB[j] && ra[i] == rb[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(ra), len(rb)) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
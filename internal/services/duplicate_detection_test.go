@@ -0,0 +1,112 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"linkedin-watcher/db"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileURLSlug(t *testing.T) {
+	tests := map[string]string{
+		"https://www.linkedin.com/in/jane-doe":         "jane-doe",
+		"https://www.linkedin.com/in/jane-doe/":        "jane-doe",
+		"https://linkedin.com/in/Jane-Doe-4b2a91":      "jane-doe",
+		"https://www.linkedin.com/in/jane-doe-cto":     "jane-doe-cto",
+		"https://www.linkedin.com/company/acme":        "",
+		"https://www.linkedin.com/in/ACoAAB12cdEFgh34": "acoaab12cdefgh34",
+	}
+
+	for url, want := range tests {
+		assert.Equal(t, want, profileURLSlug(url), url)
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, nameSimilarity("José García", "Jose Garcia"))
+	assert.Equal(t, 1.0, nameSimilarity("Dr. Jane Doe, PhD", "Jane Doe (She/Her)"))
+	assert.Equal(t, 1.0, nameSimilarity("J. Doe", "Jane Doe"))
+	assert.Equal(t, 1.0, nameSimilarity("Jon Smith", "John Smith"))
+	assert.InDelta(t, 0.9, nameSimilarity("María García López", "Maria Garcia"), 1e-9)
+	assert.Less(t, nameSimilarity("Jane Doe", "John Smith"), similarNameThreshold)
+	assert.Equal(t, 0.0, nameSimilarity("", "Jane Doe"))
+}
+
+func TestJaroWinkler(t *testing.T) {
+	assert.Equal(t, 1.0, jaroWinkler("martha", "martha"))
+	assert.InDelta(t, 0.961, jaroWinkler("martha", "marhta"), 0.001)
+	assert.InDelta(t, 0.813, jaroWinkler("dixon", "dicksonx"), 0.001)
+	assert.Equal(t, 0.0, jaroWinkler("abc", "xyz"))
+	assert.Equal(t, 0.0, jaroWinkler("", "abc"))
+}
+
+func TestScoreDuplicate(t *testing.T) {
+	acme := testProfileID(100)
+	jane := duplicateProfile{ID: testProfileID(1), URL: "https://www.linkedin.com/in/jane-doe", Name: "Jane Doe", Location: "Madrid", CompanyID: acme}
+
+	score, reasons := scoreDuplicate(jane, duplicateProfile{ID: testProfileID(2), URL: "https://www.linkedin.com/in/jane-doe-4b2a91", Name: "Jane Doe", Location: "madrid", CompanyID: acme})
+	assert.InDelta(t, 1.0, score, 1e-9)
+	assert.Equal(t, []string{reasonSimilarURL, reasonSameName, reasonSameCompany, reasonSameLocation}, reasons)
+
+	score, reasons = scoreDuplicate(jane, duplicateProfile{ID: testProfileID(3), URL: "https://www.linkedin.com/in/jdoe", Name: "Jane Doe", CompanyID: acme})
+	assert.InDelta(t, 0.75, score, 1e-9)
+	assert.Equal(t, []string{reasonSameName, reasonSameCompany}, reasons)
+
+	score, _ = scoreDuplicate(jane, duplicateProfile{ID: testProfileID(7), URL: "https://www.linkedin.com/in/janedoe", Name: "Jane Doe", Location: "Madrid"})
+	assert.Less(t, score, duplicateThreshold)
+
+	score, _ = scoreDuplicate(jane, duplicateProfile{ID: testProfileID(4), URL: "https://www.linkedin.com/in/john-smith", Name: "John Smith", Location: "Madrid", CompanyID: acme})
+	assert.Equal(t, 0.0, score)
+
+	withID := jane
+	withID.LinkedinID = "ACoAAB1"
+	score, reasons = scoreDuplicate(withID, duplicateProfile{ID: testProfileID(5), LinkedinID: "ACoAAB1", Name: "J. D."})
+	assert.Equal(t, 1.0, score)
+	assert.Equal(t, []string{reasonSameLinkedinID}, reasons)
+
+	score, _ = scoreDuplicate(withID, duplicateProfile{ID: testProfileID(6), LinkedinID: "ACoAAB2", URL: jane.URL, Name: "Jane Doe", Location: "Madrid", CompanyID: acme})
+	assert.Equal(t, 0.0, score)
+}
+
+func TestFindDuplicates(t *testing.T) {
+	acme := testProfileID(100)
+	profiles := []duplicateProfile{
+		{ID: testProfileID(3), URL: "https://www.linkedin.com/in/maria-garcia-lopez", Name: "María García López", Location: "Madrid", CompanyID: acme},
+		{ID: testProfileID(1), URL: "https://www.linkedin.com/in/mgarcia", Name: "Maria Garcia", Location: "Madrid", CompanyID: acme},
+		{ID: testProfileID(2), URL: "https://www.linkedin.com/in/maria-garcia-lopez-0a1b2c", Name: "Someone Else"},
+		{ID: testProfileID(4), URL: "https://www.linkedin.com/in/mario-garcia", Name: "Mario Garcia", Location: "Sevilla"},
+	}
+
+	pairs := findDuplicates(profiles)
+	if assert.Len(t, pairs, 1) {
+		// The lower ID comes first whatever the input order
+		assert.Equal(t, testProfileID(1), pairs[0].ProfileID)
+		assert.Equal(t, testProfileID(3), pairs[0].DuplicateID)
+		assert.InDelta(t, 0.79, pairs[0].Score, 1e-9)
+	}
+}
+
+func TestChooseMergeTarget(t *testing.T) {
+	older := db.LinkedinProfile{ID: testProfileID(1), CreatedAt: pgtype.Timestamp{Time: time.Now().Add(-time.Hour), Valid: true}}
+	newer := db.LinkedinProfile{ID: testProfileID(2), CreatedAt: pgtype.Timestamp{Time: time.Now(), Valid: true}}
+
+	target, source, err := chooseMergeTarget(newer, older, pgtype.UUID{})
+	assert.NoError(t, err)
+	assert.Equal(t, older.ID, target.ID)
+	assert.Equal(t, newer.ID, source.ID)
+
+	newer.LinkedinID = pgtype.Text{String: "ACoAAB1", Valid: true}
+	target, _, err = chooseMergeTarget(older, newer, pgtype.UUID{})
+	assert.NoError(t, err)
+	assert.Equal(t, newer.ID, target.ID)
+
+	target, _, err = chooseMergeTarget(older, newer, older.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, older.ID, target.ID)
+
+	_, _, err = chooseMergeTarget(older, newer, testProfileID(9))
+	assert.ErrorIs(t, err, ErrNotInPair)
+}
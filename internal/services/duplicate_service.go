@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/infra/logger"
+	"linkedin-watcher/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrNotInPair is returned when the profile to keep is not one of the candidate's two profiles
+var ErrNotInPair = errors.New("keep_profile_id must be one of the two profiles of the candidate")
+
+const (
+	// defaultDuplicatesLimit is the number of candidates returned when no limit is given
+	defaultDuplicatesLimit = 50
+
+	duplicateStatusPending = "pending"
+)
+
+type DuplicateService struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewDuplicateService(pool *pgxpool.Pool, queries *db.Queries) *DuplicateService {
+	return &DuplicateService{
+		pool:    pool,
+		queries: queries,
+	}
+}
+
+// DetectAll compares every profile against those sharing a LinkedIn ID, vanity
+// URL or surname, records likely duplicates for review, and drops pending
+// candidates that no longer look alike. Rejected pairs are never raised again.
+func (s *DuplicateService) DetectAll(ctx context.Context) error {
+	detectedAt := pgtype.Timestamp{Time: time.Now(), Valid: true}
+
+	rows, err := s.queries.ListProfilesForDuplicateDetection(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list profiles: %w", err)
+	}
+	profiles := make([]duplicateProfile, 0, len(rows))
+	for _, row := range rows {
+		profiles = append(profiles, duplicateProfile{
+			ID:         row.ID,
+			URL:        row.LinkedinUrl,
+			LinkedinID: textValue(row.LinkedinID),
+			Name:       row.Name,
+			Location:   textValue(row.Location),
+			CompanyID:  row.CurrentCompanyID,
+		})
+	}
+
+	pairs := findDuplicates(profiles)
+	for _, pair := range pairs {
+		err := s.queries.UpsertDuplicateCandidate(ctx, db.UpsertDuplicateCandidateParams{
+			ProfileID:   pair.ProfileID,
+			DuplicateID: pair.DuplicateID,
+			Score:       pair.Score,
+			Reasons:     pair.Reasons,
+			DetectedAt:  detectedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store duplicate candidate: %w", err)
+		}
+	}
+
+	if err := s.queries.DeleteUndetectedDuplicateCandidates(ctx, detectedAt); err != nil {
+		return fmt.Errorf("failed to delete outdated duplicate candidates: %w", err)
+	}
+
+	logger.Infof("Duplicate detection compared %d profiles and found %d likely duplicates", len(profiles), len(pairs))
+	return nil
+}
+
+// ListCandidates returns duplicate candidates with the given status, most likely first
+func (s *DuplicateService) ListCandidates(ctx context.Context, query models.DuplicateCandidatesQuery) ([]models.DuplicateCandidate, error) {
+	status := query.Status
+	if status == "" {
+		status = duplicateStatusPending
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultDuplicatesLimit
+	}
+
+	rows, err := s.queries.ListDuplicateCandidates(ctx, db.ListDuplicateCandidatesParams{
+		Status: status,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list duplicate candidates: %w", err)
+	}
+
+	candidates := make([]models.DuplicateCandidate, 0, len(rows))
+	for _, row := range rows {
+		candidate := models.DuplicateCandidate{
+			ID:      uuidString(row.ID),
+			Score:   row.Score,
+			Reasons: row.Reasons,
+			Status:  row.Status,
+			Profile: models.DuplicateProfile{
+				ID:          uuidString(row.ProfileID),
+				LinkedinURL: row.ProfileLinkedinUrl,
+				Name:        row.ProfileName,
+				Location:    textValue(row.ProfileLocation),
+				Headline:    textValue(row.ProfileHeadline),
+				Company:     textValue(row.ProfileCompanyName),
+			},
+			Duplicate: models.DuplicateProfile{
+				ID:          uuidString(row.DuplicateID),
+				LinkedinURL: row.DuplicateLinkedinUrl,
+				Name:        row.DuplicateName,
+				Location:    textValue(row.DuplicateLocation),
+				Headline:    textValue(row.DuplicateHeadline),
+				Company:     textValue(row.DuplicateCompanyName),
+			},
+			CreatedAt: row.CreatedAt.Time,
+		}
+		if candidate.Reasons == nil {
+			candidate.Reasons = []string{}
+		}
+		if row.ReviewedAt.Valid {
+			candidate.ReviewedAt = &row.ReviewedAt.Time
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
+
+// RejectCandidate marks a pending candidate as two different people
+func (s *DuplicateService) RejectCandidate(ctx context.Context, userID, candidateID string) error {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return err
+	}
+	candidateUUID, err := parseUUID(candidateID)
+	if err != nil {
+		return err
+	}
+
+	rejected, err := s.queries.RejectDuplicateCandidate(ctx, db.RejectDuplicateCandidateParams{
+		ID:         candidateUUID,
+		ReviewedBy: userUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reject duplicate candidate: %w", err)
+	}
+	if rejected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ConfirmCandidate merges the two profiles of a candidate in a single
+// transaction. Relationships, tracked connections, employment history, events,
+// tags, notes and list memberships move to the kept profile, which also takes
+// any fields it lacks, and the other profile is deleted.
+func (s *DuplicateService) ConfirmCandidate(ctx context.Context, candidateID string, req models.ConfirmDuplicateRequest) (*models.ProfileRef, error) {
+	candidateUUID, err := parseUUID(candidateID)
+	if err != nil {
+		return nil, err
+	}
+	var keepUUID pgtype.UUID
+	if req.KeepProfileID != "" {
+		if keepUUID, err = parseUUID(req.KeepProfileID); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	candidate, err := qtx.GetDuplicateCandidate(ctx, candidateUUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get duplicate candidate: %w", err)
+	}
+	first, err := qtx.GetLinkedInProfileByID(ctx, candidate.ProfileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+	second, err := qtx.GetLinkedInProfileByID(ctx, candidate.DuplicateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	target, source, err := chooseMergeTarget(first, second, keepUUID)
+	if err != nil {
+		return nil, err
+	}
+	targetID, sourceID := target.ID, source.ID
+
+	err = qtx.DeleteRelationshipsBetween(ctx, db.DeleteRelationshipsBetweenParams{SourceID: sourceID, TargetID: targetID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete relationships between the duplicates: %w", err)
+	}
+	err = qtx.RepointRelationshipsFrom(ctx, db.RepointRelationshipsFromParams{TargetID: targetID, SourceID: sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move outgoing relationships: %w", err)
+	}
+	err = qtx.RepointRelationshipsTo(ctx, db.RepointRelationshipsToParams{TargetID: targetID, SourceID: sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move incoming relationships: %w", err)
+	}
+	err = qtx.RepointTrackedConnections(ctx, db.RepointTrackedConnectionsParams{TargetID: targetID, SourceID: sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move tracked connections: %w", err)
+	}
+	err = qtx.RepointProfileEmployment(ctx, db.RepointProfileEmploymentParams{TargetID: targetID, SourceID: sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move employment history: %w", err)
+	}
+	err = qtx.RepointProfileEvents(ctx, db.RepointProfileEventsParams{TargetID: targetID, SourceID: sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move profile events: %w", err)
+	}
+	err = qtx.RepointProfileEventRelations(ctx, db.RepointProfileEventRelationsParams{TargetID: targetID, SourceID: sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move connection events: %w", err)
+	}
+	err = qtx.RepointProfileTags(ctx, db.RepointProfileTagsParams{TargetID: targetID, SourceID: sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move tags: %w", err)
+	}
+	err = qtx.RepointProfileNotes(ctx, db.RepointProfileNotesParams{TargetID: targetID, SourceID: sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move notes: %w", err)
+	}
+	err = qtx.RepointProfileListMembers(ctx, db.RepointProfileListMembersParams{TargetID: targetID, SourceID: sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move list memberships: %w", err)
+	}
+	err = qtx.RepointRuleExecutions(ctx, db.RepointRuleExecutionsParams{TargetID: targetID, SourceID: sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move rule executions: %w", err)
+	}
+	// Where both profiles are in a rule's sequence, the one further along carries on
+	err = qtx.DeleteSupersededSequenceEnrollments(ctx, db.DeleteSupersededSequenceEnrollmentsParams{TargetID: targetID, SourceID: sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge sequence enrollments: %w", err)
+	}
+	err = qtx.RepointSequenceEnrollments(ctx, db.RepointSequenceEnrollmentsParams{TargetID: targetID, SourceID: sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move sequence enrollments: %w", err)
+	}
+	err = qtx.FillMergedProfile(ctx, db.FillMergedProfileParams{TargetID: targetID, SourceID: sourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fill merged profile: %w", err)
+	}
+
+	// Deleting the source cascades to whatever could not move and to this candidate
+	if err := qtx.DeleteLinkedInProfile(ctx, sourceID); err != nil {
+		return nil, fmt.Errorf("failed to delete merged profile: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit merge: %w", err)
+	}
+
+	logger.Infof("profile %s merged into %s", source.LinkedinUrl.String, target.LinkedinUrl.String)
+	return &models.ProfileRef{
+		ID:          uuidString(target.ID),
+		Name:        target.Name,
+		LinkedinURL: target.LinkedinUrl.String,
+	}, nil
+}
+
+// chooseMergeTarget returns the profile to keep and the one to merge into it.
+// Without an explicit choice, a profile with a LinkedIn ID wins, then the older one.
+func chooseMergeTarget(a, b db.LinkedinProfile, keep pgtype.UUID) (db.LinkedinProfile, db.LinkedinProfile, error) {
+	switch {
+	case keep.Valid && keep == a.ID:
+		return a, b, nil
+	case keep.Valid && keep == b.ID:
+		return b, a, nil
+	case keep.Valid:
+		return db.LinkedinProfile{}, db.LinkedinProfile{}, ErrNotInPair
+	}
+
+	aHasID, bHasID := textValue(a.LinkedinID) != "", textValue(b.LinkedinID) != ""
+	if aHasID != bHasID {
+		if aHasID {
+			return a, b, nil
+		}
+		return b, a, nil
+	}
+	if b.CreatedAt.Time.Before(a.CreatedAt.Time) {
+		return b, a, nil
+	}
+	return a, b, nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// defaultJobChangesWindow is how far back the job change feed goes when no date is given
+	defaultJobChangesWindow = 30 * 24 * time.Hour
+
+	// defaultJobChangesLimit is the number of job changes returned when no limit is given
+	defaultJobChangesLimit = 50
+)
+
+type EventService struct {
+	queries *db.Queries
+}
+
+func NewEventService(queries *db.Queries) *EventService {
+	return &EventService{
+		queries: queries,
+	}
+}
+
+// ListJobChanges returns the most recent job changes among the profiles in the
+// user's network, optionally restricted to moves into one company.
+func (s *EventService) ListJobChanges(ctx context.Context, userID string, query models.JobChangesQuery) ([]models.JobChange, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	since := query.Since
+	if since.IsZero() {
+		since = time.Now().Add(-defaultJobChangesWindow)
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultJobChangesLimit
+	}
+	var companyID pgtype.UUID
+	if query.CompanyID != "" {
+		if companyID, err = parseUUID(query.CompanyID); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := s.queries.ListJobChangesForUser(ctx, db.ListJobChangesForUserParams{
+		UserID:     userUUID,
+		Since:      pgtype.Timestamp{Time: since, Valid: true},
+		CompanyID:  companyID,
+		MaxResults: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job changes: %w", err)
+	}
+
+	changes := make([]models.JobChange, 0, len(rows))
+	for _, row := range rows {
+		changes = append(changes, models.JobChange{
+			ID:           uuidString(row.ID),
+			ProfileID:    uuidString(row.ProfileID),
+			ProfileName:  row.ProfileName,
+			LinkedinURL:  row.LinkedinUrl,
+			OldCompanyID: uuidString(row.OldCompanyID),
+			OldCompany:   textValue(row.OldCompanyName),
+			OldPosition:  textValue(row.OldPosition),
+			NewCompanyID: uuidString(row.NewCompanyID),
+			NewCompany:   textValue(row.NewCompanyName),
+			NewPosition:  textValue(row.NewPosition),
+			DetectedAt:   row.DetectedAt.Time,
+		})
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// windowSearchDays bounds how far ahead the next opening of a window is looked
+// for, long enough to get past a year of holidays
+const windowSearchDays = 400
+
+var (
+	// ErrInvalidTimezone is returned when a timezone is not a known IANA name
+	ErrInvalidTimezone = errors.New("timezone must be an IANA name such as Europe/Madrid")
+
+	// ErrInvalidWindow is returned when an execution window's hours or holidays are invalid
+	ErrInvalidWindow = errors.New("invalid execution window")
+)
+
+type ExecutionWindowService struct {
+	queries         *db.Queries
+	defaultTimezone *time.Location
+}
+
+func NewExecutionWindowService(queries *db.Queries, defaultTimezone *time.Location) *ExecutionWindowService {
+	return &ExecutionWindowService{
+		queries:         queries,
+		defaultTimezone: defaultTimezone,
+	}
+}
+
+// executionWindow is a user's execution window, ready to evaluate
+type executionWindow struct {
+	timezone *time.Location
+	// restricted is false for users without a window, who act at any time
+	restricted bool
+	weekdays   [7]bool
+	// start and end are minutes since midnight
+	start, end int
+	holidays   map[string]bool
+}
+
+// GetWindow returns the user's execution window and whether it is open now
+func (s *ExecutionWindowService) GetWindow(ctx context.Context, userID string) (*models.ExecutionWindow, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	window, err := s.window(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	result := executionWindowModel(window, time.Now())
+	return &result, nil
+}
+
+// UpdateWindow sets the user's execution window, and their timezone if given
+func (s *ExecutionWindowService) UpdateWindow(ctx context.Context, userID string, req models.ExecutionWindowRequest) (*models.ExecutionWindow, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Timezone != "" {
+		if _, err := loadTimezone(req.Timezone); err != nil {
+			return nil, err
+		}
+	}
+	start, err := parseClock(req.Start)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseClock(req.End)
+	if err != nil {
+		return nil, err
+	}
+	if end <= start {
+		return nil, fmt.Errorf("%w: end must be after start", ErrInvalidWindow)
+	}
+	weekdays := make([]int32, 0, len(req.Weekdays))
+	for _, day := range req.Weekdays {
+		weekdays = append(weekdays, int32(day))
+	}
+	slices.Sort(weekdays)
+	weekdays = slices.Compact(weekdays)
+	holidays := make([]pgtype.Date, 0, len(req.Holidays))
+	for _, holiday := range req.Holidays {
+		date, err := time.Parse(time.DateOnly, holiday)
+		if err != nil {
+			return nil, fmt.Errorf("%w: holiday %q is not a YYYY-MM-DD date", ErrInvalidWindow, holiday)
+		}
+		holidays = append(holidays, pgtype.Date{Time: date, Valid: true})
+	}
+
+	if req.Timezone != "" {
+		err := s.queries.SetUserTimezone(ctx, db.SetUserTimezoneParams{
+			ID:       userUUID,
+			Timezone: pgtype.Text{String: req.Timezone, Valid: true},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to set timezone: %w", err)
+		}
+	}
+	_, err = s.queries.UpsertExecutionWindow(ctx, db.UpsertExecutionWindowParams{
+		UserID:    userUUID,
+		Weekdays:  weekdays,
+		StartTime: pgtype.Time{Microseconds: int64(start) * int64(time.Minute/time.Microsecond), Valid: true},
+		EndTime:   pgtype.Time{Microseconds: int64(end) * int64(time.Minute/time.Microsecond), Valid: true},
+		Holidays:  holidays,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update execution window: %w", err)
+	}
+
+	return s.GetWindow(ctx, userID)
+}
+
+// DeleteWindow removes the user's execution window, so their rules act at any time
+func (s *ExecutionWindowService) DeleteWindow(ctx context.Context, userID string) error {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.queries.DeleteExecutionWindow(ctx, userUUID)
+	if err != nil {
+		return fmt.Errorf("failed to delete execution window: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// window loads the user's execution window and timezone
+func (s *ExecutionWindowService) window(ctx context.Context, userID pgtype.UUID) (executionWindow, error) {
+	window := executionWindow{timezone: s.defaultTimezone}
+	timezone, err := s.queries.GetUserTimezone(ctx, userID)
+	if err != nil {
+		return executionWindow{}, fmt.Errorf("failed to get timezone: %w", err)
+	}
+	if loc, err := loadTimezone(textValue(timezone)); err == nil {
+		window.timezone = loc
+	}
+
+	row, err := s.queries.GetExecutionWindow(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return window, nil
+	}
+	if err != nil {
+		return executionWindow{}, fmt.Errorf("failed to get execution window: %w", err)
+	}
+
+	window.restricted = true
+	for _, day := range row.Weekdays {
+		window.weekdays[time.Weekday(day%7)] = true
+	}
+	window.start = int(row.StartTime.Microseconds / int64(time.Minute/time.Microsecond))
+	window.end = int(row.EndTime.Microseconds / int64(time.Minute/time.Microsecond))
+	window.holidays = make(map[string]bool, len(row.Holidays))
+	for _, holiday := range row.Holidays {
+		window.holidays[holiday.Time.Format(time.DateOnly)] = true
+	}
+	return window, nil
+}
+
+// location returns the timezone to evaluate the window in for a rule: its
+// own, if it has a valid one, else the user's
+func (w executionWindow) location(ruleTimezone string) *time.Location {
+	if ruleTimezone != "" {
+		if loc, err := loadTimezone(ruleTimezone); err == nil {
+			return loc
+		}
+	}
+	return w.timezone
+}
+
+// openAt reports whether the window is open at t in loc
+func (w executionWindow) openAt(t time.Time, loc *time.Location) bool {
+	if !w.restricted {
+		return true
+	}
+	local := t.In(loc)
+	if !w.weekdays[local.Weekday()] || w.holidays[local.Format(time.DateOnly)] {
+		return false
+	}
+	minute := local.Hour()*60 + local.Minute()
+	return minute >= w.start && minute < w.end
+}
+
+// nextOpen returns when the window is next open from t in loc, t itself if it
+// is open, or false if it never opens, e.g. every weekday is a holiday
+func (w executionWindow) nextOpen(t time.Time, loc *time.Location) (time.Time, bool) {
+	if w.openAt(t, loc) {
+		return t, true
+	}
+	local := t.In(loc)
+	for day := 0; day < windowSearchDays; day++ {
+		// Built from the date, so that the start keeps its wall clock time across DST changes
+		start := time.Date(local.Year(), local.Month(), local.Day()+day, w.start/60, w.start%60, 0, 0, loc)
+		if start.After(t) && w.openAt(start, loc) {
+			return start, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// openPerDay is how long the window is open on the days it opens
+func (w executionWindow) openPerDay() time.Duration {
+	if !w.restricted {
+		return 24 * time.Hour
+	}
+	return time.Duration(w.end-w.start) * time.Minute
+}
+
+// executionWindowModel describes a window as of now, in the user's timezone
+func executionWindowModel(window executionWindow, now time.Time) models.ExecutionWindow {
+	result := models.ExecutionWindow{
+		Timezone:   window.timezone.String(),
+		Restricted: window.restricted,
+		OpenNow:    window.openAt(now, window.timezone),
+	}
+	if !window.restricted {
+		return result
+	}
+
+	// ISO order, Monday first
+	for day := 1; day <= 7; day++ {
+		if window.weekdays[time.Weekday(day%7)] {
+			result.Weekdays = append(result.Weekdays, day)
+		}
+	}
+	result.Start = formatClock(window.start)
+	result.End = formatClock(window.end)
+	for holiday := range window.holidays {
+		result.Holidays = append(result.Holidays, holiday)
+	}
+	slices.Sort(result.Holidays)
+	if next, ok := window.nextOpen(now, window.timezone); ok {
+		result.NextOpenAt = &next
+	}
+	return result
+}
+
+// loadTimezone loads an IANA timezone, returning ErrInvalidTimezone for
+// unknown names. The empty name, which time.LoadLocation takes as UTC, is
+// invalid too.
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, ErrInvalidTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	return loc, nil
+}
+
+// parseClock parses an HH:MM time of day into minutes since midnight
+func parseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not an HH:MM time", ErrInvalidWindow, clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionWindow(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Weekdays 09:00-18:00 in Madrid, with Christmas off
+	window := executionWindow{
+		timezone:   madrid,
+		restricted: true,
+		start:      9 * 60,
+		end:        18 * 60,
+		holidays:   map[string]bool{"2026-12-25": true},
+	}
+	for day := time.Monday; day <= time.Friday; day++ {
+		window.weekdays[day] = true
+	}
+	at := func(loc *time.Location, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, loc)
+	}
+
+	tests := map[string]struct {
+		t        time.Time
+		timezone string
+		open     bool
+		next     time.Time
+	}{
+		"open":               {t: at(madrid, time.October, 21, 10, 30), open: true, next: at(madrid, time.October, 21, 10, 30)},
+		"before start":       {t: at(madrid, time.October, 21, 8, 59), next: at(madrid, time.October, 21, 9, 0)},
+		"at end":             {t: at(madrid, time.October, 21, 18, 0), next: at(madrid, time.October, 22, 9, 0)},
+		"weekend":            {t: at(madrid, time.October, 17, 12, 0), next: at(madrid, time.October, 19, 9, 0)},
+		"friday evening":     {t: at(madrid, time.October, 16, 20, 0), next: at(madrid, time.October, 19, 9, 0)},
+		"holiday":            {t: at(madrid, time.December, 25, 12, 0), next: at(madrid, time.December, 28, 9, 0)},
+		"rule timezone":      {t: at(madrid, time.October, 21, 10, 0), timezone: "America/New_York", next: at(newYork, time.October, 21, 9, 0)},
+		"rule timezone open": {t: at(madrid, time.October, 21, 20, 0), timezone: "America/New_York", open: true, next: at(madrid, time.October, 21, 20, 0)},
+		"invalid rule zone":  {t: at(madrid, time.October, 21, 10, 0), timezone: "Mars/Olympus", open: true, next: at(madrid, time.October, 21, 10, 0)},
+		// Madrid leaves summer time on Sunday October 25, so Monday opens at
+		// 09:00 CET, not 09:00 CEST
+		"across DST change": {t: at(madrid, time.October, 23, 19, 0), next: at(madrid, time.October, 26, 9, 0)},
+	}
+	for name, tc := range tests {
+		loc := window.location(tc.timezone)
+		assert.Equal(t, tc.open, window.openAt(tc.t, loc), name)
+		next, ok := window.nextOpen(tc.t, loc)
+		assert.True(t, ok, name)
+		assert.True(t, tc.next.Equal(next), "%s: next open at %s, want %s", name, next, tc.next)
+	}
+
+	// Without a window, rules act at any time
+	anytime := executionWindow{timezone: madrid}
+	assert.True(t, anytime.openAt(at(madrid, time.December, 25, 3, 0), madrid))
+	assert.Equal(t, 9*time.Hour, window.openPerDay())
+	assert.Equal(t, 24*time.Hour, anytime.openPerDay())
+
+	// A window whose every weekday is a holiday never opens
+	never := executionWindow{timezone: madrid, restricted: true, start: 9 * 60, end: 18 * 60, holidays: map[string]bool{}}
+	_, ok := never.nextOpen(at(madrid, time.October, 21, 10, 0), madrid)
+	assert.False(t, ok)
+
+	model := executionWindowModel(window, at(madrid, time.October, 17, 12, 0))
+	assert.Equal(t, "Europe/Madrid", model.Timezone)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, model.Weekdays)
+	assert.Equal(t, "09:00", model.Start)
+	assert.Equal(t, "18:00", model.End)
+	assert.Equal(t, []string{"2026-12-25"}, model.Holidays)
+	assert.False(t, model.OpenNow)
+	if assert.NotNil(t, model.NextOpenAt) {
+		assert.True(t, at(madrid, time.October, 19, 9, 0).Equal(*model.NextOpenAt))
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	minutes, err := parseClock("09:30")
+	assert.NoError(t, err)
+	assert.Equal(t, 570, minutes)
+	assert.Equal(t, "09:30", formatClock(minutes))
+
+	_, err = parseClock("9am")
+	assert.ErrorIs(t, err, ErrInvalidWindow)
+	_, err = loadTimezone("")
+	assert.ErrorIs(t, err, ErrInvalidTimezone)
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"time"
+
+	"linkedin-watcher/db"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// fakeRuleExecutions stands in for the database in the automation and
+// approval service tests, for one user without an execution window. It keeps
+// rule_executions in memory and gives each query the result the services
+// expect of it, so these tests cover how the services claim, queue, decide
+// and retry actions, not the SQL of the queries.
+type fakeRuleExecutions struct {
+	candidates []db.GetProfilesMatchingRulesRow
+	executions []*db.RuleExecution
+	// matched is how many candidates the last GetProfilesMatchingRules returned
+	matched int
+}
+
+var queryName = regexp.MustCompile(`^-- name: (\w+)`)
+
+func (f *fakeRuleExecutions) Exec(_ context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	switch name := queryName.FindStringSubmatch(sql)[1]; name {
+	case "QueueRuleExecution":
+		execution := f.byID(args[0].(pgtype.UUID))
+		execution.Status = RuleExecutionAwaitingApproval
+		execution.Message = args[1].(pgtype.Text)
+	case "FinishRuleExecution":
+		execution := f.byID(args[0].(pgtype.UUID))
+		execution.Status = args[1].(string)
+		execution.Outcome = args[2].(pgtype.Text)
+		execution.Error = args[3].(pgtype.Text)
+	case "EnrollRuleSequence":
+	default:
+		return pgconn.CommandTag{}, fmt.Errorf("unexpected query %s", name)
+	}
+	return pgconn.CommandTag{}, nil
+}
+
+func (f *fakeRuleExecutions) QueryRow(_ context.Context, sql string, args ...interface{}) pgx.Row {
+	switch name := queryName.FindStringSubmatch(sql)[1]; name {
+	case "GetUserTimezone":
+		return &fakeRows{values: []interface{}{pgtype.Text{}}}
+	case "GetExecutionWindow":
+		return &fakeRows{}
+	case "ClaimRuleExecution":
+		rule, profile, maxAttempts := args[0].(pgtype.UUID), args[1].(pgtype.UUID), args[2].(int32)
+		execution := f.find(rule, profile)
+		switch {
+		case execution == nil:
+			execution = &db.RuleExecution{ID: testProfileID(byte(10 + len(f.executions))), RuleID: rule, ProfileID: profile, Attempts: 1}
+			f.executions = append(f.executions, execution)
+		case execution.Status == RuleExecutionFailed && !execution.ApprovedAt.Valid && execution.Attempts < maxAttempts:
+			execution.Attempts++
+		default:
+			return &fakeRows{}
+		}
+		execution.Status, execution.Outcome, execution.Error = RuleExecutionPending, pgtype.Text{}, pgtype.Text{}
+		return &fakeRows{values: []interface{}{*execution}}
+	case "StartApprovedRuleExecution":
+		execution, maxAttempts := f.byID(args[0].(pgtype.UUID)), args[1].(int32)
+		if execution == nil || !execution.ApprovedAt.Valid ||
+			!(execution.Status == RuleExecutionApproved || execution.Status == RuleExecutionFailed && execution.Attempts < maxAttempts) {
+			return &fakeRows{}
+		}
+		if execution.Status == RuleExecutionFailed {
+			execution.Attempts++
+		}
+		execution.Status, execution.Outcome, execution.Error = RuleExecutionPending, pgtype.Text{}, pgtype.Text{}
+		return &fakeRows{values: []interface{}{*execution}}
+	}
+	return &fakeRows{err: fmt.Errorf("unexpected query %s", sql)}
+}
+
+func (f *fakeRuleExecutions) Query(_ context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	var values []interface{}
+	switch name := queryName.FindStringSubmatch(sql)[1]; name {
+	case "GetProfilesMatchingRules":
+		maxAttempts := args[1].(int32)
+		for _, row := range f.candidates {
+			execution := f.find(row.RuleID, row.ID)
+			if execution == nil || execution.Status == RuleExecutionFailed && !execution.ApprovedAt.Valid && execution.Attempts < maxAttempts {
+				values = append(values, row)
+			}
+		}
+		f.matched = len(values)
+	case "GetJobChangesMatchingRules":
+	case "ListApprovedRuleActions":
+		maxAttempts := args[1].(int32)
+		for _, execution := range f.executions {
+			if !execution.ApprovedAt.Valid ||
+				!(execution.Status == RuleExecutionApproved || execution.Status == RuleExecutionFailed && execution.Attempts < maxAttempts) {
+				continue
+			}
+			row := f.candidate(execution.RuleID, execution.ProfileID)
+			values = append(values, db.ListApprovedRuleActionsRow{
+				ExecutionID: execution.ID,
+				Message:     execution.Message,
+				RuleID:      row.RuleID,
+				RuleName:    row.RuleName,
+				TriggerType: RuleTriggerNewConnection,
+				ActionType:  row.ActionType,
+				ID:          row.ID,
+				LinkedinUrl: row.LinkedinUrl,
+				Name:        row.Name,
+			})
+		}
+	case "DecidePendingApprovals":
+		status := args[0].(string)
+		for _, id := range args[2].([]pgtype.UUID) {
+			execution := f.byID(id)
+			if execution == nil || execution.Status != RuleExecutionAwaitingApproval {
+				continue
+			}
+			execution.Status = status
+			execution.ApprovedAt = pgtype.Timestamp{Time: time.Now(), Valid: status == RuleExecutionApproved}
+			values = append(values, id)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected query %s", name)
+	}
+	return &fakeRows{values: values, index: -1}, nil
+}
+
+func (f *fakeRuleExecutions) find(rule, profile pgtype.UUID) *db.RuleExecution {
+	for _, execution := range f.executions {
+		if execution.RuleID == rule && execution.ProfileID == profile {
+			return execution
+		}
+	}
+	return nil
+}
+
+func (f *fakeRuleExecutions) byID(id pgtype.UUID) *db.RuleExecution {
+	for _, execution := range f.executions {
+		if execution.ID == id {
+			return execution
+		}
+	}
+	return nil
+}
+
+func (f *fakeRuleExecutions) candidate(rule, profile pgtype.UUID) db.GetProfilesMatchingRulesRow {
+	for _, row := range f.candidates {
+		if row.RuleID == rule && row.ID == profile {
+			return row
+		}
+	}
+	return db.GetProfilesMatchingRulesRow{}
+}
+
+// fakeRows returns values as rows, each a struct scanned field by field into
+// the destinations as sqlc scans its row types, or a single value. As a
+// pgx.Row it scans the first value, or returns pgx.ErrNoRows without any.
+type fakeRows struct {
+	values []interface{}
+	index  int
+	err    error
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.index >= len(r.values) {
+		return pgx.ErrNoRows
+	}
+	return scanValue(r.values[r.index], dest)
+}
+
+func (r *fakeRows) Next() bool {
+	r.index++
+	return r.index < len(r.values)
+}
+
+func (r *fakeRows) Close()                                       {}
+func (r *fakeRows) Err() error                                   { return nil }
+func (r *fakeRows) CommandTag() pgconn.CommandTag                { return pgconn.CommandTag{} }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (r *fakeRows) Values() ([]interface{}, error)               { return nil, nil }
+func (r *fakeRows) RawValues() [][]byte                          { return nil }
+func (r *fakeRows) Conn() *pgx.Conn                              { return nil }
+
+func scanValue(value interface{}, dest []interface{}) error {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Struct || len(dest) == 1 && reflect.TypeOf(dest[0]).Elem() == v.Type() {
+		reflect.ValueOf(dest[0]).Elem().Set(v)
+		return nil
+	}
+	if v.NumField() != len(dest) {
+		return fmt.Errorf("scanning %d fields of %s into %d destinations", v.NumField(), v.Type(), len(dest))
+	}
+	for i := range dest {
+		reflect.ValueOf(dest[i]).Elem().Set(v.Field(i))
+	}
+	return nil
+}
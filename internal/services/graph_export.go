@@ -0,0 +1,271 @@
+package services
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"linkedin-watcher/internal/models"
+	"strings"
+	"time"
+)
+
+// GraphWriter streams a network graph in a specific file format.
+// All nodes must be written before the first edge.
+type GraphWriter interface {
+	WriteNode(node models.GraphNode) error
+	WriteEdge(edge models.GraphEdge) error
+	Close() error
+}
+
+// NewGraphWriter returns a GraphWriter for the given format
+func NewGraphWriter(format models.GraphFormat, w io.Writer) (GraphWriter, error) {
+	switch format {
+	case models.GraphFormatGraphML:
+		return newGraphMLWriter(w)
+	case models.GraphFormatGEXF:
+		return newGEXFWriter(w)
+	case models.GraphFormatDOT:
+		return newDOTWriter(w)
+	case models.GraphFormatJSON:
+		return newNodeLinkJSONWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported graph format: %s", format)
+	}
+}
+
+// listSeparator joins multi-valued node attributes such as tags in the GraphML, GEXF and DOT formats
+const listSeparator = "; "
+
+// nodeAttributes lists the string node attributes written by the GraphML, GEXF and DOT formats
+var nodeAttributes = []struct {
+	key   string
+	value func(models.GraphNode) string
+}{
+	{"name", func(n models.GraphNode) string { return n.Name }},
+	{"linkedin_url", func(n models.GraphNode) string { return n.LinkedinURL }},
+	{"headline", func(n models.GraphNode) string { return n.Headline }},
+	{"company", func(n models.GraphNode) string { return n.Company }},
+	{"location", func(n models.GraphNode) string { return n.Location }},
+	{"tags", func(n models.GraphNode) string { return strings.Join(n.Tags, listSeparator) }},
+	{"lists", func(n models.GraphNode) string { return strings.Join(n.Lists, listSeparator) }},
+}
+
+// xmlEscape returns s with XML special characters escaped
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+type graphMLWriter struct {
+	w *bufio.Writer
+}
+
+func newGraphMLWriter(w io.Writer) (*graphMLWriter, error) {
+	gw := &graphMLWriter{w: bufio.NewWriter(w)}
+	gw.w.WriteString(xml.Header)
+	gw.w.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	for _, attr := range nodeAttributes {
+		fmt.Fprintf(gw.w, `  <key id="%s" for="node" attr.name="%s" attr.type="string"/>`+"\n", attr.key, attr.key)
+	}
+	gw.w.WriteString(`  <key id="tracked" for="node" attr.name="tracked" attr.type="boolean"/>` + "\n")
+	gw.w.WriteString(`  <key id="degree" for="edge" attr.name="degree" attr.type="int"/>` + "\n")
+	gw.w.WriteString(`  <key id="discovered_at" for="edge" attr.name="discovered_at" attr.type="string"/>` + "\n")
+	gw.w.WriteString(`  <graph id="network" edgedefault="undirected">` + "\n")
+	return gw, nil
+}
+
+func (gw *graphMLWriter) WriteNode(node models.GraphNode) error {
+	fmt.Fprintf(gw.w, `    <node id="%s">`+"\n", xmlEscape(node.ID))
+	for _, attr := range nodeAttributes {
+		if v := attr.value(node); v != "" {
+			fmt.Fprintf(gw.w, `      <data key="%s">%s</data>`+"\n", attr.key, xmlEscape(v))
+		}
+	}
+	fmt.Fprintf(gw.w, `      <data key="tracked">%t</data>`+"\n", node.Tracked)
+	_, err := gw.w.WriteString("    </node>\n")
+	return err
+}
+
+func (gw *graphMLWriter) WriteEdge(edge models.GraphEdge) error {
+	fmt.Fprintf(gw.w, `    <edge id="%s" source="%s" target="%s">`+"\n",
+		xmlEscape(edge.ID), xmlEscape(edge.Source), xmlEscape(edge.Target))
+	fmt.Fprintf(gw.w, `      <data key="degree">%d</data>`+"\n", edge.Degree)
+	fmt.Fprintf(gw.w, `      <data key="discovered_at">%s</data>`+"\n", edge.DiscoveredAt.Format(time.RFC3339))
+	_, err := gw.w.WriteString("    </edge>\n")
+	return err
+}
+
+func (gw *graphMLWriter) Close() error {
+	gw.w.WriteString("  </graph>\n</graphml>\n")
+	return gw.w.Flush()
+}
+
+type gexfWriter struct {
+	w       *bufio.Writer
+	inEdges bool
+}
+
+func newGEXFWriter(w io.Writer) (*gexfWriter, error) {
+	gw := &gexfWriter{w: bufio.NewWriter(w)}
+	gw.w.WriteString(xml.Header)
+	gw.w.WriteString(`<gexf xmlns="http://gexf.net/1.3" version="1.3">` + "\n")
+	fmt.Fprintf(gw.w, `  <meta lastmodifieddate="%s"><creator>linkedin-watcher</creator></meta>`+"\n", time.Now().Format("2006-01-02"))
+	gw.w.WriteString(`  <graph defaultedgetype="undirected" mode="static">` + "\n")
+	gw.w.WriteString(`    <attributes class="node">` + "\n")
+	for _, attr := range nodeAttributes {
+		fmt.Fprintf(gw.w, `      <attribute id="%s" title="%s" type="string"/>`+"\n", attr.key, attr.key)
+	}
+	gw.w.WriteString(`      <attribute id="tracked" title="tracked" type="boolean"/>` + "\n")
+	gw.w.WriteString("    </attributes>\n")
+	gw.w.WriteString(`    <attributes class="edge">` + "\n")
+	gw.w.WriteString(`      <attribute id="degree" title="degree" type="integer"/>` + "\n")
+	gw.w.WriteString(`      <attribute id="discovered_at" title="discovered_at" type="string"/>` + "\n")
+	gw.w.WriteString("    </attributes>\n")
+	gw.w.WriteString("    <nodes>\n")
+	return gw, nil
+}
+
+func (gw *gexfWriter) WriteNode(node models.GraphNode) error {
+	if gw.inEdges {
+		return fmt.Errorf("gexf: node %s written after edges", node.ID)
+	}
+	fmt.Fprintf(gw.w, `      <node id="%s" label="%s">`+"\n", xmlEscape(node.ID), xmlEscape(node.Name))
+	gw.w.WriteString("        <attvalues>\n")
+	for _, attr := range nodeAttributes {
+		if v := attr.value(node); v != "" {
+			fmt.Fprintf(gw.w, `          <attvalue for="%s" value="%s"/>`+"\n", attr.key, xmlEscape(v))
+		}
+	}
+	fmt.Fprintf(gw.w, `          <attvalue for="tracked" value="%t"/>`+"\n", node.Tracked)
+	gw.w.WriteString("        </attvalues>\n")
+	_, err := gw.w.WriteString("      </node>\n")
+	return err
+}
+
+func (gw *gexfWriter) WriteEdge(edge models.GraphEdge) error {
+	if !gw.inEdges {
+		gw.w.WriteString("    </nodes>\n    <edges>\n")
+		gw.inEdges = true
+	}
+	fmt.Fprintf(gw.w, `      <edge id="%s" source="%s" target="%s">`+"\n",
+		xmlEscape(edge.ID), xmlEscape(edge.Source), xmlEscape(edge.Target))
+	gw.w.WriteString("        <attvalues>\n")
+	fmt.Fprintf(gw.w, `          <attvalue for="degree" value="%d"/>`+"\n", edge.Degree)
+	fmt.Fprintf(gw.w, `          <attvalue for="discovered_at" value="%s"/>`+"\n", edge.DiscoveredAt.Format(time.RFC3339))
+	gw.w.WriteString("        </attvalues>\n")
+	_, err := gw.w.WriteString("      </edge>\n")
+	return err
+}
+
+func (gw *gexfWriter) Close() error {
+	if gw.inEdges {
+		gw.w.WriteString("    </edges>\n")
+	} else {
+		gw.w.WriteString("    </nodes>\n    <edges>\n    </edges>\n")
+	}
+	gw.w.WriteString("  </graph>\n</gexf>\n")
+	return gw.w.Flush()
+}
+
+type dotWriter struct {
+	w *bufio.Writer
+}
+
+func newDOTWriter(w io.Writer) (*dotWriter, error) {
+	gw := &dotWriter{w: bufio.NewWriter(w)}
+	gw.w.WriteString("graph network {\n")
+	return gw, nil
+}
+
+// dotQuote returns s as a double-quoted DOT identifier
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func (gw *dotWriter) WriteNode(node models.GraphNode) error {
+	attrs := []string{"label=" + dotQuote(node.Name)}
+	for _, attr := range nodeAttributes {
+		if attr.key == "name" {
+			continue
+		}
+		if v := attr.value(node); v != "" {
+			attrs = append(attrs, attr.key+"="+dotQuote(v))
+		}
+	}
+	attrs = append(attrs, fmt.Sprintf("tracked=%t", node.Tracked))
+	_, err := fmt.Fprintf(gw.w, "  %s [%s];\n", dotQuote(node.ID), strings.Join(attrs, ", "))
+	return err
+}
+
+func (gw *dotWriter) WriteEdge(edge models.GraphEdge) error {
+	_, err := fmt.Fprintf(gw.w, "  %s -- %s [id=%s, degree=%d, discovered_at=%s];\n",
+		dotQuote(edge.Source), dotQuote(edge.Target), dotQuote(edge.ID),
+		edge.Degree, dotQuote(edge.DiscoveredAt.Format(time.RFC3339)))
+	return err
+}
+
+func (gw *dotWriter) Close() error {
+	gw.w.WriteString("}\n")
+	return gw.w.Flush()
+}
+
+// nodeLinkJSONWriter writes the node-link layout understood by networkx and d3
+type nodeLinkJSONWriter struct {
+	w         *bufio.Writer
+	nodeCount int
+	edgeCount int
+	inLinks   bool
+}
+
+func newNodeLinkJSONWriter(w io.Writer) (*nodeLinkJSONWriter, error) {
+	gw := &nodeLinkJSONWriter{w: bufio.NewWriter(w)}
+	gw.w.WriteString(`{"directed":false,"multigraph":false,"graph":{"name":"network"},"nodes":[`)
+	return gw, nil
+}
+
+func (gw *nodeLinkJSONWriter) WriteNode(node models.GraphNode) error {
+	if gw.inLinks {
+		return fmt.Errorf("json: node %s written after edges", node.ID)
+	}
+	data, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+	if gw.nodeCount > 0 {
+		gw.w.WriteByte(',')
+	}
+	gw.nodeCount++
+	_, err = gw.w.Write(data)
+	return err
+}
+
+func (gw *nodeLinkJSONWriter) WriteEdge(edge models.GraphEdge) error {
+	if !gw.inLinks {
+		gw.w.WriteString(`],"links":[`)
+		gw.inLinks = true
+	}
+	data, err := json.Marshal(edge)
+	if err != nil {
+		return err
+	}
+	if gw.edgeCount > 0 {
+		gw.w.WriteByte(',')
+	}
+	gw.edgeCount++
+	_, err = gw.w.Write(data)
+	return err
+}
+
+func (gw *nodeLinkJSONWriter) Close() error {
+	if !gw.inLinks {
+		gw.w.WriteString(`],"links":[`)
+	}
+	gw.w.WriteString("]}\n")
+	return gw.w.Flush()
+}
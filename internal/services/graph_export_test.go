@@ -0,0 +1,129 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"linkedin-watcher/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestGraph(t *testing.T, format models.GraphFormat) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer, err := NewGraphWriter(format, &buf)
+	require.NoError(t, err)
+
+	nodes := []models.GraphNode{
+		{ID: "a", Name: `Ada "The Countess" Lovelace`, LinkedinURL: "https://www.linkedin.com/in/ada", Company: "Babbage & Co", Location: "London", Tracked: true,
+			Tags: []string{"investor", "mentor"}, Lists: []string{"Q3 hiring targets"}},
+		{ID: "b", Name: "Grace Hopper", LinkedinURL: "https://www.linkedin.com/in/grace", Headline: "Rear <Admiral>"},
+	}
+	for _, n := range nodes {
+		require.NoError(t, writer.WriteNode(n))
+	}
+	require.NoError(t, writer.WriteEdge(models.GraphEdge{
+		ID:           "e1",
+		Source:       "a",
+		Target:       "b",
+		Degree:       2,
+		DiscoveredAt: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+	}))
+	require.NoError(t, writer.Close())
+
+	return buf.Bytes()
+}
+
+// assertWellFormedXML decodes every token to make sure the document parses
+func assertWellFormedXML(t *testing.T, data []byte) {
+	t.Helper()
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return
+		}
+		require.NoError(t, err)
+	}
+}
+
+func TestNewGraphWriter_UnsupportedFormat(t *testing.T) {
+	_, err := NewGraphWriter(models.GraphFormat("csv"), &bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestGraphMLWriter(t *testing.T) {
+	data := writeTestGraph(t, models.GraphFormatGraphML)
+
+	assertWellFormedXML(t, data)
+	assert.Contains(t, string(data), `<node id="a">`)
+	assert.Contains(t, string(data), `<data key="company">Babbage &amp; Co</data>`)
+	assert.Contains(t, string(data), `<data key="tags">investor; mentor</data>`)
+	assert.Contains(t, string(data), `<edge id="e1" source="a" target="b">`)
+	assert.Contains(t, string(data), `<data key="discovered_at">2024-03-01T12:00:00Z</data>`)
+}
+
+func TestGEXFWriter(t *testing.T) {
+	data := writeTestGraph(t, models.GraphFormatGEXF)
+
+	assertWellFormedXML(t, data)
+	assert.Contains(t, string(data), `<attvalue for="location" value="London"/>`)
+	assert.Contains(t, string(data), `<attvalue for="lists" value="Q3 hiring targets"/>`)
+	assert.Contains(t, string(data), `<attvalue for="degree" value="2"/>`)
+	assert.Less(t, bytes.Index(data, []byte("</nodes>")), bytes.Index(data, []byte("<edges>")))
+}
+
+func TestGEXFWriter_RejectsNodeAfterEdge(t *testing.T) {
+	writer, err := NewGraphWriter(models.GraphFormatGEXF, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	require.NoError(t, writer.WriteEdge(models.GraphEdge{ID: "e1", Source: "a", Target: "b"}))
+	assert.Error(t, writer.WriteNode(models.GraphNode{ID: "c"}))
+}
+
+func TestDOTWriter(t *testing.T) {
+	data := string(writeTestGraph(t, models.GraphFormatDOT))
+
+	assert.Contains(t, data, "graph network {")
+	assert.Contains(t, data, `label="Ada \"The Countess\" Lovelace"`)
+	assert.Contains(t, data, `"a" -- "b" [id="e1", degree=2, discovered_at="2024-03-01T12:00:00Z"];`)
+}
+
+func TestNodeLinkJSONWriter(t *testing.T) {
+	data := writeTestGraph(t, models.GraphFormatJSON)
+
+	var graph struct {
+		Directed bool               `json:"directed"`
+		Nodes    []models.GraphNode `json:"nodes"`
+		Links    []models.GraphEdge `json:"links"`
+	}
+	require.NoError(t, json.Unmarshal(data, &graph))
+
+	assert.False(t, graph.Directed)
+	assert.Len(t, graph.Nodes, 2)
+	assert.Len(t, graph.Links, 1)
+	assert.Equal(t, "Babbage & Co", graph.Nodes[0].Company)
+	assert.Equal(t, []string{"investor", "mentor"}, graph.Nodes[0].Tags)
+	assert.Empty(t, graph.Nodes[1].Tags)
+	assert.Equal(t, int32(2), graph.Links[0].Degree)
+}
+
+func TestNodeLinkJSONWriter_EmptyGraph(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewGraphWriter(models.GraphFormatJSON, &buf)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	var graph map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &graph))
+	assert.Empty(t, graph["nodes"])
+	assert.Empty(t, graph["links"])
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+)
+
+type GraphService struct {
+	queries *db.Queries
+}
+
+func NewGraphService(queries *db.Queries) *GraphService {
+	return &GraphService{
+		queries: queries,
+	}
+}
+
+// ExportNetwork streams the user's network graph to w in the requested format.
+// Rows are written as they are read so memory use does not grow with the graph.
+func (s *GraphService) ExportNetwork(ctx context.Context, userID string, format models.GraphFormat, w io.Writer) error {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return err
+	}
+
+	writer, err := NewGraphWriter(format, w)
+	if err != nil {
+		return err
+	}
+
+	err = s.queries.StreamUserGraphNodes(ctx, userUUID, func(row db.UserGraphNodeRow) error {
+		return writer.WriteNode(models.GraphNode{
+			ID:          uuidString(row.ID),
+			Name:        row.Name,
+			LinkedinURL: row.LinkedinUrl,
+			Headline:    textValue(row.Headline),
+			Company:     textValue(row.CompanyName),
+			Location:    textValue(row.Location),
+			Tracked:     row.IsTracked,
+			Tags:        row.Tags,
+			Lists:       row.Lists,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export nodes: %w", err)
+	}
+
+	err = s.queries.StreamUserGraphEdges(ctx, userUUID, func(row db.UserGraphEdgeRow) error {
+		return writer.WriteEdge(models.GraphEdge{
+			ID:           uuidString(row.ID),
+			Source:       uuidString(row.ProfileAID),
+			Target:       uuidString(row.ProfileBID),
+			Degree:       row.Degree,
+			DiscoveredAt: row.DiscoveredAt.Time,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export edges: %w", err)
+	}
+
+	return writer.Close()
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// seniorityLevels are the seniority levels a headline can be classified into,
+// most junior first. Keep in sync with seniority_rank() in
+// db/migrations/012_profile_headlines.up.sql.
+var seniorityLevels = []string{"intern", "entry", "senior", "lead", "manager", "director", "vp", "c_level"}
+
+// jobFunctions are the job functions a headline can be classified into
+var jobFunctions = []string{
+	"engineering", "data", "product", "design", "sales", "marketing", "recruiting", "people",
+	"finance", "operations", "legal", "customer_success", "research", "consulting",
+}
+
+// headlineSeparators end the role part of a headline, e.g. "CTO | Speaker"
+var headlineSeparators = []string{"|", "·", "•", " - ", " – ", " — ", ";"}
+
+// headlineEmployer splits a role from the employer it names, e.g. "Engineer at Acme",
+// "CTO @ Acme" or "Ingeniera en Acme"
+var headlineEmployer = regexp.MustCompile(`(?i)\s+(?:at|en)\s+|\s*@\s*`)
+
+// seniorityPhrases overrides the keyword match for titles whose words would
+// otherwise be read as a different level
+var seniorityPhrases = []struct {
+	phrase string
+	level  string
+}{
+	{"chief of staff", "director"},
+	{"vice president", "vp"},
+	{"general manager", "c_level"},
+	{"managing director", "c_level"},
+	{"director general", "c_level"},
+	{"consejero delegado", "c_level"},
+	{"working student", "intern"},
+	{"entry level", "entry"},
+	{"team lead", "lead"},
+	{"tech lead", "lead"},
+}
+
+// seniorityKeywords maps title words to seniority levels
+var seniorityKeywords = map[string]string{
+	"intern": "intern", "internship": "intern", "becario": "intern", "becaria": "intern",
+	"practicas": "intern", "prácticas": "intern", "student": "intern", "estudiante": "intern",
+
+	"junior": "entry", "jr": "entry", "graduate": "entry", "trainee": "entry",
+	"apprentice": "entry", "associate": "entry",
+
+	"senior": "senior", "sr": "senior", "snr": "senior", "sénior": "senior",
+
+	"lead": "lead", "principal": "lead", "staff": "lead",
+
+	"manager": "manager", "gerente": "manager", "jefe": "manager", "jefa": "manager",
+	"responsable": "manager", "supervisor": "manager",
+
+	"director": "director", "directora": "director", "head": "director",
+
+	"vp": "vp", "svp": "vp", "evp": "vp", "avp": "vp", "vicepresidente": "vp", "vicepresidenta": "vp",
+
+	"ceo": "c_level", "cto": "c_level", "cfo": "c_level", "coo": "c_level", "cmo": "c_level",
+	"cio": "c_level", "cpo": "c_level", "cro": "c_level", "chro": "c_level", "chief": "c_level",
+	"founder": "c_level", "cofounder": "c_level", "fundador": "c_level", "fundadora": "c_level",
+	"cofundador": "c_level", "cofundadora": "c_level", "owner": "c_level", "president": "c_level",
+	"presidente": "c_level", "presidenta": "c_level", "partner": "c_level", "socio": "c_level", "socia": "c_level",
+}
+
+// functionKeywords maps title words and phrases to job functions. Phrases of
+// up to three words take precedence over their individual words.
+var functionKeywords = map[string]string{
+	"engineer": "engineering", "engineers": "engineering", "engineering": "engineering",
+	"developer": "engineering", "programmer": "engineering", "software": "engineering",
+	"devops": "engineering", "sre": "engineering", "site reliability": "engineering",
+	"backend": "engineering", "frontend": "engineering", "full stack": "engineering",
+	"fullstack": "engineering", "architect": "engineering", "qa": "engineering",
+	"quality assurance": "engineering", "cto": "engineering", "ingeniero": "engineering",
+	"ingeniera": "engineering", "desarrollador": "engineering", "desarrolladora": "engineering",
+	"programador": "engineering", "programadora": "engineering",
+
+	"data": "data", "analytics": "data", "analyst": "data", "analista": "data",
+	"data scientist": "data", "data science": "data", "machine learning": "data",
+	"ml": "data", "ai": "data", "bi": "data", "business intelligence": "data",
+
+	"product": "product", "producto": "product", "cpo": "product",
+
+	"design": "design", "designer": "design", "ux": "design", "ui": "design",
+	"diseño": "design", "diseñador": "design", "diseñadora": "design",
+
+	"sales": "sales", "account executive": "sales", "account manager": "sales",
+	"business development": "sales", "bdr": "sales", "sdr": "sales", "ventas": "sales",
+	"comercial": "sales", "cro": "sales",
+
+	"marketing": "marketing", "growth": "marketing", "seo": "marketing", "brand": "marketing",
+	"content": "marketing", "communications": "marketing", "comunicación": "marketing", "cmo": "marketing",
+
+	"recruiter": "recruiting", "recruiting": "recruiting", "recruitment": "recruiting",
+	"talent": "recruiting", "talent acquisition": "recruiting", "sourcer": "recruiting",
+	"reclutador": "recruiting", "reclutadora": "recruiting",
+
+	"hr": "people", "human resources": "people", "people": "people", "rrhh": "people",
+	"recursos humanos": "people", "chro": "people",
+
+	"finance": "finance", "financial": "finance", "accountant": "finance", "accounting": "finance",
+	"controller": "finance", "treasury": "finance", "finanzas": "finance", "contable": "finance", "cfo": "finance",
+
+	"operations": "operations", "logistics": "operations", "supply chain": "operations",
+	"operaciones": "operations", "coo": "operations",
+
+	"legal": "legal", "lawyer": "legal", "counsel": "legal", "attorney": "legal",
+	"paralegal": "legal", "abogado": "legal", "abogada": "legal",
+
+	"customer success": "customer_success", "customer service": "customer_success",
+	"customer experience": "customer_success", "support": "customer_success",
+	"atención al cliente": "customer_success",
+
+	"research": "research", "researcher": "research", "scientist": "research",
+	"investigador": "research", "investigadora": "research", "phd": "research",
+
+	"consultant": "consulting", "consulting": "consulting", "advisor": "consulting",
+	"consultor": "consulting", "consultora": "consulting",
+}
+
+// parsedHeadline is a headline split into its parts. Fields are empty when
+// the headline does not mention them.
+type parsedHeadline struct {
+	Title     string
+	Employer  string
+	Seniority string
+	Function  string
+}
+
+// parseHeadline splits a LinkedIn headline such as "Senior Software Engineer
+// at Acme | Cloud & AI" into the role title, the employer it mentions, the
+// seniority level and the job function. Only the first role is read.
+func parseHeadline(headline string) parsedHeadline {
+	role := headline
+	for _, separator := range headlineSeparators {
+		if i := strings.Index(role, separator); i >= 0 {
+			role = role[:i]
+		}
+	}
+
+	var parsed parsedHeadline
+	if loc := headlineEmployer.FindStringIndex(role); loc != nil {
+		parsed.Employer = trimHeadlinePart(role[loc[1]:])
+		role = role[:loc[0]]
+	}
+	parsed.Title = trimHeadlinePart(role)
+
+	words := headlineWords(parsed.Title)
+	parsed.Seniority = headlineSeniority(words)
+	parsed.Function = headlineFunction(words)
+	return parsed
+}
+
+// trimHeadlinePart trims whitespace and dangling punctuation
+func trimHeadlinePart(part string) string {
+	return strings.TrimFunc(part, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ',' || r == ':' || r == '-' || r == '.'
+	})
+}
+
+// headlineWords lowercases a title and splits it into words, dropping
+// whatever it says the holder reports to ("Assistant to the CEO")
+func headlineWords(title string) []string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		if word == "to" {
+			return words[:i]
+		}
+	}
+	return words
+}
+
+// headlineSeniority returns the most senior level the title mentions, except
+// that interns are interns whatever their team ("Engineering Manager Intern")
+func headlineSeniority(words []string) string {
+	text := " " + strings.Join(words, " ") + " "
+	rank := -1
+	intern := false
+	mark := func(level string) {
+		if level == "intern" {
+			intern = true
+		}
+		if r := seniorityRank(level); r > rank {
+			rank = r
+		}
+	}
+
+	for _, p := range seniorityPhrases {
+		if strings.Contains(text, " "+p.phrase+" ") {
+			mark(p.level)
+			text = strings.ReplaceAll(text, " "+p.phrase+" ", " ")
+		}
+	}
+	// "co-founder" splits into "co" and "founder", which is matched on its own
+	for _, word := range strings.Fields(text) {
+		if level, ok := seniorityKeywords[word]; ok {
+			mark(level)
+		}
+	}
+
+	if intern {
+		return "intern"
+	}
+	if rank < 0 {
+		return ""
+	}
+	return seniorityLevels[rank]
+}
+
+// headlineFunction returns the function named last in the title, as a title's
+// head noun usually comes last ("Sales Engineer", "Product Designer")
+func headlineFunction(words []string) string {
+	function := ""
+	for i := 0; i < len(words); {
+		matched := 1
+		for n := 3; n >= 1; n-- {
+			if i+n > len(words) {
+				continue
+			}
+			if f, ok := functionKeywords[strings.Join(words[i:i+n], " ")]; ok {
+				function = f
+				matched = n
+				break
+			}
+		}
+		i += matched
+	}
+	return function
+}
+
+// seniorityRank returns the position of a seniority level, or -1 when it is unknown
+func seniorityRank(level string) int {
+	for i, l := range seniorityLevels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
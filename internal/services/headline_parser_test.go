@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHeadline(t *testing.T) {
+	tests := map[string]parsedHeadline{
+		"Senior Software Engineer at Google | Cloud & AI": {"Senior Software Engineer", "Google", "senior", "engineering"},
+		"CTO @ Acme":                                    {"CTO", "Acme", "c_level", "engineering"},
+		"Co-Founder & CEO at Startup":                   {"Co-Founder & CEO", "Startup", "c_level", ""},
+		"VP of Sales - EMEA":                            {"VP of Sales", "", "vp", "sales"},
+		"Vice President, Marketing at Acme":             {"Vice President, Marketing", "Acme", "vp", "marketing"},
+		"Head of Talent Acquisition":                    {"Head of Talent Acquisition", "", "director", "recruiting"},
+		"Engineering Manager at Acme":                   {"Engineering Manager", "Acme", "manager", "engineering"},
+		"Software Engineering Intern @ Acme":            {"Software Engineering Intern", "Acme", "intern", "engineering"},
+		"Junior Product Designer":                       {"Junior Product Designer", "", "entry", "design"},
+		"Sales Engineer":                                {"Sales Engineer", "", "", "engineering"},
+		"Staff Data Scientist · Ex-Amazon":              {"Staff Data Scientist", "", "lead", "data"},
+		"Research Scientist at DeepMind":                {"Research Scientist", "DeepMind", "", "research"},
+		"Chief of Staff to the CEO":                     {"Chief of Staff to the CEO", "", "director", ""},
+		"Executive Assistant to the CEO at Acme":        {"Executive Assistant to the CEO", "Acme", "", ""},
+		"Ingeniera de software en BBVA":                 {"Ingeniera de software", "BBVA", "", "engineering"},
+		"Responsable de Recursos Humanos en Telefónica": {"Responsable de Recursos Humanos", "Telefónica", "manager", "people"},
+		"Becario de Marketing":                          {"Becario de Marketing", "", "intern", "marketing"},
+		"Technical Recruiter":                           {"Technical Recruiter", "", "", "recruiting"},
+		"Building things":                               {"Building things", "", "", ""},
+		"":                                              {},
+	}
+
+	for headline, want := range tests {
+		assert.Equal(t, want, parseHeadline(headline), headline)
+	}
+}
+
+func TestHeadlineKeywords(t *testing.T) {
+	for word, level := range seniorityKeywords {
+		assert.GreaterOrEqual(t, seniorityRank(level), 0, word)
+	}
+	for _, p := range seniorityPhrases {
+		assert.GreaterOrEqual(t, seniorityRank(p.level), 0, p.phrase)
+	}
+	for word, function := range functionKeywords {
+		assert.Contains(t, jobFunctions, function, word)
+	}
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/infra/logger"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// headlineBatchSize is how many profile headlines are parsed per query
+const headlineBatchSize = 500
+
+type HeadlineService struct {
+	queries *db.Queries
+}
+
+func NewHeadlineService(queries *db.Queries) *HeadlineService {
+	return &HeadlineService{
+		queries: queries,
+	}
+}
+
+// ParsePending parses the headline of every profile that has not been parsed
+// since its headline last changed
+func (s *HeadlineService) ParsePending(ctx context.Context) error {
+	if err := s.queries.DeleteStaleProfileHeadlines(ctx); err != nil {
+		return fmt.Errorf("failed to delete stale profile headlines: %w", err)
+	}
+
+	parsed := 0
+	for {
+		rows, err := s.queries.ListProfilesWithStaleHeadline(ctx, headlineBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list profile headlines: %w", err)
+		}
+
+		for _, row := range rows {
+			params := profileHeadlineParams(row.ID, row.Headline.String, parseHeadline(row.Headline.String))
+			if err := s.queries.UpsertProfileHeadline(ctx, params); err != nil {
+				return fmt.Errorf("failed to store profile headline: %w", err)
+			}
+			parsed++
+		}
+
+		if len(rows) < headlineBatchSize {
+			break
+		}
+	}
+
+	if parsed > 0 {
+		logger.Infof("Parsed %d profile headlines", parsed)
+	}
+	return nil
+}
+
+func profileHeadlineParams(profileID pgtype.UUID, raw string, headline parsedHeadline) db.UpsertProfileHeadlineParams {
+	return db.UpsertProfileHeadlineParams{
+		ProfileID:   profileID,
+		RawHeadline: raw,
+		Title:       pgtype.Text{String: headline.Title, Valid: headline.Title != ""},
+		Employer:    pgtype.Text{String: headline.Employer, Valid: headline.Employer != ""},
+		Seniority:   pgtype.Text{String: headline.Seniority, Valid: headline.Seniority != ""},
+		JobFunction: pgtype.Text{String: headline.Function, Valid: headline.Function != ""},
+	}
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/config"
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// invitationWeek is the rolling window LinkedIn applies its weekly limit over
+const invitationWeek = 7 * 24 * time.Hour
+
+type InvitationService struct {
+	queries  *db.Queries
+	defaults config.InvitationQuotaConfiguration
+	windows  *ExecutionWindowService
+}
+
+func NewInvitationService(queries *db.Queries, defaults config.InvitationQuotaConfiguration, windows *ExecutionWindowService) *InvitationService {
+	return &InvitationService{
+		queries:  queries,
+		defaults: defaults,
+		windows:  windows,
+	}
+}
+
+// GetQuota returns the user's invitation limits and what remains of them
+func (s *InvitationService) GetQuota(ctx context.Context, userID string) (*models.InvitationQuota, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	quota, err := s.quota(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+// UpdateQuota sets the user's invitation limits, lifting a pause after LinkedIn reported its limit
+func (s *InvitationService) UpdateQuota(ctx context.Context, userID string, req models.InvitationQuotaRequest) (*models.InvitationQuota, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.queries.UpsertInvitationQuota(ctx, db.UpsertInvitationQuotaParams{
+		UserID:      userUUID,
+		DailyLimit:  int32(*req.DailyLimit),
+		WeeklyLimit: int32(*req.WeeklyLimit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update invitation quota: %w", err)
+	}
+
+	quota, err := s.quota(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+// quota loads the user's limits, falling back to the defaults, and usage. The
+// day is counted in the user's timezone and paced over their execution window.
+func (s *InvitationService) quota(ctx context.Context, userID pgtype.UUID) (models.InvitationQuota, error) {
+	daily, weekly := s.defaults.DailyLimit, s.defaults.WeeklyLimit
+	var pausedUntil pgtype.Timestamp
+	limits, err := s.queries.GetInvitationQuota(ctx, userID)
+	switch {
+	case err == nil:
+		daily, weekly = int(limits.DailyLimit), int(limits.WeeklyLimit)
+		pausedUntil = limits.PausedUntil
+	case !errors.Is(err, pgx.ErrNoRows):
+		return models.InvitationQuota{}, fmt.Errorf("failed to get invitation quota: %w", err)
+	}
+
+	window, err := s.windows.window(ctx, userID)
+	if err != nil {
+		return models.InvitationQuota{}, err
+	}
+	dayStart, _ := invitationDay(time.Now(), window.timezone)
+	usage, err := s.queries.GetInvitationUsage(ctx, db.GetInvitationUsageParams{
+		DayStart: pgtype.Timestamp{Time: dayStart, Valid: true},
+		UserID:   userID,
+	})
+	if err != nil {
+		return models.InvitationQuota{}, fmt.Errorf("failed to get invitation usage: %w", err)
+	}
+
+	return invitationQuota(daily, weekly, window, pausedUntil, usage), nil
+}
+
+// recordSent counts a connection request against the user's quota
+func (s *InvitationService) recordSent(ctx context.Context, userID, profileID pgtype.UUID) error {
+	err := s.queries.RecordSentInvitation(ctx, db.RecordSentInvitationParams{
+		UserID:    userID,
+		ProfileID: profileID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record sent invitation: %w", err)
+	}
+	return nil
+}
+
+// pause stops the user's invitations for a day, after LinkedIn reported its
+// limit was reached despite the quota
+func (s *InvitationService) pause(ctx context.Context, userID pgtype.UUID) error {
+	err := s.queries.PauseInvitations(ctx, db.PauseInvitationsParams{
+		UserID:      userID,
+		DailyLimit:  int32(s.defaults.DailyLimit),
+		WeeklyLimit: int32(s.defaults.WeeklyLimit),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to pause invitations: %w", err)
+	}
+	return nil
+}
+
+// invitationQuota works out what remains of the limits and when the next
+// invitation may be sent. Invitations are spread evenly over the time the
+// execution window is open each day, a full day frees up at midnight in the
+// user's timezone and a full week as its oldest invitation turns seven days
+// old. A zero limit turns invitations off.
+func invitationQuota(daily, weekly int, window executionWindow, pausedUntil pgtype.Timestamp, usage db.GetInvitationUsageRow) models.InvitationQuota {
+	now := usage.CheckedAt.Time
+	quota := models.InvitationQuota{
+		DailyLimit:        daily,
+		WeeklyLimit:       weekly,
+		SentToday:         int(usage.SentToday),
+		SentThisWeek:      int(usage.SentThisWeek),
+		RemainingToday:    max(daily-int(usage.SentToday), 0),
+		RemainingThisWeek: max(weekly-int(usage.SentThisWeek), 0),
+	}
+	if pausedUntil.Valid && pausedUntil.Time.After(now) {
+		quota.PausedUntil = &pausedUntil.Time
+	}
+	if daily == 0 || weekly == 0 {
+		return quota
+	}
+
+	next := now
+	notBefore := func(t time.Time) {
+		if t.After(next) {
+			next = t
+		}
+	}
+	if usage.LastSentAt.Valid {
+		notBefore(usage.LastSentAt.Time.Add(window.openPerDay() / time.Duration(daily)))
+	}
+	if quota.RemainingToday == 0 {
+		_, dayEnd := invitationDay(now, window.timezone)
+		notBefore(dayEnd)
+	}
+	if quota.RemainingThisWeek == 0 && usage.FirstSentThisWeek.Valid {
+		notBefore(usage.FirstSentThisWeek.Time.Add(invitationWeek))
+	}
+	if quota.PausedUntil != nil {
+		notBefore(*quota.PausedUntil)
+	}
+
+	quota.NextSendAt = &next
+	quota.CanSendNow = !next.After(now)
+	return quota
+}
+
+// invitationDay returns the midnights that start and end the day of t in loc
+func invitationDay(t time.Time, loc *time.Location) (start, end time.Time) {
+	local := t.In(loc)
+	start = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// invitationCapacity is how many connection requests the quota allows before
+// its current windows end, paced or not
+func invitationCapacity(quota models.InvitationQuota) int {
+	if quota.PausedUntil != nil {
+		return 0
+	}
+	return min(quota.RemainingToday, quota.RemainingThisWeek)
+}
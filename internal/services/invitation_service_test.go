@@ -0,0 +1,98 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvitationQuota(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+	now := time.Date(2025, 3, 12, 15, 0, 0, 0, time.UTC)
+	// Midnight in Madrid
+	dayEnd := time.Date(2025, 3, 12, 23, 0, 0, 0, time.UTC)
+	anytime := executionWindow{timezone: madrid}
+	// 09:00-18:00 every day
+	office := executionWindow{timezone: madrid, restricted: true, start: 9 * 60, end: 18 * 60, weekdays: [7]bool{true, true, true, true, true, true, true}}
+	at := func(t time.Time) pgtype.Timestamp {
+		return pgtype.Timestamp{Time: t, Valid: true}
+	}
+	usage := func(today, week int32, first, last time.Time) db.GetInvitationUsageRow {
+		row := db.GetInvitationUsageRow{CheckedAt: at(now), SentToday: today, SentThisWeek: week}
+		if !first.IsZero() {
+			row.FirstSentThisWeek, row.LastSentAt = at(first), at(last)
+		}
+		return row
+	}
+
+	tests := map[string]struct {
+		daily, weekly int
+		window        *executionWindow
+		paused        pgtype.Timestamp
+		usage         db.GetInvitationUsageRow
+		next          time.Time
+		canSend       bool
+	}{
+		"nothing sent":   {daily: 20, weekly: 100, usage: usage(0, 0, time.Time{}, time.Time{}), next: now, canSend: true},
+		"paced":          {daily: 24, weekly: 100, usage: usage(3, 10, now.AddDate(0, 0, -5), now.Add(-30*time.Minute)), next: now.Add(30 * time.Minute)},
+		"pacing elapsed": {daily: 24, weekly: 100, usage: usage(3, 10, now.AddDate(0, 0, -5), now.Add(-2*time.Hour)), next: now, canSend: true},
+		"day exhausted":  {daily: 20, weekly: 100, usage: usage(20, 40, now.AddDate(0, 0, -5), now.Add(-2*time.Hour)), next: dayEnd},
+		"week exhausted": {daily: 20, weekly: 100, usage: usage(5, 100, now.AddDate(0, 0, -6), now.Add(-2*time.Hour)), next: now.AddDate(0, 0, 1)},
+		"paused":         {daily: 20, weekly: 100, paused: at(now.Add(time.Hour)), usage: usage(0, 0, time.Time{}, time.Time{}), next: now.Add(time.Hour)},
+		"pause over":     {daily: 20, weekly: 100, paused: at(now.Add(-time.Hour)), usage: usage(0, 0, time.Time{}, time.Time{}), next: now, canSend: true},
+		// 18 a day over a nine hour window are half an hour apart
+		"paced in window": {daily: 18, weekly: 100, window: &office, usage: usage(3, 10, now.AddDate(0, 0, -5), now.Add(-20*time.Minute)), next: now.Add(10 * time.Minute)},
+		"window elapsed":  {daily: 18, weekly: 100, window: &office, usage: usage(3, 10, now.AddDate(0, 0, -5), now.Add(-40*time.Minute)), next: now, canSend: true},
+	}
+
+	for name, tc := range tests {
+		window := anytime
+		if tc.window != nil {
+			window = *tc.window
+		}
+		quota := invitationQuota(tc.daily, tc.weekly, window, tc.paused, tc.usage)
+
+		assert.Equal(t, tc.canSend, quota.CanSendNow, name)
+		if assert.NotNil(t, quota.NextSendAt, name) {
+			assert.True(t, tc.next.Equal(*quota.NextSendAt), "%s: next send at %s, want %s", name, *quota.NextSendAt, tc.next)
+		}
+	}
+
+	quota := invitationQuota(20, 100, anytime, pgtype.Timestamp{}, usage(25, 30, now.AddDate(0, 0, -1), now.Add(-time.Hour)))
+	assert.Equal(t, 0, quota.RemainingToday)
+	assert.Equal(t, 70, quota.RemainingThisWeek)
+	assert.Nil(t, quota.PausedUntil)
+
+	quota = invitationQuota(0, 100, anytime, pgtype.Timestamp{}, usage(0, 0, time.Time{}, time.Time{}))
+	assert.False(t, quota.CanSendNow)
+	assert.Nil(t, quota.NextSendAt)
+}
+
+func TestInvitationDay(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+
+	// 00:30 in Madrid is still the previous day in UTC
+	start, end := invitationDay(time.Date(2025, 3, 11, 23, 30, 0, 0, time.UTC), madrid)
+	assert.True(t, time.Date(2025, 3, 12, 0, 0, 0, 0, madrid).Equal(start), start)
+	assert.True(t, time.Date(2025, 3, 13, 0, 0, 0, 0, madrid).Equal(end), end)
+
+	// Madrid leaves summer time on October 25, a 25 hour day
+	start, end = invitationDay(time.Date(2026, 10, 25, 12, 0, 0, 0, madrid), madrid)
+	assert.Equal(t, 25*time.Hour, end.Sub(start))
+}
+
+func TestInvitationCapacity(t *testing.T) {
+	assert.Equal(t, 5, invitationCapacity(models.InvitationQuota{RemainingToday: 5, RemainingThisWeek: 40}))
+	assert.Equal(t, 3, invitationCapacity(models.InvitationQuota{RemainingToday: 20, RemainingThisWeek: 3}))
+
+	paused := time.Now().Add(time.Hour)
+	assert.Equal(t, 0, invitationCapacity(models.InvitationQuota{RemainingToday: 20, RemainingThisWeek: 100, PausedUntil: &paused}))
+}
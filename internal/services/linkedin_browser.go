@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/config"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+const (
+	// connectTimeout bounds opening a profile and acting on it once
+	connectTimeout = 90 * time.Second
+
+	// connectStepTimeout bounds waiting for the page to react to one step
+	connectStepTimeout = 15 * time.Second
+)
+
+// errSignedOut is returned when LinkedIn shows its sign-in page instead of a profile
+var errSignedOut = errors.New("not signed in to LinkedIn; refresh the session cookies")
+
+// linkedinBrowser opens LinkedIn pages in Chrome with the user's session, for
+// the executors acting on profiles
+type linkedinBrowser struct {
+	browser config.BrowserConfiguration
+	options []chromedp.ExecAllocatorOption
+	timeout time.Duration
+	// pause waits between steps, as a person would
+	pause func()
+}
+
+func newLinkedinBrowser(browser config.BrowserConfiguration) linkedinBrowser {
+	options := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", browser.Headless),
+	)
+	if browser.ExecPath != "" {
+		options = append(options, chromedp.ExecPath(browser.ExecPath))
+	}
+
+	return linkedinBrowser{
+		browser: browser,
+		options: options,
+		timeout: connectTimeout,
+		pause:   func() { randomDelay(500, 1500) },
+	}
+}
+
+// open starts a browser signed in to LinkedIn on url. The returned tab lives
+// until cancel is called or the timeout passes.
+func (b linkedinBrowser) open(ctx context.Context, url string) (context.Context, context.CancelFunc, error) {
+	ctx, cancel := context.WithTimeout(ctx, b.timeout)
+	allocCtx, allocCancel := chromedp.NewExecAllocator(ctx, b.options...)
+	tab, tabCancel := chromedp.NewContext(allocCtx)
+	closeAll := func() {
+		tabCancel()
+		allocCancel()
+		cancel()
+	}
+
+	if err := b.setCookies(tab); err != nil {
+		closeAll()
+		return nil, nil, err
+	}
+	if err := chromedp.Run(tab, chromedp.Navigate(url)); err != nil {
+		closeAll()
+		return nil, nil, fmt.Errorf("failed to open profile: %w", err)
+	}
+	return tab, closeAll, nil
+}
+
+// setCookies signs the browser in with the exported session cookies, if configured
+func (b linkedinBrowser) setCookies(ctx context.Context) error {
+	if b.browser.CookieFile == "" {
+		return nil
+	}
+	cookies, err := loadCookiesFromFile(b.browser.CookieFile)
+	if err != nil {
+		return fmt.Errorf("failed to load LinkedIn session cookies: %w", err)
+	}
+
+	return chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		for _, c := range cookies {
+			err := network.SetCookie(c.Name, c.Value).
+				WithDomain(c.Domain).
+				WithPath(c.Path).
+				WithExpires(c.Expires).
+				WithHTTPOnly(c.HTTPOnly).
+				WithSecure(c.Secure).
+				Do(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to set cookie %s: %w", c.Name, err)
+			}
+		}
+		return nil
+	}))
+}
+
+// poll evaluates script until it returns a non-empty string
+func poll(ctx context.Context, script string) (string, error) {
+	var result string
+	err := chromedp.Run(ctx, chromedp.Poll(script, &result,
+		chromedp.WithPollingTimeout(connectStepTimeout),
+		chromedp.WithPollingInterval(200*time.Millisecond),
+	))
+	return result, err
+}
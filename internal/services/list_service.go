@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ListService struct {
+	queries *db.Queries
+}
+
+func NewListService(queries *db.Queries) *ListService {
+	return &ListService{
+		queries: queries,
+	}
+}
+
+// ListLists returns the user's lists ordered by name
+func (s *ListService) ListLists(ctx context.Context, userID string) ([]models.ProfileList, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListProfileLists(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lists: %w", err)
+	}
+
+	lists := make([]models.ProfileList, 0, len(rows))
+	for _, row := range rows {
+		list := profileListModel(db.ProfileList{
+			ID:          row.ID,
+			UserID:      row.UserID,
+			Name:        row.Name,
+			Description: row.Description,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+		})
+		list.MemberCount = int(row.MemberCount)
+		lists = append(lists, list)
+	}
+
+	return lists, nil
+}
+
+// CreateList creates a named list. List names are unique per user.
+func (s *ListService) CreateList(ctx context.Context, userID string, req models.ProfileListRequest) (*models.ProfileList, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.queries.CreateProfileList(ctx, db.CreateProfileListParams{
+		UserID:      userUUID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: pgtype.Text{String: req.Description, Valid: req.Description != ""},
+	})
+	if isUniqueViolation(err) {
+		return nil, ErrAlreadyExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create list: %w", err)
+	}
+
+	result := profileListModel(list)
+	return &result, nil
+}
+
+// GetList returns one of the user's lists with its members
+func (s *ListService) GetList(ctx context.Context, userID, listID string) (*models.ProfileListDetail, error) {
+	list, err := s.getOwnedList(ctx, userID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.GetProfileListMembers(ctx, list.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	members := make([]models.ListMember, 0, len(rows))
+	for _, row := range rows {
+		members = append(members, models.ListMember{
+			ID:          uuidString(row.ID),
+			LinkedinURL: row.LinkedinUrl,
+			Name:        row.Name,
+			Location:    textValue(row.Location),
+			Headline:    textValue(row.Headline),
+			Company:     textValue(row.CompanyName),
+			AddedAt:     row.AddedAt.Time,
+		})
+	}
+
+	detail := &models.ProfileListDetail{
+		ProfileList: profileListModel(list),
+		Members:     members,
+	}
+	detail.MemberCount = len(members)
+	return detail, nil
+}
+
+// UpdateList renames one of the user's lists or changes its description
+func (s *ListService) UpdateList(ctx context.Context, userID, listID string, req models.ProfileListRequest) (*models.ProfileList, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	listUUID, err := parseUUID(listID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.queries.UpdateProfileList(ctx, db.UpdateProfileListParams{
+		ID:          listUUID,
+		UserID:      userUUID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: pgtype.Text{String: req.Description, Valid: req.Description != ""},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if isUniqueViolation(err) {
+		return nil, ErrAlreadyExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update list: %w", err)
+	}
+
+	result := profileListModel(list)
+	return &result, nil
+}
+
+// DeleteList removes one of the user's lists. Rules filtering on the list stop filtering.
+func (s *ListService) DeleteList(ctx context.Context, userID, listID string) error {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return err
+	}
+	listUUID, err := parseUUID(listID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.queries.DeleteProfileList(ctx, db.DeleteProfileListParams{
+		ID:     listUUID,
+		UserID: userUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete list: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// AddMember adds a profile to one of the user's lists. Adding a profile twice is a no-op.
+func (s *ListService) AddMember(ctx context.Context, userID, listID string, req models.ListMemberRequest) error {
+	list, err := s.getOwnedList(ctx, userID, listID)
+	if err != nil {
+		return err
+	}
+	_, profileUUID, err := parseProfileRef(ctx, s.queries, userID, req.ProfileID)
+	if err != nil {
+		return err
+	}
+
+	err = s.queries.AddProfileListMember(ctx, db.AddProfileListMemberParams{
+		ListID:    list.ID,
+		ProfileID: profileUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add profile to list: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveMember removes a profile from one of the user's lists
+func (s *ListService) RemoveMember(ctx context.Context, userID, listID, profileID string) error {
+	profileUUID, err := parseUUID(profileID)
+	if err != nil {
+		return err
+	}
+	list, err := s.getOwnedList(ctx, userID, listID)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.queries.RemoveProfileListMember(ctx, db.RemoveProfileListMemberParams{
+		ListID:    list.ID,
+		ProfileID: profileUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove profile from list: %w", err)
+	}
+	if removed == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// getOwnedList returns the list if it exists and belongs to the user
+func (s *ListService) getOwnedList(ctx context.Context, userID, listID string) (db.ProfileList, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return db.ProfileList{}, err
+	}
+	listUUID, err := parseUUID(listID)
+	if err != nil {
+		return db.ProfileList{}, err
+	}
+
+	list, err := s.queries.GetProfileList(ctx, db.GetProfileListParams{
+		ID:     listUUID,
+		UserID: userUUID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.ProfileList{}, ErrNotFound
+	}
+	if err != nil {
+		return db.ProfileList{}, fmt.Errorf("failed to get list: %w", err)
+	}
+
+	return list, nil
+}
+
+func profileListModel(row db.ProfileList) models.ProfileList {
+	return models.ProfileList{
+		ID:          uuidString(row.ID),
+		Name:        row.Name,
+		Description: textValue(row.Description),
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/infra/logger"
+	"linkedin-watcher/internal/geo"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrUnknownPlace is returned when a "near" filter does not name a known city
+var ErrUnknownPlace = errors.New("unknown place: near must name a city, e.g. \"Madrid\" or \"Cambridge, Massachusetts\"")
+
+const (
+	// locationBatchSize is how many profile locations are parsed per query
+	locationBatchSize = 500
+
+	// defaultRadiusKm is the radius used when a "near" filter gives none
+	defaultRadiusKm = 50
+)
+
+type LocationService struct {
+	queries *db.Queries
+}
+
+func NewLocationService(queries *db.Queries) *LocationService {
+	return &LocationService{
+		queries: queries,
+	}
+}
+
+// NormalizePending parses the free-text location of every profile that has
+// not been parsed since its location last changed. Locations the gazetteer
+// does not know are stored unresolved so they are not parsed again.
+func (s *LocationService) NormalizePending(ctx context.Context) error {
+	if err := s.queries.DeleteStaleProfileLocations(ctx); err != nil {
+		return fmt.Errorf("failed to delete stale profile locations: %w", err)
+	}
+
+	parsed, resolved := 0, 0
+	for {
+		rows, err := s.queries.ListProfilesWithStaleLocation(ctx, locationBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list profile locations: %w", err)
+		}
+
+		for _, row := range rows {
+			location, ok := geo.Parse(row.Location.String)
+			if err := s.queries.UpsertProfileLocation(ctx, profileLocationParams(row.ID, row.Location.String, location)); err != nil {
+				return fmt.Errorf("failed to store profile location: %w", err)
+			}
+			parsed++
+			if ok {
+				resolved++
+			}
+		}
+
+		if len(rows) < locationBatchSize {
+			break
+		}
+	}
+
+	if parsed > 0 {
+		logger.Infof("Parsed %d profile locations, %d resolved", parsed, resolved)
+	}
+	return nil
+}
+
+func profileLocationParams(profileID pgtype.UUID, raw string, location geo.Location) db.UpsertProfileLocationParams {
+	params := db.UpsertProfileLocationParams{
+		ProfileID:   profileID,
+		RawLocation: raw,
+		City:        pgtype.Text{String: location.City, Valid: location.City != ""},
+		Region:      pgtype.Text{String: location.Region, Valid: location.Region != ""},
+		CountryCode: pgtype.Text{String: location.CountryCode, Valid: location.CountryCode != ""},
+	}
+	if location.HasCoordinates() {
+		params.Latitude = pgtype.Float8{Float64: location.Latitude, Valid: true}
+		params.Longitude = pgtype.Float8{Float64: location.Longitude, Valid: true}
+	}
+	return params
+}
+
+// locationFilter is the country and distance criteria shared by searches,
+// saved searches and automation rules
+type locationFilter struct {
+	CountryCode   pgtype.Text
+	Near          pgtype.Text
+	NearLatitude  pgtype.Float8
+	NearLongitude pgtype.Float8
+	RadiusKm      pgtype.Float8
+}
+
+// newLocationFilter resolves the "near" place to coordinates, defaulting the
+// radius, and returns ErrUnknownPlace when it names no known city
+func newLocationFilter(country, near string, radiusKm float64) (locationFilter, error) {
+	var filter locationFilter
+	if country = strings.ToUpper(strings.TrimSpace(country)); country != "" {
+		filter.CountryCode = pgtype.Text{String: country, Valid: true}
+	}
+
+	if near = strings.TrimSpace(near); near == "" {
+		return filter, nil
+	}
+	place, ok := geo.FindCity(near)
+	if !ok {
+		return locationFilter{}, ErrUnknownPlace
+	}
+	if radiusKm == 0 {
+		radiusKm = defaultRadiusKm
+	}
+	filter.Near = pgtype.Text{String: near, Valid: true}
+	filter.NearLatitude = pgtype.Float8{Float64: place.Latitude, Valid: true}
+	filter.NearLongitude = pgtype.Float8{Float64: place.Longitude, Valid: true}
+	filter.RadiusKm = pgtype.Float8{Float64: radiusKm, Valid: true}
+	return filter, nil
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+
+	"linkedin-watcher/internal/geo"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLocationFilter(t *testing.T) {
+	filter, err := newLocationFilter(" es ", "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, pgtype.Text{String: "ES", Valid: true}, filter.CountryCode)
+	assert.False(t, filter.NearLatitude.Valid)
+	assert.False(t, filter.RadiusKm.Valid)
+
+	filter, err = newLocationFilter("", "Madrid", 0)
+	require.NoError(t, err)
+	assert.False(t, filter.CountryCode.Valid)
+	assert.Equal(t, "Madrid", filter.Near.String)
+	assert.InDelta(t, 40.4, filter.NearLatitude.Float64, 0.1)
+	assert.InDelta(t, -3.7, filter.NearLongitude.Float64, 0.1)
+	assert.Equal(t, float64(defaultRadiusKm), filter.RadiusKm.Float64)
+
+	filter, err = newLocationFilter("", "Madrid", 120)
+	require.NoError(t, err)
+	assert.Equal(t, 120.0, filter.RadiusKm.Float64)
+}
+
+func TestNewLocationFilter_UnknownPlace(t *testing.T) {
+	_, err := newLocationFilter("", "Atlantis", 10)
+	assert.ErrorIs(t, err, ErrUnknownPlace)
+
+	// A country or region is not a point to measure a radius from
+	_, err = newLocationFilter("", "Spain", 10)
+	assert.ErrorIs(t, err, ErrUnknownPlace)
+}
+
+func TestProfileLocationParams(t *testing.T) {
+	location, ok := geo.Parse("Greater Madrid Metropolitan Area")
+	require.True(t, ok)
+	params := profileLocationParams(pgtype.UUID{}, "Greater Madrid Metropolitan Area", location)
+	assert.Equal(t, "Greater Madrid Metropolitan Area", params.RawLocation)
+	assert.Equal(t, "Madrid", params.City.String)
+	assert.Equal(t, "ES", params.CountryCode.String)
+	assert.True(t, params.Latitude.Valid)
+
+	location, ok = geo.Parse("Spain")
+	require.True(t, ok)
+	params = profileLocationParams(pgtype.UUID{}, "Spain", location)
+	assert.False(t, params.City.Valid)
+	assert.Equal(t, "ES", params.CountryCode.String)
+	assert.False(t, params.Latitude.Valid)
+
+	// Unknown locations are stored unresolved so they are not parsed again
+	location, ok = geo.Parse("Remote")
+	assert.False(t, ok)
+	params = profileLocationParams(pgtype.UUID{}, "Remote", location)
+	assert.Equal(t, "Remote", params.RawLocation)
+	assert.False(t, params.CountryCode.Valid)
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/config"
+
+	"github.com/chromedp/cdproto/input"
+	"github.com/chromedp/chromedp"
+)
+
+// Message outcomes recorded on sequence step runs
+const (
+	MessageOutcomeSent        = "sent"
+	MessageOutcomeUnavailable = "message_unavailable"
+)
+
+// Selectors of the profile page and the conversation it opens that the message
+// flow relies on. The thread marks messages from the other member with the
+// --other modifier.
+const (
+	messageSelector         = `main section button[aria-label^="Message"]`
+	composeSelector         = `div[role="textbox"][contenteditable="true"]`
+	messageSendSelector     = `button.msg-form__send-button`
+	threadEventSelector     = `.msg-s-message-list__event`
+	threadFromOtherSelector = `.msg-s-event-listitem--other`
+)
+
+// Last senders of a conversation the thread script reports
+const (
+	threadLastOther = "other"
+	threadLastSelf  = "self"
+	threadEmpty     = "empty"
+)
+
+// messageButtonScript reports whether the profile offers a Message button, or
+// "" until the top card has rendered
+var messageButtonScript = fmt.Sprintf(`(() => {
+	if (document.querySelector(%[1]q)) return %[2]q;
+	if (!document.querySelector(%[3]q)) return "";
+	return document.querySelector(%[4]q) ? "message" : %[5]q;
+})()`,
+	signedOutSelector, profileStateSignedOut,
+	topCardSelector,
+	messageSelector, profileStateUnavailable,
+)
+
+// threadScript reports who sent the last message of the opened conversation,
+// or "" until its message box shows
+var threadScript = fmt.Sprintf(`(() => {
+	if (!document.querySelector(%[1]q)) return "";
+	const events = document.querySelectorAll(%[2]q);
+	if (events.length === 0) return %[3]q;
+	return events[events.length - 1].querySelector(%[4]q) ? %[5]q : %[6]q;
+})()`,
+	composeSelector, threadEventSelector, threadEmpty,
+	threadFromOtherSelector, threadLastOther, threadLastSelf,
+)
+
+// messageSentScript reports "sent" once LinkedIn empties the message box after sending
+var messageSentScript = fmt.Sprintf(`(() => {
+	const box = document.querySelector(%[1]q);
+	return box && box.textContent.trim() === "" ? "sent" : "";
+})()`,
+	composeSelector,
+)
+
+// MessageExecutor sends messages to connections from the user's LinkedIn
+// session in Chrome, and tells whether they replied
+type MessageExecutor struct {
+	linkedinBrowser
+}
+
+func NewMessageExecutor(browser config.BrowserConfiguration) *MessageExecutor {
+	return &MessageExecutor{linkedinBrowser: newLinkedinBrowser(browser)}
+}
+
+// Execute opens the profile's conversation and sends the action's note. A
+// profile without a Message button, e.g. one that is not a connection, is
+// skipped.
+func (e *MessageExecutor) Execute(ctx context.Context, action RuleAction) (string, error) {
+	if action.Note == "" {
+		return "", fmt.Errorf("%w: the message is empty", ErrActionSkipped)
+	}
+	tab, cancel, err := e.open(ctx, action.LinkedinURL)
+	if err != nil {
+		return "", err
+	}
+	defer cancel()
+
+	if _, err := e.openConversation(tab); err != nil {
+		if errors.Is(err, ErrActionSkipped) {
+			return MessageOutcomeUnavailable, err
+		}
+		return "", err
+	}
+
+	e.pause()
+	err = chromedp.Run(tab,
+		chromedp.Focus(composeSelector, chromedp.ByQuery),
+		// Inserting the text rather than typing it keeps line breaks from
+		// pressing Enter, which LinkedIn may take as Send
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			return input.InsertText(action.Note).Do(ctx)
+		}),
+		chromedp.ActionFunc(func(context.Context) error { e.pause(); return nil }),
+		chromedp.Click(messageSendSelector, chromedp.ByQuery),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to send the message: %w", err)
+	}
+	if _, err := poll(tab, messageSentScript); err != nil {
+		return "", fmt.Errorf("message was not confirmed: %w", err)
+	}
+	return MessageOutcomeSent, nil
+}
+
+// Replied reports whether the last message of the conversation with the
+// profile is theirs, checking the replied sequence event
+func (e *MessageExecutor) Replied(ctx context.Context, action RuleAction) (bool, error) {
+	tab, cancel, err := e.open(ctx, action.LinkedinURL)
+	if err != nil {
+		return false, err
+	}
+	defer cancel()
+
+	last, err := e.openConversation(tab)
+	if err != nil {
+		if errors.Is(err, ErrActionSkipped) {
+			return false, nil
+		}
+		return false, err
+	}
+	return last == threadLastOther, nil
+}
+
+// openConversation clicks the profile's Message button and waits for the
+// conversation, returning who sent its last message. It returns
+// ErrActionSkipped when the profile offers no Message button.
+func (e *MessageExecutor) openConversation(ctx context.Context) (string, error) {
+	state, err := poll(ctx, messageButtonScript)
+	if err != nil {
+		return "", fmt.Errorf("profile did not load: %w", err)
+	}
+	switch state {
+	case profileStateSignedOut:
+		return "", errSignedOut
+	case profileStateUnavailable:
+		return "", fmt.Errorf("%w: the profile offers no Message button", ErrActionSkipped)
+	}
+
+	e.pause()
+	if err := chromedp.Run(ctx, chromedp.Click(messageSelector, chromedp.ByQuery)); err != nil {
+		return "", fmt.Errorf("failed to click Message: %w", err)
+	}
+	last, err := poll(ctx, threadScript)
+	if err != nil {
+		return "", fmt.Errorf("conversation did not open: %w", err)
+	}
+	return last, nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageExecutor_FixturePages(t *testing.T) {
+	executor := &MessageExecutor{linkedinBrowser: testConnectExecutor(t).linkedinBrowser}
+
+	var mu sync.Mutex
+	var sent []string
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.Dir("testdata/message")))
+	mux.HandleFunc("POST /sent", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		sent = append(sent, string(body))
+		mu.Unlock()
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	profile := func(page string) RuleAction {
+		return RuleAction{LinkedinURL: server.URL + "/" + page, Note: "Hi Jane,\nhow is the new role going?"}
+	}
+
+	outcome, err := executor.Execute(context.Background(), profile("message.html"))
+	assert.NoError(t, err)
+	assert.Equal(t, MessageOutcomeSent, outcome)
+	mu.Lock()
+	assert.Equal(t, []string{"Hi Jane,\nhow is the new role going?"}, sent)
+	mu.Unlock()
+
+	outcome, err = executor.Execute(context.Background(), profile("follow.html"))
+	assert.ErrorIs(t, err, ErrActionSkipped)
+	assert.Equal(t, MessageOutcomeUnavailable, outcome)
+
+	tests := map[string]struct {
+		page    string
+		replied bool
+	}{
+		"last message ours":   {page: "message.html"},
+		"last message theirs": {page: "replied.html", replied: true},
+		"not a connection":    {page: "follow.html"},
+	}
+	for name, tc := range tests {
+		replied, err := executor.Replied(context.Background(), profile(tc.page))
+		assert.NoError(t, err, name)
+		assert.Equal(t, tc.replied, replied, name)
+	}
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"linkedin-watcher/db"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// networkGraph is an undirected, unweighted in-memory view of a user's stored
+// network. Nodes are addressed by index; profileIDs maps an index back to the profile
+// and companies/locations hold the profile attributes used to describe clusters.
+type networkGraph struct {
+	profileIDs []pgtype.UUID
+	companies  []string
+	locations  []string
+	index      map[pgtype.UUID]int
+	adjacency  [][]int
+	edges      map[[2]int]struct{}
+}
+
+func newNetworkGraph() *networkGraph {
+	return &networkGraph{
+		index: make(map[pgtype.UUID]int),
+		edges: make(map[[2]int]struct{}),
+	}
+}
+
+// addNode adds a profile to the graph if needed and returns its index
+func (g *networkGraph) addNode(profileID pgtype.UUID) int {
+	if i, ok := g.index[profileID]; ok {
+		return i
+	}
+	i := len(g.profileIDs)
+	g.index[profileID] = i
+	g.profileIDs = append(g.profileIDs, profileID)
+	g.companies = append(g.companies, "")
+	g.locations = append(g.locations, "")
+	g.adjacency = append(g.adjacency, nil)
+	return i
+}
+
+// addEdge links two profiles, ignoring self-loops and parallel edges of different degrees
+func (g *networkGraph) addEdge(a, b pgtype.UUID) {
+	i, j := g.addNode(a), g.addNode(b)
+	if i == j {
+		return
+	}
+	key := [2]int{min(i, j), max(i, j)}
+	if _, ok := g.edges[key]; ok {
+		return
+	}
+	g.edges[key] = struct{}{}
+	g.adjacency[i] = append(g.adjacency[i], j)
+	g.adjacency[j] = append(g.adjacency[j], i)
+}
+
+func (g *networkGraph) size() int {
+	return len(g.profileIDs)
+}
+
+// loadNetworkGraph reads the user's profiles and relationships into memory
+func loadNetworkGraph(ctx context.Context, queries *db.Queries, userID pgtype.UUID) (*networkGraph, error) {
+	g := newNetworkGraph()
+
+	err := queries.StreamUserGraphNodes(ctx, userID, func(row db.UserGraphNodeRow) error {
+		i := g.addNode(row.ID)
+		g.companies[i] = textValue(row.CompanyName)
+		g.locations[i] = textValue(row.Location)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queries.StreamUserGraphEdges(ctx, userID, func(row db.UserGraphEdgeRow) error {
+		g.addEdge(row.ProfileAID, row.ProfileBID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/infra/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type NetworkScoreService struct {
+	queries *db.Queries
+}
+
+func NewNetworkScoreService(queries *db.Queries) *NetworkScoreService {
+	return &NetworkScoreService{
+		queries: queries,
+	}
+}
+
+// RecomputeAll refreshes centrality scores for every active user with tracked connections.
+// A failure for one user is logged and does not stop the others.
+func (s *NetworkScoreService) RecomputeAll(ctx context.Context) error {
+	userIDs, err := s.queries.ListUserIDsWithTrackedConnections(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		if err := s.RecomputeForUser(ctx, userID); err != nil {
+			logger.Errorf("network scores failed for user %s: %v", uuidString(userID), err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// RecomputeForUser computes degree, betweenness and PageRank over the user's
+// stored graph and replaces the user's persisted scores.
+func (s *NetworkScoreService) RecomputeForUser(ctx context.Context, userID pgtype.UUID) error {
+	graph, err := loadNetworkGraph(ctx, s.queries, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load network graph: %w", err)
+	}
+
+	degrees := degreeCentrality(graph)
+	betweenness := betweennessCentrality(graph)
+	ranks := pageRank(graph)
+	computedAt := pgtype.Timestamp{Time: time.Now(), Valid: true}
+
+	for i, profileID := range graph.profileIDs {
+		err := s.queries.UpsertProfileNetworkScore(ctx, db.UpsertProfileNetworkScoreParams{
+			UserID:      userID,
+			ProfileID:   profileID,
+			Degree:      int32(degrees[i]),
+			Betweenness: betweenness[i],
+			Pagerank:    ranks[i],
+			ComputedAt:  computedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save network score: %w", err)
+		}
+	}
+
+	// Profiles that left the user's network keep no score
+	err = s.queries.DeleteStaleProfileNetworkScores(ctx, db.DeleteStaleProfileNetworkScoresParams{
+		UserID:     userID,
+		ComputedAt: computedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete stale network scores: %w", err)
+	}
+
+	logger.Infof("network scores computed for user %s: %d profiles", uuidString(userID), graph.size())
+	return nil
+}
@@ -0,0 +1,313 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"linkedin-watcher/config"
+	"linkedin-watcher/db"
+	"linkedin-watcher/infra/logger"
+	"linkedin-watcher/internal/models"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Notification channel types stored in notification_channels.channel_type
+const (
+	ChannelWebhook = "webhook"
+	ChannelEmail   = "email"
+)
+
+const (
+	// webhookTimeout bounds how long a single webhook delivery may take
+	webhookTimeout = 10 * time.Second
+
+	// defaultNotificationsLimit is the number of notifications returned when no limit is given
+	defaultNotificationsLimit = 50
+)
+
+// ErrInvalidChannelTarget is returned when a channel target does not suit its type
+var ErrInvalidChannelTarget = errors.New("target must be an http(s) URL for webhooks or an email address for email")
+
+// Alert is a message delivered to a user's inbox and notification channels
+type Alert struct {
+	Kind    string
+	Title   string
+	Body    string
+	Payload interface{}
+}
+
+type NotificationService struct {
+	queries  *db.Queries
+	client   *http.Client
+	smtp     config.SMTPConfiguration
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewNotificationService(queries *db.Queries, smtpConfig config.SMTPConfiguration) *NotificationService {
+	return &NotificationService{
+		queries:  queries,
+		client:   &http.Client{Timeout: webhookTimeout},
+		smtp:     smtpConfig,
+		sendMail: smtp.SendMail,
+	}
+}
+
+// Notify stores the alert in the user's inbox and delivers it to every active
+// channel. A failing channel is logged and does not stop the others; only a
+// failure to store the alert is returned.
+func (s *NotificationService) Notify(ctx context.Context, userID pgtype.UUID, alert Alert) error {
+	payload, err := json.Marshal(alert.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification payload: %w", err)
+	}
+
+	notification, err := s.queries.CreateNotification(ctx, db.CreateNotificationParams{
+		UserID:  userID,
+		Kind:    alert.Kind,
+		Title:   alert.Title,
+		Body:    alert.Body,
+		Payload: payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store notification: %w", err)
+	}
+
+	channels, err := s.queries.ListActiveNotificationChannels(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list notification channels: %w", err)
+	}
+
+	message := notificationModel(notification)
+	for _, channel := range channels {
+		if err := s.deliver(ctx, channel, message); err != nil {
+			logger.Errorf("failed to deliver notification %s to %s channel %s: %v",
+				message.ID, channel.ChannelType, uuidString(channel.ID), err)
+		}
+	}
+
+	return nil
+}
+
+func (s *NotificationService) deliver(ctx context.Context, channel db.NotificationChannel, notification models.Notification) error {
+	switch channel.ChannelType {
+	case ChannelWebhook:
+		return s.deliverWebhook(ctx, channel.Target, notification)
+	case ChannelEmail:
+		return s.deliverEmail(channel.Target, notification)
+	default:
+		return fmt.Errorf("unsupported channel type: %s", channel.ChannelType)
+	}
+}
+
+// deliverWebhook posts the notification as JSON and expects a 2xx response
+func (s *NotificationService) deliverWebhook(ctx context.Context, target string, notification models.Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// deliverEmail sends the notification as a plain text email
+func (s *NotificationService) deliverEmail(to string, notification models.Notification) error {
+	if s.smtp.Host == "" {
+		return errors.New("SMTP is not configured")
+	}
+
+	var auth smtp.Auth
+	if s.smtp.Username != "" {
+		auth = smtp.PlainAuth("", s.smtp.Username, s.smtp.Password, s.smtp.Host)
+	}
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		s.smtp.From, to, notification.Title, strings.ReplaceAll(notification.Body, "\n", "\r\n"))
+	return s.sendMail(s.smtp.Host+":"+s.smtp.Port, auth, s.smtp.From, []string{to}, []byte(msg))
+}
+
+// validChannelTarget reports whether the target is usable for the channel type
+func validChannelTarget(channelType, target string) bool {
+	switch channelType {
+	case ChannelWebhook:
+		u, err := url.Parse(target)
+		return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+	case ChannelEmail:
+		addr, err := mail.ParseAddress(target)
+		return err == nil && addr.Address == target
+	}
+	return false
+}
+
+// ListChannels returns the user's notification channels
+func (s *NotificationService) ListChannels(ctx context.Context, userID string) ([]models.NotificationChannel, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListNotificationChannels(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification channels: %w", err)
+	}
+
+	channels := make([]models.NotificationChannel, 0, len(rows))
+	for _, row := range rows {
+		channels = append(channels, notificationChannelModel(row))
+	}
+
+	return channels, nil
+}
+
+// CreateChannel adds a webhook or email channel for the user's alerts
+func (s *NotificationService) CreateChannel(ctx context.Context, userID string, req models.CreateNotificationChannelRequest) (*models.NotificationChannel, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	target := strings.TrimSpace(req.Target)
+	if !validChannelTarget(req.ChannelType, target) {
+		return nil, ErrInvalidChannelTarget
+	}
+
+	channel, err := s.queries.CreateNotificationChannel(ctx, db.CreateNotificationChannelParams{
+		UserID:      userUUID,
+		ChannelType: req.ChannelType,
+		Target:      target,
+	})
+	if isUniqueViolation(err) {
+		return nil, ErrAlreadyExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification channel: %w", err)
+	}
+
+	result := notificationChannelModel(channel)
+	return &result, nil
+}
+
+// DeleteChannel removes one of the user's notification channels
+func (s *NotificationService) DeleteChannel(ctx context.Context, userID, channelID string) error {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return err
+	}
+	channelUUID, err := parseUUID(channelID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.queries.DeleteNotificationChannel(ctx, db.DeleteNotificationChannelParams{
+		ID:     channelUUID,
+		UserID: userUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete notification channel: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListNotifications returns the user's inbox, newest first
+func (s *NotificationService) ListNotifications(ctx context.Context, userID string, query models.NotificationsQuery) ([]models.Notification, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultNotificationsLimit
+	}
+
+	rows, err := s.queries.ListNotifications(ctx, db.ListNotificationsParams{
+		UserID:     userUUID,
+		UnreadOnly: query.UnreadOnly,
+		MaxResults: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	notifications := make([]models.Notification, 0, len(rows))
+	for _, row := range rows {
+		notifications = append(notifications, notificationModel(row))
+	}
+
+	return notifications, nil
+}
+
+// MarkRead marks one of the user's notifications as read
+func (s *NotificationService) MarkRead(ctx context.Context, userID, notificationID string) error {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return err
+	}
+	notificationUUID, err := parseUUID(notificationID)
+	if err != nil {
+		return err
+	}
+
+	updated, err := s.queries.MarkNotificationRead(ctx, db.MarkNotificationReadParams{
+		ID:     notificationUUID,
+		UserID: userUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	if updated == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func notificationChannelModel(row db.NotificationChannel) models.NotificationChannel {
+	return models.NotificationChannel{
+		ID:          uuidString(row.ID),
+		ChannelType: row.ChannelType,
+		Target:      row.Target,
+		IsActive:    row.IsActive,
+		CreatedAt:   row.CreatedAt.Time,
+	}
+}
+
+func notificationModel(row db.Notification) models.Notification {
+	notification := models.Notification{
+		ID:        uuidString(row.ID),
+		Kind:      row.Kind,
+		Title:     row.Title,
+		Body:      row.Body,
+		Payload:   json.RawMessage(row.Payload),
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if row.ReadAt.Valid {
+		notification.ReadAt = &row.ReadAt.Time
+	}
+	return notification
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"testing"
+
+	"linkedin-watcher/config"
+	"linkedin-watcher/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidChannelTarget(t *testing.T) {
+	tests := map[string]struct {
+		channelType string
+		target      string
+		valid       bool
+	}{
+		"https webhook":       {ChannelWebhook, "https://hooks.example.com/alerts", true},
+		"http webhook":        {ChannelWebhook, "http://localhost:9000/hook", true},
+		"webhook without url": {ChannelWebhook, "hooks.example.com/alerts", false},
+		"ftp webhook":         {ChannelWebhook, "ftp://example.com/hook", false},
+		"email":               {ChannelEmail, "ada@example.com", true},
+		"named email":         {ChannelEmail, "Ada <ada@example.com>", false},
+		"invalid email":       {ChannelEmail, "ada", false},
+		"unknown type":        {"sms", "+34600000000", false},
+	}
+
+	for name, tc := range tests {
+		assert.Equal(t, tc.valid, validChannelTarget(tc.channelType, tc.target), name)
+	}
+}
+
+func TestDeliverWebhook(t *testing.T) {
+	var received models.Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	service := NewNotificationService(nil, config.SMTPConfiguration{})
+	notification := models.Notification{
+		ID:      "00000000-0000-0000-0000-000000000001",
+		Kind:    NotificationKindSavedSearch,
+		Title:   `1 new match for "Engineers"`,
+		Payload: json.RawMessage(`{"saved_search_id":"abc"}`),
+	}
+
+	require.NoError(t, service.deliverWebhook(context.Background(), server.URL, notification))
+	assert.Equal(t, notification.Title, received.Title)
+	assert.JSONEq(t, `{"saved_search_id":"abc"}`, string(received.Payload))
+}
+
+func TestDeliverWebhook_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	service := NewNotificationService(nil, config.SMTPConfiguration{})
+
+	err := service.deliverWebhook(context.Background(), server.URL, models.Notification{})
+	assert.ErrorContains(t, err, "502")
+}
+
+func TestDeliverEmail(t *testing.T) {
+	service := NewNotificationService(nil, config.SMTPConfiguration{
+		Host: "smtp.example.com",
+		Port: "587",
+		From: "alerts@example.com",
+	})
+	var addr string
+	var to []string
+	var msg string
+	service.sendMail = func(a string, _ smtp.Auth, _ string, recipients []string, body []byte) error {
+		addr, to, msg = a, recipients, string(body)
+		return nil
+	}
+
+	err := service.deliverEmail("ada@example.com", models.Notification{
+		Title: "2 new matches",
+		Body:  "- Ada\n- Grace",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "smtp.example.com:587", addr)
+	assert.Equal(t, []string{"ada@example.com"}, to)
+	assert.Contains(t, msg, "Subject: 2 new matches\r\n")
+	assert.Contains(t, msg, "- Ada\r\n- Grace")
+}
+
+func TestDeliverEmail_NotConfigured(t *testing.T) {
+	service := NewNotificationService(nil, config.SMTPConfiguration{})
+
+	assert.Error(t, service.deliverEmail("ada@example.com", models.Notification{}))
+}
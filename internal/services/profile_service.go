@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/infra/logger"
+	"linkedin-watcher/internal/models"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Profile event types recorded in profile_events
+const (
+	ProfileEventJobChange         = "job_change"
+	ProfileEventHeadlineChange    = "headline_change"
+	ProfileEventConnectionAdded   = "connection_added"
+	ProfileEventConnectionRemoved = "connection_removed"
+)
+
+type ProfileService struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewProfileService(pool *pgxpool.Pool, queries *db.Queries) *ProfileService {
+	return &ProfileService{
+		pool:    pool,
+		queries: queries,
+	}
+}
+
+// employmentRole is a profile's current company and position
+type employmentRole struct {
+	companyID pgtype.UUID
+	position  string
+}
+
+// storedRole returns the current role held in the database: the latest
+// is_current history entry, falling back to the profile's current company.
+func storedRole(currentCompanyID pgtype.UUID, history []db.GetProfileCompaniesRow) *employmentRole {
+	// History is ordered by start date, newest first
+	for _, entry := range history {
+		if entry.IsCurrent {
+			return &employmentRole{companyID: entry.CompanyID, position: entry.Position}
+		}
+	}
+	if currentCompanyID.Valid {
+		return &employmentRole{companyID: currentCompanyID}
+	}
+	return nil
+}
+
+// roleChanged reports whether a profile moved between roles. Nothing is
+// reported when no previous role was known, since that is the first time
+// the employment history has been seen rather than a change. Positions are
+// only compared when both sides have one.
+func roleChanged(previous, current *employmentRole) bool {
+	if previous == nil {
+		return false
+	}
+	if current == nil {
+		return true
+	}
+	if previous.companyID != current.companyID {
+		return true
+	}
+	if previous.position == "" || current.position == "" {
+		return false
+	}
+	return !strings.EqualFold(strings.TrimSpace(previous.position), strings.TrimSpace(current.position))
+}
+
+// headlineChanged reports whether a profile's headline was rewritten. A
+// headline seen for the first time is not a change, and neither is a missing
+// one, since LinkedIn requires a headline and its absence means it was not scraped.
+func headlineChanged(previous, current string) bool {
+	previous, current = strings.TrimSpace(previous), strings.TrimSpace(current)
+	return previous != "" && current != "" && previous != current
+}
+
+// SyncProfile stores a re-scraped profile and its employment history. When the
+// current role differs from the stored one a job_change event is recorded, and
+// a headline_change event when the headline does.
+func (s *ProfileService) SyncProfile(ctx context.Context, profileID string, snapshot models.ProfileSnapshot) (*models.ProfileSync, error) {
+	profileUUID, err := parseUUID(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := syncProfile(ctx, s.queries.WithTx(tx), profileUUID, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit profile: %w", err)
+	}
+
+	if result.JobChange {
+		logger.Infof("job change detected for profile %s", profileID)
+	}
+	return result, nil
+}
+
+// syncProfile stores a snapshot and records the changes it shows, within the
+// transaction of queries
+func syncProfile(ctx context.Context, queries *db.Queries, profileID pgtype.UUID, snapshot models.ProfileSnapshot) (*models.ProfileSync, error) {
+	profile, err := queries.GetLinkedInProfileByID(ctx, profileID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	history, err := queries.GetProfileCompanies(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employment history: %w", err)
+	}
+	previous := storedRole(profile.CurrentCompanyID, history)
+
+	// The snapshot is the source of truth for which positions are current
+	if err := queries.ClearCurrentProfileCompanies(ctx, profileID); err != nil {
+		return nil, fmt.Errorf("failed to clear current positions: %w", err)
+	}
+
+	var current *employmentRole
+	var currentIndex int
+	for i, position := range snapshot.Positions {
+		company, err := findOrCreateCompany(ctx, queries, position.Company)
+		if err != nil {
+			return nil, err
+		}
+
+		var endDate pgtype.Date
+		if position.EndDate != nil {
+			endDate = pgtype.Date{Time: *position.EndDate, Valid: true}
+		}
+		err = queries.UpsertProfileCompany(ctx, db.UpsertProfileCompanyParams{
+			ProfileID: profileID,
+			CompanyID: company.ID,
+			Position:  position.Position,
+			StartDate: pgtype.Date{Time: position.StartDate, Valid: true},
+			EndDate:   endDate,
+			IsCurrent: position.IsCurrent,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to save position: %w", err)
+		}
+
+		if position.IsCurrent && (current == nil || position.StartDate.After(snapshot.Positions[currentIndex].StartDate)) {
+			current = &employmentRole{companyID: company.ID, position: position.Position}
+			currentIndex = i
+		}
+	}
+
+	var currentCompanyID pgtype.UUID
+	if current != nil {
+		currentCompanyID = current.companyID
+	}
+	err = queries.UpdateLinkedInProfile(ctx, db.UpdateLinkedInProfileParams{
+		ID:               profileID,
+		Name:             snapshot.Name,
+		Location:         pgtype.Text{String: snapshot.Location, Valid: snapshot.Location != ""},
+		CurrentCompanyID: currentCompanyID,
+		Headline:         pgtype.Text{String: snapshot.Headline, Valid: snapshot.Headline != ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update profile: %w", err)
+	}
+
+	result := &models.ProfileSync{}
+	if roleChanged(previous, current) {
+		event := db.CreateProfileEventParams{
+			ProfileID:    profileID,
+			EventType:    ProfileEventJobChange,
+			OldCompanyID: previous.companyID,
+			OldPosition:  pgtype.Text{String: previous.position, Valid: previous.position != ""},
+		}
+		if current != nil {
+			event.NewCompanyID = current.companyID
+			event.NewPosition = pgtype.Text{String: current.position, Valid: current.position != ""}
+		}
+		if _, err := queries.CreateProfileEvent(ctx, event); err != nil {
+			return nil, fmt.Errorf("failed to record job change: %w", err)
+		}
+		result.JobChange = true
+	}
+
+	if headline := strings.TrimSpace(snapshot.Headline); headlineChanged(textValue(profile.Headline), headline) {
+		_, err := queries.CreateProfileEvent(ctx, db.CreateProfileEventParams{
+			ProfileID:   profileID,
+			EventType:   ProfileEventHeadlineChange,
+			OldHeadline: profile.Headline,
+			NewHeadline: pgtype.Text{String: headline, Valid: headline != ""},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record headline change: %w", err)
+		}
+		result.HeadlineChange = true
+	}
+
+	return result, nil
+}
+
+// findOrCreateCompany returns the company whose normalized name or alias
+// matches the given name, creating it if needed
+func findOrCreateCompany(ctx context.Context, queries *db.Queries, name string) (db.Company, error) {
+	name = strings.TrimSpace(name)
+	normalized := normalizeCompanyName(name)
+	company, err := queries.FindCompanyByNormalizedName(ctx, normalized)
+	if err == nil {
+		return company, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return db.Company{}, fmt.Errorf("failed to get company: %w", err)
+	}
+
+	company, err = queries.CreateCompany(ctx, db.CreateCompanyParams{
+		Name:           name,
+		NormalizedName: normalized,
+	})
+	if err != nil {
+		return db.Company{}, fmt.Errorf("failed to create company: %w", err)
+	}
+	return company, nil
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoredRole(t *testing.T) {
+	acme, globex := testProfileID(1), testProfileID(2)
+
+	history := []db.GetProfileCompaniesRow{
+		{CompanyID: globex, Position: "Engineer", IsCurrent: false},
+		{CompanyID: acme, Position: "Manager", IsCurrent: true},
+	}
+	assert.Equal(t, &employmentRole{companyID: acme, position: "Manager"}, storedRole(globex, history))
+
+	// Without current history entries the profile's current company is used
+	assert.Equal(t, &employmentRole{companyID: globex}, storedRole(globex, history[:1]))
+	assert.Nil(t, storedRole(pgtype.UUID{}, nil))
+}
+
+func TestRoleChanged(t *testing.T) {
+	acme, globex := testProfileID(1), testProfileID(2)
+
+	tests := []struct {
+		name     string
+		previous *employmentRole
+		current  *employmentRole
+		want     bool
+	}{
+		{"first scrape", nil, &employmentRole{companyID: acme, position: "Engineer"}, false},
+		{"unchanged", &employmentRole{companyID: acme, position: "Engineer"}, &employmentRole{companyID: acme, position: " engineer"}, false},
+		{"new company", &employmentRole{companyID: acme, position: "Engineer"}, &employmentRole{companyID: globex, position: "Engineer"}, true},
+		{"promotion", &employmentRole{companyID: acme, position: "Engineer"}, &employmentRole{companyID: acme, position: "Staff Engineer"}, true},
+		{"left company", &employmentRole{companyID: acme, position: "Engineer"}, nil, true},
+		{"position first known", &employmentRole{companyID: acme}, &employmentRole{companyID: acme, position: "Engineer"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, roleChanged(tt.previous, tt.current))
+		})
+	}
+}
+
+func TestHeadlineChanged(t *testing.T) {
+	assert.False(t, headlineChanged("", "Engineer at Acme"), "first scrape")
+	assert.False(t, headlineChanged("Engineer at Acme", " Engineer at Acme "), "unchanged")
+	assert.False(t, headlineChanged("Engineer at Acme", ""), "not scraped")
+	assert.True(t, headlineChanged("Engineer at Acme", "CTO at Globex"), "rewritten")
+}
+
+// fakeProfile stands in for the database in the profile sync test. It keeps
+// one profile, its employment history, companies and events in memory, so
+// the test covers how a re-scrape is diffed and recorded, not the SQL.
+type fakeProfile struct {
+	profile   db.LinkedinProfile
+	history   []db.GetProfileCompaniesRow
+	companies []db.Company
+	events    []db.CreateProfileEventParams
+}
+
+func (f *fakeProfile) Exec(_ context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	switch name := queryName.FindStringSubmatch(sql)[1]; name {
+	case "ClearCurrentProfileCompanies":
+		for i := range f.history {
+			f.history[i].IsCurrent = false
+		}
+	case "UpsertProfileCompany":
+		row := db.GetProfileCompaniesRow{
+			ProfileID: args[0].(pgtype.UUID),
+			CompanyID: args[1].(pgtype.UUID),
+			Position:  args[2].(string),
+			StartDate: args[3].(pgtype.Date),
+			EndDate:   args[4].(pgtype.Date),
+			IsCurrent: args[5].(bool),
+		}
+		i := slices.IndexFunc(f.history, func(entry db.GetProfileCompaniesRow) bool {
+			return entry.CompanyID == row.CompanyID && entry.Position == row.Position && entry.StartDate == row.StartDate
+		})
+		if i < 0 {
+			f.history = append(f.history, row)
+		} else {
+			f.history[i] = row
+		}
+	case "UpdateLinkedInProfile":
+		f.profile.Name = args[1].(string)
+		f.profile.Location = args[2].(pgtype.Text)
+		f.profile.CurrentCompanyID = args[3].(pgtype.UUID)
+		f.profile.Headline = args[4].(pgtype.Text)
+	default:
+		return pgconn.CommandTag{}, fmt.Errorf("unexpected query %s", name)
+	}
+	return pgconn.CommandTag{}, nil
+}
+
+func (f *fakeProfile) QueryRow(_ context.Context, sql string, args ...interface{}) pgx.Row {
+	switch name := queryName.FindStringSubmatch(sql)[1]; name {
+	case "GetLinkedInProfileByID":
+		if args[0].(pgtype.UUID) != f.profile.ID {
+			return &fakeRows{}
+		}
+		p := f.profile
+		return &fakeRows{values: []interface{}{struct {
+			ID               pgtype.UUID
+			LinkedinUrl      pgtype.Text
+			Name             string
+			Location         pgtype.Text
+			CurrentCompanyID pgtype.UUID
+			Headline         pgtype.Text
+			CreatedAt        pgtype.Timestamp
+			UpdatedAt        pgtype.Timestamp
+		}{p.ID, p.LinkedinUrl, p.Name, p.Location, p.CurrentCompanyID, p.Headline, p.CreatedAt, p.UpdatedAt}}}
+	case "FindCompanyByNormalizedName":
+		for _, company := range f.companies {
+			if company.NormalizedName == args[0].(string) {
+				return &fakeRows{values: []interface{}{company}}
+			}
+		}
+		return &fakeRows{}
+	case "CreateCompany":
+		company := db.Company{ID: testProfileID(byte(100 + len(f.companies))), Name: args[0].(string), NormalizedName: args[3].(string)}
+		f.companies = append(f.companies, company)
+		return &fakeRows{values: []interface{}{company}}
+	case "CreateProfileEvent":
+		event := db.CreateProfileEventParams{
+			ProfileID:    args[0].(pgtype.UUID),
+			EventType:    args[1].(string),
+			OldCompanyID: args[2].(pgtype.UUID),
+			NewCompanyID: args[3].(pgtype.UUID),
+			OldPosition:  args[4].(pgtype.Text),
+			NewPosition:  args[5].(pgtype.Text),
+			OldHeadline:  args[7].(pgtype.Text),
+			NewHeadline:  args[8].(pgtype.Text),
+		}
+		f.events = append(f.events, event)
+		return &fakeRows{values: []interface{}{db.ProfileEvent{ProfileID: event.ProfileID, EventType: event.EventType}}}
+	}
+	return &fakeRows{err: fmt.Errorf("unexpected query %s", sql)}
+}
+
+func (f *fakeProfile) Query(_ context.Context, sql string, _ ...interface{}) (pgx.Rows, error) {
+	switch name := queryName.FindStringSubmatch(sql)[1]; name {
+	case "GetProfileCompanies":
+		history := slices.Clone(f.history)
+		slices.SortFunc(history, func(a, b db.GetProfileCompaniesRow) int {
+			return b.StartDate.Time.Compare(a.StartDate.Time)
+		})
+		values := make([]interface{}, len(history))
+		for i, entry := range history {
+			values[i] = entry
+		}
+		return &fakeRows{values: values, index: -1}, nil
+	default:
+		return nil, fmt.Errorf("unexpected query %s", name)
+	}
+}
+
+// TestSyncProfile_RecordsChanges re-scrapes a profile whose owner moved from
+// Acme to Globex and rewrote their headline
+func TestSyncProfile_RecordsChanges(t *testing.T) {
+	ctx := context.Background()
+	profileID := testProfileID(1)
+	fake := &fakeProfile{profile: db.LinkedinProfile{ID: profileID, Name: "Ada Lovelace"}}
+	queries := db.New(fake)
+
+	joined := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	left := time.Date(2026, 8, 31, 0, 0, 0, 0, time.UTC)
+	first := models.ProfileSnapshot{
+		Name:      "Ada Lovelace",
+		Headline:  "Engineer at Acme",
+		Positions: []models.PositionSnapshot{{Company: "Acme", Position: "Engineer", StartDate: joined, IsCurrent: true}},
+	}
+	rescraped := models.ProfileSnapshot{
+		Name:     "Ada Lovelace",
+		Headline: "Staff Engineer at Globex",
+		Positions: []models.PositionSnapshot{
+			{Company: "Acme", Position: "Engineer", StartDate: joined, EndDate: &left},
+			{Company: "Globex", Position: "Staff Engineer", StartDate: left.AddDate(0, 0, 1), IsCurrent: true},
+		},
+	}
+
+	// The first scrape only establishes the role and headline
+	result, err := syncProfile(ctx, queries, profileID, first)
+	require.NoError(t, err)
+	assert.Equal(t, &models.ProfileSync{}, result)
+	assert.Empty(t, fake.events)
+	acme := fake.profile.CurrentCompanyID
+
+	result, err = syncProfile(ctx, queries, profileID, rescraped)
+	require.NoError(t, err)
+	assert.Equal(t, &models.ProfileSync{JobChange: true, HeadlineChange: true}, result)
+	globex := fake.profile.CurrentCompanyID
+	assert.NotEqual(t, acme, globex)
+	assert.Equal(t, []db.CreateProfileEventParams{
+		{
+			ProfileID:    profileID,
+			EventType:    ProfileEventJobChange,
+			OldCompanyID: acme,
+			NewCompanyID: globex,
+			OldPosition:  pgtype.Text{String: "Engineer", Valid: true},
+			NewPosition:  pgtype.Text{String: "Staff Engineer", Valid: true},
+		},
+		{
+			ProfileID:   profileID,
+			EventType:   ProfileEventHeadlineChange,
+			OldHeadline: pgtype.Text{String: "Engineer at Acme", Valid: true},
+			NewHeadline: pgtype.Text{String: "Staff Engineer at Globex", Valid: true},
+		},
+	}, fake.events)
+
+	// Scraping the same profile again records nothing more
+	result, err = syncProfile(ctx, queries, profileID, rescraped)
+	require.NoError(t, err)
+	assert.Equal(t, &models.ProfileSync{}, result)
+	assert.Len(t, fake.events, 2)
+
+	_, err = syncProfile(ctx, queries, testProfileID(2), rescraped)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
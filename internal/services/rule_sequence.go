@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/infra/logger"
+	"linkedin-watcher/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Sequence events, observed on the profile while a step waits
+const (
+	SequenceEventAccepted = "accepted"
+	SequenceEventReplied  = "replied"
+)
+
+// Sequence enrollment statuses stored in sequence_enrollments.status
+const (
+	SequenceActive    = "active"
+	SequenceCompleted = "completed"
+	SequenceExited    = "exited"
+	SequenceFailed    = "failed"
+)
+
+// EventCheck reports whether a sequence event happened for the action's profile
+type EventCheck func(ctx context.Context, action RuleAction) (bool, error)
+
+// RegisterCheck sets the check for a sequence event, replacing any registered
+// before. Events without a check are never observed, so steps waiting on them
+// wait out their days.
+func (s *AutomationService) RegisterCheck(event string, check EventCheck) {
+	s.checks[event] = check
+}
+
+// AdvanceSequences moves every active enrollment of an active rule along its
+// sequence: it checks for the events its step waits on, ends it on an exit
+// event and performs the step once it is due. Enrollments are left alone
+// while their user's execution window is closed in the rule's timezone. It
+// is run by the scheduler.
+func (s *AutomationService) AdvanceSequences(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rows, err := s.queries.ListActiveEnrollments(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list sequence enrollments: %w", err)
+	}
+
+	var errs []error
+	now := time.Now()
+	windows := make(map[pgtype.UUID]executionWindow)
+	for _, row := range rows {
+		window, ok := windows[row.UserID]
+		if !ok {
+			window, err = s.windows.window(ctx, row.UserID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("user %s: %w", uuidString(row.UserID), err))
+				continue
+			}
+			windows[row.UserID] = window
+		}
+		if !window.openAt(now, window.location(textValue(row.RuleTimezone))) {
+			continue
+		}
+		if err := s.advance(ctx, row); err != nil {
+			errs = append(errs, fmt.Errorf("rule %q, %s: %w", row.RuleName, row.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// advance moves one enrollment along its rule's sequence
+func (s *AutomationService) advance(ctx context.Context, row db.ListActiveEnrollmentsRow) error {
+	steps, err := parseSequence(row.Sequence)
+	if err != nil || int(row.Step) > len(steps) {
+		// The rule's sequence was shortened or removed since
+		return s.endEnrollment(ctx, row.ID, SequenceCompleted, "")
+	}
+	step := steps[row.Step-1]
+	action := RuleAction{
+		UserID:          row.UserID,
+		RuleID:          row.RuleID,
+		RuleName:        row.RuleName,
+		TriggerType:     row.TriggerType,
+		ActionType:      step.ActionType,
+		MessageTemplate: step.MessageTemplate,
+		ProfileID:       row.ProfileID,
+		LinkedinURL:     row.LinkedinUrl,
+		Name:            row.Name,
+		Location:        textValue(row.Location),
+		Headline:        textValue(row.Headline),
+		Company:         textValue(row.CompanyName),
+		NetworkScore:    row.NetworkScore,
+		Via:             textValue(row.ViaName),
+		Timezone:        textValue(row.RuleTimezone),
+		SequenceStep:    int(row.Step),
+	}
+
+	observed := map[string]bool{
+		SequenceEventAccepted: row.Accepted,
+		SequenceEventReplied:  row.Replied,
+	}
+	for _, event := range stepEvents(step, row.Waited) {
+		if observed[event] {
+			continue
+		}
+		check, ok := s.checks[event]
+		if !ok {
+			continue
+		}
+		happened, err := check(ctx, action)
+		if err != nil {
+			return fmt.Errorf("failed to check whether %s: %w", event, err)
+		}
+		if !happened {
+			continue
+		}
+		observed[event] = true
+		err = s.queries.RecordEnrollmentEvent(ctx, db.RecordEnrollmentEventParams{
+			Event: event,
+			ID:    row.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record sequence event: %w", err)
+		}
+	}
+
+	exit, due := stepMove(step, observed, row.Waited)
+	if exit != "" {
+		logger.Infof("Rule %q ended the sequence of %s: %s", row.RuleName, row.Name, exit)
+		return s.endEnrollment(ctx, row.ID, SequenceExited, exit)
+	}
+	if !due {
+		return nil
+	}
+	return s.performStep(ctx, row, action, len(steps))
+}
+
+// performStep performs a due step and records it, moving the enrollment on to
+// the next step or completing it. A failed step is retried by later passes
+// until it uses up its attempts.
+func (s *AutomationService) performStep(ctx context.Context, row db.ListActiveEnrollmentsRow, action RuleAction, steps int) error {
+	executor, ok := s.executors[action.ActionType]
+	if !ok {
+		logger.Debugf("Holding the sequence of rule %q: no executor for %s", action.RuleName, action.ActionType)
+		return nil
+	}
+
+	var outcome string
+	err := renderNote(&action)
+	if err == nil {
+		outcome, err = executor(ctx, action)
+	}
+	status, failure := executionStatus(err)
+
+	err = s.queries.RecordSequenceStepRun(ctx, db.RecordSequenceStepRunParams{
+		EnrollmentID: row.ID,
+		Step:         row.Step,
+		ActionType:   action.ActionType,
+		Status:       status,
+		Outcome:      pgtype.Text{String: outcome, Valid: outcome != ""},
+		Message:      pgtype.Text{String: action.Note, Valid: action.Note != ""},
+		Error:        failure,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record sequence step: %w", err)
+	}
+
+	switch {
+	case status == RuleExecutionFailed:
+		logger.Warnf("Rule %q failed step %d on %s (attempt %d): %s", action.RuleName, row.Step, action.Name, row.Attempts+1, failure.String)
+		err = s.queries.FailEnrollmentStep(ctx, db.FailEnrollmentStepParams{
+			Error:       failure,
+			MaxAttempts: maxRuleAttempts,
+			ID:          row.ID,
+		})
+	case int(row.Step) == steps:
+		return s.endEnrollment(ctx, row.ID, SequenceCompleted, "")
+	default:
+		err = s.queries.AdvanceEnrollment(ctx, row.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update sequence enrollment: %w", err)
+	}
+	return nil
+}
+
+// enroll starts the rule's sequence, if it has one, for a profile its action succeeded on
+func (s *AutomationService) enroll(ctx context.Context, action RuleAction) error {
+	err := s.queries.EnrollRuleSequence(ctx, db.EnrollRuleSequenceParams{
+		ProfileID: action.ProfileID,
+		RuleID:    action.RuleID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start rule sequence: %w", err)
+	}
+	return nil
+}
+
+func (s *AutomationService) endEnrollment(ctx context.Context, id pgtype.UUID, status, reason string) error {
+	err := s.queries.EndEnrollment(ctx, db.EndEnrollmentParams{
+		ID:         id,
+		Status:     status,
+		ExitReason: pgtype.Text{String: reason, Valid: reason != ""},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to end sequence enrollment: %w", err)
+	}
+	return nil
+}
+
+// stepEvents returns the events worth checking for a step: those exiting the
+// sequence, and the one ending its wait while it lasts
+func stepEvents(step models.SequenceStep, waited bool) []string {
+	events := step.ExitOn
+	if step.Until != "" && !waited {
+		events = append(events[:len(events):len(events)], step.Until)
+	}
+	return events
+}
+
+// stepMove decides what an enrollment does given the events observed so far:
+// exit on one of the step's exit events, or perform the step once its wait is
+// over or its until event happened. Exit events win over performing the step.
+func stepMove(step models.SequenceStep, observed map[string]bool, waited bool) (exit string, due bool) {
+	for _, event := range step.ExitOn {
+		if observed[event] {
+			return event, false
+		}
+	}
+	return "", waited || (step.Until != "" && observed[step.Until])
+}
+
+// parseSequence decodes a rule's stored sequence, nil when it has none
+func parseSequence(data []byte) ([]models.SequenceStep, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var steps []models.SequenceStep
+	if err := json.Unmarshal(data, &steps); err != nil {
+		return nil, fmt.Errorf("invalid sequence: %w", err)
+	}
+	return steps, nil
+}
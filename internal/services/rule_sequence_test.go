@@ -0,0 +1,44 @@
+package services
+
+import (
+	"testing"
+
+	"linkedin-watcher/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStepMove(t *testing.T) {
+	followUp := models.SequenceStep{WaitDays: 14, Until: SequenceEventAccepted, ExitOn: []string{SequenceEventReplied}, ActionType: RuleActionSendMessage}
+	reminder := models.SequenceStep{WaitDays: 7, ExitOn: []string{SequenceEventReplied}, ActionType: RuleActionNotify}
+
+	tests := map[string]struct {
+		step     models.SequenceStep
+		observed map[string]bool
+		waited   bool
+		exit     string
+		due      bool
+	}{
+		"waiting":                {step: followUp},
+		"accepted early":         {step: followUp, observed: map[string]bool{SequenceEventAccepted: true}, due: true},
+		"never accepted":         {step: followUp, waited: true, due: true},
+		"replied before message": {step: followUp, observed: map[string]bool{SequenceEventAccepted: true, SequenceEventReplied: true}, exit: SequenceEventReplied},
+		"no reply yet":           {step: reminder, observed: map[string]bool{SequenceEventAccepted: true}},
+		"no reply in time":       {step: reminder, waited: true, due: true},
+		"replied in time":        {step: reminder, observed: map[string]bool{SequenceEventReplied: true}, waited: true, exit: SequenceEventReplied},
+	}
+	for name, tc := range tests {
+		exit, due := stepMove(tc.step, tc.observed, tc.waited)
+		assert.Equal(t, tc.exit, exit, name)
+		assert.Equal(t, tc.due, due, name)
+	}
+}
+
+func TestStepEvents(t *testing.T) {
+	step := models.SequenceStep{Until: SequenceEventAccepted, ExitOn: []string{SequenceEventReplied}}
+
+	assert.Equal(t, []string{SequenceEventReplied, SequenceEventAccepted}, stepEvents(step, false))
+	// Once the wait is over, only exit events can change what happens
+	assert.Equal(t, []string{SequenceEventReplied}, stepEvents(step, true))
+	assert.Empty(t, stepEvents(models.SequenceStep{WaitDays: 3}, false))
+}
@@ -0,0 +1,838 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/condition"
+	"linkedin-watcher/internal/message"
+	"linkedin-watcher/internal/models"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Automation rule triggers
+const (
+	RuleTriggerNewConnection = "new_connection"
+	RuleTriggerJobChange     = "job_change"
+)
+
+// Automation rule actions
+const (
+	RuleActionSendConnectionRequest = "send_connection_request"
+	RuleActionSaveProfile           = "save_profile"
+	RuleActionNotify                = "notify"
+	// RuleActionSendMessage messages a connection; it is a sequence step only
+	RuleActionSendMessage = "send_message"
+)
+
+const (
+	// maxConnectionNoteLength is the longest note LinkedIn accepts on a connection request
+	maxConnectionNoteLength = message.MaxNoteLength
+
+	// maxMessageLength is the longest message LinkedIn accepts
+	maxMessageLength = 8000
+
+	// defaultRuleExecutionsLimit is the number of executions returned when no limit is given
+	defaultRuleExecutionsLimit = 50
+
+	// defaultRulePreviewLimit is the number of profiles a preview renders when no limit is given
+	defaultRulePreviewLimit = 5
+
+	// maxRulePreviewCandidates bounds the profiles a preview evaluates the rule's condition on
+	maxRulePreviewCandidates = 500
+
+	// defaultRuleSimulationDays is how far back a simulation looks when no period is given
+	defaultRuleSimulationDays = 30
+)
+
+var (
+	// ErrMissingTemplate is returned when a rule sending connection requests has no note template
+	ErrMissingTemplate = errors.New("send_connection_request rules need a message_template")
+
+	// ErrTemplateTooLong is returned when a connection request template cannot fit LinkedIn's note limit
+	ErrTemplateTooLong = fmt.Errorf("message_template renders longer than %d characters even without profile values, so no LinkedIn connection note could fit", maxConnectionNoteLength)
+
+	// ErrNoRuleFilters is returned when a rule would match every profile
+	ErrNoRuleFilters = errors.New("a rule needs at least one filter: condition, min_network_score, list_id, country, near, min_seniority or function")
+
+	// ErrUnknownList is returned when a rule filters on a list the user does not own
+	ErrUnknownList = errors.New("list_id must be one of your lists")
+
+	// ErrInvalidCondition is returned when a rule's condition does not parse or type-check
+	ErrInvalidCondition = errors.New("invalid condition")
+
+	// ErrInvalidTemplate is returned when a message template does not parse
+	ErrInvalidTemplate = errors.New("invalid message_template")
+
+	// ErrInvalidSequence is returned when a rule's sequence step cannot be performed
+	ErrInvalidSequence = errors.New("invalid sequence")
+
+	// ErrNothingToPreview is returned when previewing a rule without a message template
+	ErrNothingToPreview = errors.New("the rule has no message_template; pass one to preview")
+)
+
+type RuleService struct {
+	queries     *db.Queries
+	invitations *InvitationService
+}
+
+func NewRuleService(queries *db.Queries, invitations *InvitationService) *RuleService {
+	return &RuleService{
+		queries:     queries,
+		invitations: invitations,
+	}
+}
+
+// ruleFields holds a validated rule request in the form the queries take
+type ruleFields struct {
+	name             string
+	triggerType      string
+	actionType       string
+	messageTemplate  pgtype.Text
+	isActive         bool
+	condition        pgtype.Text
+	minNetworkScore  pgtype.Float8
+	listID           pgtype.UUID
+	near             locationFilter
+	minSeniority     pgtype.Text
+	jobFunction      pgtype.Text
+	requiresApproval bool
+	sequence         []byte
+	timezone         pgtype.Text
+}
+
+// ListRules returns the user's automation rules, newest first
+func (s *RuleService) ListRules(ctx context.Context, userID string) ([]models.AutomationRule, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.GetAutomationRules(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rules: %w", err)
+	}
+
+	rules := make([]models.AutomationRule, 0, len(rows))
+	for _, row := range rows {
+		rules = append(rules, automationRuleModel(row))
+	}
+
+	return rules, nil
+}
+
+// CreateRule stores a new automation rule for the user
+func (s *RuleService) CreateRule(ctx context.Context, userID string, req models.AutomationRuleRequest) (*models.AutomationRule, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := newRuleFields(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkList(ctx, userUUID, fields.listID); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.queries.CreateAutomationRule(ctx, db.CreateAutomationRuleParams{
+		UserID:           userUUID,
+		Name:             fields.name,
+		Condition:        fields.condition,
+		ActionType:       fields.actionType,
+		MessageTemplate:  fields.messageTemplate,
+		MinNetworkScore:  fields.minNetworkScore,
+		TriggerType:      fields.triggerType,
+		ListID:           fields.listID,
+		CountryCode:      fields.near.CountryCode,
+		Near:             fields.near.Near,
+		NearLatitude:     fields.near.NearLatitude,
+		NearLongitude:    fields.near.NearLongitude,
+		RadiusKm:         fields.near.RadiusKm,
+		MinSeniority:     fields.minSeniority,
+		JobFunction:      fields.jobFunction,
+		IsActive:         fields.isActive,
+		RequiresApproval: fields.requiresApproval,
+		Sequence:         fields.sequence,
+		Timezone:         fields.timezone,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rule: %w", err)
+	}
+
+	result := automationRuleModel(rule)
+	return &result, nil
+}
+
+// UpdateRule replaces one of the user's automation rules
+func (s *RuleService) UpdateRule(ctx context.Context, userID, ruleID string, req models.AutomationRuleRequest) (*models.AutomationRule, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	ruleUUID, err := parseUUID(ruleID)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := newRuleFields(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkList(ctx, userUUID, fields.listID); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.queries.UpdateAutomationRule(ctx, db.UpdateAutomationRuleParams{
+		ID:               ruleUUID,
+		UserID:           userUUID,
+		Name:             fields.name,
+		Condition:        fields.condition,
+		ActionType:       fields.actionType,
+		MessageTemplate:  fields.messageTemplate,
+		IsActive:         fields.isActive,
+		MinNetworkScore:  fields.minNetworkScore,
+		TriggerType:      fields.triggerType,
+		ListID:           fields.listID,
+		CountryCode:      fields.near.CountryCode,
+		Near:             fields.near.Near,
+		NearLatitude:     fields.near.NearLatitude,
+		NearLongitude:    fields.near.NearLongitude,
+		RadiusKm:         fields.near.RadiusKm,
+		MinSeniority:     fields.minSeniority,
+		JobFunction:      fields.jobFunction,
+		RequiresApproval: fields.requiresApproval,
+		Sequence:         fields.sequence,
+		Timezone:         fields.timezone,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update rule: %w", err)
+	}
+
+	result := automationRuleModel(rule)
+	return &result, nil
+}
+
+// DeleteRule removes one of the user's automation rules
+func (s *RuleService) DeleteRule(ctx context.Context, userID, ruleID string) error {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return err
+	}
+	ruleUUID, err := parseUUID(ruleID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.queries.DeleteAutomationRule(ctx, db.DeleteAutomationRuleParams{
+		ID:     ruleUUID,
+		UserID: userUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete rule: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListExecutions returns what one of the user's rules has done, newest first
+func (s *RuleService) ListExecutions(ctx context.Context, userID, ruleID string, query models.RuleExecutionsQuery) ([]models.RuleExecution, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	ruleUUID, err := parseUUID(ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.queries.GetAutomationRuleByID(ctx, db.GetAutomationRuleByIDParams{
+		ID:     ruleUUID,
+		UserID: userUUID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rule: %w", err)
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultRuleExecutionsLimit
+	}
+	rows, err := s.queries.ListRuleExecutions(ctx, db.ListRuleExecutionsParams{
+		RuleID: ruleUUID,
+		UserID: userUUID,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rule executions: %w", err)
+	}
+
+	executions := make([]models.RuleExecution, 0, len(rows))
+	for _, row := range rows {
+		executions = append(executions, models.RuleExecution{
+			ID: uuidString(row.ID),
+			Profile: models.ProfileRef{
+				ID:          uuidString(row.ProfileID),
+				Name:        row.ProfileName,
+				LinkedinURL: row.LinkedinUrl,
+			},
+			Status:     row.Status,
+			Message:    textValue(row.Message),
+			Outcome:    textValue(row.Outcome),
+			Error:      textValue(row.Error),
+			Attempts:   int(row.Attempts),
+			ExecutedAt: row.ExecutedAt.Time,
+		})
+	}
+
+	return executions, nil
+}
+
+// ListEnrollments returns the profiles going through one of the user's rule's
+// sequence, most recently updated first, with the steps performed so far
+func (s *RuleService) ListEnrollments(ctx context.Context, userID, ruleID string, query models.RuleEnrollmentsQuery) ([]models.SequenceEnrollment, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	ruleUUID, err := parseUUID(ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.queries.GetAutomationRuleByID(ctx, db.GetAutomationRuleByIDParams{
+		ID:     ruleUUID,
+		UserID: userUUID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rule: %w", err)
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultRuleExecutionsLimit
+	}
+	rows, err := s.queries.ListRuleEnrollments(ctx, db.ListRuleEnrollmentsParams{
+		RuleID:     ruleUUID,
+		UserID:     userUUID,
+		Status:     pgtype.Text{String: query.Status, Valid: query.Status != ""},
+		LimitCount: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sequence enrollments: %w", err)
+	}
+
+	ids := make([]pgtype.UUID, 0, len(rows))
+	enrollments := make([]models.SequenceEnrollment, 0, len(rows))
+	index := make(map[pgtype.UUID]int, len(rows))
+	for i, row := range rows {
+		ids = append(ids, row.ID)
+		index[row.ID] = i
+		enrollments = append(enrollments, models.SequenceEnrollment{
+			ID: uuidString(row.ID),
+			Profile: models.ProfileRef{
+				ID:          uuidString(row.ProfileID),
+				Name:        row.ProfileName,
+				LinkedinURL: row.LinkedinUrl,
+			},
+			Status:        row.Status,
+			Step:          int(row.Step),
+			StepStartedAt: row.StepStartedAt.Time,
+			ExitReason:    textValue(row.ExitReason),
+			Attempts:      int(row.Attempts),
+			Error:         textValue(row.Error),
+			AcceptedAt:    timeValue(row.AcceptedAt),
+			RepliedAt:     timeValue(row.RepliedAt),
+			CreatedAt:     row.CreatedAt.Time,
+			UpdatedAt:     row.UpdatedAt.Time,
+			Steps:         []models.SequenceStepRun{},
+		})
+	}
+	if len(ids) == 0 {
+		return enrollments, nil
+	}
+
+	runs, err := s.queries.ListEnrollmentStepRuns(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sequence steps: %w", err)
+	}
+	for _, run := range runs {
+		enrollment := &enrollments[index[run.EnrollmentID]]
+		enrollment.Steps = append(enrollment.Steps, models.SequenceStepRun{
+			Step:       int(run.Step),
+			ActionType: run.ActionType,
+			Status:     run.Status,
+			Outcome:    textValue(run.Outcome),
+			Message:    textValue(run.Message),
+			Error:      textValue(run.Error),
+			ExecutedAt: run.ExecutedAt.Time,
+		})
+	}
+
+	return enrollments, nil
+}
+
+// PreviewRule renders a message template, the rule's own unless the request
+// gives one, for the profiles in the user's network the rule matches. Profiles
+// the rule has already actioned are included, so a rule can be previewed at
+// any time.
+func (s *RuleService) PreviewRule(ctx context.Context, userID, ruleID string, req models.RulePreviewRequest) ([]models.RulePreview, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	ruleUUID, err := parseUUID(ruleID)
+	if err != nil {
+		return nil, err
+	}
+	template := strings.TrimSpace(req.MessageTemplate)
+	if template != "" {
+		if _, err := parseTemplate(template); err != nil {
+			return nil, err
+		}
+	}
+
+	rule, err := s.queries.GetAutomationRuleByID(ctx, db.GetAutomationRuleByIDParams{
+		ID:     ruleUUID,
+		UserID: userUUID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rule: %w", err)
+	}
+	if template == "" {
+		template = textValue(rule.MessageTemplate)
+	}
+	if template == "" {
+		return nil, ErrNothingToPreview
+	}
+	tmpl, err := parseTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.GetRulePreviewCandidates(ctx, db.GetRulePreviewCandidatesParams{
+		UserID: userUUID,
+		ID:     ruleUUID,
+		Limit:  maxRulePreviewCandidates,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preview profiles: %w", err)
+	}
+
+	actions := make([]RuleAction, 0, len(rows))
+	for _, row := range rows {
+		actions = append(actions, RuleAction{
+			RuleID:       rule.ID,
+			RuleName:     rule.Name,
+			ProfileID:    row.ID,
+			LinkedinURL:  row.LinkedinUrl,
+			Name:         row.Name,
+			Location:     textValue(row.Location),
+			Headline:     textValue(row.Headline),
+			Company:      textValue(row.CompanyName),
+			Condition:    textValue(rule.Condition),
+			Degree:       int(row.Degree),
+			Tags:         row.Tags,
+			DiscoveredAt: row.DiscoveredAt.Time,
+			Via:          textValue(row.ViaName),
+		})
+	}
+	actions = matchConditions(actions)
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultRulePreviewLimit
+	}
+	if len(actions) > limit {
+		actions = actions[:limit]
+	}
+
+	previews := make([]models.RulePreview, 0, len(actions))
+	for _, action := range actions {
+		previews = append(previews, rulePreview(action, tmpl.Render(messageVars(action))))
+	}
+
+	return previews, nil
+}
+
+// SimulateRule works out what one of the user's rules would do had it been
+// created the requested number of days ago, active or not, against the
+// connections discovered and job changes detected since. Nothing is performed
+// or recorded.
+func (s *RuleService) SimulateRule(ctx context.Context, userID, ruleID string, req models.RuleSimulationRequest) (*models.RuleSimulation, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	ruleUUID, err := parseUUID(ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := s.queries.GetAutomationRuleByID(ctx, db.GetAutomationRuleByIDParams{
+		ID:     ruleUUID,
+		UserID: userUUID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rule: %w", err)
+	}
+
+	days := req.Days
+	if days == 0 {
+		days = defaultRuleSimulationDays
+	}
+	fresh, actioned, blocked, err := s.simulationCandidates(ctx, rule, days)
+	if err != nil {
+		return nil, err
+	}
+	fresh, actioned = matchConditions(fresh), matchConditions(actioned)
+	sort.SliceStable(fresh, func(i, j int) bool {
+		return fresh[i].NetworkScore > fresh[j].NetworkScore
+	})
+
+	sample := req.Sample
+	if sample == 0 {
+		sample = defaultRulePreviewLimit
+	}
+	simulation := &models.RuleSimulation{
+		Days:            days,
+		Matched:         len(fresh) + len(actioned),
+		AlreadyActioned: len(actioned),
+		WouldAction:     len(fresh),
+		Sample:          make([]models.RulePreview, 0, min(sample, len(fresh))),
+	}
+	if err := tallySimulation(simulation, fresh, blocked, sample); err != nil {
+		return nil, err
+	}
+
+	if rule.ActionType == RuleActionSendConnectionRequest {
+		quota, err := s.invitations.quota(ctx, userUUID)
+		if err != nil {
+			return nil, err
+		}
+		simulation.OverQuota = max(simulation.WouldAction-simulation.NotesTooLong-simulation.Blocked-invitationCapacity(quota), 0)
+	}
+
+	return simulation, nil
+}
+
+// tallySimulation counts the fresh matches whose note is too long or whose
+// profile is blocked, and renders the first sample of their messages. A note
+// too long fails before the executor looks at the profile, so such matches
+// are not counted as blocked too.
+func tallySimulation(simulation *models.RuleSimulation, fresh []RuleAction, blocked map[pgtype.UUID]bool, sample int) error {
+	for _, action := range fresh {
+		rendered, err := renderMessage(action)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+		}
+		tooLong := action.ActionType == RuleActionSendConnectionRequest && rendered.TooLong
+		switch {
+		case tooLong:
+			simulation.NotesTooLong++
+		case blocked[action.ProfileID]:
+			simulation.Blocked++
+		}
+		if len(simulation.Sample) < sample {
+			preview := rulePreview(action, rendered)
+			preview.TooLong = tooLong
+			simulation.Sample = append(simulation.Sample, preview)
+		}
+	}
+	return nil
+}
+
+// simulationCandidates returns the profiles passing the rule's structured
+// filters over the last days days, split by whether the rule actioned them,
+// and which of them an executor skipped for another rule with the same action
+func (s *RuleService) simulationCandidates(ctx context.Context, rule db.AutomationRule, days int) (fresh, actioned []RuleAction, blocked map[pgtype.UUID]bool, err error) {
+	connections, err := s.queries.GetRuleSimulationConnections(ctx, db.GetRuleSimulationConnectionsParams{
+		UserID:      rule.UserID,
+		MaxAttempts: maxRuleAttempts,
+		RuleID:      rule.ID,
+		Days:        int32(days),
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to simulate rule on new connections: %w", err)
+	}
+	jobChanges, err := s.queries.GetRuleSimulationJobChanges(ctx, db.GetRuleSimulationJobChangesParams{
+		UserID:      rule.UserID,
+		MaxAttempts: maxRuleAttempts,
+		RuleID:      rule.ID,
+		Days:        int32(days),
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to simulate rule on job changes: %w", err)
+	}
+
+	blocked = make(map[pgtype.UUID]bool)
+
+	add := func(action RuleAction, wasActioned bool) {
+		action.UserID = rule.UserID
+		action.RuleID = rule.ID
+		action.RuleName = rule.Name
+		action.TriggerType = rule.TriggerType
+		action.ActionType = rule.ActionType
+		action.MessageTemplate = textValue(rule.MessageTemplate)
+		action.Condition = textValue(rule.Condition)
+		if wasActioned {
+			actioned = append(actioned, action)
+		} else {
+			fresh = append(fresh, action)
+		}
+	}
+	for _, row := range connections {
+		add(RuleAction{
+			ProfileID:    row.ID,
+			LinkedinURL:  row.LinkedinUrl,
+			Name:         row.Name,
+			Location:     textValue(row.Location),
+			Headline:     textValue(row.Headline),
+			Company:      textValue(row.CompanyName),
+			NetworkScore: row.NetworkScore,
+			Degree:       int(row.Degree),
+			Tags:         row.Tags,
+			DiscoveredAt: row.DiscoveredAt.Time,
+			Via:          textValue(row.ViaName),
+		}, row.Actioned)
+		if row.Blocked {
+			blocked[row.ID] = true
+		}
+	}
+	// Latest job change first, as a rule actions each profile once
+	seen := make(map[pgtype.UUID]bool, len(jobChanges))
+	for _, row := range jobChanges {
+		if seen[row.ID] {
+			continue
+		}
+		seen[row.ID] = true
+		add(RuleAction{
+			ProfileID:    row.ID,
+			LinkedinURL:  row.LinkedinUrl,
+			Name:         row.Name,
+			Location:     textValue(row.Location),
+			Headline:     textValue(row.Headline),
+			Company:      row.CompanyName,
+			NewPosition:  textValue(row.NewPosition),
+			NetworkScore: row.NetworkScore,
+			Degree:       int(row.Degree),
+			Tags:         row.Tags,
+			DiscoveredAt: row.DiscoveredAt.Time,
+			Via:          textValue(row.ViaName),
+		}, row.Actioned)
+		if row.Blocked {
+			blocked[row.ID] = true
+		}
+	}
+
+	return fresh, actioned, blocked, nil
+}
+
+func rulePreview(action RuleAction, rendered message.Rendered) models.RulePreview {
+	return models.RulePreview{
+		Profile: models.ProfileRef{
+			ID:          uuidString(action.ProfileID),
+			Name:        action.Name,
+			LinkedinURL: action.LinkedinURL,
+		},
+		Message: rendered.Text,
+		Length:  rendered.Length,
+		TooLong: rendered.TooLong,
+		Missing: rendered.Missing,
+	}
+}
+
+// parseTemplate parses a message template, wrapping syntax errors in ErrInvalidTemplate
+func parseTemplate(template string) (*message.Template, error) {
+	tmpl, err := message.Parse(template)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	return tmpl, nil
+}
+
+// checkList returns ErrUnknownList unless the rule's list, if any, belongs to the user
+func (s *RuleService) checkList(ctx context.Context, userID, listID pgtype.UUID) error {
+	if !listID.Valid {
+		return nil
+	}
+	_, err := s.queries.GetProfileList(ctx, db.GetProfileListParams{
+		ID:     listID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrUnknownList
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get list: %w", err)
+	}
+	return nil
+}
+
+// newRuleFields trims and validates a rule request. Blank filters count as
+// unset, and a rule must keep at least one. The condition is parsed here so
+// a stored rule always evaluates. Rules sending connection requests
+// need a note template that fits LinkedIn's note limit once rendered without
+// profile values. Sequence steps are messages, not notes, and are only held
+// to maxMessageLength.
+func newRuleFields(req models.AutomationRuleRequest) (ruleFields, error) {
+	fields := ruleFields{
+		name:             strings.TrimSpace(req.Name),
+		triggerType:      req.TriggerType,
+		actionType:       req.ActionType,
+		isActive:         req.IsActive == nil || *req.IsActive,
+		requiresApproval: req.RequiresApproval,
+	}
+	if fields.triggerType == "" {
+		fields.triggerType = RuleTriggerNewConnection
+	}
+
+	template := strings.TrimSpace(req.MessageTemplate)
+	if fields.actionType == RuleActionSendConnectionRequest && template == "" {
+		return ruleFields{}, ErrMissingTemplate
+	}
+	if template != "" {
+		tmpl, err := parseTemplate(template)
+		if err != nil {
+			return ruleFields{}, err
+		}
+		// Rendered without profile values, the note is as short as it gets;
+		// notes that only overflow for some profiles fail when rendered for them
+		if fields.actionType == RuleActionSendConnectionRequest && tmpl.Render(message.Vars{}).Length > maxConnectionNoteLength {
+			return ruleFields{}, ErrTemplateTooLong
+		}
+	}
+	fields.messageTemplate = pgtype.Text{String: template, Valid: template != ""}
+
+	if src := strings.TrimSpace(req.Condition); src != "" {
+		if _, err := condition.Parse(src); err != nil {
+			return ruleFields{}, fmt.Errorf("%w: %v", ErrInvalidCondition, err)
+		}
+		fields.condition = pgtype.Text{String: src, Valid: true}
+	}
+	if req.MinNetworkScore != nil {
+		fields.minNetworkScore = pgtype.Float8{Float64: *req.MinNetworkScore, Valid: true}
+	}
+	if req.ListID != "" {
+		listID, err := parseUUID(req.ListID)
+		if err != nil {
+			return ruleFields{}, err
+		}
+		fields.listID = listID
+	}
+	near, err := newLocationFilter(req.Country, req.Near, req.RadiusKm)
+	if err != nil {
+		return ruleFields{}, err
+	}
+	fields.near = near
+	fields.minSeniority = pgtype.Text{String: req.MinSeniority, Valid: req.MinSeniority != ""}
+	fields.jobFunction = pgtype.Text{String: req.Function, Valid: req.Function != ""}
+	sequence, err := newSequence(req.Sequence)
+	if err != nil {
+		return ruleFields{}, err
+	}
+	fields.sequence = sequence
+	if req.Timezone != "" {
+		if _, err := loadTimezone(req.Timezone); err != nil {
+			return ruleFields{}, err
+		}
+		fields.timezone = pgtype.Text{String: req.Timezone, Valid: true}
+	}
+
+	if !fields.condition.Valid && !fields.minNetworkScore.Valid &&
+		!fields.listID.Valid && !near.CountryCode.Valid && !near.Near.Valid &&
+		!fields.minSeniority.Valid && !fields.jobFunction.Valid {
+		return ruleFields{}, ErrNoRuleFilters
+	}
+
+	return fields, nil
+}
+
+// newSequence trims and validates a rule's sequence steps, returning them in
+// the form they are stored in, or nil for a rule without a sequence
+func newSequence(steps []models.SequenceStep) ([]byte, error) {
+	if len(steps) == 0 {
+		return nil, nil
+	}
+
+	cleaned := make([]models.SequenceStep, 0, len(steps))
+	for i, step := range steps {
+		invalid := func(format string, args ...interface{}) error {
+			return fmt.Errorf("%w: step %d: %s", ErrInvalidSequence, i+1, fmt.Sprintf(format, args...))
+		}
+		step.MessageTemplate = strings.TrimSpace(step.MessageTemplate)
+		if step.ActionType == RuleActionSendMessage && step.MessageTemplate == "" {
+			return nil, invalid("send_message steps need a message_template")
+		}
+		if utf8.RuneCountInString(step.MessageTemplate) > maxMessageLength {
+			return nil, invalid("message_template must be at most %d characters", maxMessageLength)
+		}
+		if step.MessageTemplate != "" {
+			if _, err := message.Parse(step.MessageTemplate); err != nil {
+				return nil, invalid("invalid message_template: %v", err)
+			}
+		}
+		if step.Until != "" && slices.Contains(step.ExitOn, step.Until) {
+			return nil, invalid("%s cannot both end the wait and exit the sequence", step.Until)
+		}
+		cleaned = append(cleaned, step)
+	}
+
+	sequence, err := json.Marshal(cleaned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sequence: %w", err)
+	}
+	return sequence, nil
+}
+
+func automationRuleModel(row db.AutomationRule) models.AutomationRule {
+	rule := models.AutomationRule{
+		ID:               uuidString(row.ID),
+		Name:             row.Name,
+		TriggerType:      row.TriggerType,
+		ActionType:       row.ActionType,
+		MessageTemplate:  textValue(row.MessageTemplate),
+		IsActive:         row.IsActive,
+		Condition:        textValue(row.Condition),
+		ListID:           uuidString(row.ListID),
+		Country:          textValue(row.CountryCode),
+		Near:             textValue(row.Near),
+		RadiusKm:         row.RadiusKm.Float64,
+		MinSeniority:     textValue(row.MinSeniority),
+		Function:         textValue(row.JobFunction),
+		RequiresApproval: row.RequiresApproval,
+		Timezone:         textValue(row.Timezone),
+		CreatedAt:        row.CreatedAt.Time,
+	}
+	// Sequences are validated when stored, so one that fails to decode is left out
+	rule.Sequence, _ = parseSequence(row.Sequence)
+	if row.MinNetworkScore.Valid {
+		rule.MinNetworkScore = &row.MinNetworkScore.Float64
+	}
+	return rule
+}
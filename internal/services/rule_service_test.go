@@ -0,0 +1,133 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"linkedin-watcher/internal/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRuleFields(t *testing.T) {
+	inactive := false
+	fields, err := newRuleFields(models.AutomationRuleRequest{
+		Name:            " Madrid engineers ",
+		ActionType:      RuleActionSendConnectionRequest,
+		MessageTemplate: " Hi {{first_name}}, let's connect! ",
+		IsActive:        &inactive,
+		Condition:       " ",
+		Near:            "Madrid",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Madrid engineers", fields.name)
+	assert.Equal(t, RuleTriggerNewConnection, fields.triggerType)
+	assert.Equal(t, "Hi {{first_name}}, let's connect!", fields.messageTemplate.String)
+	assert.False(t, fields.isActive)
+	assert.False(t, fields.condition.Valid)
+	assert.True(t, fields.near.RadiusKm.Valid)
+
+	// Notes are limited in characters, not bytes
+	fields, err = newRuleFields(models.AutomationRuleRequest{
+		ActionType:      RuleActionSendConnectionRequest,
+		MessageTemplate: strings.Repeat("ñ", maxConnectionNoteLength),
+		MinSeniority:    "director",
+	})
+	assert.NoError(t, err)
+	assert.True(t, fields.isActive)
+	assert.Nil(t, fields.sequence)
+
+	// Placeholders count as what they render to, not as written
+	_, err = newRuleFields(models.AutomationRuleRequest{
+		ActionType:      RuleActionSendConnectionRequest,
+		MessageTemplate: strings.Repeat("{{mutual_first_name}} ", 20) + "Hi!",
+		MinSeniority:    "director",
+	})
+	assert.NoError(t, err)
+
+	fields, err = newRuleFields(models.AutomationRuleRequest{
+		ActionType:      RuleActionSendConnectionRequest,
+		MessageTemplate: "Hi {{first_name}}",
+		Function:        "engineering",
+		Sequence: []models.SequenceStep{
+			{WaitDays: 14, Until: SequenceEventAccepted, ActionType: RuleActionSendMessage, MessageTemplate: " Thanks for connecting, {{first_name}}! "},
+			{WaitDays: 7, ExitOn: []string{SequenceEventReplied}, ActionType: RuleActionNotify},
+		},
+	})
+	assert.NoError(t, err)
+	steps, err := parseSequence(fields.sequence)
+	assert.NoError(t, err)
+	assert.Len(t, steps, 2)
+	assert.Equal(t, "Thanks for connecting, {{first_name}}!", steps[0].MessageTemplate)
+	assert.Equal(t, []string{SequenceEventReplied}, steps[1].ExitOn)
+
+	fields, err = newRuleFields(models.AutomationRuleRequest{
+		ActionType:   RuleActionNotify,
+		MinSeniority: "lead",
+		Timezone:     "America/New_York",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "America/New_York", fields.timezone.String)
+
+	tests := map[string]struct {
+		req  models.AutomationRuleRequest
+		want error
+	}{
+		"no filters":        {models.AutomationRuleRequest{ActionType: RuleActionNotify}, ErrNoRuleFilters},
+		"blank filters":     {models.AutomationRuleRequest{ActionType: RuleActionNotify, Condition: " "}, ErrNoRuleFilters},
+		"missing template":  {models.AutomationRuleRequest{ActionType: RuleActionSendConnectionRequest, Condition: `company == "Acme"`, MessageTemplate: "  "}, ErrMissingTemplate},
+		"long template":     {models.AutomationRuleRequest{ActionType: RuleActionSendConnectionRequest, Condition: `company == "Acme"`, MessageTemplate: strings.Repeat("a", maxConnectionNoteLength+1)}, ErrTemplateTooLong},
+		"long fallback":     {models.AutomationRuleRequest{ActionType: RuleActionSendConnectionRequest, Condition: `company == "Acme"`, MessageTemplate: `Hi {{first_name | "` + strings.Repeat("a", maxConnectionNoteLength) + `"}}`}, ErrTemplateTooLong},
+		"invalid condition": {models.AutomationRuleRequest{ActionType: RuleActionNotify, Condition: `degree == "2"`}, ErrInvalidCondition},
+		"invalid template":  {models.AutomationRuleRequest{ActionType: RuleActionNotify, Condition: `degree == 2`, MessageTemplate: "Hi {{first_name}"}, ErrInvalidTemplate},
+		"unknown place":     {models.AutomationRuleRequest{ActionType: RuleActionNotify, Near: "Atlantis"}, ErrUnknownPlace},
+		"invalid list":      {models.AutomationRuleRequest{ActionType: RuleActionNotify, ListID: "hiring"}, ErrInvalidID},
+		"message step without template": {models.AutomationRuleRequest{ActionType: RuleActionNotify, MinSeniority: "lead", Sequence: []models.SequenceStep{
+			{WaitDays: 14, Until: SequenceEventAccepted, ActionType: RuleActionSendMessage, MessageTemplate: " "},
+		}}, ErrInvalidSequence},
+		"invalid step template": {models.AutomationRuleRequest{ActionType: RuleActionNotify, MinSeniority: "lead", Sequence: []models.SequenceStep{
+			{ActionType: RuleActionSendMessage, MessageTemplate: "Hi {{first_name"},
+		}}, ErrInvalidSequence},
+		"until and exit on the same event": {models.AutomationRuleRequest{ActionType: RuleActionNotify, MinSeniority: "lead", Sequence: []models.SequenceStep{
+			{WaitDays: 7, Until: SequenceEventReplied, ExitOn: []string{SequenceEventReplied}, ActionType: RuleActionNotify},
+		}}, ErrInvalidSequence},
+		"unknown timezone": {models.AutomationRuleRequest{ActionType: RuleActionNotify, MinSeniority: "lead", Timezone: "Europe/Atlantis"}, ErrInvalidTimezone},
+	}
+	for name, tc := range tests {
+		_, err := newRuleFields(tc.req)
+		assert.ErrorIs(t, err, tc.want, name)
+	}
+}
+
+func TestTallySimulation(t *testing.T) {
+	action := func(profile pgtype.UUID, name string) RuleAction {
+		return RuleAction{
+			ActionType:      RuleActionSendConnectionRequest,
+			MessageTemplate: "Hi {{first_name}}, {{headline}}",
+			ProfileID:       profile,
+			Name:            name,
+			Headline:        "Engineer",
+		}
+	}
+	long := action(testProfileID(3), "Grace")
+	long.Headline = strings.Repeat("x", 300)
+	blockedLong := action(testProfileID(4), "Linus")
+	blockedLong.Headline = long.Headline
+	fresh := []RuleAction{action(testProfileID(1), "Ada"), action(testProfileID(2), "Alan"), long, blockedLong}
+	blocked := map[pgtype.UUID]bool{testProfileID(2): true, testProfileID(4): true}
+
+	simulation := &models.RuleSimulation{}
+	require.NoError(t, tallySimulation(simulation, fresh, blocked, 2))
+	assert.Equal(t, 1, simulation.Blocked)
+	// A note too long fails first, so Linus is not counted as blocked too
+	assert.Equal(t, 2, simulation.NotesTooLong)
+	if assert.Len(t, simulation.Sample, 2) {
+		assert.Equal(t, "Hi Ada, Engineer", simulation.Sample[0].Message)
+	}
+
+	invalid := action(testProfileID(5), "Barbara")
+	invalid.MessageTemplate = "Hi {{first_name"
+	assert.ErrorIs(t, tallySimulation(&models.RuleSimulation{}, []RuleAction{invalid}, nil, 1), ErrInvalidTemplate)
+}
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// NotificationKindSavedSearch is the notification kind for new saved search matches
+const NotificationKindSavedSearch = "saved_search_match"
+
+const (
+	// defaultSavedSearchMatchesLimit is the number of matches returned when no limit is given
+	defaultSavedSearchMatchesLimit = 50
+
+	// maxAlertProfiles caps how many matched profiles are listed in an alert body
+	maxAlertProfiles = 10
+)
+
+type SavedSearchService struct {
+	queries  *db.Queries
+	notifier *NotificationService
+}
+
+func NewSavedSearchService(queries *db.Queries, notifier *NotificationService) *SavedSearchService {
+	return &SavedSearchService{
+		queries:  queries,
+		notifier: notifier,
+	}
+}
+
+// ListSavedSearches returns the user's saved searches
+func (s *SavedSearchService) ListSavedSearches(ctx context.Context, userID string) ([]models.SavedSearch, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListSavedSearches(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+
+	searches := make([]models.SavedSearch, 0, len(rows))
+	for _, row := range rows {
+		searches = append(searches, savedSearchModel(row))
+	}
+
+	return searches, nil
+}
+
+// CreateSavedSearch stores a standing search. Profiles that already match are
+// recorded as a baseline without alerting, so only later matches are delivered.
+func (s *SavedSearchService) CreateSavedSearch(ctx context.Context, userID string, req models.CreateSavedSearchRequest) (*models.SavedSearch, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var companyID pgtype.UUID
+	if req.CompanyID != "" {
+		if companyID, err = parseUUID(req.CompanyID); err != nil {
+			return nil, err
+		}
+	}
+	near, err := newLocationFilter(req.Country, req.Near, req.RadiusKm)
+	if err != nil {
+		return nil, err
+	}
+	var listID pgtype.UUID
+	if req.ListID != "" {
+		if listID, err = parseUUID(req.ListID); err != nil {
+			return nil, err
+		}
+		_, err = s.queries.GetProfileList(ctx, db.GetProfileListParams{
+			ID:     listID,
+			UserID: userUUID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get list: %w", err)
+		}
+	}
+	var degree pgtype.Int4
+	if req.Degree != nil {
+		degree = pgtype.Int4{Int32: int32(*req.Degree), Valid: true}
+	}
+	query := strings.TrimSpace(req.Query)
+	location := strings.TrimSpace(req.Location)
+	tag := normalizeTag(req.Tag)
+
+	search, err := s.queries.CreateSavedSearch(ctx, db.CreateSavedSearchParams{
+		UserID:        userUUID,
+		Name:          strings.TrimSpace(req.Name),
+		Query:         pgtype.Text{String: query, Valid: query != ""},
+		CompanyID:     companyID,
+		Location:      pgtype.Text{String: location, Valid: location != ""},
+		Degree:        degree,
+		Tag:           pgtype.Text{String: tag, Valid: tag != ""},
+		ListID:        listID,
+		CountryCode:   near.CountryCode,
+		Near:          near.Near,
+		NearLatitude:  near.NearLatitude,
+		NearLongitude: near.NearLongitude,
+		RadiusKm:      near.RadiusKm,
+		MinSeniority:  pgtype.Text{String: req.MinSeniority, Valid: req.MinSeniority != ""},
+		JobFunction:   pgtype.Text{String: req.Function, Valid: req.Function != ""},
+	})
+	if isUniqueViolation(err) {
+		return nil, ErrAlreadyExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create saved search: %w", err)
+	}
+
+	if _, err := s.recordMatches(ctx, search); err != nil {
+		return nil, err
+	}
+	evaluatedAt := time.Now()
+	if err := s.markEvaluated(ctx, search.ID, evaluatedAt); err != nil {
+		return nil, err
+	}
+	search.LastEvaluatedAt = pgtype.Timestamp{Time: evaluatedAt, Valid: true}
+
+	result := savedSearchModel(search)
+	return &result, nil
+}
+
+// DeleteSavedSearch removes one of the user's saved searches and its matches
+func (s *SavedSearchService) DeleteSavedSearch(ctx context.Context, userID, searchID string) error {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return err
+	}
+	searchUUID, err := parseUUID(searchID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.queries.DeleteSavedSearch(ctx, db.DeleteSavedSearchParams{
+		ID:     searchUUID,
+		UserID: userUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListMatches returns the profiles a saved search has matched, newest first
+func (s *SavedSearchService) ListMatches(ctx context.Context, userID, searchID string, query models.SavedSearchMatchesQuery) ([]models.SavedSearchMatch, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	searchUUID, err := parseUUID(searchID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.queries.GetSavedSearch(ctx, db.GetSavedSearchParams{
+		ID:     searchUUID,
+		UserID: userUUID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved search: %w", err)
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultSavedSearchMatchesLimit
+	}
+	rows, err := s.queries.ListSavedSearchMatches(ctx, db.ListSavedSearchMatchesParams{
+		SavedSearchID: searchUUID,
+		Limit:         int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved search matches: %w", err)
+	}
+
+	matches := make([]models.SavedSearchMatch, 0, len(rows))
+	for _, row := range rows {
+		matches = append(matches, models.SavedSearchMatch{
+			ID:          uuidString(row.ID),
+			LinkedinURL: row.LinkedinUrl,
+			Name:        row.Name,
+			Location:    textValue(row.Location),
+			Headline:    textValue(row.Headline),
+			Company:     textValue(row.CompanyName),
+			MatchedAt:   row.MatchedAt.Time,
+		})
+	}
+
+	return matches, nil
+}
+
+// EvaluateForUser re-runs the user's active saved searches and alerts on
+// profiles that newly match. It is run after every connection check.
+func (s *SavedSearchService) EvaluateForUser(ctx context.Context, userID pgtype.UUID, checkedAt time.Time) error {
+	searches, err := s.queries.ListActiveSavedSearches(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list saved searches: %w", err)
+	}
+
+	var errs []error
+	for _, search := range searches {
+		matches, err := s.recordMatches(ctx, search)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if len(matches) > 0 {
+			if err := s.notifier.Notify(ctx, userID, savedSearchAlert(search, matches, checkedAt)); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+		if err := s.markEvaluated(ctx, search.ID, checkedAt); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// recordMatches stores the profiles currently matching the search and returns those not matched before
+func (s *SavedSearchService) recordMatches(ctx context.Context, search db.SavedSearch) ([]db.RecordSavedSearchMatchesRow, error) {
+	matches, err := s.queries.RecordSavedSearchMatches(ctx, db.RecordSavedSearchMatchesParams{
+		UserID:        search.UserID,
+		Query:         search.Query,
+		CompanyID:     search.CompanyID,
+		Location:      search.Location,
+		Degree:        search.Degree,
+		ListID:        search.ListID,
+		Tag:           search.Tag,
+		CountryCode:   search.CountryCode,
+		NearLatitude:  search.NearLatitude,
+		NearLongitude: search.NearLongitude,
+		RadiusKm:      search.RadiusKm,
+		MinSeniority:  search.MinSeniority,
+		JobFunction:   search.JobFunction,
+		SavedSearchID: search.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate saved search %q: %w", search.Name, err)
+	}
+	return matches, nil
+}
+
+func (s *SavedSearchService) markEvaluated(ctx context.Context, searchID pgtype.UUID, evaluatedAt time.Time) error {
+	err := s.queries.MarkSavedSearchEvaluated(ctx, db.MarkSavedSearchEvaluatedParams{
+		ID:              searchID,
+		LastEvaluatedAt: pgtype.Timestamp{Time: evaluatedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark saved search evaluated: %w", err)
+	}
+	return nil
+}
+
+// savedSearchAlert builds the notification for a saved search's new matches
+func savedSearchAlert(search db.SavedSearch, matches []db.RecordSavedSearchMatchesRow, matchedAt time.Time) Alert {
+	noun := "matches"
+	if len(matches) == 1 {
+		noun = "match"
+	}
+
+	profiles := make([]models.SavedSearchMatch, 0, len(matches))
+	lines := make([]string, 0, maxAlertProfiles+1)
+	for i, match := range matches {
+		profile := models.SavedSearchMatch{
+			ID:          uuidString(match.ID),
+			LinkedinURL: match.LinkedinUrl,
+			Name:        match.Name,
+			Location:    textValue(match.Location),
+			Headline:    textValue(match.Headline),
+			Company:     textValue(match.CompanyName),
+			MatchedAt:   matchedAt,
+		}
+		profiles = append(profiles, profile)
+
+		if i < maxAlertProfiles {
+			line := "- " + profile.Name
+			if profile.Headline != "" {
+				line += " (" + profile.Headline + ")"
+			}
+			lines = append(lines, line+" "+profile.LinkedinURL)
+		}
+	}
+	if len(matches) > maxAlertProfiles {
+		lines = append(lines, fmt.Sprintf("...and %d more", len(matches)-maxAlertProfiles))
+	}
+
+	return Alert{
+		Kind:  NotificationKindSavedSearch,
+		Title: fmt.Sprintf("%d new %s for %q", len(matches), noun, search.Name),
+		Body:  strings.Join(lines, "\n"),
+		Payload: map[string]interface{}{
+			"saved_search_id": uuidString(search.ID),
+			"profiles":        profiles,
+		},
+	}
+}
+
+func savedSearchModel(row db.SavedSearch) models.SavedSearch {
+	search := models.SavedSearch{
+		ID:           uuidString(row.ID),
+		Name:         row.Name,
+		Query:        textValue(row.Query),
+		CompanyID:    uuidString(row.CompanyID),
+		Location:     textValue(row.Location),
+		Tag:          textValue(row.Tag),
+		ListID:       uuidString(row.ListID),
+		Country:      textValue(row.CountryCode),
+		Near:         textValue(row.Near),
+		RadiusKm:     row.RadiusKm.Float64,
+		MinSeniority: textValue(row.MinSeniority),
+		Function:     textValue(row.JobFunction),
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+	}
+	if row.Degree.Valid {
+		degree := int(row.Degree.Int32)
+		search.Degree = &degree
+	}
+	if row.LastEvaluatedAt.Valid {
+		search.LastEvaluatedAt = &row.LastEvaluatedAt.Time
+	}
+	return search
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedSearchAlert(t *testing.T) {
+	search := db.SavedSearch{Name: "Madrid engineers"}
+	matchedAt := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	matches := []db.RecordSavedSearchMatchesRow{{
+		LinkedinUrl: "https://www.linkedin.com/in/ada",
+		Name:        "Ada Lovelace",
+		Headline:    pgtype.Text{String: "Platform Engineer", Valid: true},
+	}}
+
+	alert := savedSearchAlert(search, matches, matchedAt)
+
+	assert.Equal(t, NotificationKindSavedSearch, alert.Kind)
+	assert.Equal(t, `1 new match for "Madrid engineers"`, alert.Title)
+	assert.Equal(t, "- Ada Lovelace (Platform Engineer) https://www.linkedin.com/in/ada", alert.Body)
+	profiles := alert.Payload.(map[string]interface{})["profiles"].([]models.SavedSearchMatch)
+	assert.Len(t, profiles, 1)
+	assert.Equal(t, matchedAt, profiles[0].MatchedAt)
+}
+
+func TestSavedSearchAlert_TruncatesBody(t *testing.T) {
+	matches := make([]db.RecordSavedSearchMatchesRow, maxAlertProfiles+3)
+	for i := range matches {
+		matches[i] = db.RecordSavedSearchMatchesRow{
+			LinkedinUrl: fmt.Sprintf("https://www.linkedin.com/in/p%d", i),
+			Name:        fmt.Sprintf("Person %d", i),
+		}
+	}
+
+	alert := savedSearchAlert(db.SavedSearch{Name: "Everyone"}, matches, time.Now())
+
+	assert.Equal(t, fmt.Sprintf(`%d new matches for "Everyone"`, len(matches)), alert.Title)
+	lines := strings.Split(alert.Body, "\n")
+	assert.Len(t, lines, maxAlertProfiles+1)
+	assert.Equal(t, "...and 3 more", lines[maxAlertProfiles])
+	assert.Len(t, alert.Payload.(map[string]interface{})["profiles"], len(matches))
+}
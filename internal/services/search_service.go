@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// defaultSearchLimit is the page size when no limit is given
+	defaultSearchLimit = 20
+
+	// maxFacetValues is how many values are returned per facet, most common first
+	maxFacetValues = 20
+)
+
+type SearchService struct {
+	queries *db.Queries
+}
+
+func NewSearchService(queries *db.Queries) *SearchService {
+	return &SearchService{
+		queries: queries,
+	}
+}
+
+// SearchProfiles runs a ranked full-text search over profile names, headlines,
+// locations and current companies, returning one page of results together
+// with facet counts over every match. Country and distance filters use the
+// parsed profile locations, so profiles not yet parsed only match without them.
+func (s *SearchService) SearchProfiles(ctx context.Context, userID string, query models.ProfileSearchQuery) (*models.ProfileSearchResponse, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var companyID pgtype.UUID
+	if query.CompanyID != "" {
+		if companyID, err = parseUUID(query.CompanyID); err != nil {
+			return nil, err
+		}
+	}
+	var listID pgtype.UUID
+	if query.ListID != "" {
+		if listID, err = parseUUID(query.ListID); err != nil {
+			return nil, err
+		}
+	}
+	location := pgtype.Text{String: query.Location, Valid: query.Location != ""}
+	near, err := newLocationFilter(query.Country, query.Near, query.RadiusKm)
+	if err != nil {
+		return nil, err
+	}
+	tag := normalizeTag(query.Tag)
+	var degree pgtype.Int4
+	if query.Degree != nil {
+		degree = pgtype.Int4{Int32: int32(*query.Degree), Valid: true}
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	text := strings.TrimSpace(query.Q)
+
+	rows, err := s.queries.SearchProfiles(ctx, db.SearchProfilesParams{
+		Query:         text,
+		UserID:        userUUID,
+		CompanyID:     companyID,
+		Location:      location,
+		Degree:        degree,
+		ListID:        listID,
+		Tag:           pgtype.Text{String: tag, Valid: tag != ""},
+		CountryCode:   near.CountryCode,
+		NearLatitude:  near.NearLatitude,
+		NearLongitude: near.NearLongitude,
+		RadiusKm:      near.RadiusKm,
+		MinSeniority:  pgtype.Text{String: query.MinSeniority, Valid: query.MinSeniority != ""},
+		JobFunction:   pgtype.Text{String: query.Function, Valid: query.Function != ""},
+		MaxResults:    int32(limit),
+		Skip:          int32(query.Offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search profiles: %w", err)
+	}
+
+	facets, err := s.queries.SearchProfileFacets(ctx, db.SearchProfileFacetsParams{
+		UserID:        userUUID,
+		Query:         text,
+		CompanyID:     companyID,
+		Location:      location,
+		Degree:        degree,
+		ListID:        listID,
+		Tag:           pgtype.Text{String: tag, Valid: tag != ""},
+		CountryCode:   near.CountryCode,
+		NearLatitude:  near.NearLatitude,
+		NearLongitude: near.NearLongitude,
+		RadiusKm:      near.RadiusKm,
+		MinSeniority:  pgtype.Text{String: query.MinSeniority, Valid: query.MinSeniority != ""},
+		JobFunction:   pgtype.Text{String: query.Function, Valid: query.Function != ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count search facets: %w", err)
+	}
+
+	total, grouped := groupFacets(facets)
+	response := &models.ProfileSearchResponse{
+		Results: make([]models.ProfileSearchResult, 0, len(rows)),
+		Total:   total,
+		Limit:   limit,
+		Offset:  query.Offset,
+		Facets:  grouped,
+	}
+	for _, row := range rows {
+		result := models.ProfileSearchResult{
+			ID:          uuidString(row.ID),
+			LinkedinURL: row.LinkedinUrl,
+			Name:        row.Name,
+			Location:    textValue(row.Location),
+			Headline:    textValue(row.Headline),
+			Company:     textValue(row.CompanyName),
+			Seniority:   textValue(row.Seniority),
+			Function:    textValue(row.JobFunction),
+			Rank:        row.Rank,
+		}
+		if row.Degree.Valid {
+			d := int(row.Degree.Int32)
+			result.Degree = &d
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+// groupFacets splits facet rows by facet name, keeping the most common values.
+// It also returns the total number of matches, carried by the "total" row.
+func groupFacets(rows []db.SearchProfileFacetsRow) (int, models.ProfileSearchFacets) {
+	total := 0
+	facets := models.ProfileSearchFacets{
+		Companies:   []models.FacetCount{},
+		Locations:   []models.FacetCount{},
+		Countries:   []models.FacetCount{},
+		Degrees:     []models.FacetCount{},
+		Seniorities: []models.FacetCount{},
+		Functions:   []models.FacetCount{},
+	}
+	for _, row := range rows {
+		var bucket *[]models.FacetCount
+		switch row.Facet {
+		case "company":
+			bucket = &facets.Companies
+		case "location":
+			bucket = &facets.Locations
+		case "country":
+			bucket = &facets.Countries
+		case "degree":
+			bucket = &facets.Degrees
+		case "seniority":
+			bucket = &facets.Seniorities
+		case "function":
+			bucket = &facets.Functions
+		case "total":
+			total = int(row.Count)
+			continue
+		default:
+			continue
+		}
+		if len(*bucket) < maxFacetValues {
+			*bucket = append(*bucket, models.FacetCount{Value: row.Value, Label: row.Label, Count: int(row.Count)})
+		}
+	}
+	return total, facets
+}
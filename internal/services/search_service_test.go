@@ -0,0 +1,46 @@
+package services
+
+import (
+	"testing"
+
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupFacets(t *testing.T) {
+	rows := []db.SearchProfileFacetsRow{
+		{Facet: "company", Value: "c1", Label: "Acme", Count: 4},
+		{Facet: "degree", Value: "2", Label: "2", Count: 3},
+		{Facet: "location", Value: "Madrid", Label: "Madrid", Count: 2},
+		{Facet: "country", Value: "ES", Label: "ES", Count: 3},
+		{Facet: "seniority", Value: "senior", Label: "senior", Count: 2},
+		{Facet: "function", Value: "engineering", Label: "engineering", Count: 4},
+		{Facet: "total", Count: 5},
+	}
+
+	total, facets := groupFacets(rows)
+
+	assert.Equal(t, 5, total)
+	assert.Equal(t, []models.FacetCount{{Value: "c1", Label: "Acme", Count: 4}}, facets.Companies)
+	assert.Equal(t, []models.FacetCount{{Value: "Madrid", Label: "Madrid", Count: 2}}, facets.Locations)
+	assert.Equal(t, []models.FacetCount{{Value: "ES", Label: "ES", Count: 3}}, facets.Countries)
+	assert.Equal(t, []models.FacetCount{{Value: "2", Label: "2", Count: 3}}, facets.Degrees)
+	assert.Equal(t, []models.FacetCount{{Value: "senior", Label: "senior", Count: 2}}, facets.Seniorities)
+	assert.Equal(t, []models.FacetCount{{Value: "engineering", Label: "engineering", Count: 4}}, facets.Functions)
+}
+
+func TestGroupFacets_CapsValuesAndKeepsEmptyFacets(t *testing.T) {
+	var rows []db.SearchProfileFacetsRow
+	for i := 0; i < maxFacetValues+5; i++ {
+		rows = append(rows, db.SearchProfileFacetsRow{Facet: "location", Value: "x", Label: "x", Count: 1})
+	}
+
+	total, facets := groupFacets(rows)
+
+	assert.Zero(t, total)
+	assert.Len(t, facets.Locations, maxFacetValues)
+	assert.NotNil(t, facets.Companies)
+	assert.Empty(t, facets.Companies)
+}
@@ -0,0 +1,58 @@
+// Mimics the parts of LinkedIn's profile page the connect flow uses. The
+// body's data-invite attribute picks the dialog Connect opens: "note" (the
+// default), "email" for profiles that require an email address, or "limit"
+// for an account over its weekly invitation limit.
+(() => {
+  const mode = document.body.dataset.invite || "note";
+  const card = document.querySelector("main section");
+
+  const more = card.querySelector('button[aria-label="More actions"]');
+  if (more) {
+    more.addEventListener("click", () => {
+      const menu = document.getElementById("more-menu");
+      const open = more.getAttribute("aria-expanded") !== "true";
+      more.setAttribute("aria-expanded", String(open));
+      menu.style.display = open ? "block" : "none";
+    });
+  }
+
+  const markPending = () => {
+    const connect = card.querySelector('[aria-label$="to connect"]');
+    const pending = document.createElement("button");
+    pending.setAttribute("aria-label", "Pending, click to withdraw invitation");
+    pending.textContent = "Pending";
+    connect.replaceWith(pending);
+  };
+
+  const send = (note) => {
+    fetch("/sent", { method: "POST", body: note }).then(() => {
+      if (mode === "limit") {
+        dialog.innerHTML = "<p>You've reached the weekly invitation limit</p>";
+        return;
+      }
+      dialog.remove();
+      markPending();
+    });
+  };
+
+  let dialog;
+  const openDialog = () => {
+    dialog = document.createElement("div");
+    dialog.setAttribute("role", "dialog");
+    if (mode === "email") {
+      dialog.innerHTML = '<label>Email <input type="email"></label><button aria-label="Dismiss">x</button>';
+      dialog.querySelector('[aria-label="Dismiss"]').addEventListener("click", () => dialog.remove());
+    } else {
+      dialog.innerHTML = '<button aria-label="Add a note">Add a note</button><button aria-label="Send without a note">Send without a note</button>';
+      dialog.querySelector('[aria-label="Send without a note"]').addEventListener("click", () => send(""));
+      dialog.querySelector('[aria-label="Add a note"]').addEventListener("click", () => {
+        dialog.innerHTML = '<textarea name="message"></textarea><button aria-label="Send invitation">Send</button>';
+        dialog.querySelector("button").addEventListener("click", () => send(dialog.querySelector("textarea").value));
+      });
+    }
+    // LinkedIn renders the dialog a moment after the click
+    setTimeout(() => document.body.appendChild(dialog), 100);
+  };
+
+  card.querySelectorAll('[aria-label$="to connect"]').forEach((el) => el.addEventListener("click", openDialog));
+})();
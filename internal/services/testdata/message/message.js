@@ -0,0 +1,44 @@
+// Mimics the parts of LinkedIn's profile page and messaging overlay the
+// message flow uses. The body's data-thread attribute picks who sent the last
+// message of the conversation: "self" (the default), "other" or "none".
+(() => {
+  const thread = document.body.dataset.thread || "self";
+
+  const event = (fromOther, text) => {
+    const li = document.createElement("li");
+    li.className = "msg-s-message-list__event";
+    const item = document.createElement("div");
+    item.className = "msg-s-event-listitem" + (fromOther ? " msg-s-event-listitem--other" : "");
+    item.textContent = text;
+    li.appendChild(item);
+    return li;
+  };
+
+  const openConversation = () => {
+    const bubble = document.createElement("div");
+    bubble.className = "msg-overlay-conversation-bubble";
+    bubble.innerHTML =
+      '<ul class="msg-s-message-list-content"></ul>' +
+      '<form><div role="textbox" contenteditable="true" aria-label="Write a message…"></div>' +
+      '<button type="submit" class="msg-form__send-button">Send</button></form>';
+    const list = bubble.querySelector("ul");
+    if (thread !== "none") {
+      list.appendChild(event(false, "Thanks for connecting!"));
+      if (thread === "other") list.appendChild(event(true, "Likewise, let's talk"));
+    }
+
+    const box = bubble.querySelector('[role="textbox"]');
+    bubble.querySelector("form").addEventListener("submit", (e) => {
+      e.preventDefault();
+      const text = box.innerText;
+      fetch("/sent", { method: "POST", body: text }).then(() => {
+        list.appendChild(event(false, text));
+        box.textContent = "";
+      });
+    });
+    // LinkedIn renders the conversation a moment after the click
+    setTimeout(() => document.body.appendChild(bubble), 100);
+  };
+
+  document.querySelectorAll('main section button[aria-label^="Message"]').forEach((el) => el.addEventListener("click", openConversation));
+})();
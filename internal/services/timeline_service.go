@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Timeline entry kinds besides the profile event types
+const (
+	TimelineDiscovered = "discovered"
+	TimelineNote       = "note"
+	TimelineAutomation = "automation"
+)
+
+// defaultTimelineLimit is the number of timeline entries returned when no limit is given
+const defaultTimelineLimit = 100
+
+type TimelineService struct {
+	queries *db.Queries
+}
+
+func NewTimelineService(queries *db.Queries) *TimelineService {
+	return &TimelineService{
+		queries: queries,
+	}
+}
+
+// GetTimeline returns everything known about a profile, newest first: when it
+// was discovered and through whom, connections gained and lost, job and
+// headline changes, the user's notes and what the user's rules did.
+func (s *TimelineService) GetTimeline(ctx context.Context, userID, profileID string, query models.ProfileTimelineQuery) ([]models.TimelineEntry, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	profileUUID, err := parseUUID(profileID)
+	if err != nil {
+		return nil, err
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultTimelineLimit
+	}
+
+	profile, err := s.queries.GetLinkedInProfileByID(ctx, profileUUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	events, err := s.queries.ListProfileEvents(ctx, profileUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profile events: %w", err)
+	}
+	notes, err := s.queries.ListProfileNotes(ctx, db.ListProfileNotesParams{
+		UserID:    userUUID,
+		ProfileID: profileUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes: %w", err)
+	}
+	executions, err := s.queries.ListProfileRuleExecutions(ctx, db.ListProfileRuleExecutionsParams{
+		ProfileID: profileUUID,
+		UserID:    userUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rule executions: %w", err)
+	}
+
+	entries := buildTimeline(profile.CreatedAt.Time, events, notes, executions)
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// buildTimeline merges a profile's events, notes and rule executions, newest
+// first. The first connection recorded is when the profile was discovered; a
+// profile with none, such as one added by hand, was discovered when it was
+// first stored.
+func buildTimeline(firstSeen time.Time, events []db.ListProfileEventsRow, notes []db.ProfileNote, executions []db.ListProfileRuleExecutionsRow) []models.TimelineEntry {
+	entries := make([]models.TimelineEntry, 0, len(events)+len(notes)+len(executions)+1)
+
+	// Events are newest first, so the discovery is the last connection added
+	discovery := -1
+	for i, event := range events {
+		if event.EventType == ProfileEventConnectionAdded {
+			discovery = i
+		}
+	}
+	if discovery < 0 {
+		entries = append(entries, models.TimelineEntry{
+			Kind:       TimelineDiscovered,
+			OccurredAt: firstSeen,
+			Summary:    "First seen",
+		})
+	}
+
+	for i, event := range events {
+		entry := timelineEvent(event)
+		if i == discovery {
+			entry.Kind = TimelineDiscovered
+			entry.Summary = "Discovered"
+			if entry.Via != nil {
+				entry.Summary += " through " + entry.Via.Name
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	for _, note := range notes {
+		entries = append(entries, models.TimelineEntry{
+			Kind:       TimelineNote,
+			OccurredAt: note.CreatedAt.Time,
+			Summary:    "Note added",
+			NoteID:     uuidString(note.ID),
+			Note:       note.Body,
+		})
+	}
+
+	for _, execution := range executions {
+		entries = append(entries, timelineExecution(execution))
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].OccurredAt.After(entries[j].OccurredAt)
+	})
+	return entries
+}
+
+func timelineEvent(event db.ListProfileEventsRow) models.TimelineEntry {
+	entry := models.TimelineEntry{
+		Kind:        event.EventType,
+		OccurredAt:  event.DetectedAt.Time,
+		OldCompany:  textValue(event.OldCompanyName),
+		OldPosition: textValue(event.OldPosition),
+		NewCompany:  textValue(event.NewCompanyName),
+		NewPosition: textValue(event.NewPosition),
+		OldHeadline: textValue(event.OldHeadline),
+		NewHeadline: textValue(event.NewHeadline),
+	}
+	if event.RelatedProfileID.Valid {
+		entry.Via = &models.ProfileRef{
+			ID:          uuidString(event.RelatedProfileID),
+			Name:        textValue(event.RelatedProfileName),
+			LinkedinURL: textValue(event.RelatedProfileUrl),
+		}
+	}
+
+	// The tracked profile may since have been deleted
+	with := "a deleted profile"
+	if entry.Via != nil {
+		with = entry.Via.Name
+	}
+	oldRole := roleLabel(entry.OldPosition, entry.OldCompany)
+	newRole := roleLabel(entry.NewPosition, entry.NewCompany)
+
+	switch event.EventType {
+	case ProfileEventConnectionAdded:
+		entry.Summary = "Connected with " + with
+	case ProfileEventConnectionRemoved:
+		entry.Summary = "No longer connected with " + with
+	case ProfileEventHeadlineChange:
+		entry.Summary = fmt.Sprintf("Changed headline to %q", entry.NewHeadline)
+	case ProfileEventJobChange:
+		// Either company may since have been merged away or deleted
+		switch {
+		case oldRole == "" && newRole == "":
+			entry.Summary = "Changed jobs"
+		case newRole == "":
+			entry.Summary = "Left " + oldRole
+		case oldRole == "":
+			entry.Summary = "Moved to " + newRole
+		default:
+			entry.Summary = "Moved from " + oldRole + " to " + newRole
+		}
+	}
+	return entry
+}
+
+func timelineExecution(execution db.ListProfileRuleExecutionsRow) models.TimelineEntry {
+	entry := models.TimelineEntry{
+		Kind:        TimelineAutomation,
+		OccurredAt:  execution.ExecutedAt.Time,
+		RuleID:      uuidString(execution.RuleID),
+		RuleName:    execution.RuleName,
+		RuleStatus:  execution.Status,
+		RuleOutcome: textValue(execution.Outcome),
+		RuleError:   textValue(execution.Error),
+	}
+
+	switch execution.Status {
+	case RuleExecutionFailed:
+		entry.Summary = fmt.Sprintf("Rule %q failed", execution.RuleName)
+	case RuleExecutionSkipped:
+		entry.Summary = fmt.Sprintf("Rule %q skipped", execution.RuleName)
+		if entry.RuleOutcome != "" {
+			entry.Summary += ": " + strings.ReplaceAll(entry.RuleOutcome, "_", " ")
+		}
+	case RuleExecutionPending:
+		entry.Summary = fmt.Sprintf("Rule %q is running", execution.RuleName)
+	case RuleExecutionAwaitingApproval:
+		entry.Summary = fmt.Sprintf("Rule %q is awaiting your approval", execution.RuleName)
+	case RuleExecutionApproved:
+		entry.Summary = fmt.Sprintf("Rule %q was approved and will run shortly", execution.RuleName)
+	case RuleExecutionRejected:
+		entry.Summary = fmt.Sprintf("Rule %q was rejected", execution.RuleName)
+	default:
+		switch execution.ActionType {
+		case RuleActionSendConnectionRequest:
+			entry.Summary = fmt.Sprintf("Connection request sent by rule %q", execution.RuleName)
+		case RuleActionSaveProfile:
+			entry.Summary = fmt.Sprintf("Saved by rule %q", execution.RuleName)
+		default:
+			entry.Summary = fmt.Sprintf("Alert sent by rule %q", execution.RuleName)
+		}
+	}
+	return entry
+}
+
+// roleLabel describes a position held at a company, e.g. "CTO at Acme"
+func roleLabel(position, company string) string {
+	switch {
+	case position != "" && company != "":
+		return position + " at " + company
+	case position != "":
+		return position
+	default:
+		return company
+	}
+}
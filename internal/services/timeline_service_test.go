@@ -0,0 +1,103 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"linkedin-watcher/db"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildTimeline(t *testing.T) {
+	day := func(n int) pgtype.Timestamp {
+		return pgtype.Timestamp{Time: time.Date(2025, 3, n, 12, 0, 0, 0, time.UTC), Valid: true}
+	}
+	jane := testProfileID(1)
+	text := func(s string) pgtype.Text { return pgtype.Text{String: s, Valid: true} }
+
+	events := []db.ListProfileEventsRow{
+		{EventType: ProfileEventConnectionRemoved, DetectedAt: day(20), RelatedProfileID: jane, RelatedProfileName: text("Jane Doe")},
+		{EventType: ProfileEventJobChange, DetectedAt: day(15), OldPosition: text("Engineer"), OldCompanyName: text("Acme"), NewPosition: text("CTO"), NewCompanyName: text("Globex")},
+		{EventType: ProfileEventHeadlineChange, DetectedAt: day(10), OldHeadline: text("Engineer at Acme"), NewHeadline: text("CTO at Globex")},
+		{EventType: ProfileEventConnectionAdded, DetectedAt: day(5), RelatedProfileID: testProfileID(2), RelatedProfileName: text("John Roe")},
+		{EventType: ProfileEventConnectionAdded, DetectedAt: day(2), RelatedProfileID: jane, RelatedProfileName: text("Jane Doe")},
+	}
+	notes := []db.ProfileNote{{ID: testProfileID(9), Body: "Met at KubeCon", CreatedAt: day(12)}}
+
+	timeline := buildTimeline(day(1).Time, events, notes, nil)
+
+	var kinds, summaries []string
+	for _, entry := range timeline {
+		kinds = append(kinds, entry.Kind)
+		summaries = append(summaries, entry.Summary)
+	}
+	assert.Equal(t, []string{
+		ProfileEventConnectionRemoved, ProfileEventJobChange, TimelineNote,
+		ProfileEventHeadlineChange, ProfileEventConnectionAdded, TimelineDiscovered,
+	}, kinds)
+	assert.Equal(t, []string{
+		"No longer connected with Jane Doe",
+		"Moved from Engineer at Acme to CTO at Globex",
+		"Note added",
+		`Changed headline to "CTO at Globex"`,
+		"Connected with John Roe",
+		"Discovered through Jane Doe",
+	}, summaries)
+	assert.Equal(t, "Met at KubeCon", timeline[2].Note)
+	if assert.NotNil(t, timeline[5].Via) {
+		assert.Equal(t, uuidString(jane), timeline[5].Via.ID)
+	}
+}
+
+func TestBuildTimeline_WithoutConnections(t *testing.T) {
+	firstSeen := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	events := []db.ListProfileEventsRow{
+		{EventType: ProfileEventJobChange, DetectedAt: pgtype.Timestamp{Time: firstSeen.AddDate(0, 1, 0), Valid: true}, OldPosition: pgtype.Text{String: "Engineer", Valid: true}},
+		{EventType: ProfileEventConnectionRemoved, DetectedAt: pgtype.Timestamp{Time: firstSeen.AddDate(0, 2, 0), Valid: true}},
+	}
+
+	timeline := buildTimeline(firstSeen, events, nil, nil)
+
+	if assert.Len(t, timeline, 3) {
+		assert.Equal(t, "No longer connected with a deleted profile", timeline[0].Summary)
+		assert.Equal(t, "Left Engineer", timeline[1].Summary)
+		assert.Equal(t, TimelineDiscovered, timeline[2].Kind)
+		assert.Equal(t, firstSeen, timeline[2].OccurredAt)
+		assert.Nil(t, timeline[2].Via)
+	}
+}
+
+func TestBuildTimeline_RuleExecutions(t *testing.T) {
+	firstSeen := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(days int) pgtype.Timestamp {
+		return pgtype.Timestamp{Time: firstSeen.AddDate(0, 0, days), Valid: true}
+	}
+	executions := []db.ListProfileRuleExecutionsRow{
+		{RuleID: testProfileID(5), RuleName: "Review", ActionType: RuleActionSendConnectionRequest, Status: RuleExecutionAwaitingApproval, ExecutedAt: at(5)},
+		{RuleID: testProfileID(4), RuleName: "Reconnect", ActionType: RuleActionSendConnectionRequest, Status: RuleExecutionSkipped, Outcome: pgtype.Text{String: "already_connected", Valid: true}, ExecutedAt: at(4)},
+		{RuleID: testProfileID(1), RuleName: "Connect", ActionType: RuleActionSendConnectionRequest, Status: RuleExecutionFailed, Error: pgtype.Text{String: "rate limited", Valid: true}, ExecutedAt: at(3)},
+		{RuleID: testProfileID(2), RuleName: "Save", ActionType: RuleActionSaveProfile, Status: RuleExecutionSucceeded, ExecutedAt: at(2)},
+		{RuleID: testProfileID(3), RuleName: "Alert", ActionType: RuleActionNotify, Status: RuleExecutionSucceeded, ExecutedAt: at(1)},
+	}
+
+	timeline := buildTimeline(firstSeen, nil, nil, executions)
+
+	var summaries []string
+	for _, entry := range timeline {
+		summaries = append(summaries, entry.Summary)
+	}
+	assert.Equal(t, []string{
+		`Rule "Review" is awaiting your approval`,
+		`Rule "Reconnect" skipped: already connected`,
+		`Rule "Connect" failed`,
+		`Saved by rule "Save"`,
+		`Alert sent by rule "Alert"`,
+		"First seen",
+	}, summaries)
+	assert.Equal(t, TimelineAutomation, timeline[1].Kind)
+	assert.Equal(t, "already_connected", timeline[1].RuleOutcome)
+	assert.Equal(t, "rate limited", timeline[2].RuleError)
+	assert.Equal(t, uuidString(testProfileID(1)), timeline[2].RuleID)
+}
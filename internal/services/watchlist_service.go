@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// NotificationKindCompanyWatch is the notification kind for new people at a watched company
+const NotificationKindCompanyWatch = "company_watch_match"
+
+type WatchlistService struct {
+	queries  *db.Queries
+	notifier *NotificationService
+}
+
+func NewWatchlistService(queries *db.Queries, notifier *NotificationService) *WatchlistService {
+	return &WatchlistService{
+		queries:  queries,
+		notifier: notifier,
+	}
+}
+
+// ListWatchedCompanies returns the companies on the user's watchlist
+func (s *WatchlistService) ListWatchedCompanies(ctx context.Context, userID string) ([]models.WatchedCompany, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListCompanyWatches(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list watched companies: %w", err)
+	}
+
+	companies := make([]models.WatchedCompany, 0, len(rows))
+	for _, row := range rows {
+		companies = append(companies, models.WatchedCompany{
+			ID:            uuidString(row.ID),
+			Name:          row.Name,
+			LinkedinURL:   textValue(row.LinkedinUrl),
+			Industry:      textValue(row.Industry),
+			WatchedAt:     row.WatchedAt.Time,
+			KnownProfiles: int(row.MatchCount),
+		})
+	}
+
+	return companies, nil
+}
+
+// WatchCompany adds a company to the user's watchlist. The 2nd and 3rd degree
+// profiles already there are recorded without alerting, so only people
+// discovered by later checks are alerted.
+func (s *WatchlistService) WatchCompany(ctx context.Context, userID string, req models.WatchCompanyRequest) (*models.WatchedCompany, error) {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	companyUUID, err := parseUUID(req.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := s.queries.GetCompanyByID(ctx, companyUUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+
+	watch, err := s.queries.CreateCompanyWatch(ctx, db.CreateCompanyWatchParams{
+		UserID:    userUUID,
+		CompanyID: companyUUID,
+	})
+	if isUniqueViolation(err) {
+		return nil, ErrAlreadyExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch company: %w", err)
+	}
+
+	baseline, err := s.queries.RecordCompanyWatchMatches(ctx, db.RecordCompanyWatchMatchesParams{
+		UserID:    userUUID,
+		CompanyID: companyUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record known profiles: %w", err)
+	}
+
+	return &models.WatchedCompany{
+		ID:            uuidString(company.ID),
+		Name:          company.Name,
+		LinkedinURL:   textValue(company.LinkedinUrl),
+		Industry:      textValue(company.Industry),
+		WatchedAt:     watch.CreatedAt.Time,
+		KnownProfiles: len(baseline),
+	}, nil
+}
+
+// UnwatchCompany removes a company from the user's watchlist and forgets the
+// profiles seen there
+func (s *WatchlistService) UnwatchCompany(ctx context.Context, userID, companyID string) error {
+	userUUID, err := parseUUID(userID)
+	if err != nil {
+		return err
+	}
+	companyUUID, err := parseUUID(companyID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.queries.DeleteCompanyWatch(ctx, db.DeleteCompanyWatchParams{
+		UserID:    userUUID,
+		CompanyID: companyUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unwatch company: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+
+	err = s.queries.DeleteCompanyWatchMatches(ctx, db.DeleteCompanyWatchMatchesParams{
+		UserID:    userUUID,
+		CompanyID: companyUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete known profiles: %w", err)
+	}
+
+	return nil
+}
+
+// EvaluateForUser alerts on 2nd and 3rd degree profiles newly found at the
+// user's watched companies, one alert per company. It is run after every
+// connection check.
+func (s *WatchlistService) EvaluateForUser(ctx context.Context, userID pgtype.UUID, checkedAt time.Time) error {
+	rows, err := s.queries.RecordCompanyWatchMatches(ctx, db.RecordCompanyWatchMatchesParams{
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to evaluate watched companies: %w", err)
+	}
+
+	var errs []error
+	for _, matches := range groupWatchMatches(rows) {
+		if err := s.notifier.Notify(ctx, userID, companyWatchAlert(matches)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// groupWatchMatches splits the new matches by company, keeping their order
+func groupWatchMatches(rows []db.RecordCompanyWatchMatchesRow) [][]db.RecordCompanyWatchMatchesRow {
+	var groups [][]db.RecordCompanyWatchMatchesRow
+	index := make(map[pgtype.UUID]int)
+	for _, row := range rows {
+		i, ok := index[row.CompanyID]
+		if !ok {
+			i = len(groups)
+			index[row.CompanyID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], row)
+	}
+	return groups
+}
+
+// companyWatchAlert builds the notification for the new profiles at one watched company
+func companyWatchAlert(matches []db.RecordCompanyWatchMatchesRow) Alert {
+	company := matches[0]
+	noun := "people"
+	if len(matches) == 1 {
+		noun = "person"
+	}
+
+	profiles := make([]models.WatchedCompanyProfile, 0, len(matches))
+	lines := make([]string, 0, maxAlertProfiles+1)
+	for i, match := range matches {
+		profile := models.WatchedCompanyProfile{
+			ID:          uuidString(match.ID),
+			LinkedinURL: match.LinkedinUrl,
+			Name:        match.Name,
+			Headline:    textValue(match.Headline),
+			Degree:      int(match.Degree),
+			ConnectedVia: models.ProfileRef{
+				ID:          uuidString(match.ViaProfileID),
+				Name:        match.ViaName,
+				LinkedinURL: match.ViaLinkedinUrl,
+			},
+		}
+		profiles = append(profiles, profile)
+
+		if i < maxAlertProfiles {
+			line := "- " + profile.Name
+			if profile.Headline != "" {
+				line += " (" + profile.Headline + ")"
+			}
+			lines = append(lines, fmt.Sprintf("%s, %s degree via %s %s",
+				line, ordinal(profile.Degree), profile.ConnectedVia.Name, profile.LinkedinURL))
+		}
+	}
+	if len(matches) > maxAlertProfiles {
+		lines = append(lines, fmt.Sprintf("...and %d more", len(matches)-maxAlertProfiles))
+	}
+
+	return Alert{
+		Kind:  NotificationKindCompanyWatch,
+		Title: fmt.Sprintf("%d new %s at %s", len(matches), noun, company.CompanyName),
+		Body:  strings.Join(lines, "\n"),
+		Payload: map[string]interface{}{
+			"company_id":   uuidString(company.CompanyID),
+			"company_name": company.CompanyName,
+			"profiles":     profiles,
+		},
+	}
+}
+
+// ordinal formats a connection degree as 2nd, 3rd and so on
+func ordinal(n int) string {
+	switch n {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	default:
+		return fmt.Sprintf("%dth", n)
+	}
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+
+	"linkedin-watcher/db"
+	"linkedin-watcher/internal/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupWatchMatches(t *testing.T) {
+	acme := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	globex := pgtype.UUID{Bytes: [16]byte{2}, Valid: true}
+	rows := []db.RecordCompanyWatchMatchesRow{
+		{Name: "Ada", CompanyID: acme},
+		{Name: "Grace", CompanyID: acme},
+		{Name: "Linus", CompanyID: globex},
+	}
+
+	groups := groupWatchMatches(rows)
+
+	assert.Len(t, groups, 2)
+	assert.Equal(t, []string{"Ada", "Grace"}, []string{groups[0][0].Name, groups[0][1].Name})
+	assert.Equal(t, "Linus", groups[1][0].Name)
+}
+
+func TestCompanyWatchAlert(t *testing.T) {
+	matches := []db.RecordCompanyWatchMatchesRow{{
+		LinkedinUrl:    "https://www.linkedin.com/in/ada",
+		Name:           "Ada Lovelace",
+		Headline:       pgtype.Text{String: "Staff Engineer", Valid: true},
+		CompanyName:    "Acme",
+		Degree:         2,
+		ViaName:        "Grace Hopper",
+		ViaLinkedinUrl: "https://www.linkedin.com/in/grace",
+	}}
+
+	alert := companyWatchAlert(matches)
+
+	assert.Equal(t, NotificationKindCompanyWatch, alert.Kind)
+	assert.Equal(t, "1 new person at Acme", alert.Title)
+	assert.Equal(t, "- Ada Lovelace (Staff Engineer), 2nd degree via Grace Hopper https://www.linkedin.com/in/ada", alert.Body)
+	profiles := alert.Payload.(map[string]interface{})["profiles"].([]models.WatchedCompanyProfile)
+	assert.Equal(t, "Grace Hopper", profiles[0].ConnectedVia.Name)
+	assert.Equal(t, "https://www.linkedin.com/in/grace", profiles[0].ConnectedVia.LinkedinURL)
+}
+
+func TestOrdinal(t *testing.T) {
+	assert.Equal(t, "2nd", ordinal(2))
+	assert.Equal(t, "3rd", ordinal(3))
+	assert.Equal(t, "4th", ordinal(4))
+}
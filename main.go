@@ -25,43 +25,133 @@ package main
 
 import (
 	"context"
+	"errors"
 	"linkedin-watcher/config"
 	"linkedin-watcher/db"
 	_ "linkedin-watcher/docs"
 	"linkedin-watcher/infra/logger"
+	"linkedin-watcher/internal/jobs"
 	"linkedin-watcher/internal/routers"
+	"linkedin-watcher/internal/services"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/spf13/viper"
 )
 
-func initDB(ctx context.Context, connectionString string) (db.Querier, func()) {
+func initDB(ctx context.Context, connectionString string) (db.Querier, *pgxpool.Pool, func()) {
 	// Try to initialize database, but don't fail if it's not available
 	if err := db.Init(connectionString); err != nil {
 		logger.Warnf("Database initialization failed: %v", err)
 		logger.Infof("Starting application without database connection")
-		return nil, func() {}
+		return nil, nil, func() {}
 	}
 
-	conn, err := pgxpool.New(ctx, connectionString)
+	conn, err := db.Connect(ctx, connectionString)
 	if err != nil {
 		logger.Warnf("Database connection failed: %v", err)
 		logger.Infof("Starting application without database connection")
-		return nil, func() {}
+		return nil, nil, func() {}
 	}
 
 	queries := db.New(conn)
 	logger.Infof("Database connection established successfully")
-	return queries, conn.Close
+	return queries, conn, conn.Close
 }
 
-func main() {
-	//set timezone
-	viper.SetDefault("SERVER_TIMEZONE", "Europe/Madrid")
-	loc, _ := time.LoadLocation(viper.GetString("SERVER_TIMEZONE"))
-	time.Local = loc
+func startJobs(ctx context.Context, pool *pgxpool.Pool, queries *db.Queries) {
+	scheduler := jobs.NewScheduler()
+
+	networkScoreService := services.NewNetworkScoreService(queries)
+	scheduler.Register(jobs.Job{
+		Name:     "network_scores",
+		Interval: config.JobInterval("network_scores", 6*time.Hour),
+		Run:      networkScoreService.RecomputeAll,
+	})
+
+	clusterService := services.NewClusterService(pool, queries)
+	scheduler.Register(jobs.Job{
+		Name:     "community_detection",
+		Interval: config.JobInterval("community_detection", 12*time.Hour),
+		Run:      clusterService.RecomputeAll,
+	})
+
+	locationService := services.NewLocationService(queries)
+	scheduler.Register(jobs.Job{
+		Name:     "location_normalization",
+		Interval: config.JobInterval("location_normalization", time.Hour),
+		Run:      locationService.NormalizePending,
+	})
+
+	headlineService := services.NewHeadlineService(queries)
+	scheduler.Register(jobs.Job{
+		Name:     "headline_parsing",
+		Interval: config.JobInterval("headline_parsing", time.Hour),
+		Run:      headlineService.ParsePending,
+	})
+
+	duplicateService := services.NewDuplicateService(pool, queries)
+	scheduler.Register(jobs.Job{
+		Name:     "duplicate_detection",
+		Interval: config.JobInterval("duplicate_detection", 24*time.Hour),
+		Run:      duplicateService.DetectAll,
+	})
+
+	analyticsService := services.NewAnalyticsService(queries)
+	scheduler.Register(jobs.Job{
+		Name:     "analytics_refresh",
+		Interval: config.JobInterval("analytics_refresh", time.Hour),
+		Run:      analyticsService.Refresh,
+	})
+
+	// New connections are recorded and their locations and headlines parsed
+	// first, then saved searches, watched companies and automation rules are
+	// evaluated against them
+	notificationService := services.NewNotificationService(queries, config.SMTPConfig())
+	savedSearchService := services.NewSavedSearchService(queries, notificationService)
+	watchlistService := services.NewWatchlistService(queries, notificationService)
+	windowService := services.NewExecutionWindowService(queries, config.DefaultTimezone())
+	invitationService := services.NewInvitationService(queries, config.InvitationQuotaConfig(), windowService)
+	automationService := services.NewAutomationService(queries, notificationService, invitationService, windowService)
+	connectExecutor := services.NewConnectExecutor(config.BrowserConfig())
+	messageExecutor := services.NewMessageExecutor(config.BrowserConfig())
+	automationService.Register(services.RuleActionSendConnectionRequest, connectExecutor.Execute)
+	automationService.Register(services.RuleActionSendMessage, messageExecutor.Execute)
+	automationService.RegisterCheck(services.SequenceEventAccepted, connectExecutor.Accepted)
+	automationService.RegisterCheck(services.SequenceEventReplied, messageExecutor.Replied)
+	connectionCheckService := services.NewConnectionCheckService(queries,
+		services.ScrapeLinkedInConnections,
+		func(ctx context.Context, _ pgtype.UUID, _ time.Time) error {
+			return errors.Join(locationService.NormalizePending(ctx), headlineService.ParsePending(ctx))
+		},
+		savedSearchService.EvaluateForUser,
+		watchlistService.EvaluateForUser,
+		automationService.RunForUser,
+	)
+	scheduler.Register(jobs.Job{
+		Name:     "connection_check",
+		Interval: config.JobInterval("connection_check", 24*time.Hour),
+		Run:      connectionCheckService.CheckAll,
+	})
+	// Connection requests wait for the invitation quota, so rules also run
+	// between checks to spread them across the day
+	scheduler.Register(jobs.Job{
+		Name:     "automation",
+		Interval: config.JobInterval("automation", 15*time.Minute),
+		Run:      automationService.RunAll,
+	})
+	// Each pass visits the profiles whose sequence step waits on an event
+	scheduler.Register(jobs.Job{
+		Name:     "sequences",
+		Interval: config.JobInterval("sequences", time.Hour),
+		Run:      automationService.AdvanceSequences,
+	})
+
+	scheduler.Start(ctx)
+}
 
+func main() {
 	if err := config.SetupConfig(); err != nil {
 		logger.Fatalf("config SetupConfig() error: %s", err)
 	}
@@ -69,7 +159,7 @@ func main() {
 	dbDSN := config.DbConfiguration()
 
 	ctx := context.Background()
-	queries, cleanup := initDB(ctx, dbDSN)
+	queries, pool, cleanup := initDB(ctx, dbDSN)
 	defer cleanup()
 
 	// Prepare router dependencies
@@ -79,9 +169,13 @@ func main() {
 			jwtSecret := viper.GetString("JWT_SECRET")
 
 			routerDeps = &routers.RouterDependencies{
+				Pool:      pool,
 				Queries:   q,
 				JWTSecret: jwtSecret,
 			}
+
+			// Background jobs need the database, so they only run when it is available
+			startJobs(ctx, pool, q)
 		}
 	}
 